@@ -1,7 +1,7 @@
 package main
 
 import (
-	"time"
+	"fmt"
 
 	"github.com/hybridgroup/gobot"
 	"github.com/hybridgroup/gobot/platforms/gpio"
@@ -15,32 +15,30 @@ func main() {
 	buzzer := gpio.NewBuzzerDriver(board, "buzzer", "3")
 
 	work := func() {
-		type note struct {
-			tone     float64
-			duration float64
+		song := gpio.Melody{
+			Name: "twinkle",
+			Notes: []gpio.Note{
+				{Tone: gpio.C4, Duration: gpio.Quarter},
+				{Tone: gpio.C4, Duration: gpio.Quarter},
+				{Tone: gpio.G4, Duration: gpio.Quarter},
+				{Tone: gpio.G4, Duration: gpio.Quarter},
+				{Tone: gpio.A4, Duration: gpio.Quarter},
+				{Tone: gpio.A4, Duration: gpio.Quarter},
+				{Tone: gpio.G4, Duration: gpio.Half},
+				{Tone: gpio.F4, Duration: gpio.Quarter},
+				{Tone: gpio.F4, Duration: gpio.Quarter},
+				{Tone: gpio.E4, Duration: gpio.Quarter},
+				{Tone: gpio.E4, Duration: gpio.Quarter},
+				{Tone: gpio.D4, Duration: gpio.Quarter},
+				{Tone: gpio.D4, Duration: gpio.Quarter},
+				{Tone: gpio.C4, Duration: gpio.Half},
+			},
 		}
 
-		song := []note{
-			{gpio.C4, gpio.Quarter},
-			{gpio.C4, gpio.Quarter},
-			{gpio.G4, gpio.Quarter},
-			{gpio.G4, gpio.Quarter},
-			{gpio.A4, gpio.Quarter},
-			{gpio.A4, gpio.Quarter},
-			{gpio.G4, gpio.Half},
-			{gpio.F4, gpio.Quarter},
-			{gpio.F4, gpio.Quarter},
-			{gpio.E4, gpio.Quarter},
-			{gpio.E4, gpio.Quarter},
-			{gpio.D4, gpio.Quarter},
-			{gpio.D4, gpio.Quarter},
-			{gpio.C4, gpio.Half},
-		}
-
-		for _, val := range song {
-			buzzer.Tone(val.tone, val.duration)
-			<-time.After(10 * time.Millisecond)
-		}
+		gobot.On(buzzer.Event(gpio.Finished), func(data interface{}) {
+			fmt.Println("finished playing", data.(gpio.Melody).Name)
+		})
+		buzzer.Play(song)
 	}
 
 	robot := gobot.NewRobot("bot",
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/hybridgroup/gobot"
@@ -15,32 +16,33 @@ func main() {
 	buzzer := gpio.NewBuzzerDriver(firmataAdaptor, "buzzer", "3")
 
 	work := func() {
-		type note struct {
-			tone     float64
-			duration float64
+		song := gpio.Melody{
+			Name: "twinkle",
+			Notes: []gpio.Note{
+				{Tone: gpio.C4, Duration: gpio.Quarter},
+				{Tone: gpio.C4, Duration: gpio.Quarter},
+				{Tone: gpio.G4, Duration: gpio.Quarter},
+				{Tone: gpio.G4, Duration: gpio.Quarter},
+				{Tone: gpio.A4, Duration: gpio.Quarter},
+				{Tone: gpio.A4, Duration: gpio.Quarter},
+				{Tone: gpio.G4, Duration: gpio.Half},
+				{Tone: gpio.F4, Duration: gpio.Quarter},
+				{Tone: gpio.F4, Duration: gpio.Quarter},
+				{Tone: gpio.E4, Duration: gpio.Quarter},
+				{Tone: gpio.E4, Duration: gpio.Quarter},
+				{Tone: gpio.D4, Duration: gpio.Quarter},
+				{Tone: gpio.D4, Duration: gpio.Quarter},
+				{Tone: gpio.C4, Duration: gpio.Half},
+			},
 		}
 
-		song := []note{
-			{gpio.C4, gpio.Quarter},
-			{gpio.C4, gpio.Quarter},
-			{gpio.G4, gpio.Quarter},
-			{gpio.G4, gpio.Quarter},
-			{gpio.A4, gpio.Quarter},
-			{gpio.A4, gpio.Quarter},
-			{gpio.G4, gpio.Half},
-			{gpio.F4, gpio.Quarter},
-			{gpio.F4, gpio.Quarter},
-			{gpio.E4, gpio.Quarter},
-			{gpio.E4, gpio.Quarter},
-			{gpio.D4, gpio.Quarter},
-			{gpio.D4, gpio.Quarter},
-			{gpio.C4, gpio.Half},
-		}
+		gobot.On(buzzer.Event("finished"), func(data interface{}) {
+			fmt.Println("finished playing", data.(gpio.Melody).Name)
+			<-time.After(2 * time.Second)
+			buzzer.PlayRTTTL("scale:d=8,o=5,b=120:c,d,e,f,g,a,b,c6")
+		})
 
-		for _, val := range song {
-			buzzer.Tone(val.tone, val.duration)
-			<-time.After(10 * time.Millisecond)
-		}
+		buzzer.Play(song)
 	}
 
 	robot := gobot.NewRobot("bot",
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/firmata"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+)
+
+// The board needs node-pixel firmware, which adds the pixel sysex to firmata
+func main() {
+	gbot := gobot.NewGobot()
+
+	firmataAdaptor := firmata.NewFirmataAdaptor("firmata", "/dev/ttyACM0")
+	strip := gpio.NewLedStripDriver(firmataAdaptor, "strip", "6", 8)
+
+	work := func() {
+		effects := []string{"rainbow", "chase", "blink"}
+		i := 0
+
+		strip.AnimateEffect(effects[i], map[string]interface{}{"color": "#ff0000"})
+		gobot.Every(5*time.Second, func() {
+			i = (i + 1) % len(effects)
+			strip.AnimateEffect(effects[i], map[string]interface{}{"color": "#ff0000"})
+		})
+	}
+
+	robot := gobot.NewRobot("stripBot",
+		[]gobot.Connection{firmataAdaptor},
+		[]gobot.Device{strip},
+		work,
+	)
+
+	gbot.AddRobot(robot)
+
+	gbot.Start()
+}
@@ -17,8 +17,9 @@ func main() {
 
 	work := func() {
 		gobot.Every(1*time.Second, func() {
-			fmt.Println("Pressure", mpl115a2.Pressure)
-			fmt.Println("Temperature", mpl115a2.Temperature)
+			data, timestamp := mpl115a2.Reading()
+			fmt.Println("Pressure", data.Pressure, "at", timestamp)
+			fmt.Println("Temperature", data.Temperature)
 		})
 	}
 
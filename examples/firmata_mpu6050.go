@@ -17,9 +17,10 @@ func main() {
 
 	work := func() {
 		gobot.Every(100*time.Millisecond, func() {
-			fmt.Println("Accelerometer", mpu6050.Accelerometer)
-			fmt.Println("Gyroscope", mpu6050.Gyroscope)
-			fmt.Println("Temperature", mpu6050.Temperature)
+			data, _ := mpu6050.Reading()
+			fmt.Println("Accelerometer", data.Accelerometer)
+			fmt.Println("Gyroscope", data.Gyroscope)
+			fmt.Println("Temperature", data.Temperature)
 		})
 	}
 
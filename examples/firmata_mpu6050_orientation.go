@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/firmata"
+	"github.com/hybridgroup/gobot/platforms/i2c"
+)
+
+func main() {
+	gbot := gobot.NewGobot()
+
+	firmataAdaptor := firmata.NewFirmataAdaptor("firmata", "/dev/ttyACM0")
+	mpu6050 := i2c.NewMPU6050Driver(firmataAdaptor, "mpu6050")
+	mpu6050.GyroRange = i2c.MPU6050_GYRO_FS_500
+	hmc6352 := i2c.NewHMC6352Driver(firmataAdaptor, "hmc6352")
+	orientation := i2c.NewOrientationDriver(mpu6050, "orientation",
+		i2c.NewComplementaryFilter(0.98), hmc6352)
+
+	work := func() {
+		// keep the board still and level while calibrating
+		if err := mpu6050.Calibrate(100); err != nil {
+			fmt.Println(err)
+		}
+
+		gobot.On(orientation.Event("data"), func(data interface{}) {
+			o := data.(i2c.Orientation)
+			fmt.Printf("roll %6.1f pitch %6.1f yaw %6.1f\n", o.Roll, o.Pitch, o.Yaw)
+		})
+	}
+
+	robot := gobot.NewRobot("orientationBot",
+		[]gobot.Connection{firmataAdaptor},
+		[]gobot.Device{mpu6050, hmc6352, orientation},
+		work,
+	)
+
+	gbot.AddRobot(robot)
+
+	gbot.Start()
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/firmata"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+)
+
+func main() {
+	gbot := gobot.NewGobot()
+
+	firmataAdaptor := firmata.NewFirmataAdaptor("firmata", "/dev/ttyACM0")
+	led := gpio.NewRgbLedDriver(firmataAdaptor, "rgb", "9", "10", "11")
+	led.Gamma = 2.8
+
+	work := func() {
+		gobot.On(led.Event(gpio.Finished), func(data interface{}) {
+			fmt.Println("finished", data)
+			led.AnimateEffect("pulse", map[string]interface{}{"color": "#00ffff"})
+		})
+
+		led.SetHex("#ff8800")
+		<-time.After(time.Second)
+		led.AnimateEffect("fade", map[string]interface{}{
+			"from":     "#ff8800",
+			"to":       "#8800ff",
+			"duration": 3000.0,
+		})
+	}
+
+	robot := gobot.NewRobot("rgbBot",
+		[]gobot.Connection{firmataAdaptor},
+		[]gobot.Device{led},
+		work,
+	)
+
+	gbot.AddRobot(robot)
+
+	gbot.Start()
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/firmata"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+)
+
+func main() {
+	gbot := gobot.NewGobot()
+
+	firmataAdaptor := firmata.NewFirmataAdaptor("firmata", "/dev/ttyACM0")
+	shoulder := gpio.NewServoDriver(firmataAdaptor, "shoulder", "3")
+	elbow := gpio.NewServoDriver(firmataAdaptor, "elbow", "5")
+
+	// the elbow servo is mounted the other way around and hits the
+	// frame below 30 degrees
+	elbow.Calibration = gpio.ServoCalibration{
+		MinAngle: 30,
+		MaxAngle: 180,
+		MinPulse: 180,
+		MaxPulse: 0,
+	}
+
+	arm := gpio.NewServoGroup(gpio.MotionProfile{
+		Speed:        90,
+		Acceleration: 180,
+	}, shoulder, elbow)
+
+	work := func() {
+		poses := [][]uint8{{45, 120}, {135, 60}, {90, 90}}
+		go func() {
+			for {
+				for _, pose := range poses {
+					fmt.Println("Moving to", pose)
+					if err := arm.Move(pose...); err != nil {
+						fmt.Println(err)
+					}
+					<-time.After(1 * time.Second)
+				}
+			}
+		}()
+	}
+
+	robot := gobot.NewRobot("armBot",
+		[]gobot.Connection{firmataAdaptor},
+		[]gobot.Device{shoulder, elbow},
+		work,
+	)
+
+	gbot.AddRobot(robot)
+	gbot.Start()
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/firmata"
+	"github.com/hybridgroup/gobot/platforms/i2c"
+)
+
+func main() {
+	gbot := gobot.NewGobot()
+
+	firmataAdaptor := firmata.NewFirmataAdaptor("firmata", "/dev/ttyACM0")
+	classic := i2c.NewWiiClassicDriver(firmataAdaptor, "classic")
+
+	work := func() {
+		gobot.On(classic.Event("left_joystick"), func(data interface{}) {
+			fmt.Println("left_joystick", data)
+		})
+
+		gobot.On(classic.Event("a_press"), func(data interface{}) {
+			fmt.Println("a_press")
+		})
+
+		gobot.On(classic.Event("a_release"), func(data interface{}) {
+			fmt.Println("a_release")
+		})
+
+		gobot.On(classic.Event("home_press"), func(data interface{}) {
+			fmt.Println("home_press")
+		})
+	}
+
+	robot := gobot.NewRobot("classic",
+		[]gobot.Connection{firmataAdaptor},
+		[]gobot.Device{classic},
+		work,
+	)
+
+	gbot.AddRobot(robot)
+
+	gbot.Start()
+}
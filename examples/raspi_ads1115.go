@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+	"github.com/hybridgroup/gobot/platforms/i2c"
+	"github.com/hybridgroup/gobot/platforms/raspi"
+)
+
+func main() {
+	gbot := gobot.NewGobot()
+
+	r := raspi.NewRaspiAdaptor("raspi")
+	ads := i2c.NewADS1115Driver(r, "ads")
+	ads.Range = i2c.ADS1115Range4_096
+	sensor := gpio.NewAnalogSensorDriver(ads, "sensor", "0", 100*time.Millisecond)
+
+	work := func() {
+		gobot.On(sensor.Event("data"), func(data interface{}) {
+			fmt.Println("sensor", data)
+		})
+
+		gobot.Every(1*time.Second, func() {
+			voltage, err := ads.Voltage("2-3")
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			fmt.Println("difference", voltage, "V")
+		})
+	}
+
+	robot := gobot.NewRobot("adsBot",
+		[]gobot.Connection{r, ads},
+		[]gobot.Device{ads, sensor},
+		work,
+	)
+
+	gbot.AddRobot(robot)
+
+	gbot.Start()
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/i2c"
+	"github.com/hybridgroup/gobot/platforms/raspi"
+)
+
+func main() {
+	gbot := gobot.NewGobot()
+
+	r := raspi.NewRaspiAdaptor("raspi")
+	bme280 := i2c.NewBMP280Driver(r, "bme280")
+	tsl2561 := i2c.NewTSL2561Driver(r, "tsl2561")
+
+	work := func() {
+		gobot.On(bme280.Event(i2c.Data), func(data interface{}) {
+			reading := data.(i2c.BMP280Data)
+			fmt.Printf("%.1f°C %.0fPa %.0f%%\n",
+				reading.Temperature, reading.Pressure, reading.Humidity)
+		})
+
+		gobot.On(tsl2561.Event(i2c.Data), func(data interface{}) {
+			fmt.Printf("%.0f lux\n", data.(i2c.TSL2561Data).Lux)
+		})
+
+		gobot.On(tsl2561.Event(i2c.Error), func(data interface{}) {
+			fmt.Println(data)
+		})
+	}
+
+	robot := gobot.NewRobot("weatherBot",
+		[]gobot.Connection{r},
+		[]gobot.Device{bme280, tsl2561},
+		work,
+	)
+
+	gbot.AddRobot(robot)
+
+	gbot.Start()
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+	"github.com/hybridgroup/gobot/platforms/raspi"
+)
+
+func main() {
+	gbot := gobot.NewGobot()
+
+	r := raspi.NewRaspiAdaptor("raspi")
+	// the echo pin of the HC-SR04 is 5V, so divide it down for the pi
+	sonar := gpio.NewHCSR04Driver(r, "sonar", "16", "18")
+
+	work := func() {
+		gobot.On(sonar.Event("data"), func(data interface{}) {
+			fmt.Printf("%.1f cm\n", data.(float64))
+		})
+		gobot.On(sonar.Event("error"), func(data interface{}) {
+			fmt.Println("error", data)
+		})
+	}
+
+	robot := gobot.NewRobot("sonarBot",
+		[]gobot.Connection{r},
+		[]gobot.Device{sonar},
+		work,
+	)
+
+	gbot.AddRobot(robot)
+	gbot.Start()
+}
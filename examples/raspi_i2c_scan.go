@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/i2c"
+	"github.com/hybridgroup/gobot/platforms/raspi"
+)
+
+func main() {
+	gbot := gobot.NewGobot()
+
+	r := raspi.NewRaspiAdaptor("raspi")
+	r.Connect()
+
+	scanner := i2c.NewScanner(r)
+	devices, err := scanner.Scan()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, d := range devices {
+		fmt.Println("found", d)
+	}
+
+	// a driver for each device the scanner knows
+	drivers := scanner.Drivers(devices)
+
+	work := func() {
+		for _, d := range drivers {
+			fmt.Println("started", d.Name())
+		}
+	}
+
+	robot := gobot.NewRobot("scanBot",
+		[]gobot.Connection{r},
+		drivers,
+		work,
+	)
+
+	gbot.AddRobot(robot)
+
+	gbot.Start()
+}
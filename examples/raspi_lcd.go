@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/i2c"
+	"github.com/hybridgroup/gobot/platforms/raspi"
+)
+
+func main() {
+	gbot := gobot.NewGobot()
+
+	r := raspi.NewRaspiAdaptor("raspi")
+	lcd := i2c.NewPCF8574LcdDriver(r, "lcd", 20, 4)
+
+	work := func() {
+		lcd.SetCustomChar(0, i2c.CustomLCDChars["heart"])
+		lcd.Write("gobot " + string(byte(0)))
+
+		count := 0
+		gobot.Every(1*time.Second, func() {
+			count++
+			// lines scroll up once the display is full
+			lcd.Write(fmt.Sprintf("\nstatus %v", count))
+		})
+	}
+
+	robot := gobot.NewRobot("lcdBot",
+		[]gobot.Connection{r},
+		[]gobot.Device{lcd},
+		work,
+	)
+
+	gbot.AddRobot(robot)
+
+	gbot.Start()
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+	"github.com/hybridgroup/gobot/platforms/raspi"
+)
+
+func main() {
+	gbot := gobot.NewGobot()
+
+	r := raspi.NewRaspiAdaptor("raspi")
+	// four chained 8x8 matrices, with DIN, CLK and CS on pins 19, 23 and 24
+	matrix := gpio.NewMAX7219Driver(r, "matrix", "19", "23", "24", 4)
+
+	work := func() {
+		matrix.SetIntensity(2)
+
+		gobot.On(matrix.Event(gpio.Finished), func(data interface{}) {
+			matrix.ScrollText(time.Now().Format("15:04:05"), 50*time.Millisecond)
+		})
+		matrix.ScrollText("Hello, gobot!", 50*time.Millisecond)
+	}
+
+	robot := gobot.NewRobot("matrixBot",
+		[]gobot.Connection{r},
+		[]gobot.Device{matrix},
+		work,
+	)
+
+	gbot.AddRobot(robot)
+	gbot.Start()
+}
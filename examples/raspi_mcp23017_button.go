@@ -0,0 +1,39 @@
+package main
+
+import (
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+	"github.com/hybridgroup/gobot/platforms/i2c"
+	"github.com/hybridgroup/gobot/platforms/raspi"
+)
+
+func main() {
+	gbot := gobot.NewGobot()
+
+	r := raspi.NewRaspiAdaptor("raspi")
+	expander := i2c.NewMCP23017Driver(r, "expander", i2c.MCP23017Config{Mirror: 1}, 0x20)
+	// INTA, which also signals changes on port B, is connected to pin 11
+	expander.SetInterruptPins(r, "11", "")
+
+	led := gpio.NewLedDriver(expander, "led", "A0")
+	button := gpio.NewButtonDriver(expander, "button", "B0")
+
+	work := func() {
+		gobot.On(button.Event("push"), func(data interface{}) {
+			led.On()
+		})
+		gobot.On(button.Event("release"), func(data interface{}) {
+			led.Off()
+		})
+	}
+
+	robot := gobot.NewRobot("expanderBot",
+		[]gobot.Connection{r, expander},
+		[]gobot.Device{expander, led, button},
+		work,
+	)
+
+	gbot.AddRobot(robot)
+
+	gbot.Start()
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+	"github.com/hybridgroup/gobot/platforms/raspi"
+	"github.com/hybridgroup/gobot/platforms/spi"
+)
+
+func main() {
+	gbot := gobot.NewGobot()
+
+	r := raspi.NewRaspiAdaptor("raspi")
+	adc := spi.NewMCP3008Driver(r, "adc", 0, 0)
+	sensor := gpio.NewAnalogSensorDriver(adc, "sensor", "0")
+
+	work := func() {
+		gobot.On(sensor.Event("data"), func(data interface{}) {
+			fmt.Println("sensor", data)
+		})
+	}
+
+	robot := gobot.NewRobot("adcBot",
+		[]gobot.Connection{r},
+		[]gobot.Device{adc, sensor},
+		work,
+	)
+
+	gbot.AddRobot(robot)
+
+	gbot.Start()
+}
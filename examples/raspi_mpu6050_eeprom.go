@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/i2c"
+	"github.com/hybridgroup/gobot/platforms/raspi"
+)
+
+// calibration is kept at the start of the EEPROM, after a marker showing it
+// has been written
+type calibration struct {
+	Marker        uint32
+	Accelerometer i2c.ThreeDData
+	Gyroscope     i2c.ThreeDData
+}
+
+const marker = 0x60B07001
+
+func main() {
+	gbot := gobot.NewGobot()
+
+	r := raspi.NewRaspiAdaptor("raspi")
+	mpu6050 := i2c.NewMPU6050Driver(r, "mpu6050")
+	eeprom := i2c.NewAT24CDriver(r, "eeprom", i2c.AT24C32)
+
+	work := func() {
+		var c calibration
+		err := binary.Read(io.NewSectionReader(eeprom, 0, eeprom.Size()), binary.LittleEndian, &c)
+		if err == nil && c.Marker == marker {
+			fmt.Println("loaded calibration", c.Accelerometer, c.Gyroscope)
+			mpu6050.SetOffsets(c.Accelerometer, c.Gyroscope)
+		} else {
+			fmt.Println("calibrating, keep the MPU6050 still and level")
+			if err := mpu6050.Calibrate(100); err != nil {
+				fmt.Println(err)
+				return
+			}
+			accel, gyro := mpu6050.Offsets()
+			c = calibration{marker, accel, gyro}
+			buf := new(bytes.Buffer)
+			binary.Write(buf, binary.LittleEndian, c)
+			if _, err := eeprom.WriteAt(buf.Bytes(), 0); err != nil {
+				fmt.Println(err)
+			}
+		}
+
+		gobot.On(mpu6050.Event(i2c.Data), func(data interface{}) {
+			fmt.Println(data.(i2c.MPU6050Data).Accelerometer)
+		})
+	}
+
+	robot := gobot.NewRobot("calibratedBot",
+		[]gobot.Connection{r},
+		[]gobot.Device{eeprom, mpu6050},
+		work,
+	)
+
+	gbot.AddRobot(robot)
+
+	gbot.Start()
+}
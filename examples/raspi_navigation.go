@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/i2c"
+	"github.com/hybridgroup/gobot/platforms/raspi"
+)
+
+func main() {
+	gbot := gobot.NewGobot()
+
+	r := raspi.NewRaspiAdaptor("raspi")
+	compass := i2c.NewHMC5883LDriver(r, "compass")
+	// the magnetic declination where the robot is
+	compass.Declination = -1.5
+	tof := i2c.NewVL53L0XDriver(r, "tof")
+	lidar := i2c.NewLIDARLiteDriver(r, "lidar")
+	lidar.Continuous = true
+
+	work := func() {
+		gobot.On(compass.Event(i2c.Data), func(data interface{}) {
+			fmt.Printf("heading %.0f°\n", data.(i2c.HMC5883LData).Heading)
+		})
+
+		gobot.On(tof.Event(i2c.Data), func(data interface{}) {
+			if data.(i2c.VL53L0XData).Distance < 100 {
+				fmt.Println("obstacle ahead")
+			}
+		})
+
+		gobot.On(lidar.Event(i2c.Data), func(data interface{}) {
+			reading := data.(i2c.LIDARLiteData)
+			fmt.Printf("%vcm away, closing at %vcm\n", reading.Distance, -reading.Velocity)
+		})
+		lidar.StartPolling()
+	}
+
+	robot := gobot.NewRobot("navigationBot",
+		[]gobot.Connection{r},
+		[]gobot.Device{compass, tof, lidar},
+		work,
+	)
+
+	gbot.AddRobot(robot)
+
+	gbot.Start()
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+	"github.com/hybridgroup/gobot/platforms/i2c"
+	"github.com/hybridgroup/gobot/platforms/raspi"
+)
+
+func main() {
+	gbot := gobot.NewGobot()
+
+	r := raspi.NewRaspiAdaptor("raspi")
+	pca := i2c.NewPCA9685Driver(r, "pca")
+	pan := gpio.NewServoDriver(pca, "pan", "0")
+	tilt := gpio.NewServoDriver(pca, "tilt", "1")
+
+	work := func() {
+		gobot.Every(1*time.Second, func() {
+			i := uint8(gobot.Rand(180))
+			fmt.Println("Turning", i)
+			pan.Move(i)
+			tilt.Move(180 - i)
+		})
+	}
+
+	robot := gobot.NewRobot("servoBot",
+		[]gobot.Connection{r, pca},
+		[]gobot.Device{pca, pan, tilt},
+		work,
+	)
+
+	gbot.AddRobot(robot)
+
+	gbot.Start()
+}
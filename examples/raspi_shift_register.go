@@ -0,0 +1,33 @@
+package main
+
+import (
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+	"github.com/hybridgroup/gobot/platforms/raspi"
+)
+
+func main() {
+	gbot := gobot.NewGobot()
+
+	r := raspi.NewRaspiAdaptor("raspi")
+	// a 74HC595 with DS, SHCP and STCP on pins 11, 13 and 15
+	shifter := gpio.NewShiftRegisterDriver(r, "shifter", "11", "13", "15")
+	led := gpio.NewLedDriver(shifter, "led", "0")
+
+	work := func() {
+		gobot.Every(1*time.Second, func() {
+			led.Toggle()
+		})
+	}
+
+	robot := gobot.NewRobot("shiftBot",
+		[]gobot.Connection{r},
+		[]gobot.Device{shifter, led},
+		work,
+	)
+
+	gbot.AddRobot(robot)
+	gbot.Start()
+}
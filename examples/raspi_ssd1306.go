@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/i2c"
+	"github.com/hybridgroup/gobot/platforms/raspi"
+)
+
+func main() {
+	gbot := gobot.NewGobot()
+
+	r := raspi.NewRaspiAdaptor("raspi")
+	oled := i2c.NewSSD1306Driver(r, "oled", 128, 64)
+
+	work := func() {
+		oled.DrawText(0, 0, "gobot")
+		// a frame around the status bar
+		bounds := oled.Bounds()
+		white := image.NewUniform(color.White)
+		draw.Draw(oled, image.Rect(0, 16, bounds.Dx(), 17), white, image.ZP, draw.Src)
+		draw.Draw(oled, image.Rect(0, 63, bounds.Dx(), 64), white, image.ZP, draw.Src)
+
+		start := time.Now()
+		gobot.Every(500*time.Millisecond, func() {
+			seconds := int(time.Since(start).Seconds())
+			oled.DrawText(0, 24, fmt.Sprintf("up %4vs", seconds))
+			// a bar growing across the display every minute
+			draw.Draw(oled, image.Rect(0, 40, seconds%60*bounds.Dx()/60, 48), white, image.ZP, draw.Src)
+			if seconds%60 == 0 {
+				draw.Draw(oled, image.Rect(0, 40, bounds.Dx(), 48), image.Black, image.ZP, draw.Src)
+			}
+			oled.Flush()
+		})
+	}
+
+	robot := gobot.NewRobot("oledBot",
+		[]gobot.Connection{r},
+		[]gobot.Device{oled},
+		work,
+	)
+
+	gbot.AddRobot(robot)
+
+	gbot.Start()
+}
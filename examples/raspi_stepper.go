@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+	"github.com/hybridgroup/gobot/platforms/raspi"
+)
+
+func main() {
+	gbot := gobot.NewGobot()
+
+	r := raspi.NewRaspiAdaptor("raspi")
+	// a 28BYJ-48 stepper on a ULN2003 driver board
+	stepper := gpio.NewStepperDriver(r, "stepper", []string{"11", "13", "15", "16"}, 2048)
+
+	work := func() {
+		stepper.SetSpeed(10)
+		stepper.SetAcceleration(500)
+
+		gobot.Every(5*time.Second, func() {
+			if stepper.Position() == 0 {
+				stepper.MoveTo(1024)
+			} else {
+				stepper.MoveTo(0)
+			}
+			fmt.Println("Position", stepper.Position())
+		})
+	}
+
+	robot := gobot.NewRobot("stepperBot",
+		[]gobot.Connection{r},
+		[]gobot.Device{stepper},
+		work,
+	)
+
+	gbot.AddRobot(robot)
+	gbot.Start()
+}
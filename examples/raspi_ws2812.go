@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/raspi"
+	"github.com/hybridgroup/gobot/platforms/spi"
+)
+
+// The data line of the strip goes to the MOSI pin, pin 19
+func main() {
+	gbot := gobot.NewGobot()
+
+	r := raspi.NewRaspiAdaptor("raspi")
+	strip := spi.NewWS2812Driver(r, "strip", 0, 0, 30)
+	strip.Gamma = 2.8
+
+	work := func() {
+		strip.AnimateEffect("rainbow", map[string]interface{}{"period": 3000.0})
+	}
+
+	robot := gobot.NewRobot("stripBot",
+		[]gobot.Connection{r},
+		[]gobot.Device{strip},
+		work,
+	)
+
+	gbot.AddRobot(robot)
+
+	gbot.Start()
+}
@@ -1,5 +1,6 @@
 /*
-CLI tool for generating new Gobot projects.
+CLI tool for generating new Gobot projects, and finding the devices on the
+i2c bus of a board.
 
 	NAME:
 		 gobot - Command Line Utility for Gobot
@@ -12,6 +13,7 @@ CLI tool for generating new Gobot projects.
 
 	COMMANDS:
 		 generate     Generate new Gobot skeleton project
+		 i2c          Scan the i2c bus of a board for devices
 		 help, h      Shows a list of commands or help for one command
 
 	GLOBAL OPTIONS:
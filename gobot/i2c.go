@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/codegangsta/cli"
+	"github.com/hybridgroup/gobot/platforms/beaglebone"
+	"github.com/hybridgroup/gobot/platforms/i2c"
+	"github.com/hybridgroup/gobot/platforms/intel-iot/edison"
+	"github.com/hybridgroup/gobot/platforms/raspi"
+)
+
+// newFirmataI2c returns the adaptor of the firmata board at port. It is only
+// set when gobot is built with the firmata tag, see i2c_firmata.go, sparing
+// the other boards the firmata adaptor and its serial dependencies.
+var newFirmataI2c func(port string) i2c.I2c
+
+func I2c() cli.Command {
+	return cli.Command{
+		Name:  "i2c",
+		Usage: "Scan the i2c bus of a board for devices",
+		Action: func(c *cli.Context) {
+			if c.Args().First() != "scan" || len(c.Args()) < 2 {
+				fmt.Println("Invalid/no subcommand supplied.")
+				fmt.Println()
+				fmt.Println("Usage:")
+				fmt.Println(" gobot i2c scan raspi           # scan the i2c bus of a Raspberry Pi")
+				fmt.Println(" gobot i2c scan beaglebone      # scan the i2c bus of a Beaglebone Black")
+				fmt.Println(" gobot i2c scan edison          # scan the i2c bus of an Intel Edison")
+				fmt.Println(" gobot i2c scan firmata <port>  # scan the i2c bus of a firmata board, when built with -tags firmata")
+				return
+			}
+
+			var adaptor i2c.I2c
+			switch c.Args()[1] {
+			case "raspi":
+				adaptor = raspi.NewRaspiAdaptor("raspi")
+			case "beaglebone":
+				adaptor = beaglebone.NewBeagleboneAdaptor("beaglebone")
+			case "edison":
+				adaptor = edison.NewEdisonAdaptor("edison")
+			case "firmata":
+				if newFirmataI2c == nil {
+					fmt.Println("Build gobot with -tags firmata to scan firmata boards.")
+					return
+				}
+				if len(c.Args()) < 3 {
+					fmt.Println("Please provide the port of the firmata board.")
+					return
+				}
+				adaptor = newFirmataI2c(c.Args()[2])
+			default:
+				fmt.Println("Unknown board", c.Args()[1])
+				return
+			}
+
+			if err := scanI2c(adaptor); err != nil {
+				fmt.Println(err)
+			}
+		},
+	}
+}
+
+func scanI2c(adaptor i2c.I2c) error {
+	if errs := adaptor.Connect(); len(errs) > 0 {
+		return errs[0]
+	}
+	defer adaptor.Finalize()
+
+	devices, err := i2c.NewScanner(adaptor).Scan()
+	if err != nil {
+		return err
+	}
+	if len(devices) == 0 {
+		fmt.Println("No i2c devices found.")
+		return nil
+	}
+	for _, d := range devices {
+		fmt.Println(d)
+	}
+	return nil
+}
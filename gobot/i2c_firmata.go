@@ -0,0 +1,14 @@
+// +build firmata
+
+package main
+
+import (
+	"github.com/hybridgroup/gobot/platforms/firmata"
+	"github.com/hybridgroup/gobot/platforms/i2c"
+)
+
+func init() {
+	newFirmataI2c = func(port string) i2c.I2c {
+		return firmata.NewFirmataAdaptor("firmata", port)
+	}
+}
@@ -8,10 +8,12 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hybridgroup/gobot"
 	"github.com/hybridgroup/gobot/platforms/gpio"
 	"github.com/hybridgroup/gobot/platforms/i2c"
+	"github.com/hybridgroup/gobot/platforms/spi"
 	"github.com/hybridgroup/gobot/sysfs"
 )
 
@@ -19,12 +21,17 @@ var _ gobot.Adaptor = (*BeagleboneAdaptor)(nil)
 
 var _ gpio.DigitalReader = (*BeagleboneAdaptor)(nil)
 var _ gpio.DigitalWriter = (*BeagleboneAdaptor)(nil)
+var _ gpio.PulseReader = (*BeagleboneAdaptor)(nil)
+var _ gpio.TriggeredPulseReader = (*BeagleboneAdaptor)(nil)
 var _ gpio.AnalogReader = (*BeagleboneAdaptor)(nil)
 var _ gpio.PwmWriter = (*BeagleboneAdaptor)(nil)
+var _ gpio.PwmPeriodWriter = (*BeagleboneAdaptor)(nil)
 var _ gpio.ServoWriter = (*BeagleboneAdaptor)(nil)
 
 var _ i2c.I2c = (*BeagleboneAdaptor)(nil)
 
+var _ spi.SpiTransferer = (*BeagleboneAdaptor)(nil)
+
 var slots = "/sys/devices/bone_capemgr.*"
 var ocp = "/sys/devices/ocp.*"
 var usrLed = "/sys/devices/ocp.3/gpio-leds.8/leds/beaglebone:green:"
@@ -129,6 +136,7 @@ type BeagleboneAdaptor struct {
 	digitalPins []sysfs.DigitalPin
 	pwmPins     map[string]*pwmPin
 	i2cDevice   sysfs.I2cDevice
+	spiDevices  map[string]sysfs.SpiDevice
 	ocp         string
 	helper      string
 	slots       string
@@ -140,6 +148,7 @@ func NewBeagleboneAdaptor(name string) *BeagleboneAdaptor {
 		name:        name,
 		digitalPins: make([]sysfs.DigitalPin, 120),
 		pwmPins:     make(map[string]*pwmPin),
+		spiDevices:  make(map[string]sysfs.SpiDevice),
 	}
 
 	g, _ := glob(ocp)
@@ -193,6 +202,11 @@ func (b *BeagleboneAdaptor) Finalize() (errs []error) {
 			errs = append(errs, err)
 		}
 	}
+	for _, device := range b.spiDevices {
+		if err := device.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	return
 }
 
@@ -201,6 +215,17 @@ func (b *BeagleboneAdaptor) PwmWrite(pin string, val byte) (err error) {
 	return b.pwmWrite(pin, val)
 }
 
+// PwmPeriodWrite drives the specified pin with a signal of period, high for the
+// 0-255 fraction duty of each period
+func (b *BeagleboneAdaptor) PwmPeriodWrite(pin string, period time.Duration, duty byte) (err error) {
+	i, err := b.pwmPin(pin)
+	if err != nil {
+		return err
+	}
+	high := gobot.FromScale(float64(duty), 0, 255.0)
+	return b.pwmPins[i].periodWrite(strconv.Itoa(int(period)), strconv.Itoa(int(float64(period)*high)))
+}
+
 // ServoWrite writes the 0-180 degree val to the specified pin.
 func (b *BeagleboneAdaptor) ServoWrite(pin string, val byte) (err error) {
 	i, err := b.pwmPin(pin)
@@ -240,6 +265,52 @@ func (b *BeagleboneAdaptor) DigitalWrite(pin string, val byte) (err error) {
 	return sysfsPin.Write(int(val))
 }
 
+// PulseRead waits for pin to go to level and returns how long it stays there,
+// timing the edges through the interrupts of the pin. If trigger is not 0, pin
+// is driven to level for trigger once its interrupts are ready, and is then
+// returned to an input.
+func (b *BeagleboneAdaptor) PulseRead(pin string, level int, trigger time.Duration, timeout time.Duration) (time.Duration, error) {
+	if trigger > 0 {
+		return b.TriggeredPulseRead(pin, pin, level, trigger, timeout)
+	}
+	return b.pulse(pin, level, nil, timeout)
+}
+
+// TriggeredPulseRead drives triggerPin to level for trigger once the
+// interrupts of pin are ready, then returns how long pin stays at level.
+// triggerPin may be pin itself.
+func (b *BeagleboneAdaptor) TriggeredPulseRead(triggerPin string, pin string, level int, trigger time.Duration, timeout time.Duration) (time.Duration, error) {
+	return b.pulse(pin, level, func() (err error) {
+		if err = b.trigger(triggerPin, level, trigger); err != nil || triggerPin != pin {
+			return
+		}
+		_, err = b.digitalPin(pin, sysfs.IN)
+		return
+	}, timeout)
+}
+
+// pulse times a pulse at level on pin, started by trigger when not nil
+func (b *BeagleboneAdaptor) pulse(pin string, level int, trigger func() error, timeout time.Duration) (time.Duration, error) {
+	sysfsPin, err := b.digitalPin(pin, sysfs.IN)
+	if err != nil {
+		return 0, err
+	}
+	pulsePin, ok := sysfsPin.(sysfs.PulsePin)
+	if !ok {
+		return 0, sysfs.ErrPulseUnsupported
+	}
+	return pulsePin.Pulse(level, trigger, timeout)
+}
+
+// trigger drives pin to level for duration
+func (b *BeagleboneAdaptor) trigger(pin string, level int, duration time.Duration) (err error) {
+	if err = b.DigitalWrite(pin, byte(level)); err != nil {
+		return
+	}
+	time.Sleep(duration)
+	return b.DigitalWrite(pin, byte(level^1))
+}
+
 // AnalogRead returns an analog value from specified pin
 func (b *BeagleboneAdaptor) AnalogRead(pin string) (val int, err error) {
 	analogPin, err := b.translateAnalogPin(pin)
@@ -290,6 +361,30 @@ func (b *BeagleboneAdaptor) I2cRead(address int, size int) (data []byte, err err
 	return
 }
 
+// SpiStart opens the spidev device for the given bus and chip select, eg. bus 1
+// chip 0 is /dev/spidev1.0 once the BB-SPIDEV0 overlay has been loaded
+func (b *BeagleboneAdaptor) SpiStart(bus int, chip int, mode int, bits int, maxSpeed int) (err error) {
+	location := fmt.Sprintf("/dev/spidev%v.%v", bus, chip)
+	if b.spiDevices[location] != nil {
+		return
+	}
+	device, err := sysfs.NewSpiDevice(location, byte(mode), byte(bits), uint32(maxSpeed))
+	if err != nil {
+		return
+	}
+	b.spiDevices[location] = device
+	return
+}
+
+// SpiTransfer writes tx to the spi device and returns the bytes read back
+func (b *BeagleboneAdaptor) SpiTransfer(bus int, chip int, tx []byte) (rx []byte, err error) {
+	device, ok := b.spiDevices[fmt.Sprintf("/dev/spidev%v.%v", bus, chip)]
+	if !ok {
+		return nil, spi.ErrNotStarted
+	}
+	return device.Transfer(tx)
+}
+
 // translatePin converts digital pin name to pin position
 func (b *BeagleboneAdaptor) translatePin(pin string) (value int, err error) {
 	for key, value := range pins {
@@ -4,8 +4,10 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/spi"
 	"github.com/hybridgroup/gobot/sysfs"
 )
 
@@ -99,6 +101,18 @@ func TestBeagleboneAdaptor(t *testing.T) {
 		"1898148",
 	)
 
+	a.PwmPeriodWrite("P9_14", 2*time.Millisecond, 128)
+	gobot.Assert(
+		t,
+		fs.Files["/sys/devices/ocp.3/pwm_test_P9_14.5/period"].Contents,
+		"2000000",
+	)
+	gobot.Assert(
+		t,
+		fs.Files["/sys/devices/ocp.3/pwm_test_P9_14.5/duty"].Contents,
+		"1003921",
+	)
+
 	// Analog
 	fs.Files["/sys/devices/ocp.3/helper.5/AIN1"].Contents = "567\n"
 	i, _ := a.AnalogRead("P9_40")
@@ -133,5 +147,22 @@ func TestBeagleboneAdaptor(t *testing.T) {
 	data, _ := a.I2cRead(0xff, 2)
 	gobot.Assert(t, data, []byte{0x00, 0x01})
 
+	// Spi
+	_, err = a.SpiTransfer(1, 0, []byte{0x01})
+	gobot.Assert(t, err, spi.ErrNotStarted)
+
+	fs.Add("/dev/spidev1.0")
+	gobot.Assert(t, a.SpiStart(1, 0, spi.Mode0, 8, 500000), nil)
+
+	device := sysfs.NewMockSpiDevice()
+	device.Respond = func(tx []byte) []byte {
+		return []byte{0x12, 0x34}
+	}
+	a.spiDevices["/dev/spidev1.0"] = device
+
+	data, _ = a.SpiTransfer(1, 0, []byte{0x00, 0x00})
+	gobot.Assert(t, data, []byte{0x12, 0x34})
+
 	gobot.Assert(t, len(a.Finalize()), 0)
+	gobot.Assert(t, device.Closed, true)
 }
@@ -98,6 +98,20 @@ func (p *pwmPin) pwmWrite(period string, duty string) (err error) {
 	return
 }
 
+// periodWrite sets a new period and duty, clearing the duty first as it may
+// not exceed the period
+func (p *pwmPin) periodWrite(period string, duty string) (err error) {
+	fi, err := sysfs.OpenFile(fmt.Sprintf("%v/duty", p.pwmDevice), os.O_WRONLY|os.O_APPEND, 0666)
+	defer fi.Close()
+	if err != nil {
+		return
+	}
+	if _, err = fi.WriteString("0"); err != nil {
+		return
+	}
+	return p.pwmWrite(period, duty)
+}
+
 // releae writes string to close a pwm pin
 func (p *pwmPin) release() (err error) {
 	fi, err := sysfs.OpenFile(fmt.Sprintf("%v/run", p.pwmDevice), os.O_WRONLY|os.O_APPEND, 0666)
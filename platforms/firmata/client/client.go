@@ -3,13 +3,16 @@
 package client
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"math"
+	"sync"
 	"time"
 
 	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/serial"
 )
 
 // Pin Modes
@@ -52,6 +55,11 @@ const (
 	I2CModeContinuousRead    byte = 0x02
 	I2CModeStopReading       byte = 0x03
 	ServoConfig              byte = 0x70
+	PulseIn                  byte = 0x75
+	PixelCommand             byte = 0x51
+	PixelConfig              byte = 0x01
+	PixelShow                byte = 0x02
+	PixelSetPixel            byte = 0x03
 )
 
 // Errors
@@ -59,6 +67,13 @@ var (
 	ErrConnected = errors.New("client is already connected")
 )
 
+// sysexFramer splits sysex messages out of the stream
+var sysexFramer = &serial.Delimited{
+	Start:     []byte{StartSysex},
+	End:       []byte{EndSysex},
+	MaxLength: 4096,
+}
+
 // Client represents a client connection to a firmata board
 type Client struct {
 	pins             []Pin
@@ -68,6 +83,8 @@ type Client struct {
 	connection       io.ReadWriteCloser
 	analogPins       []int
 	initTimeInterval time.Duration
+	digitalMutex     sync.Mutex
+	digitalHandler   func(pin int, value int)
 	gobot.Eventer
 }
 
@@ -291,6 +308,45 @@ func (b *Client) I2cConfig(delay int) error {
 	return b.writeSysex([]byte{I2CConfig, byte(delay & 0xFF), byte((delay >> 8) & 0xFF)})
 }
 
+// PulseIn requests the duration of a pulse at value on pin. If pulseOut is not
+// 0, the board first drives pin to value for pulseOut microseconds. The board
+// replies with a "PulseIn<pin>" event carrying the duration in microseconds, 0
+// if no pulse ended within timeout microseconds.
+func (b *Client) PulseIn(pin int, value int, pulseOut int, timeout int) error {
+	ret := []byte{PulseIn, byte(pin), byte(value)}
+	for _, val := range []int{pulseOut, timeout} {
+		for shift := uint(24); ; shift -= 8 {
+			ret = append(ret, byte(val>>shift)&0x7F, byte(val>>shift)>>7)
+			if shift == 0 {
+				break
+			}
+		}
+	}
+	return b.writeSysex(ret)
+}
+
+// PixelConfig sets up a strip of length WS2812 LEDs, which take their colors
+// green first, on pin for the pixel sysex of node-pixel firmware
+func (b *Client) PixelConfig(pin int, length int) error {
+	return b.writeSysex([]byte{PixelCommand, PixelConfig,
+		byte(pin) & 0x1F, byte(length) & 0x7F, byte(length>>7) & 0x7F})
+}
+
+// PixelSet sets the color of the LED at index of the strip, shown once
+// PixelShow is called
+func (b *Client) PixelSet(index int, red, green, blue byte) error {
+	color := int(red)<<16 | int(green)<<8 | int(blue)
+	return b.writeSysex([]byte{PixelCommand, PixelSetPixel,
+		byte(index) & 0x7F, byte(index>>7) & 0x7F,
+		byte(color) & 0x7F, byte(color>>7) & 0x7F,
+		byte(color>>14) & 0x7F, byte(color>>21) & 0x7F})
+}
+
+// PixelShow shows the colors set on the strip
+func (b *Client) PixelShow() error {
+	return b.writeSysex([]byte{PixelCommand, PixelShow})
+}
+
 func (b *Client) togglePinReporting(pin int, state int, mode byte) error {
 	if state != 0 {
 		state = 1
@@ -306,6 +362,16 @@ func (b *Client) togglePinReporting(pin int, state int, mode byte) error {
 
 }
 
+// HandleDigital sets fn to be called with the value of each digital input pin
+// the board reports. Unlike the handlers of the DigitalRead events, which are
+// run concurrently, fn is called from the loop reading the board, in the order
+// the reports arrive.
+func (b *Client) HandleDigital(fn func(pin int, value int)) {
+	b.digitalMutex.Lock()
+	defer b.digitalMutex.Unlock()
+	b.digitalHandler = fn
+}
+
 func (b *Client) writeSysex(data []byte) (err error) {
 	return b.write(append([]byte{StartSysex}, append(data, EndSysex)...))
 }
@@ -316,21 +382,27 @@ func (b *Client) write(data []byte) (err error) {
 }
 
 func (b *Client) read(length int) (buf []byte, err error) {
-	i := 0
-	for length > 0 {
-		tmp := make([]byte, length)
-		if i, err = b.connection.Read(tmp); err != nil {
-			if err.Error() != "EOF" {
-				return
-			}
-			<-time.After(5 * time.Millisecond)
+	buf = make([]byte, length)
+	_, err = io.ReadFull(connectionReader{b.connection}, buf)
+	return
+}
+
+// connectionReader reads from a connection, waiting for data instead of
+// returning the EOF a serial port reports while none has arrived
+type connectionReader struct {
+	io.Reader
+}
+
+func (r connectionReader) Read(buf []byte) (n int, err error) {
+	for {
+		if n, err = r.Reader.Read(buf); err == nil || err.Error() != "EOF" {
+			return
 		}
-		if i > 0 {
-			buf = append(buf, tmp...)
-			length = length - i
+		if n > 0 {
+			return n, nil
 		}
+		<-time.After(5 * time.Millisecond)
 	}
-	return
 }
 
 func (b *Client) process() (err error) {
@@ -362,26 +434,29 @@ func (b *Client) process() (err error) {
 		port := messageType & 0x0F
 		portValue := buf[1] | (buf[2] << 7)
 
+		b.digitalMutex.Lock()
+		handler := b.digitalHandler
+		b.digitalMutex.Unlock()
+
 		for i := 0; i < 8; i++ {
 			pinNumber := int((8*byte(port) + byte(i)))
 			if len(b.pins) > pinNumber {
 				if b.pins[pinNumber].Mode == Input {
 					b.pins[pinNumber].Value = int((portValue >> (byte(i) & 0x07)) & 0x01)
+					if handler != nil {
+						handler(pinNumber, b.pins[pinNumber].Value)
+					}
 					gobot.Publish(b.Event(fmt.Sprintf("DigitalRead%v", pinNumber)), b.pins[pinNumber].Value)
 				}
 			}
 		}
 	case StartSysex == messageType:
-		currentBuffer := buf
-		for {
-			buf, err = b.read(1)
-			if err != nil {
-				return err
-			}
-			currentBuffer = append(currentBuffer, buf[0])
-			if buf[0] == EndSysex {
-				break
-			}
+		// the message continues up to EndSysex, which may already have been read
+		currentBuffer, err := sysexFramer.ReadFrame(
+			io.MultiReader(bytes.NewReader(buf), connectionReader{b.connection}),
+		)
+		if err != nil {
+			return err
 		}
 		command := currentBuffer[1]
 		switch command {
@@ -402,6 +477,7 @@ func (b *Client) process() (err error) {
 					b.pins = append(b.pins, Pin{SupportedModes: modes, Mode: Output})
 					b.AddEvent(fmt.Sprintf("DigitalRead%v", len(b.pins)-1))
 					b.AddEvent(fmt.Sprintf("PinState%v", len(b.pins)-1))
+					b.AddEvent(fmt.Sprintf("PulseIn%v", len(b.pins)-1))
 					supportedModes = 0
 					n = 0
 					continue
@@ -459,6 +535,13 @@ func (b *Client) process() (err error) {
 				)
 			}
 			gobot.Publish(b.Event("I2cReply"), reply)
+		case PulseIn:
+			pin := int(currentBuffer[2]) | int(currentBuffer[3])<<7
+			duration := 0
+			for i := 4; i < 12; i += 2 {
+				duration = duration<<8 | int(currentBuffer[i]) | int(currentBuffer[i+1])<<7
+			}
+			gobot.Publish(b.Event(fmt.Sprintf("PulseIn%v", pin)), duration)
 		case FirmwareQuery:
 			name := []byte{}
 			for _, val := range currentBuffer[4:(len(currentBuffer) - 1)] {
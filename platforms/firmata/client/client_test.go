@@ -97,6 +97,64 @@ func TestQueryPinState(t *testing.T) {
 	b.PinStateQuery(1)
 }
 
+type writeRecorder struct {
+	readWriteCloser
+	written []byte
+}
+
+func (w *writeRecorder) Write(p []byte) (int, error) {
+	w.written = append(w.written, p...)
+	return len(p), nil
+}
+
+func TestPulseIn(t *testing.T) {
+	b := initTestFirmata()
+	w := &writeRecorder{}
+	b.connection = w
+
+	gobot.Assert(t, b.PulseIn(7, 1, 10, 1000000), nil)
+	gobot.Assert(t, w.written, []byte{240, 117, 7, 1,
+		0, 0, 0, 0, 0, 0, 10, 0,
+		0, 0, 15, 0, 66, 0, 64, 0,
+		247})
+}
+
+func TestPixel(t *testing.T) {
+	b := initTestFirmata()
+	w := &writeRecorder{}
+	b.connection = w
+
+	gobot.Assert(t, b.PixelConfig(6, 150), nil)
+	gobot.Assert(t, b.PixelSet(130, 0xFF, 0x80, 0x01), nil)
+	gobot.Assert(t, b.PixelShow(), nil)
+	gobot.Assert(t, w.written, []byte{
+		240, 0x51, 0x01, 6, 22, 1, 247,
+		240, 0x51, 0x03, 2, 1, 1, 0, 126, 7, 247,
+		240, 0x51, 0x02, 247,
+	})
+}
+
+func TestHandleDigital(t *testing.T) {
+	b := initTestFirmata()
+	b.pins[2].Mode = Input
+	b.pins[4].Mode = Input
+	reports := [][2]int{}
+	b.HandleDigital(func(pin int, value int) {
+		reports = append(reports, [2]int{pin, value})
+	})
+
+	// the whole port is reported, with pin 2 unchanged
+	for _, data := range [][]byte{{0x90, 0x04, 0x00}, {0x90, 0x14, 0x00}, {0x90, 0x00, 0x00}} {
+		testReadData = data
+		gobot.Assert(t, b.process(), nil)
+	}
+	gobot.Assert(t, reports, [][2]int{
+		{2, 1}, {4, 0},
+		{2, 1}, {4, 1},
+		{2, 0}, {4, 0},
+	})
+}
+
 func TestProcess(t *testing.T) {
 	sem := make(chan bool)
 	b := initTestFirmata()
@@ -153,6 +211,12 @@ func TestProcess(t *testing.T) {
 			},
 			init: func() {},
 		},
+		{
+			event:    "PulseIn7",
+			data:     []byte{240, 117, 7, 0, 0, 0, 0, 0, 5, 0, 92, 1, 247},
+			expected: 1500,
+			init:     func() {},
+		},
 		{
 			event: "FirmwareQuery",
 			data: []byte{240, 121, 2, 3, 83, 0, 116, 0, 97, 0, 110, 0, 100, 0, 97,
@@ -167,6 +231,12 @@ func TestProcess(t *testing.T) {
 			expected: "Hello Firmata!",
 			init:     func() {},
 		},
+		{
+			event:    "StringData",
+			data:     []byte{240, 0x71, 247},
+			expected: "",
+			init:     func() {},
+		},
 	}
 
 	for _, test := range tests {
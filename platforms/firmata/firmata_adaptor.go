@@ -1,24 +1,31 @@
 package firmata
 
 import (
+	"errors"
+	"fmt"
+	"image/color"
 	"io"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/hybridgroup/gobot"
 	"github.com/hybridgroup/gobot/platforms/firmata/client"
 	"github.com/hybridgroup/gobot/platforms/gpio"
 	"github.com/hybridgroup/gobot/platforms/i2c"
-	"github.com/tarm/goserial"
+	"github.com/hybridgroup/gobot/serial"
 )
 
 var _ gobot.Adaptor = (*FirmataAdaptor)(nil)
 
 var _ gpio.DigitalReader = (*FirmataAdaptor)(nil)
+var _ gpio.DigitalNotifier = (*FirmataAdaptor)(nil)
+var _ gpio.PulseReader = (*FirmataAdaptor)(nil)
 var _ gpio.DigitalWriter = (*FirmataAdaptor)(nil)
 var _ gpio.AnalogReader = (*FirmataAdaptor)(nil)
 var _ gpio.PwmWriter = (*FirmataAdaptor)(nil)
 var _ gpio.ServoWriter = (*FirmataAdaptor)(nil)
+var _ gpio.PixelWriter = (*FirmataAdaptor)(nil)
 
 var _ i2c.I2c = (*FirmataAdaptor)(nil)
 
@@ -34,9 +41,17 @@ type firmataBoard interface {
 	I2cRead(int, int) error
 	I2cWrite(int, []byte) error
 	I2cConfig(int) error
+	PulseIn(int, int, int, int) error
+	PixelConfig(int, int) error
+	PixelSet(int, byte, byte, byte) error
+	PixelShow() error
+	HandleDigital(func(pin int, value int))
 	Event(string) *gobot.Event
 }
 
+// ErrPulseTimeout is returned by PulseRead when the board measures no pulse
+var ErrPulseTimeout = errors.New("timed out waiting for pulse")
+
 // FirmataAdaptor is the Gobot Adaptor for Firmata based boards
 type FirmataAdaptor struct {
 	name   string
@@ -44,6 +59,18 @@ type FirmataAdaptor struct {
 	board  firmataBoard
 	conn   io.ReadWriteCloser
 	openSP func(port string) (io.ReadWriteCloser, error)
+	// the strip of LEDs last configured by PixelWrite and its colors
+	pixelMutex sync.Mutex
+	pixelPin   int
+	pixels     []color.RGBA
+	// the DigitalNotify callbacks of each pin, called in order from the loop
+	// reading the board, as the handlers of its events run concurrently
+	notifyMutex sync.Mutex
+	notifiers   map[int][]func(val int)
+	// the channel of the PulseRead waiting on each pin, sent the duration by
+	// a single handler of the pin's board event
+	pulseMutex sync.Mutex
+	pulses     map[int]chan int
 }
 
 // NewFirmataAdaptor returns a new FirmataAdaptor with specified name and optionally accepts:
@@ -57,10 +84,12 @@ type FirmataAdaptor struct {
 // string port as a label to be displayed in the log and api.
 func NewFirmataAdaptor(name string, args ...interface{}) *FirmataAdaptor {
 	f := &FirmataAdaptor{
-		name:  name,
-		port:  "",
-		conn:  nil,
-		board: client.New(),
+		name:      name,
+		port:      "",
+		conn:      nil,
+		board:     client.New(),
+		notifiers: make(map[int][]func(val int)),
+		pulses:    make(map[int]chan int),
 		openSP: func(port string) (io.ReadWriteCloser, error) {
 			return serial.OpenPort(&serial.Config{Name: port, Baud: 57600})
 		},
@@ -188,6 +217,137 @@ func (f *FirmataAdaptor) DigitalRead(pin string) (val int, err error) {
 	return f.board.Pins()[p].Value, nil
 }
 
+// DigitalNotify calls f with the value of pin every time the board reports it,
+// in the order the reports arrive. Firmata reports a whole port of 8 pins
+// whenever any of them changes, so f is also called with an unchanged value
+// when another pin of the same port changes.
+func (f *FirmataAdaptor) DigitalNotify(pin string, fn func(val int)) (err error) {
+	if _, err = f.DigitalRead(pin); err != nil {
+		return
+	}
+	p, _ := strconv.Atoi(pin)
+
+	if f.board.Event(fmt.Sprintf("DigitalRead%v", p)) == nil {
+		return errors.New("firmata: board does not report pin " + pin)
+	}
+
+	f.notifyMutex.Lock()
+	defer f.notifyMutex.Unlock()
+	f.notifiers[p] = append(f.notifiers[p], fn)
+	f.board.HandleDigital(f.notify)
+	return
+}
+
+// notify calls the DigitalNotify callbacks of pin with its value
+func (f *FirmataAdaptor) notify(pin int, value int) {
+	f.notifyMutex.Lock()
+	notifiers := f.notifiers[pin]
+	f.notifyMutex.Unlock()
+	for _, fn := range notifiers {
+		fn(value)
+	}
+}
+
+// DigitalUnnotify stops calling the functions given to DigitalNotify for pin
+func (f *FirmataAdaptor) DigitalUnnotify(pin string) (err error) {
+	p, err := strconv.Atoi(pin)
+	if err != nil {
+		return
+	}
+
+	f.notifyMutex.Lock()
+	defer f.notifyMutex.Unlock()
+	if _, ok := f.notifiers[p]; ok {
+		f.notifiers[p] = nil
+	}
+	return
+}
+
+// PulseRead waits for pin to go to level and returns how long it stays there,
+// as measured by the board through the pulse-in sysex, which the firmware has
+// to support. If trigger is not 0, pin is first driven to level for trigger.
+func (f *FirmataAdaptor) PulseRead(pin string, level int, trigger time.Duration, timeout time.Duration) (duration time.Duration, err error) {
+	p, err := strconv.Atoi(pin)
+	if err != nil {
+		return
+	}
+
+	e := f.board.Event(fmt.Sprintf("PulseIn%v", p))
+	if e == nil {
+		return 0, errors.New("firmata: board does not report pin " + pin)
+	}
+	ret := make(chan int, 1)
+	f.pulseMutex.Lock()
+	// the event handler is kept once added, dropping replies which come after
+	// their PulseRead timed out
+	if _, ok := f.pulses[p]; !ok {
+		gobot.On(e, func(data interface{}) {
+			f.pulseMutex.Lock()
+			defer f.pulseMutex.Unlock()
+			if ret := f.pulses[p]; ret != nil {
+				ret <- data.(int)
+				f.pulses[p] = nil
+			}
+		})
+	}
+	f.pulses[p] = ret
+	f.pulseMutex.Unlock()
+	defer func() {
+		f.pulseMutex.Lock()
+		if f.pulses[p] == ret {
+			f.pulses[p] = nil
+		}
+		f.pulseMutex.Unlock()
+	}()
+
+	if err = f.board.PulseIn(p, level, int(trigger/time.Microsecond), int(timeout/time.Microsecond)); err != nil {
+		return
+	}
+
+	select {
+	case micros := <-ret:
+		if micros == 0 {
+			return 0, ErrPulseTimeout
+		}
+		return time.Duration(micros) * time.Microsecond, nil
+	case <-time.After(timeout + time.Second):
+		return 0, ErrPulseTimeout
+	}
+}
+
+// PixelWrite shows colors on the strip of WS2812 LEDs on pin, through the
+// pixel sysex of node-pixel firmware. Only the LEDs whose colors have changed
+// since the last write are sent to the board.
+func (f *FirmataAdaptor) PixelWrite(pin string, colors []color.RGBA) (err error) {
+	p, err := strconv.Atoi(pin)
+	if err != nil {
+		return
+	}
+
+	f.pixelMutex.Lock()
+	defer f.pixelMutex.Unlock()
+
+	if f.pixels == nil || p != f.pixelPin || len(colors) != len(f.pixels) {
+		if err = f.board.PixelConfig(p, len(colors)); err != nil {
+			return
+		}
+		f.pixelPin = p
+		f.pixels = make([]color.RGBA, len(colors))
+		// a newly configured strip starts with every LED off
+	}
+
+	for i, c := range colors {
+		if c.R == f.pixels[i].R && c.G == f.pixels[i].G && c.B == f.pixels[i].B {
+			continue
+		}
+		if err = f.board.PixelSet(i, c.R, c.G, c.B); err != nil {
+			return
+		}
+		f.pixels[i] = c
+	}
+	return f.board.PixelShow()
+}
+
 // AnalogRead retrieves value from analog pin.
 // Returns -1 if the response from the board has timed out
 func (f *FirmataAdaptor) AnalogRead(pin string) (val int, err error) {
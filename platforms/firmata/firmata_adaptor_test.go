@@ -2,6 +2,8 @@ package firmata
 
 import (
 	"errors"
+	"fmt"
+	"image/color"
 	"io"
 	"testing"
 	"time"
@@ -35,6 +37,9 @@ func (readWriteCloser) Close() error {
 
 type mockFirmataBoard struct {
 	disconnectError error
+	pulseDuration   int
+	pixelWrites     []string
+	digitalHandler  func(pin int, value int)
 	gobot.Eventer
 	pins []client.Pin
 }
@@ -50,6 +55,8 @@ func newMockFirmataBoard() *mockFirmataBoard {
 	m.pins[15].Value = 133
 
 	m.AddEvent("I2cReply")
+	m.AddEvent("DigitalRead1")
+	m.AddEvent("PulseIn7")
 	return m
 }
 
@@ -68,6 +75,31 @@ func (mockFirmataBoard) DigitalWrite(int, int) error  { return nil }
 func (mockFirmataBoard) I2cRead(int, int) error       { return nil }
 func (mockFirmataBoard) I2cWrite(int, []byte) error   { return nil }
 func (mockFirmataBoard) I2cConfig(int) error          { return nil }
+func (m mockFirmataBoard) PulseIn(pin int, value int, pulseOut int, timeout int) error {
+	// a negative duration is a reply which never comes
+	if m.pulseDuration < 0 {
+		return nil
+	}
+	gobot.Publish(m.Event(fmt.Sprintf("PulseIn%v", pin)), m.pulseDuration)
+	return nil
+}
+
+func (m *mockFirmataBoard) PixelConfig(pin int, length int) error {
+	m.pixelWrites = append(m.pixelWrites, fmt.Sprintf("config %v %v", pin, length))
+	return nil
+}
+func (m *mockFirmataBoard) PixelSet(index int, red, green, blue byte) error {
+	m.pixelWrites = append(m.pixelWrites, fmt.Sprintf("set %v %v %v %v", index, red, green, blue))
+	return nil
+}
+func (m *mockFirmataBoard) HandleDigital(fn func(pin int, value int)) {
+	m.digitalHandler = fn
+}
+
+func (m *mockFirmataBoard) PixelShow() error {
+	m.pixelWrites = append(m.pixelWrites, "show")
+	return nil
+}
 
 func initTestFirmataAdaptor() *FirmataAdaptor {
 	a := NewFirmataAdaptor("board", "/dev/null")
@@ -138,6 +170,70 @@ func TestFirmataAdaptorDigitalRead(t *testing.T) {
 	gobot.Assert(t, val, 1)
 }
 
+func TestFirmataAdaptorDigitalNotify(t *testing.T) {
+	a := initTestFirmataAdaptor()
+	board := a.board.(*mockFirmataBoard)
+	vals := []int{}
+	gobot.Assert(t, a.DigitalNotify("1", func(val int) {
+		vals = append(vals, val)
+	}), nil)
+
+	// the values are passed on in the order the board reports them
+	for _, val := range []int{0, 1, 1, 0} {
+		board.digitalHandler(1, val)
+	}
+	board.digitalHandler(3, 1)
+	gobot.Assert(t, vals, []int{0, 1, 1, 0})
+
+	gobot.Refute(t, a.DigitalNotify("2", func(int) {}), nil)
+
+	gobot.Assert(t, a.DigitalUnnotify("1"), nil)
+	board.digitalHandler(1, 1)
+	gobot.Assert(t, vals, []int{0, 1, 1, 0})
+	gobot.Refute(t, a.DigitalUnnotify("x"), nil)
+}
+
+func TestFirmataAdaptorPulseRead(t *testing.T) {
+	a := initTestFirmataAdaptor()
+	a.board.(*mockFirmataBoard).pulseDuration = 1500
+
+	duration, err := a.PulseRead("7", 1, 10*time.Microsecond, time.Second)
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, duration, 1500*time.Microsecond)
+
+	a.board.(*mockFirmataBoard).pulseDuration = 0
+	_, err = a.PulseRead("7", 1, 0, time.Second)
+	gobot.Assert(t, err, ErrPulseTimeout)
+
+	// the PulseRead which times out is no longer waiting for the reply
+	a.board.(*mockFirmataBoard).pulseDuration = -1
+	_, err = a.PulseRead("7", 1, 0, time.Millisecond)
+	gobot.Assert(t, err, ErrPulseTimeout)
+	a.pulseMutex.Lock()
+	gobot.Assert(t, a.pulses[7] == nil, true)
+	a.pulseMutex.Unlock()
+
+	_, err = a.PulseRead("8", 1, 0, time.Second)
+	gobot.Refute(t, err, nil)
+}
+
+func TestFirmataAdaptorPixelWrite(t *testing.T) {
+	a := initTestFirmataAdaptor()
+	board := a.board.(*mockFirmataBoard)
+	red := color.RGBA{R: 255, A: 255}
+
+	gobot.Assert(t, a.PixelWrite("6", []color.RGBA{red, {}}), nil)
+	gobot.Assert(t, a.PixelWrite("6", []color.RGBA{red, {B: 3}}), nil)
+	gobot.Assert(t, a.PixelWrite("7", []color.RGBA{{}}), nil)
+	gobot.Assert(t, board.pixelWrites, []string{
+		"config 6 2", "set 0 255 0 0", "show",
+		"set 1 0 0 3", "show",
+		"config 7 1", "show",
+	})
+
+	gobot.Refute(t, a.PixelWrite("six", nil), nil)
+}
+
 func TestFirmataAdaptorAnalogRead(t *testing.T) {
 	a := initTestFirmataAdaptor()
 	val, err := a.AnalogRead("1")
@@ -0,0 +1,178 @@
+package gpio
+
+import (
+	"math"
+	"sort"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// AnalogScaler converts the raw reading of an analog sensor to engineering
+// units
+type AnalogScaler func(input int) (value float64)
+
+// LinearScaler returns an AnalogScaler which maps readings from fromMin to
+// fromMax onto toMin to toMax, clamping readings outside the range. Either
+// range may be reversed, eg. for a sensor whose reading drops as its value
+// rises.
+func LinearScaler(fromMin, fromMax, toMin, toMax float64) AnalogScaler {
+	reverse := (fromMin > fromMax) != (toMin > toMax)
+	return func(input int) float64 {
+		v := gobot.FromScale(float64(input), fromMin, fromMax)
+		if reverse {
+			v = 1 - v
+		}
+		return gobot.ToScale(v, toMin, toMax)
+	}
+}
+
+// TableScaler returns an AnalogScaler which interpolates linearly between the
+// entries of a lookup table of readings and their values, for sensors which
+// do not respond linearly. Readings outside the table are clamped to its first
+// and last entries.
+func TableScaler(table map[int]float64) AnalogScaler {
+	inputs := []int{}
+	for input := range table {
+		inputs = append(inputs, input)
+	}
+	sort.Ints(inputs)
+
+	return func(input int) float64 {
+		if len(inputs) == 0 {
+			return float64(input)
+		}
+		i := sort.SearchInts(inputs, input)
+		if i == 0 {
+			return table[inputs[0]]
+		}
+		if i == len(inputs) {
+			return table[inputs[len(inputs)-1]]
+		}
+		lo, hi := inputs[i-1], inputs[i]
+		v := gobot.FromScale(float64(input), float64(lo), float64(hi))
+		return table[lo] + v*(table[hi]-table[lo])
+	}
+}
+
+// GroveTemperatureScaler converts the readings of the thermistor of a Grove
+// Temperature Sensor to degrees celsius
+func GroveTemperatureScaler(input int) float64 {
+	thermistor := 3975.0
+	resistance := float64(1023.0-input) * 10000 / float64(input)
+	return 1/(math.Log(resistance/10000.0)/thermistor+1/298.15) - 273.15
+}
+
+// AnalogFilter smooths the readings of an analog sensor
+type AnalogFilter interface {
+	// Filter adds value to the readings and returns the filtered value
+	Filter(value float64) float64
+}
+
+type movingAverageFilter struct {
+	values []float64
+	size   int
+	sum    float64
+}
+
+// NewMovingAverageFilter returns an AnalogFilter which averages the last size
+// readings
+func NewMovingAverageFilter(size int) AnalogFilter {
+	if size < 1 {
+		size = 1
+	}
+	return &movingAverageFilter{size: size}
+}
+
+func (f *movingAverageFilter) Filter(value float64) float64 {
+	f.values = append(f.values, value)
+	f.sum += value
+	if len(f.values) > f.size {
+		f.sum -= f.values[0]
+		f.values = f.values[1:]
+	}
+	return f.sum / float64(len(f.values))
+}
+
+type medianFilter struct {
+	values []float64
+	size   int
+}
+
+// NewMedianFilter returns an AnalogFilter which takes the median of the last
+// size readings, which drops spikes entirely instead of averaging them in
+func NewMedianFilter(size int) AnalogFilter {
+	if size < 1 {
+		size = 1
+	}
+	return &medianFilter{size: size}
+}
+
+func (f *medianFilter) Filter(value float64) float64 {
+	f.values = append(f.values, value)
+	if len(f.values) > f.size {
+		f.values = f.values[1:]
+	}
+	sorted := append([]float64{}, f.values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+type exponentialFilter struct {
+	alpha   float64
+	value   float64
+	started bool
+}
+
+// NewExponentialFilter returns an AnalogFilter which smooths the readings
+// exponentially, weighting each new reading by alpha between 0 and 1. The
+// smaller alpha, the smoother and the slower the filter.
+func NewExponentialFilter(alpha float64) AnalogFilter {
+	return &exponentialFilter{alpha: math.Max(0, math.Min(1, alpha))}
+}
+
+func (f *exponentialFilter) Filter(value float64) float64 {
+	if !f.started {
+		f.value, f.started = value, true
+	} else {
+		f.value += f.alpha * (value - f.value)
+	}
+	return f.value
+}
+
+// AnalogThresholds configure the Above, Below and InRange events of an analog
+// sensor
+type AnalogThresholds struct {
+	// Low and High bound the range of values
+	Low, High float64
+	// Deadband is how far a value has to move back past a threshold it
+	// crossed before leaving the zone it entered, so a value hovering at a
+	// threshold does not publish an event on each reading
+	Deadband float64
+}
+
+// analog sensor zones relative to its thresholds
+const (
+	zoneUnknown = iota
+	zoneBelow
+	zoneInRange
+	zoneAbove
+)
+
+// zone returns the zone of value, given the current zone
+func (t *AnalogThresholds) zone(current int, value float64) int {
+	switch {
+	case current == zoneAbove && value >= t.High-t.Deadband:
+		return zoneAbove
+	case current == zoneBelow && value <= t.Low+t.Deadband:
+		return zoneBelow
+	case value > t.High:
+		return zoneAbove
+	case value < t.Low:
+		return zoneBelow
+	}
+	return zoneInRange
+}
@@ -0,0 +1,78 @@
+package gpio
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestLinearScaler(t *testing.T) {
+	s := LinearScaler(0, 1023, 0, 100)
+	gobot.Assert(t, s(0), 0.0)
+	gobot.Assert(t, s(1023), 100.0)
+	gobot.Assert(t, math.Abs(s(512)-50.05) < 0.01, true)
+	gobot.Assert(t, s(2000), 100.0)
+
+	// a reading which drops as the value rises
+	s = LinearScaler(1023, 0, -40, 125)
+	gobot.Assert(t, s(1023), -40.0)
+	gobot.Assert(t, s(0), 125.0)
+
+	s = LinearScaler(0, 1023, 100, 0)
+	gobot.Assert(t, s(0), 100.0)
+	gobot.Assert(t, s(1023), 0.0)
+}
+
+func TestTableScaler(t *testing.T) {
+	s := TableScaler(map[int]float64{100: 10, 300: 30, 200: 15})
+	gobot.Assert(t, s(0), 10.0)
+	gobot.Assert(t, s(100), 10.0)
+	gobot.Assert(t, s(150), 12.5)
+	gobot.Assert(t, s(200), 15.0)
+	gobot.Assert(t, s(250), 22.5)
+	gobot.Assert(t, s(400), 30.0)
+
+	gobot.Assert(t, TableScaler(map[int]float64{})(42), 42.0)
+}
+
+func TestGroveTemperatureScaler(t *testing.T) {
+	gobot.Assert(t, math.Abs(GroveTemperatureScaler(512)-25) < 0.1, true)
+}
+
+func TestMovingAverageFilter(t *testing.T) {
+	f := NewMovingAverageFilter(3)
+	gobot.Assert(t, f.Filter(3), 3.0)
+	gobot.Assert(t, f.Filter(6), 4.5)
+	gobot.Assert(t, f.Filter(9), 6.0)
+	gobot.Assert(t, f.Filter(12), 9.0)
+}
+
+func TestMedianFilter(t *testing.T) {
+	f := NewMedianFilter(3)
+	gobot.Assert(t, f.Filter(10), 10.0)
+	gobot.Assert(t, f.Filter(20), 15.0)
+	gobot.Assert(t, f.Filter(1000), 20.0)
+	gobot.Assert(t, f.Filter(30), 30.0)
+	gobot.Assert(t, f.Filter(40), 40.0)
+}
+
+func TestExponentialFilter(t *testing.T) {
+	f := NewExponentialFilter(0.5)
+	gobot.Assert(t, f.Filter(10), 10.0)
+	gobot.Assert(t, f.Filter(20), 15.0)
+	gobot.Assert(t, f.Filter(20), 17.5)
+}
+
+func TestAnalogThresholdsZone(t *testing.T) {
+	th := &AnalogThresholds{Low: 10, High: 20, Deadband: 2}
+	gobot.Assert(t, th.zone(zoneUnknown, 15), zoneInRange)
+	gobot.Assert(t, th.zone(zoneInRange, 21), zoneAbove)
+	// within the deadband of the threshold it crossed
+	gobot.Assert(t, th.zone(zoneAbove, 19), zoneAbove)
+	gobot.Assert(t, th.zone(zoneAbove, 17), zoneInRange)
+	gobot.Assert(t, th.zone(zoneInRange, 9), zoneBelow)
+	gobot.Assert(t, th.zone(zoneBelow, 11), zoneBelow)
+	gobot.Assert(t, th.zone(zoneBelow, 13), zoneInRange)
+	gobot.Assert(t, th.zone(zoneBelow, 25), zoneAbove)
+}
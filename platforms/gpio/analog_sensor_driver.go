@@ -1,6 +1,8 @@
 package gpio
 
 import (
+	"math"
+	"sync"
 	"time"
 
 	"github.com/hybridgroup/gobot"
@@ -15,8 +17,26 @@ type AnalogSensorDriver struct {
 	halt       chan bool
 	interval   time.Duration
 	connection AnalogReader
+	mutex      sync.Mutex
+	value      float64
+	published  bool
+	zone       int
+	// the events of the raw readings and the processed values, Data and
+	// Value unless a driver built on it needs other names
+	rawEvent   string
+	valueEvent string
 	gobot.Eventer
 	gobot.Commander
+	// Scale converts the readings to engineering units, nil leaves them raw
+	Scale AnalogScaler
+	// Filter smooths the scaled readings, nil leaves them as they are
+	Filter AnalogFilter
+	// Hysteresis is how far a value has to move from the last published one
+	// to publish a Value event
+	Hysteresis float64
+	// Thresholds configure the Above, Below and InRange events, nil disables
+	// them
+	Thresholds *AnalogThresholds
 }
 
 // NewAnalogSensorDriver returns a new AnalogSensorDriver with a polling interval of
@@ -25,8 +45,12 @@ type AnalogSensorDriver struct {
 // Optinally accepts:
 // 	time.Duration: Interval at which the AnalogSensor is polled for new information
 //
+// Readings are processed by the Scale, Filter, Hysteresis and Thresholds
+// of the AnalogSensorDriver, in that order.
+//
 // Adds the following API Commands:
 // 	"Read" - See AnalogSensor.Read
+// 	"Value" - See AnalogSensor.Value
 func NewAnalogSensorDriver(a AnalogReader, name string, pin string, v ...time.Duration) *AnalogSensorDriver {
 	d := &AnalogSensorDriver{
 		name:       name,
@@ -36,6 +60,8 @@ func NewAnalogSensorDriver(a AnalogReader, name string, pin string, v ...time.Du
 		Commander:  gobot.NewCommander(),
 		interval:   10 * time.Millisecond,
 		halt:       make(chan bool),
+		rawEvent:   Data,
+		valueEvent: Value,
 	}
 
 	if len(v) > 0 {
@@ -43,12 +69,19 @@ func NewAnalogSensorDriver(a AnalogReader, name string, pin string, v ...time.Du
 	}
 
 	d.AddEvent(Data)
+	d.AddEvent(Value)
+	d.AddEvent(Above)
+	d.AddEvent(Below)
+	d.AddEvent(InRange)
 	d.AddEvent(Error)
 
 	d.AddCommand("Read", func(params map[string]interface{}) interface{} {
 		val, err := d.Read()
 		return map[string]interface{}{"val": val, "err": err}
 	})
+	d.AddCommand("Value", func(params map[string]interface{}) interface{} {
+		return d.Value()
+	})
 
 	return d
 }
@@ -56,6 +89,10 @@ func NewAnalogSensorDriver(a AnalogReader, name string, pin string, v ...time.Du
 // Start starts the AnalogSensorDriver and reads the Analog Sensor at the given interval.
 // Emits the Events:
 //	Data int - Event is emitted on change and represents the current reading from the sensor.
+//	Value float64 - Event is emitted when the processed reading moves by more than the Hysteresis.
+//	Above float64 - Event is emitted when the processed reading rises above the High threshold.
+//	Below float64 - Event is emitted when the processed reading drops below the Low threshold.
+//	InRange float64 - Event is emitted when the processed reading returns between the thresholds.
 //	Error error - Event is emitted on error reading from the sensor.
 func (a *AnalogSensorDriver) Start() (errs []error) {
 	value := 0
@@ -64,9 +101,12 @@ func (a *AnalogSensorDriver) Start() (errs []error) {
 			newValue, err := a.Read()
 			if err != nil {
 				gobot.Publish(a.Event(Error), err)
-			} else if newValue != value && newValue != -1 {
-				value = newValue
-				gobot.Publish(a.Event(Data), value)
+			} else if newValue != -1 {
+				if newValue != value {
+					value = newValue
+					gobot.Publish(a.Event(a.rawEvent), value)
+				}
+				a.process(newValue)
 			}
 			select {
 			case <-time.After(a.interval):
@@ -97,3 +137,49 @@ func (a *AnalogSensorDriver) Connection() gobot.Connection { return a.connection
 func (a *AnalogSensorDriver) Read() (val int, err error) {
 	return a.connection.AnalogRead(a.Pin())
 }
+
+// Value returns the last processed reading from the Analog Sensor
+func (a *AnalogSensorDriver) Value() float64 {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.value
+}
+
+// process runs a reading through the Scale, Filter, Hysteresis and Thresholds
+// and publishes the resulting events
+func (a *AnalogSensorDriver) process(input int) {
+	value := float64(input)
+	if a.Scale != nil {
+		value = a.Scale(input)
+	}
+	if a.Filter != nil {
+		value = a.Filter.Filter(value)
+	}
+
+	a.mutex.Lock()
+	publish := !a.published || math.Abs(value-a.value) > a.Hysteresis
+	if publish {
+		a.value, a.published = value, true
+	}
+	a.mutex.Unlock()
+
+	if publish {
+		gobot.Publish(a.Event(a.valueEvent), value)
+	}
+
+	if a.Thresholds == nil {
+		return
+	}
+	zone := a.Thresholds.zone(a.zone, value)
+	if zone != a.zone {
+		a.zone = zone
+		switch zone {
+		case zoneAbove:
+			gobot.Publish(a.Event(Above), value)
+		case zoneBelow:
+			gobot.Publish(a.Event(Below), value)
+		case zoneInRange:
+			gobot.Publish(a.Event(InRange), value)
+		}
+	}
+}
@@ -97,3 +97,64 @@ func TestAnalogSensorDriverHalt(t *testing.T) {
 	}()
 	gobot.Assert(t, len(d.Halt()), 0)
 }
+
+func TestAnalogSensorDriverProcess(t *testing.T) {
+	d := NewAnalogSensorDriver(newGpioTestAdaptor("adaptor"), "bot", "1")
+	d.Scale = LinearScaler(0, 1000, 0, 100)
+	d.Filter = NewMovingAverageFilter(2)
+	d.Hysteresis = 1
+	d.Thresholds = &AnalogThresholds{Low: 20, High: 80, Deadband: 5}
+
+	events := make(chan string, 20)
+	values := make(chan float64, 20)
+	for _, e := range []string{Value, Above, Below, InRange} {
+		name := e
+		gobot.On(d.Event(name), func(data interface{}) {
+			events <- name
+			values <- data.(float64)
+		})
+	}
+	next := func() (string, float64) {
+		select {
+		case e := <-events:
+			return e, <-values
+		case <-time.After(100 * time.Millisecond):
+			return "", 0
+		}
+	}
+	// events are published concurrently, so sort them out per reading
+	expect := func(want map[string]float64) {
+		got := map[string]float64{}
+		for range want {
+			e, v := next()
+			got[e] = v
+		}
+		gobot.Assert(t, got, want)
+	}
+
+	d.process(500)
+	expect(map[string]float64{Value: 50, InRange: 50})
+	gobot.Assert(t, d.Command("Value")(nil), 50.0)
+
+	// within the hysteresis
+	d.process(510)
+	gobot.Assert(t, d.Value(), 50.0)
+
+	d.process(1000)
+	expect(map[string]float64{Value: 75.5})
+	d.process(1000)
+	expect(map[string]float64{Value: 100, Above: 100})
+
+	d.process(780)
+	expect(map[string]float64{Value: 89})
+	d.process(700)
+	expect(map[string]float64{Value: 74, InRange: 74})
+
+	d.process(0)
+	expect(map[string]float64{Value: 35})
+	d.process(0)
+	expect(map[string]float64{Value: 0, Below: 0})
+
+	e, _ := next()
+	gobot.Assert(t, e, "")
+}
@@ -0,0 +1,67 @@
+package gpio
+
+import (
+	"sync"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// backgroundTask runs one task at a time in a goroutine, such as an
+// animation or a melody, so that a driver keeps responding meanwhile.
+// Starting a task stops the one running.
+type backgroundTask struct {
+	mutex sync.Mutex
+	halt  chan bool
+	done  chan bool
+}
+
+// start stops any running task and runs task in the background. task returns
+// once it has finished or halt is closed, with the event to publish to e for
+// how it ended, or "" for none. The returned channel is closed once task has
+// returned.
+func (b *backgroundTask) start(e gobot.Eventer, task func(halt chan bool) (event string, data interface{})) (done chan bool) {
+	b.stop()
+
+	halt, done := make(chan bool), make(chan bool)
+	b.mutex.Lock()
+	b.halt, b.done = halt, done
+	b.mutex.Unlock()
+
+	go func() {
+		event, data := task(halt)
+		close(done)
+		if event != "" {
+			gobot.Publish(e.Event(event), data)
+		}
+	}()
+	return done
+}
+
+// running returns true while a task is running
+func (b *backgroundTask) running() bool {
+	b.mutex.Lock()
+	done := b.done
+	b.mutex.Unlock()
+	if done == nil {
+		return false
+	}
+	select {
+	case <-done:
+		return false
+	default:
+		return true
+	}
+}
+
+// stop halts the running task, if any, and waits for it to return
+func (b *backgroundTask) stop() {
+	b.mutex.Lock()
+	halt, done := b.halt, b.done
+	b.halt, b.done = nil, nil
+	b.mutex.Unlock()
+	if halt == nil {
+		return
+	}
+	close(halt)
+	<-done
+}
@@ -0,0 +1,56 @@
+package gpio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestBackgroundTask(t *testing.T) {
+	var b backgroundTask
+	e := gobot.NewEventer()
+	e.AddEvent(Finished)
+	finished := make(chan interface{}, 1)
+	gobot.On(e.Event(Finished), func(data interface{}) {
+		finished <- data
+	})
+	gobot.Assert(t, b.running(), false)
+
+	// a task which finishes publishes its event
+	done := b.start(e, func(halt chan bool) (string, interface{}) {
+		return Finished, "first"
+	})
+	<-done
+	gobot.Assert(t, b.running(), false)
+	select {
+	case data := <-finished:
+		gobot.Assert(t, data, "first")
+	case <-time.After(time.Second):
+		t.Errorf("finished was not published")
+	}
+
+	// a halted task publishes nothing
+	halted := make(chan bool, 1)
+	b.start(e, func(halt chan bool) (string, interface{}) {
+		<-halt
+		halted <- true
+		return "", nil
+	})
+	gobot.Assert(t, b.running(), true)
+	b.start(e, func(halt chan bool) (string, interface{}) {
+		<-halt
+		return "", nil
+	})
+	gobot.Assert(t, <-halted, true)
+	b.stop()
+	gobot.Assert(t, b.running(), false)
+	select {
+	case data := <-finished:
+		t.Errorf("unexpected finished %v", data)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// stopping with no task running is harmless
+	b.stop()
+}
@@ -16,23 +16,30 @@ type ButtonDriver struct {
 	halt       chan bool
 	interval   time.Duration
 	connection DigitalReader
+	buttonGestures
 	gobot.Eventer
 }
 
 // NewButtonDriver returns a new ButtonDriver with a polling interval of
 // 10 Milliseconds given a DigitalReader, name and pin.
 //
+// Debouncing is off by default, set DebounceTime to enable it. LongPressTime,
+// DoubleClickTime and HoldInterval default to DefaultLongPressTime,
+// DefaultDoubleClickTime and DefaultHoldInterval, set them to 0 to disable
+// the corresponding events.
+//
 // Optinally accepts:
 //  time.Duration: Interval at which the ButtonDriver is polled for new information
 func NewButtonDriver(a DigitalReader, name string, pin string, v ...time.Duration) *ButtonDriver {
 	b := &ButtonDriver{
-		name:       name,
-		connection: a,
-		pin:        pin,
-		Active:     false,
-		Eventer:    gobot.NewEventer(),
-		interval:   10 * time.Millisecond,
-		halt:       make(chan bool),
+		name:           name,
+		connection:     a,
+		pin:            pin,
+		Active:         false,
+		buttonGestures: newButtonGestures(),
+		Eventer:        gobot.NewEventer(),
+		interval:       10 * time.Millisecond,
+		halt:           make(chan bool),
 	}
 
 	if len(v) > 0 {
@@ -41,6 +48,9 @@ func NewButtonDriver(a DigitalReader, name string, pin string, v ...time.Duratio
 
 	b.AddEvent(Push)
 	b.AddEvent(Release)
+	b.AddEvent(LongPress)
+	b.AddEvent(Hold)
+	b.AddEvent(DoubleClick)
 	b.AddEvent(Error)
 
 	return b
@@ -51,16 +61,17 @@ func NewButtonDriver(a DigitalReader, name string, pin string, v ...time.Duratio
 // Emits the Events:
 // 	Push int - On button push
 //	Release int - On button release
+//	LongPress time.Duration - On button held down for LongPressTime
+//	Hold time.Duration - Every HoldInterval while held down after a LongPress
+//	DoubleClick int - On button push within DoubleClickTime of the previous push
 //	Error error - On button error
 func (b *ButtonDriver) Start() (errs []error) {
-	state := 0
 	go func() {
 		for {
 			newValue, err := b.connection.DigitalRead(b.Pin())
 			if err != nil {
 				gobot.Publish(b.Event(Error), err)
-			} else if newValue != state && newValue != -1 {
-				state = newValue
+			} else if newValue != -1 {
 				b.update(newValue)
 			}
 			select {
@@ -89,11 +100,18 @@ func (b *ButtonDriver) Pin() string { return b.pin }
 func (b *ButtonDriver) Connection() gobot.Connection { return b.connection.(gobot.Connection) }
 
 func (b *ButtonDriver) update(newValue int) {
-	if newValue == 1 {
-		b.Active = true
-		gobot.Publish(b.Event(Push), newValue)
-	} else {
-		b.Active = false
-		gobot.Publish(b.Event(Release), newValue)
+	for _, e := range b.sample(newValue == 1, time.Now()) {
+		switch e.name {
+		case Push:
+			b.Active = true
+			gobot.Publish(b.Event(Push), newValue)
+		case Release:
+			b.Active = false
+			gobot.Publish(b.Event(Release), newValue)
+		case DoubleClick:
+			gobot.Publish(b.Event(DoubleClick), newValue)
+		default:
+			gobot.Publish(b.Event(e.name), e.held)
+		}
 	}
 }
@@ -99,3 +99,49 @@ func TestButtonDriverStart(t *testing.T) {
 	}
 
 }
+
+func TestButtonDriverDoubleClick(t *testing.T) {
+	sem := make(chan int, 1)
+	d := initTestButtonDriver()
+	gobot.Assert(t, d.DoubleClickTime, DefaultDoubleClickTime)
+
+	gobot.Once(d.Event(DoubleClick), func(data interface{}) {
+		sem <- data.(int)
+	})
+
+	d.update(1)
+	d.update(0)
+	d.update(1)
+	gobot.Assert(t, d.Active, true)
+
+	select {
+	case data := <-sem:
+		gobot.Assert(t, data, 1)
+	case <-time.After(15 * time.Millisecond):
+		t.Errorf("Button Event \"DoubleClick\" was not published")
+	}
+}
+
+func TestButtonDriverLongPress(t *testing.T) {
+	sem := make(chan time.Duration, 1)
+	d := initTestButtonDriver()
+	d.LongPressTime = 20 * time.Millisecond
+
+	gobot.Once(d.Event(LongPress), func(data interface{}) {
+		sem <- data.(time.Duration)
+	})
+
+	testAdaptorDigitalRead = func() (val int, err error) {
+		val = 1
+		return
+	}
+	gobot.Assert(t, len(d.Start()), 0)
+
+	select {
+	case data := <-sem:
+		gobot.Assert(t, data >= 20*time.Millisecond, true)
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("Button Event \"LongPress\" was not published")
+	}
+	d.halt <- true
+}
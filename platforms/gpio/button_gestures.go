@@ -0,0 +1,105 @@
+package gpio
+
+import "time"
+
+// Default gesture thresholds of the button drivers
+const (
+	DefaultLongPressTime   = 1 * time.Second
+	DefaultDoubleClickTime = 400 * time.Millisecond
+	DefaultHoldInterval    = 250 * time.Millisecond
+)
+
+// buttonGestures debounces the raw readings of a button and detects push,
+// release, long press, hold and double click gestures from them. Setting a
+// duration to 0 disables the corresponding feature.
+type buttonGestures struct {
+	// DebounceTime is how long a new level has to be read steadily before
+	// it is taken as a push or release. Defaults to 0, no debouncing.
+	DebounceTime time.Duration
+	// LongPressTime is how long the button has to be held down before
+	// a LongPress event is emitted
+	LongPressTime time.Duration
+	// DoubleClickTime is the longest time between two pushes for them to
+	// emit a DoubleClick event
+	DoubleClickTime time.Duration
+	// HoldInterval is the interval at which Hold events are repeated while
+	// the button is held down after a long press
+	HoldInterval time.Duration
+
+	pressed    bool
+	candidate  bool
+	changedAt  time.Time
+	pushedAt   time.Time
+	lastPushAt time.Time
+	lastHoldAt time.Time
+	longPress  bool
+}
+
+// buttonEvent is a gesture detected by buttonGestures, held is how long the
+// button has been held down for LongPress and Hold events
+type buttonEvent struct {
+	name string
+	held time.Duration
+}
+
+func newButtonGestures() buttonGestures {
+	return buttonGestures{
+		LongPressTime:   DefaultLongPressTime,
+		DoubleClickTime: DefaultDoubleClickTime,
+		HoldInterval:    DefaultHoldInterval,
+	}
+}
+
+// sample feeds whether the button reads as pressed at time now and returns the
+// events this causes
+func (g *buttonGestures) sample(pressed bool, now time.Time) (events []buttonEvent) {
+	if pressed == g.pressed {
+		g.candidate = pressed
+		if pressed {
+			events = g.held(now)
+		}
+		return
+	}
+
+	if pressed != g.candidate {
+		g.candidate = pressed
+		g.changedAt = now
+	}
+	if now.Sub(g.changedAt) < g.DebounceTime {
+		return
+	}
+
+	g.pressed = pressed
+	if !pressed {
+		g.longPress = false
+		return []buttonEvent{{name: Release}}
+	}
+
+	g.pushedAt = now
+	events = []buttonEvent{{name: Push}}
+	if g.DoubleClickTime > 0 && !g.lastPushAt.IsZero() &&
+		now.Sub(g.lastPushAt) <= g.DoubleClickTime {
+		events = append(events, buttonEvent{name: DoubleClick})
+		// a third push starts a new double click
+		g.lastPushAt = time.Time{}
+	} else {
+		g.lastPushAt = now
+	}
+	return
+}
+
+// held returns the LongPress and Hold events of a button held down at time now
+func (g *buttonGestures) held(now time.Time) []buttonEvent {
+	held := now.Sub(g.pushedAt)
+	if !g.longPress {
+		if g.LongPressTime > 0 && held >= g.LongPressTime {
+			g.longPress = true
+			g.lastHoldAt = now
+			return []buttonEvent{{name: LongPress, held: held}}
+		}
+	} else if g.HoldInterval > 0 && now.Sub(g.lastHoldAt) >= g.HoldInterval {
+		g.lastHoldAt = now
+		return []buttonEvent{{name: Hold, held: held}}
+	}
+	return nil
+}
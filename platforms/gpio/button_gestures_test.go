@@ -0,0 +1,90 @@
+package gpio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func eventNames(events []buttonEvent) (names []string) {
+	for _, e := range events {
+		names = append(names, e.name)
+	}
+	return
+}
+
+func TestButtonGesturesDebounce(t *testing.T) {
+	g := newButtonGestures()
+	g.DebounceTime = 20 * time.Millisecond
+	start := time.Now()
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	// bouncing contacts do not push the button
+	gobot.Assert(t, len(g.sample(true, at(0))), 0)
+	gobot.Assert(t, len(g.sample(false, at(5))), 0)
+	gobot.Assert(t, len(g.sample(true, at(10))), 0)
+	gobot.Assert(t, len(g.sample(true, at(25))), 0)
+	gobot.Assert(t, eventNames(g.sample(true, at(30))), []string{Push})
+	gobot.Assert(t, len(g.sample(true, at(40))), 0)
+
+	gobot.Assert(t, len(g.sample(false, at(50))), 0)
+	gobot.Assert(t, eventNames(g.sample(false, at(70))), []string{Release})
+
+	g.DebounceTime = 0
+	gobot.Assert(t, eventNames(g.sample(true, at(1000))), []string{Push})
+}
+
+func TestButtonGesturesLongPressHold(t *testing.T) {
+	g := newButtonGestures()
+	start := time.Now()
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	gobot.Assert(t, eventNames(g.sample(true, at(0))), []string{Push})
+	gobot.Assert(t, len(g.sample(true, at(999))), 0)
+
+	events := g.sample(true, at(1000))
+	gobot.Assert(t, eventNames(events), []string{LongPress})
+	gobot.Assert(t, events[0].held, DefaultLongPressTime)
+
+	gobot.Assert(t, len(g.sample(true, at(1100))), 0)
+	events = g.sample(true, at(1250))
+	gobot.Assert(t, eventNames(events), []string{Hold})
+	gobot.Assert(t, events[0].held, 1250*time.Millisecond)
+	gobot.Assert(t, len(g.sample(true, at(1300))), 0)
+	gobot.Assert(t, eventNames(g.sample(true, at(1500))), []string{Hold})
+
+	gobot.Assert(t, eventNames(g.sample(false, at(1510))), []string{Release})
+
+	// a long press starts over on the next push
+	g.sample(true, at(3000))
+	gobot.Assert(t, len(g.sample(true, at(3500))), 0)
+	gobot.Assert(t, eventNames(g.sample(true, at(4000))), []string{LongPress})
+	g.sample(false, at(4010))
+
+	g.LongPressTime = 0
+	g.sample(true, at(5000))
+	gobot.Assert(t, len(g.sample(true, at(9000))), 0)
+}
+
+func TestButtonGesturesDoubleClick(t *testing.T) {
+	g := newButtonGestures()
+	start := time.Now()
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	g.sample(true, at(0))
+	g.sample(false, at(100))
+	gobot.Assert(t, eventNames(g.sample(true, at(300))), []string{Push, DoubleClick})
+	g.sample(false, at(350))
+
+	// the third push does not count as another double click
+	gobot.Assert(t, eventNames(g.sample(true, at(400))), []string{Push})
+	g.sample(false, at(450))
+
+	// too slow
+	gobot.Assert(t, eventNames(g.sample(true, at(1000))), []string{Push})
+	g.sample(false, at(1050))
+
+	g.DoubleClickTime = 0
+	gobot.Assert(t, eventNames(g.sample(true, at(1100))), []string{Push})
+}
@@ -1,6 +1,7 @@
 package gpio
 
 import (
+	"sync"
 	"time"
 
 	"github.com/hybridgroup/gobot"
@@ -127,33 +128,87 @@ const (
 
 var _ gobot.Driver = (*BuzzerDriver)(nil)
 
-// BuzzerDriver represents a digital buzzer
+// buzzer player controls
+const (
+	buzzerPause = iota
+	buzzerResume
+)
+
+// BuzzerDriver represents a digital buzzer. It sounds tones through the Pwm of
+// its pin when the adaptor is a PwmPeriodWriter, as the Beaglebone and Edison
+// adaptors are, and by toggling the pin otherwise. Toggled tones are less
+// accurate in pitch, and are used on the Raspberry Pi, as pi-blaster runs its
+// Pwm at a fixed frequency, and on Firmata boards, whose protocol can not set
+// the frequency of a Pwm pin.
 type BuzzerDriver struct {
 	pin        string
 	name       string
 	connection DigitalWriter
 	high       bool
-	BPM        float64
+	mutex      sync.Mutex
+	control    chan int
+	ack        chan bool
+	done       chan bool
+	task       backgroundTask
+	gobot.Eventer
+	gobot.Commander
+	BPM float64
 }
 
 // NewBuzzerDriver return a new BuzzerDriver given a DigitalWriter, name and pin.
+//
+// Adds the following API Commands:
+// 	"Tone" - See BuzzerDriver.Tone
+// 	"PlayRTTTL" - See BuzzerDriver.PlayRTTTL
+// 	"Pause" - See BuzzerDriver.Pause
+// 	"Resume" - See BuzzerDriver.Resume
+// 	"Stop" - See BuzzerDriver.Stop
 func NewBuzzerDriver(a DigitalWriter, name string, pin string) *BuzzerDriver {
 	l := &BuzzerDriver{
 		name:       name,
 		pin:        pin,
 		connection: a,
 		high:       false,
+		Eventer:    gobot.NewEventer(),
+		Commander:  gobot.NewCommander(),
 		BPM:        96.0,
 	}
 
+	l.AddEvent(Finished)
+	l.AddEvent(Error)
+
+	l.AddCommand("Tone", func(params map[string]interface{}) interface{} {
+		hz := params["hz"].(float64)
+		duration := params["duration"].(float64)
+		return l.Tone(hz, duration)
+	})
+	l.AddCommand("PlayRTTTL", func(params map[string]interface{}) interface{} {
+		return l.PlayRTTTL(params["song"].(string))
+	})
+	l.AddCommand("Pause", func(params map[string]interface{}) interface{} {
+		l.Pause()
+		return nil
+	})
+	l.AddCommand("Resume", func(params map[string]interface{}) interface{} {
+		l.Resume()
+		return nil
+	})
+	l.AddCommand("Stop", func(params map[string]interface{}) interface{} {
+		l.Stop()
+		return nil
+	})
+
 	return l
 }
 
 // Start implements the Driver interface
 func (l *BuzzerDriver) Start() (errs []error) { return }
 
-// Halt implements the Driver interface
-func (l *BuzzerDriver) Halt() (errs []error) { return }
+// Halt stops the melody which is playing
+func (l *BuzzerDriver) Halt() (errs []error) {
+	l.Stop()
+	return
+}
 
 // Name returns the BuzzerDrivers name
 func (l *BuzzerDriver) Name() string { return l.name }
@@ -199,23 +254,174 @@ func (l *BuzzerDriver) Toggle() (err error) {
 	return
 }
 
+// Tone sounds the tone of hz for duration beats at the BPM of the buzzer, eg.
+// Quarter, in the background, as Play does a melody of that one note
 func (l *BuzzerDriver) Tone(hz, duration float64) (err error) {
-	// calculation based off https://www.arduino.cc/en/Tutorial/Melody
-	tone := (1.0 / (2.0 * hz)) * 1000000.0
+	return l.Play(Melody{Notes: []Note{{Tone: hz, Duration: duration}}})
+}
 
-	tempo := ((60 / l.BPM) * (duration * 1000))
+// Play plays the melody m in the background, stopping any melody which is
+// playing. A melody without BPM is played at the BPM of the buzzer. It
+// returns once the first note sounds, or the error sounding it.
+//
+// Emits the Events:
+// 	Finished Melody - When the melody played to its end
+// 	Error error - On error sounding a note, which ends the melody
+func (l *BuzzerDriver) Play(m Melody) (err error) {
+	l.Stop()
+	if m.BPM == 0 {
+		m.BPM = l.BPM
+	}
 
-	for i := 0.0; i < tempo*1000; i += tone * 2.0 {
-		if err = l.On(); err != nil {
+	var stop func() error
+	if len(m.Notes) > 0 {
+		if stop, err = l.startTone(m.Notes[0].Tone); err != nil {
 			return
 		}
-		<-time.After(time.Duration(tone) * time.Microsecond)
+	}
+
+	control, ack := make(chan int), make(chan bool)
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.control, l.ack = control, ack
+	l.done = l.task.start(l, func(halt chan bool) (string, interface{}) {
+		for _, note := range m.Notes {
+			finished, err := l.playNote(note, beats(note.Duration, m.BPM), stop, control, ack, halt)
+			stop = nil
+			if err != nil {
+				return Error, err
+			}
+			if !finished {
+				return "", nil
+			}
+		}
+		return Finished, m
+	})
+	return
+}
+
+// PlayRTTTL parses song in the Ring Tone Text Transfer Language and plays it
+// as Play does
+func (l *BuzzerDriver) PlayRTTTL(song string) (err error) {
+	m, err := ParseRTTTL(song)
+	if err != nil {
+		return
+	}
+	return l.Play(m)
+}
+
+// Playing returns true while a melody is playing, including when it is paused
+func (l *BuzzerDriver) Playing() bool { return l.task.running() }
+
+// Pause silences the melody which is playing until it is resumed
+func (l *BuzzerDriver) Pause() { l.send(buzzerPause) }
+
+// Resume resumes the melody which is paused where it was paused
+func (l *BuzzerDriver) Resume() { l.send(buzzerResume) }
+
+// Stop stops the melody which is playing, and returns once the buzzer is
+// silent
+func (l *BuzzerDriver) Stop() { l.task.stop() }
+
+// send passes c to the player and waits until it was carried out, unless no
+// melody is playing
+func (l *BuzzerDriver) send(c int) {
+	l.mutex.Lock()
+	control, ack, done := l.control, l.ack, l.done
+	l.mutex.Unlock()
+	if control == nil {
+		return
+	}
+	select {
+	case control <- c:
+		<-ack
+	case <-done:
+	}
+}
+
+// playNote sounds note for duration, following the controls sent meanwhile
+// and acknowledging each, unless stop is given to silence the note which
+// already sounds. It returns false if the melody was halted.
+func (l *BuzzerDriver) playNote(note Note, duration time.Duration, stop func() error, control chan int, ack chan bool, halt chan bool) (finished bool, err error) {
+	for duration > 0 {
+		if stop == nil {
+			if stop, err = l.startTone(note.Tone); err != nil {
+				return false, err
+			}
+		}
+		started := time.Now()
 
-		if err = l.Off(); err != nil {
+		select {
+		case <-time.After(duration):
+			return true, stop()
+		case <-halt:
+			return false, stop()
+		case c := <-control:
+			err = stop()
+			stop = nil
+			duration -= time.Now().Sub(started)
+			for err == nil && c == buzzerPause {
+				ack <- true
+				select {
+				case c = <-control:
+				case <-halt:
+					return false, nil
+				}
+			}
+			ack <- true
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+	return true, nil
+}
+
+// startTone drives the pin at hz until the returned func is called, which
+// silences it
+func (l *BuzzerDriver) startTone(hz float64) (stop func() error, err error) {
+	if hz <= 0 {
+		return func() error { return nil }, nil
+	}
+	period := time.Duration(float64(time.Second) / hz)
+
+	if writer, ok := l.connection.(PwmPeriodWriter); ok {
+		if err = writer.PwmPeriodWrite(l.Pin(), period, 128); err != nil {
 			return
 		}
-		<-time.After(time.Duration(tone) * time.Microsecond)
+		return func() error { return writer.PwmPeriodWrite(l.Pin(), period, 0) }, nil
 	}
 
-	return
+	if err = l.connection.DigitalWrite(l.Pin(), 1); err != nil {
+		return
+	}
+	// toggle the pin on a schedule of absolute times, so the time taken to
+	// write the pin does not lower the pitch
+	quit, done := make(chan bool), make(chan error, 1)
+	go func() {
+		next, level := time.Now(), byte(0)
+		for {
+			next = next.Add(period / 2)
+			select {
+			case <-time.After(next.Sub(time.Now())):
+			case <-quit:
+				done <- l.connection.DigitalWrite(l.Pin(), 0)
+				return
+			}
+			if err := l.connection.DigitalWrite(l.Pin(), level); err != nil {
+				done <- err
+				return
+			}
+			level ^= 1
+		}
+	}()
+	return func() error {
+		close(quit)
+		return <-done
+	}, nil
+}
+
+// beats returns the duration of n beats at bpm
+func beats(n float64, bpm float64) time.Duration {
+	return time.Duration(n * 60 / bpm * float64(time.Second))
 }
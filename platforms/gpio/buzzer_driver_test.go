@@ -0,0 +1,160 @@
+package gpio
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestBuzzerDriver(t *testing.T) {
+	d := NewBuzzerDriver(newGpioTestAdaptor("adaptor"), "buzzer", "1")
+	gobot.Assert(t, d.Name(), "buzzer")
+	gobot.Assert(t, d.Pin(), "1")
+	gobot.Assert(t, d.Connection().Name(), "adaptor")
+	gobot.Assert(t, d.BPM, 96.0)
+	gobot.Assert(t, len(d.Halt()), 0)
+}
+
+// waitForBuzzer waits for the melody d plays to finish
+func waitForBuzzer(t *testing.T, d *BuzzerDriver) {
+	for start := time.Now(); d.Playing(); time.Sleep(time.Millisecond) {
+		if time.Since(start) > 100*time.Millisecond {
+			t.Errorf("buzzer did not finish playing")
+			return
+		}
+	}
+}
+
+func TestBuzzerDriverToneSoftware(t *testing.T) {
+	a := newGpioTestPinWriter()
+	d := NewBuzzerDriver(a, "buzzer", "1")
+	d.BPM = 6000
+
+	// 10 Milliseconds at 1000hz, played in the background
+	gobot.Assert(t, d.Tone(1000, 1), nil)
+	gobot.Assert(t, d.Playing(), true)
+	waitForBuzzer(t, d)
+	writes := a.history("1")
+	gobot.Assert(t, len(writes) > 10, true)
+	gobot.Assert(t, writes[0], byte(1))
+	gobot.Assert(t, writes[1], byte(0))
+	gobot.Assert(t, writes[len(writes)-1], byte(0))
+
+	a = newGpioTestPinWriter()
+	d = NewBuzzerDriver(a, "buzzer", "1")
+	d.BPM = 6000
+	gobot.Assert(t, d.Tone(Rest, 1), nil)
+	waitForBuzzer(t, d)
+	gobot.Assert(t, len(a.history("1")), 0)
+}
+
+func TestBuzzerDriverToneHardware(t *testing.T) {
+	a := newGpioTestPwmPeriodWriter()
+	d := NewBuzzerDriver(a, "buzzer", "1")
+	d.BPM = 6000
+
+	gobot.Assert(t, d.Tone(A4, 1), nil)
+	waitForBuzzer(t, d)
+	gobot.Assert(t, a.history("1"), []byte{128, 0})
+	gobot.Assert(t, a.periods, []time.Duration{2272727, 2272727})
+}
+
+func TestBuzzerDriverToneError(t *testing.T) {
+	testAdaptorDigitalWrite = func() (err error) {
+		return errors.New("write error")
+	}
+	defer func() {
+		testAdaptorDigitalWrite = func() (err error) {
+			return nil
+		}
+	}()
+
+	d := NewBuzzerDriver(newGpioTestAdaptor("adaptor"), "buzzer", "1")
+	d.BPM = 6000
+	gobot.Assert(t, d.Tone(C4, 1), errors.New("write error"))
+}
+
+func TestBuzzerDriverPlay(t *testing.T) {
+	a := newGpioTestPwmPeriodWriter()
+	d := NewBuzzerDriver(a, "buzzer", "1")
+	sem := make(chan Melody, 1)
+	gobot.On(d.Event(Finished), func(data interface{}) {
+		sem <- data.(Melody)
+	})
+
+	m := Melody{Name: "scale", Notes: []Note{{C4, 0.5}, {Rest, 0.5}, {E4, 0.5}}}
+	d.BPM = 6000
+	gobot.Assert(t, d.Play(m), nil)
+	gobot.Assert(t, d.Playing(), true)
+
+	select {
+	case played := <-sem:
+		gobot.Assert(t, played.Name, "scale")
+		gobot.Assert(t, played.BPM, 6000.0)
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("Event \"finished\" was not published")
+	}
+	gobot.Assert(t, d.Playing(), false)
+	gobot.Assert(t, a.history("1"), []byte{128, 0, 128, 0})
+}
+
+func TestBuzzerDriverPauseStop(t *testing.T) {
+	a := newGpioTestPwmPeriodWriter()
+	d := NewBuzzerDriver(a, "buzzer", "1")
+	d.BPM = 600
+
+	gobot.Assert(t, d.PlayRTTTL("long:d=1,o=4,b=600:c,c"), nil)
+	<-time.After(10 * time.Millisecond)
+	d.Pause()
+	gobot.Assert(t, a.history("1"), []byte{128, 0})
+	gobot.Assert(t, d.Playing(), true)
+
+	d.Resume()
+	<-time.After(10 * time.Millisecond)
+	gobot.Assert(t, a.history("1"), []byte{128, 0, 128})
+
+	d.Command("Stop")(nil)
+	gobot.Assert(t, d.Playing(), false)
+	gobot.Assert(t, a.history("1"), []byte{128, 0, 128, 0})
+
+	// controls without a melody playing do nothing
+	d.Pause()
+	d.Resume()
+	d.Stop()
+}
+
+func TestParseRTTTL(t *testing.T) {
+	m, err := ParseRTTTL("Test:d=4,o=5,b=120:8c,c#6.,p,2a4, 16b.5,h")
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, m.Name, "Test")
+	gobot.Assert(t, m.BPM, 120.0)
+	gobot.Assert(t, len(m.Notes), 6)
+
+	tones := []float64{C5, Db6, Rest, A4, B5, B5}
+	durations := []float64{0.5, 1.5, 1, 2, 0.375, 1}
+	for i, note := range m.Notes {
+		gobot.Assert(t, math.Abs(note.Tone-tones[i]) < 0.01, true)
+		gobot.Assert(t, note.Duration, durations[i])
+	}
+
+	m, err = ParseRTTTL("defaults::c")
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, m.BPM, 63.0)
+	gobot.Assert(t, math.Abs(m.Notes[0].Tone-C6) < 0.01, true)
+	gobot.Assert(t, m.Notes[0].Duration, 1.0)
+
+	for _, song := range []string{
+		"no sections",
+		"bad:d=x:c",
+		"bad:q=4:c",
+		"bad:d=4:x",
+		"bad:d=4:4",
+		"bad:d=4:c#z",
+	} {
+		_, err = ParseRTTTL(song)
+		gobot.Assert(t, err, ErrInvalidRTTTL)
+	}
+}
@@ -0,0 +1,143 @@
+package gpio
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidRTTTL is the error resulting when a song is not valid Ring Tone
+// Text Transfer Language
+var ErrInvalidRTTTL = errors.New("invalid RTTTL song")
+
+// Note is a tone of a Melody
+type Note struct {
+	// Tone in hz, or Rest
+	Tone float64
+	// Duration in beats, eg. Quarter
+	Duration float64
+}
+
+// Melody is a sequence of notes played at a tempo
+type Melody struct {
+	Name  string
+	BPM   float64
+	Notes []Note
+}
+
+// rtttlTones are the semitones of the RTTTL notes above C
+var rtttlTones = map[byte]int{
+	'c': 0, 'd': 2, 'e': 4, 'f': 5, 'g': 7, 'a': 9, 'b': 11, 'h': 11,
+}
+
+// ParseRTTTL parses a song in the Ring Tone Text Transfer Language, such as
+// "scale:d=4,o=5,b=120:c,d,e,f,g,a,b,c6,2p". Durations are converted to
+// beats of a quarter note.
+func ParseRTTTL(song string) (m Melody, err error) {
+	sections := strings.Split(strings.Replace(song, " ", "", -1), ":")
+	if len(sections) != 3 {
+		return m, ErrInvalidRTTTL
+	}
+	m.Name = sections[0]
+
+	duration, octave := 4, 6
+	m.BPM = 63
+	for _, setting := range strings.Split(strings.ToLower(sections[1]), ",") {
+		if setting == "" {
+			continue
+		}
+		kv := strings.SplitN(setting, "=", 2)
+		if len(kv) != 2 {
+			return m, ErrInvalidRTTTL
+		}
+		val, err := strconv.Atoi(kv[1])
+		if err != nil || val <= 0 {
+			return m, ErrInvalidRTTTL
+		}
+		switch kv[0] {
+		case "d":
+			duration = val
+		case "o":
+			octave = val
+		case "b":
+			m.BPM = float64(val)
+		default:
+			return m, ErrInvalidRTTTL
+		}
+	}
+
+	for _, code := range strings.Split(strings.ToLower(sections[2]), ",") {
+		if code == "" {
+			continue
+		}
+		note, err := parseRTTTLNote(code, duration, octave)
+		if err != nil {
+			return m, err
+		}
+		m.Notes = append(m.Notes, note)
+	}
+	return
+}
+
+// parseRTTTLNote parses a note such as "8c#6." given the default duration and
+// octave
+func parseRTTTLNote(code string, duration int, octave int) (note Note, err error) {
+	i := 0
+	number := func() (n int, ok bool) {
+		start := i
+		for i < len(code) && code[i] >= '0' && code[i] <= '9' {
+			i++
+		}
+		if i == start {
+			return 0, false
+		}
+		n, _ = strconv.Atoi(code[start:i])
+		return n, true
+	}
+
+	if n, ok := number(); ok {
+		duration = n
+	}
+	if duration <= 0 || i == len(code) {
+		return note, ErrInvalidRTTTL
+	}
+
+	name := code[i]
+	i++
+	semitone, tone := rtttlTones[name]
+	if !tone && name != 'p' {
+		return note, ErrInvalidRTTTL
+	}
+	if i < len(code) && code[i] == '#' {
+		semitone++
+		i++
+	}
+
+	dotted := false
+	if i < len(code) && code[i] == '.' {
+		dotted = true
+		i++
+	}
+	if n, ok := number(); ok {
+		octave = n
+	}
+	if i < len(code) && code[i] == '.' {
+		dotted = true
+		i++
+	}
+	if i != len(code) {
+		return note, ErrInvalidRTTTL
+	}
+
+	// a quarter note is a beat
+	note.Duration = 4 / float64(duration)
+	if dotted {
+		note.Duration *= 1.5
+	}
+	if tone {
+		// A4 is 440hz, and 57 semitones above C0
+		note.Tone = 440 * math.Pow(2, float64(octave*12+semitone-57)/12)
+	}
+	return
+}
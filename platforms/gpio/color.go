@@ -0,0 +1,100 @@
+package gpio
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidColor is the error resulting when a color can not be parsed
+var ErrInvalidColor = errors.New("color must be hex such as #ff8800 or #f80")
+
+// ParseHexColor parses colors written as "#rrggbb", "#rgb" or either without
+// the leading #
+func ParseHexColor(s string) (c color.RGBA, err error) {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) != 6 {
+		return c, ErrInvalidColor
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return c, ErrInvalidColor
+	}
+	return color.RGBA{R: byte(v >> 16), G: byte(v >> 8), B: byte(v), A: 255}, nil
+}
+
+// HexColor formats c as "#rrggbb"
+func HexColor(c color.RGBA) string {
+	return "#" + strconv.FormatUint(1<<24|uint64(c.R)<<16|uint64(c.G)<<8|uint64(c.B), 16)[1:]
+}
+
+// HSV returns the color of hue h in degrees, saturation s and value v, both
+// 0-1
+func HSV(h, s, v float64) color.RGBA {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	s = math.Max(0, math.Min(1, s))
+	v = math.Max(0, math.Min(1, v))
+
+	c := v * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	m := v - c
+	return color.RGBA{
+		R: colorByte(r + m),
+		G: colorByte(g + m),
+		B: colorByte(b + m),
+		A: 255,
+	}
+}
+
+// GammaCorrect returns c corrected for the non-linear brightness of LEDs, so
+// that fades look even. A gamma of 2.8 suits most LEDs, 0 or 1 leaves c as
+// it is. The alpha channel is left as it is.
+func GammaCorrect(c color.RGBA, gamma float64) color.RGBA {
+	if gamma == 0 || gamma == 1 {
+		return c
+	}
+	correct := func(v byte) byte {
+		return colorByte(math.Pow(float64(v)/255, gamma))
+	}
+	return color.RGBA{R: correct(c.R), G: correct(c.G), B: correct(c.B), A: c.A}
+}
+
+// blendColors returns the color the fraction t of the way from a to b
+func blendColors(a, b color.RGBA, t float64) color.RGBA {
+	mix := func(x, y byte) byte {
+		return byte(math.Floor(float64(x) + (float64(y)-float64(x))*t + 0.5))
+	}
+	return color.RGBA{R: mix(a.R, b.R), G: mix(a.G, b.G), B: mix(a.B, b.B), A: mix(a.A, b.A)}
+}
+
+// scaleColor returns c with its red, green and blue dimmed to the fraction t
+func scaleColor(c color.RGBA, t float64) color.RGBA {
+	return blendColors(color.RGBA{A: c.A}, c, t)
+}
+
+func colorByte(v float64) byte {
+	return byte(math.Floor(math.Max(0, math.Min(1, v))*255 + 0.5))
+}
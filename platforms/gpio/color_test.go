@@ -0,0 +1,58 @@
+package gpio
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestParseHexColor(t *testing.T) {
+	c, err := ParseHexColor("#ff8800")
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, c, color.RGBA{R: 255, G: 136, B: 0, A: 255})
+
+	c, err = ParseHexColor("0A1b2C")
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, c, color.RGBA{R: 10, G: 27, B: 44, A: 255})
+
+	c, err = ParseHexColor("#f80")
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, c, color.RGBA{R: 255, G: 136, B: 0, A: 255})
+
+	_, err = ParseHexColor("#ff88")
+	gobot.Assert(t, err, ErrInvalidColor)
+	_, err = ParseHexColor("#gg8800")
+	gobot.Assert(t, err, ErrInvalidColor)
+}
+
+func TestHexColor(t *testing.T) {
+	gobot.Assert(t, HexColor(color.RGBA{R: 255, G: 8, B: 0, A: 255}), "#ff0800")
+	gobot.Assert(t, HexColor(color.RGBA{}), "#000000")
+}
+
+func TestHSV(t *testing.T) {
+	gobot.Assert(t, HSV(0, 1, 1), color.RGBA{R: 255, A: 255})
+	gobot.Assert(t, HSV(120, 1, 1), color.RGBA{G: 255, A: 255})
+	gobot.Assert(t, HSV(240, 1, 1), color.RGBA{B: 255, A: 255})
+	gobot.Assert(t, HSV(600, 1, 1), color.RGBA{B: 255, A: 255})
+	gobot.Assert(t, HSV(-60, 1, 1), color.RGBA{R: 255, B: 255, A: 255})
+	gobot.Assert(t, HSV(30, 1, 1), color.RGBA{R: 255, G: 128, A: 255})
+	gobot.Assert(t, HSV(0, 0, 0.5), color.RGBA{R: 128, G: 128, B: 128, A: 255})
+}
+
+func TestGammaCorrect(t *testing.T) {
+	c := color.RGBA{R: 255, G: 128, B: 0, A: 100}
+	gobot.Assert(t, GammaCorrect(c, 0), c)
+	gobot.Assert(t, GammaCorrect(c, 1), c)
+	gobot.Assert(t, GammaCorrect(c, 2), color.RGBA{R: 255, G: 64, B: 0, A: 100})
+}
+
+func TestBlendColors(t *testing.T) {
+	a := color.RGBA{R: 0, G: 100, B: 200, A: 255}
+	b := color.RGBA{R: 100, G: 100, B: 0, A: 255}
+	gobot.Assert(t, blendColors(a, b, 0), a)
+	gobot.Assert(t, blendColors(a, b, 1), b)
+	gobot.Assert(t, blendColors(a, b, 0.5), color.RGBA{R: 50, G: 100, B: 100, A: 255})
+	gobot.Assert(t, scaleColor(b, 0.5), color.RGBA{R: 50, G: 50, B: 0, A: 255})
+}
@@ -0,0 +1,296 @@
+package gpio
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*DifferentialDriveDriver)(nil)
+
+// WheelEncoder is the interface of an encoder measuring how far a wheel has
+// turned, such as a RotaryEncoderDriver
+type WheelEncoder interface {
+	Position() int
+}
+
+// DifferentialDriveDriver represents a chassis steered by driving a left and
+// a right motor at different speeds, eg. through an L298N or TB6612 H-bridge
+type DifferentialDriveDriver struct {
+	name     string
+	left     *MotorDriver
+	right    *MotorDriver
+	interval time.Duration
+	halt     chan bool
+	mutex    sync.Mutex
+
+	targetLeft   float64
+	targetRight  float64
+	currentLeft  float64
+	currentRight float64
+	straight     bool
+
+	leftEncoder  WheelEncoder
+	rightEncoder WheelEncoder
+	leftStart    int
+	rightStart   int
+
+	// Ramp is the largest change in speed per second of each wheel, in full
+	// speeds, to protect gearboxes. 0 changes speed at once.
+	Ramp float64
+	// EncoderGain is how strongly the wheel speeds are corrected per count of
+	// difference between the wheel encoders while driving straight
+	EncoderGain float64
+	gobot.Eventer
+	gobot.Commander
+}
+
+// NewDifferentialDriveDriver returns a new DifferentialDriveDriver with an
+// update interval of 20 Milliseconds given a name and its left and right
+// motors, which need a PwmWriter connection and either a DirectionPin or a
+// ForwardPin and BackwardPin.
+//
+// Optionally accepts:
+// 	time.Duration: Interval at which the speeds of the motors are updated
+//
+// Adds the following API Commands:
+// 	"Drive" - See DifferentialDriveDriver.Drive
+// 	"Turn" - See DifferentialDriveDriver.Turn
+// 	"Stop" - See DifferentialDriveDriver.Stop
+// 	"Brake" - See DifferentialDriveDriver.Brake
+// 	"Coast" - See DifferentialDriveDriver.Coast
+//
+// Emits the Events:
+// 	"error" - an error setting the motors while updating their speeds
+func NewDifferentialDriveDriver(name string, left *MotorDriver, right *MotorDriver, v ...time.Duration) *DifferentialDriveDriver {
+	d := &DifferentialDriveDriver{
+		name:        name,
+		left:        left,
+		right:       right,
+		interval:    20 * time.Millisecond,
+		halt:        make(chan bool),
+		EncoderGain: 0.01,
+		Eventer:     gobot.NewEventer(),
+		Commander:   gobot.NewCommander(),
+	}
+
+	if len(v) > 0 {
+		d.interval = v[0]
+	}
+
+	d.AddEvent(Error)
+
+	d.AddCommand("Drive", func(params map[string]interface{}) interface{} {
+		linear := params["linear"].(float64)
+		angular := params["angular"].(float64)
+		return d.Drive(linear, angular)
+	})
+	d.AddCommand("Turn", func(params map[string]interface{}) interface{} {
+		return d.Turn(params["angular"].(float64))
+	})
+	d.AddCommand("Stop", func(params map[string]interface{}) interface{} {
+		return d.Stop()
+	})
+	d.AddCommand("Brake", func(params map[string]interface{}) interface{} {
+		return d.Brake()
+	})
+	d.AddCommand("Coast", func(params map[string]interface{}) interface{} {
+		return d.Coast()
+	})
+
+	return d
+}
+
+// Name returns the DifferentialDriveDrivers name
+func (d *DifferentialDriveDriver) Name() string { return d.name }
+
+// Connection returns the Connection of the DifferentialDriveDrivers left motor
+func (d *DifferentialDriveDriver) Connection() gobot.Connection { return d.left.Connection() }
+
+// Left returns the left motor
+func (d *DifferentialDriveDriver) Left() *MotorDriver { return d.left }
+
+// Right returns the right motor
+func (d *DifferentialDriveDriver) Right() *MotorDriver { return d.right }
+
+// SetEncoders sets the encoders of the left and right wheels, which are used
+// to keep the chassis going straight when driving without turning
+func (d *DifferentialDriveDriver) SetEncoders(left WheelEncoder, right WheelEncoder) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.leftEncoder = left
+	d.rightEncoder = right
+	d.resetEncoders()
+}
+
+// Start starts updating the speeds of the motors at the given interval,
+// ramping them towards the speeds set by Drive
+func (d *DifferentialDriveDriver) Start() (errs []error) {
+	go func() {
+		for {
+			select {
+			case <-time.After(d.interval):
+				if err := d.update(d.interval); err != nil {
+					gobot.Publish(d.Event(Error), err)
+				}
+			case <-d.halt:
+				return
+			}
+		}
+	}()
+	return
+}
+
+// Halt stops updating the motors and lets them coast
+func (d *DifferentialDriveDriver) Halt() (errs []error) {
+	d.halt <- true
+	if err := d.Coast(); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Speeds returns the current speeds of the left and right wheels, from -1 for
+// full speed backward to 1 for full speed forward
+func (d *DifferentialDriveDriver) Speeds() (left float64, right float64) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.currentLeft, d.currentRight
+}
+
+// Drive sets the linear speed, from -1 for full speed backward to 1 for full
+// speed forward, and the angular speed, from -1 for turning clockwise to 1 for
+// turning counter-clockwise, of the chassis. The wheel speeds are scaled down
+// together when they would exceed full speed.
+func (d *DifferentialDriveDriver) Drive(linear float64, angular float64) (err error) {
+	left, right := linear-angular, linear+angular
+	if max := math.Max(math.Abs(left), math.Abs(right)); max > 1 {
+		left, right = left/max, right/max
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	straight := angular == 0 && linear != 0
+	if straight && (!d.straight || (linear > 0) != (d.targetLeft > 0)) {
+		d.resetEncoders()
+	}
+	d.straight = straight
+	d.targetLeft, d.targetRight = left, right
+
+	if d.Ramp == 0 {
+		return d.apply(d.trim(left, right))
+	}
+	return
+}
+
+// Turn turns the chassis on the spot at the angular speed, see Drive
+func (d *DifferentialDriveDriver) Turn(angular float64) (err error) {
+	return d.Drive(0, angular)
+}
+
+// Stop slows the chassis down to a standstill, ramping down the speeds
+// like Drive
+func (d *DifferentialDriveDriver) Stop() (err error) {
+	return d.Drive(0, 0)
+}
+
+// Brake stops both motors at once, see MotorDriver.Brake
+func (d *DifferentialDriveDriver) Brake() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.stopped()
+	if err = d.left.Brake(); err != nil {
+		return
+	}
+	return d.right.Brake()
+}
+
+// Coast lets both motors spin down freely, see MotorDriver.Coast
+func (d *DifferentialDriveDriver) Coast() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.stopped()
+	if err = d.left.Coast(); err != nil {
+		return
+	}
+	return d.right.Coast()
+}
+
+// stopped sets every speed to 0
+func (d *DifferentialDriveDriver) stopped() {
+	d.targetLeft, d.targetRight = 0, 0
+	d.currentLeft, d.currentRight = 0, 0
+	d.straight = false
+}
+
+// update ramps the wheel speeds towards their targets by the change allowed
+// over elapsed
+func (d *DifferentialDriveDriver) update(elapsed time.Duration) (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	left, right := d.trim(d.targetLeft, d.targetRight)
+	if d.Ramp > 0 {
+		step := d.Ramp * elapsed.Seconds()
+		left = rampTowards(d.currentLeft, left, step)
+		right = rampTowards(d.currentRight, right, step)
+	}
+	if left != d.currentLeft || right != d.currentRight {
+		return d.apply(left, right)
+	}
+	return
+}
+
+// trim corrects the wheel speeds by the difference between the wheel
+// encoders while driving straight
+func (d *DifferentialDriveDriver) trim(left float64, right float64) (float64, float64) {
+	if !d.straight || d.leftEncoder == nil || d.rightEncoder == nil {
+		return left, right
+	}
+	diff := (d.leftEncoder.Position() - d.leftStart) - (d.rightEncoder.Position() - d.rightStart)
+	correction := d.EncoderGain * float64(diff)
+	return clampSpeed(left - correction), clampSpeed(right + correction)
+}
+
+func (d *DifferentialDriveDriver) resetEncoders() {
+	if d.leftEncoder != nil && d.rightEncoder != nil {
+		d.leftStart = d.leftEncoder.Position()
+		d.rightStart = d.rightEncoder.Position()
+	}
+}
+
+// apply sets the wheels to the speeds
+func (d *DifferentialDriveDriver) apply(left float64, right float64) (err error) {
+	d.currentLeft, d.currentRight = left, right
+	if err = driveMotor(d.left, left); err != nil {
+		return
+	}
+	return driveMotor(d.right, right)
+}
+
+// driveMotor sets motor m to speed, from -1 to 1
+func driveMotor(m *MotorDriver, speed float64) error {
+	value := byte(math.Floor(math.Abs(speed)*255 + 0.5))
+	switch {
+	case value == 0:
+		return m.Coast()
+	case speed > 0:
+		return m.Forward(value)
+	default:
+		return m.Backward(value)
+	}
+}
+
+func rampTowards(current float64, target float64, step float64) float64 {
+	if target > current {
+		return math.Min(target, current+step)
+	}
+	return math.Max(target, current-step)
+}
+
+func clampSpeed(speed float64) float64 {
+	return math.Max(-1, math.Min(1, speed))
+}
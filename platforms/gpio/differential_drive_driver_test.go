@@ -0,0 +1,191 @@
+package gpio
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+type testWheelEncoder struct {
+	position int
+}
+
+func (e *testWheelEncoder) Position() int { return e.position }
+
+func initTestDifferentialDriveDriver() (*DifferentialDriveDriver, *gpioTestPinWriter) {
+	a := newGpioTestPinWriter()
+	left := NewMotorDriver(a, "left", "ls")
+	left.ForwardPin = "lf"
+	left.BackwardPin = "lb"
+	right := NewMotorDriver(a, "right", "rs")
+	right.DirectionPin = "rd"
+	return NewDifferentialDriveDriver("bot", left, right), a
+}
+
+func TestDifferentialDriveDriver(t *testing.T) {
+	d, _ := initTestDifferentialDriveDriver()
+	gobot.Assert(t, d.Name(), "bot")
+	gobot.Assert(t, d.Left().Name(), "left")
+	gobot.Assert(t, d.Right().Name(), "right")
+	gobot.Assert(t, d.interval, 20*time.Millisecond)
+
+	d = NewDifferentialDriveDriver("bot", NewMotorDriver(newGpioTestAdaptor("adaptor"), "left", "1"),
+		NewMotorDriver(newGpioTestAdaptor("adaptor"), "right", "2"), 50*time.Millisecond)
+	gobot.Assert(t, d.Connection().Name(), "adaptor")
+	gobot.Assert(t, d.interval, 50*time.Millisecond)
+}
+
+func TestDifferentialDriveDriverDrive(t *testing.T) {
+	d, a := initTestDifferentialDriveDriver()
+
+	gobot.Assert(t, d.Drive(1, 0), nil)
+	gobot.Assert(t, a.history("ls"), []byte{255})
+	gobot.Assert(t, a.history("lf"), []byte{1})
+	gobot.Assert(t, a.history("lb"), []byte{0})
+	gobot.Assert(t, a.history("rs"), []byte{255})
+	gobot.Assert(t, a.history("rd"), []byte{1})
+
+	// turning faster than full speed scales both wheels down
+	d.Drive(1, 1)
+	left, right := d.Speeds()
+	gobot.Assert(t, left, 0.0)
+	gobot.Assert(t, right, 1.0)
+
+	d.Turn(-0.5)
+	left, right = d.Speeds()
+	gobot.Assert(t, left, 0.5)
+	gobot.Assert(t, right, -0.5)
+	gobot.Assert(t, a.history("rd")[2], byte(0))
+	gobot.Assert(t, a.history("rs")[2], byte(128))
+
+	d.Stop()
+	left, right = d.Speeds()
+	gobot.Assert(t, left, 0.0)
+	gobot.Assert(t, right, 0.0)
+	gobot.Assert(t, d.Right().CurrentSpeed, uint8(0))
+	gobot.Assert(t, d.Left().CurrentDirection, "none")
+}
+
+func TestDifferentialDriveDriverRamp(t *testing.T) {
+	d, _ := initTestDifferentialDriveDriver()
+	d.Ramp = 2
+
+	d.Drive(1, 0)
+	left, _ := d.Speeds()
+	gobot.Assert(t, left, 0.0)
+
+	d.update(100 * time.Millisecond)
+	left, right := d.Speeds()
+	gobot.Assert(t, left, 0.2)
+	gobot.Assert(t, right, 0.2)
+
+	d.update(time.Second)
+	left, _ = d.Speeds()
+	gobot.Assert(t, left, 1.0)
+
+	d.Drive(-1, 0)
+	d.update(250 * time.Millisecond)
+	left, _ = d.Speeds()
+	gobot.Assert(t, left, 0.5)
+}
+
+func TestDifferentialDriveDriverStart(t *testing.T) {
+	d, a := initTestDifferentialDriveDriver()
+	d.Ramp = 10
+	gobot.Assert(t, len(d.Start()), 0)
+
+	d.Drive(0.5, 0)
+	<-time.After(150 * time.Millisecond)
+	left, _ := d.Speeds()
+	gobot.Assert(t, left, 0.5)
+
+	gobot.Assert(t, len(d.Halt()), 0)
+	left, _ = d.Speeds()
+	gobot.Assert(t, left, 0.0)
+	h := a.history("ls")
+	gobot.Assert(t, h[len(h)-1], byte(0))
+}
+
+func TestDifferentialDriveDriverUpdateError(t *testing.T) {
+	d, a := initTestDifferentialDriveDriver()
+	d.Ramp = 10
+	d.Drive(0.5, 0)
+	a.fail(errors.New("write error"))
+
+	errs := make(chan interface{}, 1)
+	gobot.Once(d.Event(Error), func(data interface{}) {
+		errs <- data
+	})
+	gobot.Assert(t, len(d.Start()), 0)
+	select {
+	case err := <-errs:
+		gobot.Assert(t, err, errors.New("write error"))
+	case <-time.After(time.Second):
+		t.Errorf("error was not published")
+	}
+	gobot.Assert(t, d.Halt()[0], errors.New("write error"))
+}
+
+func TestDifferentialDriveDriverEncoders(t *testing.T) {
+	d, _ := initTestDifferentialDriveDriver()
+	l, r := &testWheelEncoder{position: 100}, &testWheelEncoder{position: 50}
+	d.SetEncoders(l, r)
+	d.EncoderGain = 0.1
+
+	d.Drive(0.5, 0)
+	l.position, r.position = 103, 51
+	d.update(d.interval)
+
+	// the left wheel got ahead by 2 counts
+	left, right := d.Speeds()
+	gobot.Assert(t, left, 0.3)
+	gobot.Assert(t, right, 0.7)
+
+	// going backward
+	d.Drive(-0.5, 0)
+	l.position, r.position = 101, 50
+	d.update(d.interval)
+	left, right = d.Speeds()
+	gobot.Assert(t, left, -0.4)
+	gobot.Assert(t, right, -0.6)
+
+	// no correction while turning
+	d.Drive(0.5, 0.1)
+	l.position = 200
+	d.update(d.interval)
+	left, right = d.Speeds()
+	gobot.Assert(t, left, 0.4)
+	gobot.Assert(t, right, 0.6)
+}
+
+func TestDifferentialDriveDriverBrakeCoast(t *testing.T) {
+	d, a := initTestDifferentialDriveDriver()
+	d.Drive(1, 0)
+
+	// the right motor has no backward pin to brake with
+	gobot.Assert(t, d.Brake(), ErrMotorBrakeUnsupported)
+	gobot.Assert(t, a.history("lf")[1], byte(1))
+	gobot.Assert(t, a.history("lb")[1], byte(1))
+	left, _ := d.Speeds()
+	gobot.Assert(t, left, 0.0)
+
+	d.Right().DirectionPin = ""
+	d.Right().ForwardPin = "rf"
+	d.Right().BackwardPin = "rb"
+	gobot.Assert(t, d.Brake(), nil)
+
+	gobot.Assert(t, d.Coast(), nil)
+	gobot.Assert(t, d.Left().CurrentSpeed, uint8(0))
+	gobot.Assert(t, d.Right().CurrentDirection, "none")
+}
+
+func TestDifferentialDriveDriverCommands(t *testing.T) {
+	d, _ := initTestDifferentialDriveDriver()
+	gobot.Assert(t, d.Command("Drive")(map[string]interface{}{"linear": 0.5, "angular": 0.0}), nil)
+	gobot.Assert(t, d.Command("Turn")(map[string]interface{}{"angular": 0.5}), nil)
+	gobot.Assert(t, d.Command("Stop")(nil), nil)
+	gobot.Assert(t, d.Command("Coast")(nil), nil)
+	gobot.Assert(t, d.Command("Brake")(nil), ErrMotorBrakeUnsupported)
+}
@@ -0,0 +1,147 @@
+package gpio
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// digitalWatcher reads a set of digital input pins whenever one of them
+// changes, through DigitalNotify if the connection is a DigitalNotifier and by
+// polling them otherwise, and reports on them at a slower interval.
+type digitalWatcher struct {
+	connection DigitalReader
+	pins       []string
+	interval   time.Duration
+	halt       chan bool
+	mutex      sync.Mutex
+	halted     bool
+	// the last values of the pins, and the DigitalNotifier calling back with
+	// their changes, if any
+	vals     []int
+	notifier DigitalNotifier
+	// ReportInterval is the interval at which Data events are published
+	ReportInterval time.Duration
+}
+
+func newDigitalWatcher(a DigitalReader, pins []string, interval time.Duration) digitalWatcher {
+	return digitalWatcher{
+		connection:     a,
+		pins:           pins,
+		interval:       interval,
+		halt:           make(chan bool),
+		ReportInterval: 100 * time.Millisecond,
+	}
+}
+
+// start calls sample with the values of the pins whenever they may have
+// changed and report at the ReportInterval, until the watcher is halted.
+// Read errors are published to errorEvent.
+func (w *digitalWatcher) start(errorEvent *gobot.Event, sample func(vals []int, now time.Time), report func(now time.Time)) (err error) {
+	read := func() {
+		vals, rerr := w.read()
+		if rerr != nil {
+			gobot.Publish(errorEvent, rerr)
+			return
+		}
+
+		w.mutex.Lock()
+		defer w.mutex.Unlock()
+		if !w.halted {
+			sample(vals, time.Now())
+		}
+	}
+
+	interval := w.interval
+	notifier, notify := w.connection.(DigitalNotifier)
+	w.mutex.Lock()
+	w.halted = false
+	w.mutex.Unlock()
+	if notify {
+		// the pins are read once, then each callback gives the new value of
+		// its pin
+		vals, rerr := w.read()
+		if rerr != nil {
+			return rerr
+		}
+		w.mutex.Lock()
+		w.vals = vals
+		sample(vals, time.Now())
+		w.mutex.Unlock()
+		for i, pin := range w.pins {
+			i := i
+			if err = notifier.DigitalNotify(pin, func(val int) { w.notified(i, val, sample) }); err != nil {
+				w.unnotify(notifier)
+				return
+			}
+		}
+		w.notifier = notifier
+		interval = w.ReportInterval
+	}
+
+	go func() {
+		lastReport := time.Now()
+		for {
+			if !notify {
+				read()
+			}
+			if now := time.Now(); now.Sub(lastReport) >= w.ReportInterval {
+				w.mutex.Lock()
+				report(now)
+				w.mutex.Unlock()
+				lastReport = now
+			}
+			select {
+			case <-time.After(interval):
+			case <-w.halt:
+				w.mutex.Lock()
+				w.halted = true
+				w.mutex.Unlock()
+				return
+			}
+		}
+	}()
+	return
+}
+
+// read reads the values of the pins
+func (w *digitalWatcher) read() (vals []int, err error) {
+	vals = make([]int, len(w.pins))
+	for i, pin := range w.pins {
+		if vals[i], err = w.connection.DigitalRead(pin); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// notified calls sample with the value of pin i given by a DigitalNotify
+// callback, and the last values of the other pins
+func (w *digitalWatcher) notified(i int, val int, sample func(vals []int, now time.Time)) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.halted {
+		return
+	}
+	vals := append([]int{}, w.vals...)
+	vals[i] = val
+	w.vals = vals
+	sample(vals, time.Now())
+}
+
+// unnotify stops the DigitalNotify callbacks of the pins
+func (w *digitalWatcher) unnotify(notifier DigitalNotifier) {
+	for _, pin := range w.pins {
+		notifier.DigitalUnnotify(pin)
+	}
+}
+
+// stop halts the watcher
+func (w *digitalWatcher) stop() {
+	w.halt <- true
+	if w.notifier != nil {
+		w.unnotify(w.notifier)
+		w.notifier = nil
+	}
+}
@@ -2,6 +2,8 @@ package gpio
 
 import (
 	"errors"
+	"image/color"
+	"time"
 
 	"github.com/hybridgroup/gobot"
 )
@@ -22,6 +24,9 @@ var (
 	// ErrDigitalReadUnsupported is the error resulting when a driver attempts to use
 	// hardware capabilities which a connection does not support
 	ErrDigitalReadUnsupported = errors.New("DigitalRead is not supported by this platform")
+	// ErrTriggeredPulseReadUnsupported is the error resulting when a driver attempts to use
+	// hardware capabilities which a connection does not support
+	ErrTriggeredPulseReadUnsupported = errors.New("TriggeredPulseRead is not supported by this platform")
 	// ErrServoOutOfRange is the error resulting when a driver attempts to use
 	// hardware capabilities which a connection does not support
 	ErrServoOutOfRange = errors.New("servo angle must be between 0-180")
@@ -32,12 +37,30 @@ const (
 	Release = "release"
 	// Push event
 	Push = "push"
+	// LongPress event
+	LongPress = "longpress"
+	// Hold event
+	Hold = "hold"
+	// DoubleClick event
+	DoubleClick = "doubleclick"
 	// Error event
 	Error = "error"
 	// Data event
 	Data = "data"
+	// Value event
+	Value = "value"
+	// Raw event
+	Raw = "raw"
+	// Above event
+	Above = "above"
+	// Below event
+	Below = "below"
+	// InRange event
+	InRange = "in-range"
 	// Vibration event
 	Vibration = "vibration"
+	// Finished event
+	Finished = "finished"
 )
 
 // PwmWriter interface represents an Adaptor which has Pwm capabilities
@@ -46,6 +69,15 @@ type PwmWriter interface {
 	PwmWrite(string, byte) (err error)
 }
 
+// PwmPeriodWriter interface represents an Adaptor which can also set the period
+// of the Pwm signal of a pin, and so drive it at a frequency
+type PwmPeriodWriter interface {
+	PwmWriter
+	// PwmPeriodWrite drives pin with a signal of period, high for the 0-255
+	// fraction duty of each period
+	PwmPeriodWrite(pin string, period time.Duration, duty byte) (err error)
+}
+
 // ServoWriter interface represents an Adaptor which has Servo capabilities
 type ServoWriter interface {
 	gobot.Adaptor
@@ -69,3 +101,43 @@ type DigitalReader interface {
 	gobot.Adaptor
 	DigitalRead(string) (val int, err error)
 }
+
+// DigitalNotifier interface represents an Adaptor which can notify drivers of
+// changes of digital input pins, sparing them from polling the pins
+type DigitalNotifier interface {
+	DigitalReader
+	// DigitalNotify calls f with the value of pin, in order, every time it
+	// changes, and possibly also when it has not
+	DigitalNotify(pin string, f func(val int)) (err error)
+	// DigitalUnnotify stops calling the functions given to DigitalNotify for pin
+	DigitalUnnotify(pin string) (err error)
+}
+
+// PulseReader interface represents an Adaptor which can measure the duration of
+// pulses on digital input pins
+type PulseReader interface {
+	gobot.Adaptor
+	// PulseRead waits for pin to go to level and returns how long it stays
+	// there, or an error when no pulse ends within timeout. If trigger is not
+	// 0, pin is driven to level for trigger once it is ready to time the
+	// pulse, starting a measurement as single pin sensors expect.
+	PulseRead(pin string, level int, trigger time.Duration, timeout time.Duration) (time.Duration, error)
+}
+
+// TriggeredPulseReader interface represents a PulseReader which can start the
+// pulse on another pin, as sensors with separate trigger and echo pins expect
+type TriggeredPulseReader interface {
+	PulseReader
+	// TriggeredPulseRead drives triggerPin to level for trigger once pin is
+	// ready to time the pulse, then measures it as PulseRead does
+	TriggeredPulseRead(triggerPin string, pin string, level int, trigger time.Duration, timeout time.Duration) (time.Duration, error)
+}
+
+// PixelWriter interface represents an Adaptor which can drive a strip of
+// addressable RGB LEDs, such as WS2812 (NeoPixel), on a pin
+type PixelWriter interface {
+	gobot.Adaptor
+	// PixelWrite shows colors on the strip of LEDs on pin, the first color on
+	// the LED nearest the pin
+	PixelWrite(pin string, colors []color.RGBA) (err error)
+}
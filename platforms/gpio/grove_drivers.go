@@ -97,7 +97,8 @@ type GrovePiezoVibrationSensorDriver struct {
 }
 
 // NewGrovePiezoVibrationSensorDriver returns a new GrovePiezoVibrationSensorDriver with a polling interval of
-// 10 Milliseconds given an AnalogReader, name and pin.
+// 10 Milliseconds given an AnalogReader, name and pin. It publishes Vibration
+// with each new reading above 1000.
 //
 // Optinally accepts:
 // 	time.Duration: Interval at which the AnalogSensor is polled for new information
@@ -142,6 +143,8 @@ type GroveButtonDriver struct {
 // NewGroveButtonDriver returns a new GroveButtonDriver with a polling interval of
 // 10 Milliseconds given a DigitalReader, name and pin.
 //
+// Debouncing and gesture detection are configured as for the ButtonDriver.
+//
 // Optinally accepts:
 //  time.Duration: Interval at which the ButtonDriver is polled for new information
 func NewGroveButtonDriver(a DigitalReader, name string, pin string, v ...time.Duration) *GroveButtonDriver {
@@ -179,6 +182,8 @@ type GroveTouchDriver struct {
 // NewGroveTouchDriver returns a new GroveTouchDriver with a polling interval of
 // 10 Milliseconds given a DigitalReader, name and pin.
 //
+// Debouncing and gesture detection are configured as for the ButtonDriver.
+//
 // Optinally accepts:
 //  time.Duration: Interval at which the ButtonDriver is polled for new information
 func NewGroveTouchDriver(a DigitalReader, name string, pin string, v ...time.Duration) *GroveTouchDriver {
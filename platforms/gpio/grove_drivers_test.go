@@ -0,0 +1,36 @@
+package gpio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestGrovePiezoVibrationSensorDriver(t *testing.T) {
+	d := NewGrovePiezoVibrationSensorDriver(newGpioTestAdaptor("adaptor"), "bot", "1")
+	sem := make(chan int, 3)
+	gobot.On(d.Event(Vibration), func(data interface{}) {
+		sem <- data.(int)
+	})
+
+	// a vibration is published with each new reading above 1000
+	for _, val := range []int{20, 1010, 1020, 30, 1005} {
+		gobot.Publish(d.Event(Data), val)
+	}
+	vibrations := map[int]bool{}
+	for i := 0; i < 3; i++ {
+		select {
+		case val := <-sem:
+			vibrations[val] = true
+		case <-time.After(100 * time.Millisecond):
+			t.Errorf("GrovePiezoVibrationSensor Event \"Vibration\" was not published")
+		}
+	}
+	gobot.Assert(t, vibrations, map[int]bool{1010: true, 1020: true, 1005: true})
+	select {
+	case val := <-sem:
+		t.Errorf("unexpected vibration %v", val)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
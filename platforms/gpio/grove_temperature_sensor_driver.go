@@ -1,7 +1,6 @@
 package gpio
 
 import (
-	"math"
 	"time"
 
 	"github.com/hybridgroup/gobot"
@@ -9,15 +8,10 @@ import (
 
 var _ gobot.Driver = (*GroveTemperatureSensorDriver)(nil)
 
-// GroveTemperatureSensorDriver represents a Temperature Sensor
+// GroveTemperatureSensorDriver represents a Temperature Sensor, an
+// AnalogSensorDriver which scales its readings with GroveTemperatureScaler
 type GroveTemperatureSensorDriver struct {
-	name        string
-	pin         string
-	halt        chan bool
-	temperature float64
-	interval    time.Duration
-	connection  AnalogReader
-	gobot.Eventer
+	*AnalogSensorDriver
 }
 
 // NewGroveTemperatureSensorDriver returns a new GroveTemperatureSensorDriver with a polling interval of
@@ -26,82 +20,28 @@ type GroveTemperatureSensorDriver struct {
 // Optinally accepts:
 // 	time.Duration: Interval at which the TemperatureSensor is polled for new information
 //
+// Emits the Events:
+//	Data float64 - Event is emitted when the temperature in celsius moves by more than the Hysteresis.
+//	Raw int - Event is emitted on change and represents the raw reading from the sensor.
+//	Error error - Event is emitted on error reading from the sensor.
+//
 // Adds the following API Commands:
 // 	"Read" - See AnalogSensor.Read
+// 	"Value" - See AnalogSensor.Value
 func NewGroveTemperatureSensorDriver(a AnalogReader, name string, pin string, v ...time.Duration) *GroveTemperatureSensorDriver {
 	d := &GroveTemperatureSensorDriver{
-		name:       name,
-		connection: a,
-		pin:        pin,
-		Eventer:    gobot.NewEventer(),
-		interval:   10 * time.Millisecond,
-		halt:       make(chan bool),
+		AnalogSensorDriver: NewAnalogSensorDriver(a, name, pin, v...),
 	}
-
-	if len(v) > 0 {
-		d.interval = v[0]
-	}
-
-	d.AddEvent(Data)
-	d.AddEvent(Error)
+	d.Scale = GroveTemperatureScaler
+	// Data stays the temperature, as it was before the driver was built on
+	// AnalogSensorDriver
+	d.rawEvent, d.valueEvent = Raw, Data
+	d.AddEvent(Raw)
 
 	return d
 }
 
-// Start starts the GroveTemperatureSensorDriver and reads the Sensor at the given interval.
-// Emits the Events:
-//	Data int - Event is emitted on change and represents the current temperature in celsius from the sensor.
-//	Error error - Event is emitted on error reading from the sensor.
-func (a *GroveTemperatureSensorDriver) Start() (errs []error) {
-	thermistor := 3975.0
-	a.temperature = 0
-
-	go func() {
-		for {
-			rawValue, err := a.Read()
-
-			resistance := float64(1023.0-rawValue) * 10000 / float64(rawValue)
-			newValue := 1/(math.Log(resistance/10000.0)/thermistor+1/298.15) - 273.15
-
-			if err != nil {
-				gobot.Publish(a.Event(Error), err)
-			} else if newValue != a.temperature && newValue != -1 {
-				a.temperature = newValue
-				gobot.Publish(a.Event(Data), a.temperature)
-			}
-			select {
-			case <-time.After(a.interval):
-			case <-a.halt:
-				return
-			}
-		}
-	}()
-	return
-}
-
-// Halt stops polling the analog sensor for new information
-func (a *GroveTemperatureSensorDriver) Halt() (errs []error) {
-	a.halt <- true
-	return
-}
-
-// Name returns the GroveTemperatureSensorDrivers name
-func (a *GroveTemperatureSensorDriver) Name() string { return a.name }
-
-// Pin returns the GroveTemperatureSensorDrivers pin
-func (a *GroveTemperatureSensorDriver) Pin() string { return a.pin }
-
-// Connection returns the GroveTemperatureSensorDrivers Connection
-func (a *GroveTemperatureSensorDriver) Connection() gobot.Connection {
-	return a.connection.(gobot.Connection)
-}
-
-// Read returns the current Temperature from the Sensor
+// Temperature returns the last temperature read from the Sensor, in celsius
 func (a *GroveTemperatureSensorDriver) Temperature() (val float64) {
-	return a.temperature
-}
-
-// Read returns the raw reading from the Sensor
-func (a *GroveTemperatureSensorDriver) Read() (val int, err error) {
-	return a.connection.AnalogRead(a.Pin())
+	return a.Value()
 }
@@ -0,0 +1,37 @@
+package gpio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestGroveTemperatureSensorDriver(t *testing.T) {
+	d := NewGroveTemperatureSensorDriver(newGpioTestAdaptor("adaptor"), "bot", "1")
+	gobot.Assert(t, d.Name(), "bot")
+	gobot.Assert(t, d.Pin(), "1")
+	gobot.Assert(t, d.Connection().Name(), "adaptor")
+
+	d = NewGroveTemperatureSensorDriver(newGpioTestAdaptor("adaptor"), "bot", "1", 30*time.Second)
+	gobot.Assert(t, d.interval, 30*time.Second)
+}
+
+func TestGroveTemperatureSensorDriverTemperature(t *testing.T) {
+	d := NewGroveTemperatureSensorDriver(newGpioTestAdaptor("adaptor"), "bot", "1")
+	sem := make(chan float64, 1)
+	gobot.Once(d.Event(Data), func(data interface{}) {
+		sem <- data.(float64)
+	})
+
+	// the thermistor reads half the supply at 25 celsius
+	d.process(511)
+	select {
+	case val := <-sem:
+		gobot.Assert(t, val, GroveTemperatureScaler(511))
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("GroveTemperatureSensor Event \"Data\" was not published")
+	}
+	gobot.Assert(t, d.Temperature(), GroveTemperatureScaler(511))
+	gobot.Assert(t, d.Temperature() > 24.9 && d.Temperature() < 25.1, true)
+}
@@ -0,0 +1,128 @@
+package gpio
+
+import (
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*HCSR04Driver)(nil)
+
+// HCSR04Driver represents an HC-SR04 ultrasonic distance sensor. It can be
+// wired with separate trigger and echo pins, or with both on one pin, in which
+// case the adaptor triggers the measurement itself.
+type HCSR04Driver struct {
+	name       string
+	triggerPin string
+	echoPin    string
+	halt       chan bool
+	interval   time.Duration
+	connection PulseReader
+	gobot.Eventer
+	gobot.Commander
+	// Timeout limits how long a measurement waits for the echo
+	Timeout time.Duration
+	// SpeedOfSound in meters per second, 343 at 20°C
+	SpeedOfSound float64
+}
+
+// NewHCSR04Driver returns a new HCSR04Driver with a polling interval of
+// 100 Milliseconds given a PulseReader, name, trigger pin and echo pin. When
+// the pins differ, the PulseReader must also be a TriggeredPulseReader.
+//
+// Optionally accepts:
+// 	time.Duration: Interval at which the distance is measured
+//
+// Adds the following API Commands:
+// 	"Distance" - See HCSR04Driver.Distance
+func NewHCSR04Driver(a PulseReader, name string, triggerPin string, echoPin string, v ...time.Duration) *HCSR04Driver {
+	h := &HCSR04Driver{
+		name:         name,
+		connection:   a,
+		triggerPin:   triggerPin,
+		echoPin:      echoPin,
+		Eventer:      gobot.NewEventer(),
+		Commander:    gobot.NewCommander(),
+		interval:     100 * time.Millisecond,
+		halt:         make(chan bool),
+		Timeout:      60 * time.Millisecond,
+		SpeedOfSound: 343,
+	}
+
+	if len(v) > 0 {
+		h.interval = v[0]
+	}
+
+	h.AddEvent(Data)
+	h.AddEvent(Error)
+
+	h.AddCommand("Distance", func(params map[string]interface{}) interface{} {
+		distance, err := h.Distance()
+		return map[string]interface{}{"distance": distance, "err": err}
+	})
+
+	return h
+}
+
+// Name returns the HCSR04Drivers name
+func (h *HCSR04Driver) Name() string { return h.name }
+
+// Pins returns the HCSR04Drivers trigger and echo pins
+func (h *HCSR04Driver) Pins() (string, string) { return h.triggerPin, h.echoPin }
+
+// Connection returns the HCSR04Drivers Connection
+func (h *HCSR04Driver) Connection() gobot.Connection { return h.connection.(gobot.Connection) }
+
+// Start starts the HCSR04Driver and measures the distance at the given interval.
+// Emits the Events:
+//	Data float64 - Event is emitted on every measurement and is the distance in centimeters.
+//	Error error - Event is emitted on error measuring the distance.
+func (h *HCSR04Driver) Start() (errs []error) {
+	go func() {
+		for {
+			distance, err := h.Distance()
+			if err != nil {
+				gobot.Publish(h.Event(Error), err)
+			} else {
+				gobot.Publish(h.Event(Data), distance)
+			}
+			select {
+			case <-time.After(h.interval):
+			case <-h.halt:
+				return
+			}
+		}
+	}()
+	return
+}
+
+// Halt stops measuring the distance
+func (h *HCSR04Driver) Halt() (errs []error) {
+	h.halt <- true
+	return
+}
+
+// Distance triggers a measurement and returns the distance to the obstacle in
+// centimeters
+func (h *HCSR04Driver) Distance() (distance float64, err error) {
+	echo, err := h.echo()
+	if err != nil {
+		return
+	}
+	// the sound travels to the obstacle and back, at meters per second, which
+	// are centimeters per 10 Milliseconds
+	return float64(echo) * h.SpeedOfSound / float64(2*10*time.Millisecond), nil
+}
+
+// echo triggers a measurement and returns the duration of the echo pulse
+func (h *HCSR04Driver) echo() (time.Duration, error) {
+	if h.triggerPin == h.echoPin {
+		return h.connection.PulseRead(h.echoPin, 1, 10*time.Microsecond, h.Timeout)
+	}
+
+	reader, ok := h.connection.(TriggeredPulseReader)
+	if !ok {
+		return 0, ErrTriggeredPulseReadUnsupported
+	}
+	return reader.TriggeredPulseRead(h.triggerPin, h.echoPin, 1, 10*time.Microsecond, h.Timeout)
+}
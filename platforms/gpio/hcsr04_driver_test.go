@@ -0,0 +1,96 @@
+package gpio
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func initTestHCSR04Driver() (*HCSR04Driver, *gpioTestPulseReader) {
+	a := newGpioTestPulseReader(1 * time.Millisecond)
+	return NewHCSR04Driver(a, "sonar", "1", "2"), a
+}
+
+func TestHCSR04Driver(t *testing.T) {
+	d, _ := initTestHCSR04Driver()
+	gobot.Assert(t, d.Name(), "sonar")
+	gobot.Assert(t, d.Connection().Name(), "")
+	trigger, echo := d.Pins()
+	gobot.Assert(t, trigger, "1")
+	gobot.Assert(t, echo, "2")
+	gobot.Assert(t, d.interval, 100*time.Millisecond)
+
+	d = NewHCSR04Driver(newGpioTestPulseReader(0), "sonar", "1", "2", 30*time.Millisecond)
+	gobot.Assert(t, d.interval, 30*time.Millisecond)
+}
+
+func TestHCSR04DriverDistance(t *testing.T) {
+	d, a := initTestHCSR04Driver()
+
+	distance, err := d.Distance()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, distance, 17.15)
+	gobot.Assert(t, a.triggerPins, []string{"1"})
+	gobot.Assert(t, a.triggers, []time.Duration{10 * time.Microsecond})
+
+	ret := d.Command("Distance")(nil).(map[string]interface{})
+	gobot.Assert(t, ret["distance"].(float64), 17.15)
+	gobot.Assert(t, ret["err"], nil)
+
+	a.err = errors.New("pulse read error")
+	_, err = d.Distance()
+	gobot.Assert(t, err, errors.New("pulse read error"))
+
+	// only a TriggeredPulseReader can trigger on another pin
+	d = NewHCSR04Driver(struct{ PulseReader }{a}, "sonar", "1", "2")
+	_, err = d.Distance()
+	gobot.Assert(t, err, ErrTriggeredPulseReadUnsupported)
+}
+
+func TestHCSR04DriverDistanceSinglePin(t *testing.T) {
+	a := newGpioTestPulseReader(2 * time.Millisecond)
+	d := NewHCSR04Driver(a, "sonar", "1", "1")
+
+	distance, err := d.Distance()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, distance, 34.3)
+	gobot.Assert(t, len(a.triggerPins), 0)
+	gobot.Assert(t, a.triggers, []time.Duration{10 * time.Microsecond})
+}
+
+func TestHCSR04DriverStart(t *testing.T) {
+	d, a := initTestHCSR04Driver()
+	d.interval = 1 * time.Millisecond
+	data := make(chan interface{}, 1)
+	errs := make(chan interface{}, 1)
+
+	// the handlers are added before the driver starts publishing
+	gobot.Once(d.Event(Data), func(distance interface{}) {
+		data <- distance
+	})
+	gobot.Once(d.Event(Error), func(err interface{}) {
+		errs <- err
+	})
+	gobot.Assert(t, len(d.Start()), 0)
+
+	select {
+	case distance := <-data:
+		gobot.Assert(t, distance.(float64), 17.15)
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("HCSR04 Event \"Data\" was not published")
+	}
+
+	a.mutex.Lock()
+	a.err = errors.New("pulse read error")
+	a.mutex.Unlock()
+
+	select {
+	case err := <-errs:
+		gobot.Assert(t, err.(error), errors.New("pulse read error"))
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("HCSR04 Event \"Error\" was not published")
+	}
+	gobot.Assert(t, len(d.Halt()), 0)
+}
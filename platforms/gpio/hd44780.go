@@ -0,0 +1,312 @@
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidHD44780Position is the error resulting when the cursor is moved
+// off the display
+var ErrInvalidHD44780Position = errors.New("Position is off the display")
+
+// ErrInvalidHD44780Char is the error resulting when a custom character is set
+// at a location other than 0 to 7
+var ErrInvalidHD44780Char = errors.New("Custom characters are 0 to 7")
+
+// HD44780 instructions and their flags
+const (
+	hd44780Clear          = 0x01
+	hd44780Home           = 0x02
+	hd44780EntryMode      = 0x04
+	hd44780EntryLeft      = 0x02
+	hd44780DisplayControl = 0x08
+	hd44780DisplayOn      = 0x04
+	hd44780CursorOn       = 0x02
+	hd44780BlinkOn        = 0x01
+	hd44780Shift          = 0x10
+	hd44780DisplayMove    = 0x08
+	hd44780MoveRight      = 0x04
+	hd44780FunctionSet    = 0x20
+	hd44780TwoLines       = 0x08
+	hd44780SetCGRAM       = 0x40
+	hd44780SetDDRAM       = 0x80
+)
+
+// HD44780Bus is the way an HD44780, or a compatible controller, is wired to
+// the HD44780 which drives it
+type HD44780Bus interface {
+	// Begin sets the controller up to receive instructions over the bus,
+	// which are 4 bits wide unless the bus has its own protocol
+	Begin() error
+	// Command writes an instruction
+	Command(b byte) error
+	// Data writes bytes to the display or character RAM
+	Data(b ...byte) error
+}
+
+// HD44780 drives the HD44780 character LCD controller, and the many
+// compatible ones, over an HD44780Bus. It keeps a copy of the text on the
+// display, so text written past the last line scrolls the lines up.
+type HD44780 struct {
+	bus     HD44780Bus
+	mutex   sync.Mutex
+	cols    int
+	rows    int
+	control byte
+	lines   [][]byte
+	col     int
+	row     int
+	// Wrap moves text written past the end of a line onto the next one,
+	// true by default. Otherwise the text is written off the display, where
+	// Scroll can show it.
+	Wrap bool
+}
+
+// NewHD44780 returns a new HD44780 given the bus it is wired to and the
+// number of columns and rows of the display, such as 16x2 or 20x4
+func NewHD44780(bus HD44780Bus, cols int, rows int) *HD44780 {
+	h := &HD44780{
+		bus:  bus,
+		cols: cols,
+		rows: rows,
+		Wrap: true,
+	}
+	h.clearLines()
+	return h
+}
+
+// Columns returns the number of characters in a line of the display
+func (h *HD44780) Columns() int { return h.cols }
+
+// Rows returns the number of lines of the display
+func (h *HD44780) Rows() int { return h.rows }
+
+// Init sets the display up with the cursor hidden, clears it and turns it on
+func (h *HD44780) Init() (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if err = h.bus.Begin(); err != nil {
+		return
+	}
+	function := byte(hd44780FunctionSet)
+	if h.rows > 1 {
+		function |= hd44780TwoLines
+	}
+	if err = h.bus.Command(function); err != nil {
+		return
+	}
+	h.control = hd44780DisplayOn
+	if err = h.bus.Command(hd44780DisplayControl | h.control); err != nil {
+		return
+	}
+	if err = h.clear(); err != nil {
+		return
+	}
+	return h.bus.Command(hd44780EntryMode | hd44780EntryLeft)
+}
+
+// Clear clears the display and moves the cursor home
+func (h *HD44780) Clear() error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.clear()
+}
+
+// Home moves the cursor to the start of the first line, and the display back
+// to it if it was scrolled
+func (h *HD44780) Home() (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	err = h.bus.Command(hd44780Home)
+	// the longest instruction, taking 1.52ms
+	<-time.After(2 * time.Millisecond)
+	h.col, h.row = 0, 0
+	return
+}
+
+// SetCursor moves the cursor to col and row, both from 0
+func (h *HD44780) SetCursor(col int, row int) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if col < 0 || col >= h.cols || row < 0 || row >= h.rows {
+		return ErrInvalidHD44780Position
+	}
+	return h.moveTo(col, row)
+}
+
+// SetPosition moves the cursor to pos, counting the characters of each line
+// in turn from 0, so 16 is the start of the second line of a 16x2 display
+func (h *HD44780) SetPosition(pos int) error {
+	if pos < 0 || pos >= h.cols*h.rows {
+		return ErrInvalidHD44780Position
+	}
+	return h.SetCursor(pos%h.cols, pos/h.cols)
+}
+
+// Write writes message at the cursor. A newline moves the cursor to the
+// start of the next line, and past the last line the lines scroll up. Each
+// byte of message is a character code of the display, so characters beyond
+// ASCII are written as escapes, such as "\xdf" for the degree sign.
+func (h *HD44780) Write(message string) (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for i := 0; i < len(message); i++ {
+		c := message[i]
+		if c == '\n' {
+			if err = h.newLine(); err != nil {
+				return
+			}
+			continue
+		}
+		if h.col >= h.cols && h.Wrap {
+			if err = h.newLine(); err != nil {
+				return
+			}
+		}
+		if err = h.bus.Data(c); err != nil {
+			return
+		}
+		if h.col < h.cols {
+			h.lines[h.row][h.col] = c
+		}
+		h.col++
+	}
+	return
+}
+
+// Lines returns the text on the display, with the lines padded with spaces
+func (h *HD44780) Lines() []string {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	lines := []string{}
+	for _, line := range h.lines {
+		lines = append(lines, string(line))
+	}
+	return lines
+}
+
+// String returns the lines of text on the display
+func (h *HD44780) String() string {
+	return strings.Join(h.Lines(), "\n")
+}
+
+// Display turns the display on or off, keeping the text on it
+func (h *HD44780) Display(on bool) error {
+	return h.setControl(hd44780DisplayOn, on)
+}
+
+// Cursor shows or hides an underline cursor
+func (h *HD44780) Cursor(on bool) error {
+	return h.setControl(hd44780CursorOn, on)
+}
+
+// Blink turns blinking of the character at the cursor on or off
+func (h *HD44780) Blink(on bool) error {
+	return h.setControl(hd44780BlinkOn, on)
+}
+
+// Scroll moves the display a character to the left when leftToRight is true,
+// so text scrolls in from the right, or a character to the right otherwise
+func (h *HD44780) Scroll(leftToRight bool) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if leftToRight {
+		return h.bus.Command(hd44780Shift | hd44780DisplayMove)
+	}
+	return h.bus.Command(hd44780Shift | hd44780DisplayMove | hd44780MoveRight)
+}
+
+// SetCustomChar sets one of the 8 custom characters, location 0 to 7, to
+// the 5x8 dots of charMap, a byte for each row. It is displayed by writing a
+// byte of its location, such as string(byte(0)).
+func (h *HD44780) SetCustomChar(location int, charMap [8]byte) (err error) {
+	if location < 0 || location > 7 {
+		return ErrInvalidHD44780Char
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if err = h.bus.Command(hd44780SetCGRAM | byte(location)<<3); err != nil {
+		return
+	}
+	if err = h.bus.Data(charMap[:]...); err != nil {
+		return
+	}
+	// writes go to the character RAM until the cursor is moved back
+	return h.moveTo(h.col, h.row)
+}
+
+// clear clears the display and the copy of its text
+func (h *HD44780) clear() (err error) {
+	err = h.bus.Command(hd44780Clear)
+	<-time.After(2 * time.Millisecond)
+	h.clearLines()
+	h.col, h.row = 0, 0
+	return
+}
+
+// clearLines fills the copy of the text with spaces
+func (h *HD44780) clearLines() {
+	h.lines = make([][]byte, h.rows)
+	for i := range h.lines {
+		h.lines[i] = []byte(strings.Repeat(" ", h.cols))
+	}
+}
+
+// moveTo moves the cursor to col and row
+func (h *HD44780) moveTo(col int, row int) (err error) {
+	if err = h.bus.Command(hd44780SetDDRAM | h.address(col, row)); err != nil {
+		return
+	}
+	h.col, h.row = col, row
+	return
+}
+
+// address returns the display RAM address of col and row. The third and
+// fourth lines of a display continue the first and second.
+func (h *HD44780) address(col int, row int) byte {
+	offsets := []int{0x00, 0x40, h.cols, 0x40 + h.cols}
+	return byte(offsets[row%len(offsets)] + col)
+}
+
+// newLine moves the cursor to the start of the next line, scrolling the
+// lines up from the last one
+func (h *HD44780) newLine() (err error) {
+	if h.row < h.rows-1 {
+		return h.moveTo(0, h.row+1)
+	}
+
+	copy(h.lines, h.lines[1:])
+	h.lines[h.rows-1] = []byte(strings.Repeat(" ", h.cols))
+	for row, line := range h.lines {
+		if err = h.moveTo(0, row); err != nil {
+			return
+		}
+		if err = h.bus.Data(line...); err != nil {
+			return
+		}
+	}
+	return h.moveTo(0, h.rows-1)
+}
+
+// setControl sets or clears flag of the display control
+func (h *HD44780) setControl(flag byte, on bool) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if on {
+		h.control |= flag
+	} else {
+		h.control &^= flag
+	}
+	return h.bus.Command(hd44780DisplayControl | h.control)
+}
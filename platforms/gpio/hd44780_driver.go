@@ -0,0 +1,148 @@
+package gpio
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*HD44780Driver)(nil)
+
+// ErrInvalidHD44780Pins is the error resulting when an HD44780Driver is not
+// given the 4 pins wired to D4 to D7
+var ErrInvalidHD44780Pins = errors.New("HD44780 needs the 4 pins of D4 to D7")
+
+// HD44780Driver represents a character LCD with an HD44780 controller wired
+// directly to 6 pins, RS, E and D4 to D7, with its RW pin tied to ground.
+type HD44780Driver struct {
+	name       string
+	connection DigitalWriter
+	rsPin      string
+	enPin      string
+	dataPins   []string
+	*HD44780
+	gobot.Commander
+}
+
+// NewHD44780Driver returns a new HD44780Driver given a DigitalWriter, name,
+// the number of columns and rows of the display, such as 16x2 or 20x4, and
+// the pins wired to its RS, E and D4 to D7 inputs.
+//
+// Adds the following API Commands:
+//	"Write" - See HD44780.Write, the text is given as "message"
+//	"Clear" - See HD44780.Clear
+//	"Home" - See HD44780.Home
+//	"SetCursor" - See HD44780.SetCursor
+func NewHD44780Driver(a DigitalWriter, name string, cols int, rows int, rsPin string, enPin string, dataPins []string) *HD44780Driver {
+	h := &HD44780Driver{
+		name:       name,
+		connection: a,
+		rsPin:      rsPin,
+		enPin:      enPin,
+		dataPins:   dataPins,
+		Commander:  gobot.NewCommander(),
+	}
+	h.HD44780 = NewHD44780(&hd44780PinBus{h}, cols, rows)
+
+	h.AddCommand("Write", func(params map[string]interface{}) interface{} {
+		return h.Write(params["message"].(string))
+	})
+	h.AddCommand("Clear", func(params map[string]interface{}) interface{} {
+		return h.Clear()
+	})
+	h.AddCommand("Home", func(params map[string]interface{}) interface{} {
+		return h.Home()
+	})
+	h.AddCommand("SetCursor", func(params map[string]interface{}) interface{} {
+		return h.SetCursor(int(params["col"].(float64)), int(params["row"].(float64)))
+	})
+
+	return h
+}
+
+// Name returns the HD44780Drivers name
+func (h *HD44780Driver) Name() string { return h.name }
+
+// Connection returns the HD44780Drivers connection
+func (h *HD44780Driver) Connection() gobot.Connection { return h.connection.(gobot.Connection) }
+
+// Start sets the display up and clears it
+func (h *HD44780Driver) Start() (errs []error) {
+	if len(h.dataPins) != 4 {
+		return []error{ErrInvalidHD44780Pins}
+	}
+	if err := h.Init(); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Halt implements the Driver interface
+func (h *HD44780Driver) Halt() (errs []error) { return }
+
+// hd44780PinBus writes to an HD44780 4 bits at a time over the pins of an
+// HD44780Driver
+type hd44780PinBus struct {
+	driver *HD44780Driver
+}
+
+// Begin switches the controller to 4-bit instructions, whatever it was set
+// to, as in the datasheet
+func (b *hd44780PinBus) Begin() (err error) {
+	<-time.After(50 * time.Millisecond)
+	if err = b.driver.connection.DigitalWrite(b.driver.rsPin, 0); err != nil {
+		return
+	}
+	for _, wait := range []time.Duration{4500 * time.Microsecond, 150 * time.Microsecond, 150 * time.Microsecond} {
+		if err = b.write4(0x03); err != nil {
+			return
+		}
+		<-time.After(wait)
+	}
+	return b.write4(0x02)
+}
+
+// Command writes an instruction
+func (b *hd44780PinBus) Command(c byte) error {
+	return b.write(0, c)
+}
+
+// Data writes bytes to the display or character RAM
+func (b *hd44780PinBus) Data(data ...byte) (err error) {
+	for _, d := range data {
+		if err = b.write(1, d); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// write writes a byte, high bits first, to the register selected by rs
+func (b *hd44780PinBus) write(rs byte, c byte) (err error) {
+	if err = b.driver.connection.DigitalWrite(b.driver.rsPin, rs); err != nil {
+		return
+	}
+	if err = b.write4(c >> 4); err != nil {
+		return
+	}
+	if err = b.write4(c & 0x0F); err != nil {
+		return
+	}
+	// most instructions take 37us
+	<-time.After(50 * time.Microsecond)
+	return
+}
+
+// write4 sets D4 to D7 to the 4 bits of nibble and pulses E to latch them
+func (b *hd44780PinBus) write4(nibble byte) (err error) {
+	for i, pin := range b.driver.dataPins {
+		if err = b.driver.connection.DigitalWrite(pin, nibble>>uint(i)&1); err != nil {
+			return
+		}
+	}
+	if err = b.driver.connection.DigitalWrite(b.driver.enPin, 1); err != nil {
+		return
+	}
+	return b.driver.connection.DigitalWrite(b.driver.enPin, 0)
+}
@@ -0,0 +1,90 @@
+package gpio
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// gpioTestHD44780Receiver decodes the nibbles latched by a falling "e" into
+// bytes written with "rs" low, as instructions, or high, as data
+type gpioTestHD44780Receiver struct {
+	gpioTestBareAdaptor
+	rs       byte
+	pins     [4]byte
+	nibbles  []byte
+	commands []byte
+	data     []byte
+}
+
+func (t *gpioTestHD44780Receiver) DigitalWrite(pin string, level byte) (err error) {
+	switch pin {
+	case "rs":
+		t.rs = level
+	case "d4", "d5", "d6", "d7":
+		t.pins[pin[1]-'4'] = level
+	case "e":
+		if level == 1 {
+			return
+		}
+		nibble := t.pins[0] | t.pins[1]<<1 | t.pins[2]<<2 | t.pins[3]<<3
+		t.nibbles = append(t.nibbles, nibble)
+	}
+	return
+}
+
+// decode turns the nibbles after the first 4, which switch to 4-bit mode,
+// into bytes
+func (t *gpioTestHD44780Receiver) decode() {
+	for i := 4; i+1 < len(t.nibbles); i += 2 {
+		t.commands = append(t.commands, t.nibbles[i]<<4|t.nibbles[i+1])
+	}
+}
+
+func initTestHD44780Driver() (*HD44780Driver, *gpioTestHD44780Receiver) {
+	a := &gpioTestHD44780Receiver{}
+	return NewHD44780Driver(a, "bot", 16, 2, "rs", "e", []string{"d4", "d5", "d6", "d7"}), a
+}
+
+func TestHD44780Driver(t *testing.T) {
+	h, _ := initTestHD44780Driver()
+	gobot.Assert(t, h.Name(), "bot")
+	gobot.Assert(t, h.Connection().Name(), "")
+	gobot.Assert(t, h.Columns(), 16)
+	gobot.Assert(t, len(h.Halt()), 0)
+	gobot.Refute(t, h.Command("Write"), nil)
+	gobot.Refute(t, h.Command("Clear"), nil)
+	gobot.Refute(t, h.Command("Home"), nil)
+	gobot.Refute(t, h.Command("SetCursor"), nil)
+}
+
+func TestHD44780DriverStart(t *testing.T) {
+	h, a := initTestHD44780Driver()
+	gobot.Assert(t, len(h.Start()), 0)
+	gobot.Assert(t, a.nibbles[:4], []byte{0x03, 0x03, 0x03, 0x02})
+	a.decode()
+	gobot.Assert(t, a.commands, []byte{0x28, 0x0C, 0x01, 0x06})
+
+	h = NewHD44780Driver(&gpioTestHD44780Receiver{}, "bot", 16, 2, "rs", "e", []string{"d4"})
+	gobot.Assert(t, h.Start()[0], ErrInvalidHD44780Pins)
+
+	h = NewHD44780Driver(newGpioTestAdaptor("adaptor"), "bot", 16, 2, "rs", "e", []string{"d4", "d5", "d6", "d7"})
+	testAdaptorDigitalWrite = func() (err error) {
+		return errors.New("write error")
+	}
+	defer func() { testAdaptorDigitalWrite = func() (err error) { return nil } }()
+	gobot.Assert(t, h.Start()[0], errors.New("write error"))
+}
+
+func TestHD44780DriverWrite(t *testing.T) {
+	h, a := initTestHD44780Driver()
+	h.Command("Write")(map[string]interface{}{"message": "hi"})
+	gobot.Assert(t, a.rs, byte(1))
+	gobot.Assert(t, a.nibbles, []byte{0x6, 0x8, 0x6, 0x9})
+
+	a.nibbles = nil
+	h.Command("SetCursor")(map[string]interface{}{"col": 1.0, "row": 1.0})
+	gobot.Assert(t, a.rs, byte(0))
+	gobot.Assert(t, a.nibbles, []byte{0xC, 0x1})
+}
@@ -0,0 +1,136 @@
+package gpio
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// hd44780TestBus records the instructions and data written to it
+type hd44780TestBus struct {
+	begun    bool
+	commands []byte
+	data     []byte
+	err      error
+}
+
+func (b *hd44780TestBus) Begin() error {
+	b.begun = true
+	return b.err
+}
+
+func (b *hd44780TestBus) Command(c byte) error {
+	b.commands = append(b.commands, c)
+	return b.err
+}
+
+func (b *hd44780TestBus) Data(d ...byte) error {
+	b.data = append(b.data, d...)
+	return b.err
+}
+
+func initTestHD44780(cols int, rows int) (*HD44780, *hd44780TestBus) {
+	bus := &hd44780TestBus{}
+	return NewHD44780(bus, cols, rows), bus
+}
+
+func TestHD44780Init(t *testing.T) {
+	h, bus := initTestHD44780(16, 2)
+	gobot.Assert(t, h.Columns(), 16)
+	gobot.Assert(t, h.Rows(), 2)
+	gobot.Assert(t, h.Init(), nil)
+	gobot.Assert(t, bus.begun, true)
+	gobot.Assert(t, bus.commands, []byte{0x28, 0x0C, 0x01, 0x06})
+
+	h, bus = initTestHD44780(8, 1)
+	h.Init()
+	gobot.Assert(t, bus.commands[0], byte(0x20))
+
+	bus.err = errors.New("write error")
+	gobot.Assert(t, h.Init(), errors.New("write error"))
+}
+
+func TestHD44780Write(t *testing.T) {
+	h, bus := initTestHD44780(16, 2)
+	gobot.Assert(t, h.Write("hello\nworld"), nil)
+	gobot.Assert(t, string(bus.data), "helloworld")
+	gobot.Assert(t, bus.commands, []byte{0xC0})
+	gobot.Assert(t, h.Lines(), []string{"hello           ", "world           "})
+	gobot.Assert(t, h.String(), "hello           \nworld           ")
+
+	gobot.Assert(t, h.Clear(), nil)
+	gobot.Assert(t, h.Lines(), []string{"                ", "                "})
+
+	// character codes beyond ASCII are written as they are
+	bus.data = nil
+	gobot.Assert(t, h.Write("21\xdfC"), nil)
+	gobot.Assert(t, bus.data, []byte{'2', '1', 0xDF, 'C'})
+	gobot.Assert(t, h.Lines()[0], "21\xdfC            ")
+}
+
+func TestHD44780WriteWrap(t *testing.T) {
+	h, bus := initTestHD44780(4, 2)
+	h.Write("abcdefgh")
+	gobot.Assert(t, h.Lines(), []string{"abcd", "efgh"})
+	gobot.Assert(t, bus.commands, []byte{0xC0})
+
+	// past the last line the lines scroll up
+	bus.commands, bus.data = nil, nil
+	h.Write("ij")
+	gobot.Assert(t, h.Lines(), []string{"efgh", "ij  "})
+	gobot.Assert(t, bus.commands, []byte{0x80, 0xC0, 0xC0})
+	gobot.Assert(t, string(bus.data), "efgh    ij")
+
+	h.Clear()
+	h.Wrap = false
+	bus.commands, bus.data = nil, nil
+	h.Write("abcdef")
+	gobot.Assert(t, h.Lines(), []string{"abcd", "    "})
+	gobot.Assert(t, string(bus.data), "abcdef")
+	gobot.Assert(t, len(bus.commands), 0)
+}
+
+func TestHD44780SetCursor(t *testing.T) {
+	h, bus := initTestHD44780(20, 4)
+	gobot.Assert(t, h.SetCursor(0, 0), nil)
+	gobot.Assert(t, h.SetCursor(5, 1), nil)
+	gobot.Assert(t, h.SetCursor(0, 2), nil)
+	gobot.Assert(t, h.SetPosition(79), nil)
+	gobot.Assert(t, bus.commands, []byte{0x80, 0xC5, 0x94, 0xE7})
+
+	h.Write("x")
+	gobot.Assert(t, h.Lines()[3], "                   x")
+
+	gobot.Assert(t, h.SetCursor(20, 0), ErrInvalidHD44780Position)
+	gobot.Assert(t, h.SetCursor(0, 4), ErrInvalidHD44780Position)
+	gobot.Assert(t, h.SetPosition(80), ErrInvalidHD44780Position)
+	gobot.Assert(t, h.SetPosition(-1), ErrInvalidHD44780Position)
+}
+
+func TestHD44780Control(t *testing.T) {
+	h, bus := initTestHD44780(16, 2)
+	h.Init()
+	bus.commands = nil
+	h.Cursor(true)
+	h.Blink(true)
+	h.Display(false)
+	h.Cursor(false)
+	h.Scroll(true)
+	h.Scroll(false)
+	h.Home()
+	gobot.Assert(t, bus.commands, []byte{0x0E, 0x0F, 0x0B, 0x09, 0x18, 0x1C, 0x02})
+}
+
+func TestHD44780SetCustomChar(t *testing.T) {
+	h, bus := initTestHD44780(16, 2)
+	h.SetCursor(3, 1)
+	bus.commands = nil
+	smiley := [8]byte{0, 0, 10, 0, 0, 17, 14, 0}
+	gobot.Assert(t, h.SetCustomChar(2, smiley), nil)
+	// back to the cursor after setting the character
+	gobot.Assert(t, bus.commands, []byte{0x50, 0xC3})
+	gobot.Assert(t, bus.data, smiley[:])
+
+	gobot.Assert(t, h.SetCustomChar(8, smiley), ErrInvalidHD44780Char)
+}
@@ -1,5 +1,11 @@
 package gpio
 
+import (
+	"image/color"
+	"sync"
+	"time"
+)
+
 type gpioTestBareAdaptor struct{}
 
 func (t *gpioTestBareAdaptor) Connect() (errs []error)  { return }
@@ -12,6 +18,221 @@ type gpioTestDigitalWriter struct {
 
 func (t *gpioTestDigitalWriter) DigitalWrite(string, byte) (err error) { return }
 
+type gpioTestPinWriter struct {
+	gpioTestBareAdaptor
+	mutex  sync.Mutex
+	writes map[string][]byte
+	err    error
+}
+
+func (t *gpioTestPinWriter) DigitalWrite(pin string, level byte) (err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.err != nil {
+		return t.err
+	}
+	t.writes[pin] = append(t.writes[pin], level)
+	return
+}
+
+func (t *gpioTestPinWriter) PwmWrite(pin string, level byte) (err error) {
+	return t.DigitalWrite(pin, level)
+}
+
+func (t *gpioTestPinWriter) history(pin string) []byte {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return append([]byte{}, t.writes[pin]...)
+}
+
+// fail has every later write return err
+func (t *gpioTestPinWriter) fail(err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.err = err
+}
+
+func newGpioTestPinWriter() *gpioTestPinWriter {
+	return &gpioTestPinWriter{writes: make(map[string][]byte)}
+}
+
+// gpioTestShiftReceiver decodes data shifted out on pin "d", clocked by pin
+// "c", into a frame of bytes each time pin "l" rises
+type gpioTestShiftReceiver struct {
+	gpioTestBareAdaptor
+	data   byte
+	bits   []byte
+	frames [][]byte
+}
+
+func (t *gpioTestShiftReceiver) DigitalWrite(pin string, level byte) (err error) {
+	switch pin {
+	case "d":
+		t.data = level
+	case "c":
+		if level == 1 {
+			t.bits = append(t.bits, t.data)
+		}
+	case "l":
+		if level == 0 {
+			t.bits = nil
+			return
+		}
+		frame := make([]byte, len(t.bits)/8)
+		for i, bit := range t.bits {
+			frame[i/8] |= bit << uint(7-i%8)
+		}
+		t.frames = append(t.frames, frame)
+	}
+	return
+}
+
+type gpioTestDigitalNotifier struct {
+	gpioTestBareAdaptor
+	mutex     sync.Mutex
+	values    map[string]int
+	callbacks map[string]func(int)
+}
+
+func (t *gpioTestDigitalNotifier) DigitalRead(pin string) (val int, err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.values[pin], nil
+}
+
+func (t *gpioTestDigitalNotifier) DigitalNotify(pin string, f func(int)) (err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.callbacks[pin] = f
+	return
+}
+
+func (t *gpioTestDigitalNotifier) DigitalUnnotify(pin string) (err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	delete(t.callbacks, pin)
+	return
+}
+
+// set changes the value of pin and notifies its callback
+func (t *gpioTestDigitalNotifier) set(pin string, val int) {
+	t.mutex.Lock()
+	t.values[pin] = val
+	t.mutex.Unlock()
+	t.notify(pin, val)
+}
+
+// notify calls the callback of pin with val, without changing the value
+// DigitalRead returns
+func (t *gpioTestDigitalNotifier) notify(pin string, val int) {
+	t.mutex.Lock()
+	f := t.callbacks[pin]
+	t.mutex.Unlock()
+	if f != nil {
+		f(val)
+	}
+}
+
+// notified returns true if pin has a callback
+func (t *gpioTestDigitalNotifier) notified(pin string) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	_, ok := t.callbacks[pin]
+	return ok
+}
+
+func newGpioTestDigitalNotifier() *gpioTestDigitalNotifier {
+	return &gpioTestDigitalNotifier{
+		values:    make(map[string]int),
+		callbacks: make(map[string]func(int)),
+	}
+}
+
+type gpioTestPulseReader struct {
+	gpioTestPinWriter
+	echo        time.Duration
+	err         error
+	triggers    []time.Duration
+	triggerPins []string
+}
+
+func (t *gpioTestPulseReader) PulseRead(pin string, level int, trigger time.Duration, timeout time.Duration) (time.Duration, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.triggers = append(t.triggers, trigger)
+	return t.echo, t.err
+}
+
+func (t *gpioTestPulseReader) TriggeredPulseRead(triggerPin string, pin string, level int, trigger time.Duration, timeout time.Duration) (time.Duration, error) {
+	t.mutex.Lock()
+	t.triggerPins = append(t.triggerPins, triggerPin)
+	t.mutex.Unlock()
+	return t.PulseRead(pin, level, trigger, timeout)
+}
+
+func newGpioTestPulseReader(echo time.Duration) *gpioTestPulseReader {
+	return &gpioTestPulseReader{
+		gpioTestPinWriter: gpioTestPinWriter{writes: make(map[string][]byte)},
+		echo:              echo,
+	}
+}
+
+type gpioTestPwmPeriodWriter struct {
+	gpioTestPinWriter
+	periods []time.Duration
+}
+
+func (t *gpioTestPwmPeriodWriter) PwmPeriodWrite(pin string, period time.Duration, duty byte) (err error) {
+	t.mutex.Lock()
+	t.periods = append(t.periods, period)
+	t.mutex.Unlock()
+	return t.PwmWrite(pin, duty)
+}
+
+func newGpioTestPwmPeriodWriter() *gpioTestPwmPeriodWriter {
+	return &gpioTestPwmPeriodWriter{
+		gpioTestPinWriter: gpioTestPinWriter{writes: make(map[string][]byte)},
+	}
+}
+
+type gpioTestPixelWriter struct {
+	gpioTestBareAdaptor
+	mutex  sync.Mutex
+	err    error
+	frames map[string][][]color.RGBA
+}
+
+func (t *gpioTestPixelWriter) PixelWrite(pin string, colors []color.RGBA) (err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.frames[pin] = append(t.frames[pin], append([]color.RGBA{}, colors...))
+	return t.err
+}
+
+func (t *gpioTestPixelWriter) history(pin string) [][]color.RGBA {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return append([][]color.RGBA{}, t.frames[pin]...)
+}
+
+func newGpioTestPixelWriter() *gpioTestPixelWriter {
+	return &gpioTestPixelWriter{frames: make(map[string][][]color.RGBA)}
+}
+
+type gpioTestServoWriter struct {
+	gpioTestBareAdaptor
+	writes map[string][]byte
+}
+
+func (t *gpioTestServoWriter) ServoWrite(pin string, val byte) (err error) {
+	t.writes[pin] = append(t.writes[pin], val)
+	return
+}
+
+func newGpioTestServoWriter() *gpioTestServoWriter {
+	return &gpioTestServoWriter{writes: make(map[string][]byte)}
+}
+
 type gpioTestAdaptor struct {
 	name string
 	port string
@@ -0,0 +1,283 @@
+package gpio
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// ErrUnknownEffect is the error resulting when an animation is started with
+// the name of an effect which has not been added
+var ErrUnknownEffect = errors.New("unknown LED effect")
+
+// Pixels represents a set of RGB LEDs, such as an RGB LED or an addressable
+// LED strip, which can be animated
+type Pixels interface {
+	// Len returns the number of LEDs
+	Len() int
+	// SetRGBA sets the color of the LED at index i, shown once Draw is called
+	SetRGBA(i int, c color.RGBA)
+	// Draw shows the colors which have been set
+	Draw() (err error)
+}
+
+// minEffectPeriod is the shortest period or step of the repeating effects,
+// which divide the time by it
+const minEffectPeriod = time.Millisecond
+
+// LedEffect sets the colors of p for the frame at time t since the effect
+// started, and returns false once the effect has finished
+type LedEffect func(p Pixels, t time.Duration) (running bool)
+
+// fill sets every LED of p to c
+func fill(p Pixels, c color.RGBA) {
+	for i := 0; i < p.Len(); i++ {
+		p.SetRGBA(i, c)
+	}
+}
+
+// FadeEffect fades every LED from one color to another over d
+func FadeEffect(from, to color.RGBA, d time.Duration) LedEffect {
+	return func(p Pixels, t time.Duration) bool {
+		if t >= d {
+			fill(p, to)
+			return false
+		}
+		fill(p, blendColors(from, to, float64(t)/float64(d)))
+		return true
+	}
+}
+
+// BlinkEffect turns every LED on in color c for the first half of each
+// period and off for the second, until stopped. The period is at least 1ms.
+func BlinkEffect(c color.RGBA, period time.Duration) LedEffect {
+	period = clampPeriod(period)
+	return func(p Pixels, t time.Duration) bool {
+		if t%period < period/2 {
+			fill(p, c)
+		} else {
+			fill(p, color.RGBA{A: c.A})
+		}
+		return true
+	}
+}
+
+// PulseEffect smoothly brightens every LED to color c and dims it to off
+// again once each period, until stopped. The period is at least 1ms.
+func PulseEffect(c color.RGBA, period time.Duration) LedEffect {
+	period = clampPeriod(period)
+	return func(p Pixels, t time.Duration) bool {
+		phase := float64(t%period) / float64(period)
+		fill(p, scaleColor(c, (1-math.Cos(2*math.Pi*phase))/2))
+		return true
+	}
+}
+
+// RainbowEffect cycles the LEDs through every hue once each period, spreading
+// the hues along the LEDs, until stopped. The period is at least 1ms.
+func RainbowEffect(period time.Duration) LedEffect {
+	period = clampPeriod(period)
+	return func(p Pixels, t time.Duration) bool {
+		hue := 360 * float64(t%period) / float64(period)
+		for i := 0; i < p.Len(); i++ {
+			p.SetRGBA(i, HSV(hue+360*float64(i)/float64(p.Len()), 1, 1))
+		}
+		return true
+	}
+}
+
+// ChaseEffect lights one LED in color c at a time, moving along the LEDs
+// every step, until stopped. The step is at least 1ms.
+func ChaseEffect(c color.RGBA, step time.Duration) LedEffect {
+	step = clampPeriod(step)
+	return func(p Pixels, t time.Duration) bool {
+		if p.Len() == 0 {
+			return true
+		}
+		lit := int(t/step) % p.Len()
+		for i := 0; i < p.Len(); i++ {
+			if i == lit {
+				p.SetRGBA(i, c)
+			} else {
+				p.SetRGBA(i, color.RGBA{A: c.A})
+			}
+		}
+		return true
+	}
+}
+
+// ledEffectFactory builds an LedEffect from the params of a command
+type ledEffectFactory func(params map[string]interface{}) (LedEffect, error)
+
+// LedAnimator runs LedEffects on Pixels in the background, so that a driver
+// keeps responding while its LEDs animate. Drivers embed it to share its
+// methods, events and commands.
+type LedAnimator struct {
+	pixels  Pixels
+	eventer gobot.Eventer
+	mutex   sync.Mutex
+	effects map[string]ledEffectFactory
+	task    backgroundTask
+	// Interval is the time between frames, 20ms by default
+	Interval time.Duration
+}
+
+// NewLedAnimator returns a new LedAnimator which draws on p, publishes to e
+// and adds its commands to c.
+//
+// The following effects are available by name, with their params:
+//	"fade" - "from" and "to" hex colors and "duration" in milliseconds
+//	"blink" - "color" and "period" in milliseconds
+//	"pulse" - "color" and "period" in milliseconds
+//	"rainbow" - "period" in milliseconds
+//	"chase" - "color" and "step" in milliseconds
+//
+// Adds the following API Commands:
+//	"Animate" - See LedAnimator.AnimateEffect, the name is given as "effect"
+//	"StopAnimation" - See LedAnimator.StopAnimation
+//
+// Emits the Events:
+//	"finished" - the name of an effect which has finished
+//	"error" - an error drawing a frame, which stops the animation
+func NewLedAnimator(p Pixels, e gobot.Eventer, c gobot.Commander) *LedAnimator {
+	a := &LedAnimator{
+		pixels:   p,
+		eventer:  e,
+		effects:  make(map[string]ledEffectFactory),
+		Interval: 20 * time.Millisecond,
+	}
+
+	for _, name := range []string{Finished, Error} {
+		if e.Event(name) == nil {
+			e.AddEvent(name)
+		}
+	}
+
+	a.effects["fade"] = func(params map[string]interface{}) (LedEffect, error) {
+		from, err := colorParam(params, "from", color.RGBA{A: 255})
+		if err != nil {
+			return nil, err
+		}
+		to, err := colorParam(params, "to", color.RGBA{R: 255, G: 255, B: 255, A: 255})
+		if err != nil {
+			return nil, err
+		}
+		return FadeEffect(from, to, durationParam(params, "duration", time.Second)), nil
+	}
+	a.effects["blink"] = func(params map[string]interface{}) (LedEffect, error) {
+		c, err := colorParam(params, "color", color.RGBA{R: 255, G: 255, B: 255, A: 255})
+		return BlinkEffect(c, durationParam(params, "period", time.Second)), err
+	}
+	a.effects["pulse"] = func(params map[string]interface{}) (LedEffect, error) {
+		c, err := colorParam(params, "color", color.RGBA{R: 255, G: 255, B: 255, A: 255})
+		return PulseEffect(c, durationParam(params, "period", 2*time.Second)), err
+	}
+	a.effects["rainbow"] = func(params map[string]interface{}) (LedEffect, error) {
+		return RainbowEffect(durationParam(params, "period", 5*time.Second)), nil
+	}
+	a.effects["chase"] = func(params map[string]interface{}) (LedEffect, error) {
+		c, err := colorParam(params, "color", color.RGBA{R: 255, G: 255, B: 255, A: 255})
+		return ChaseEffect(c, durationParam(params, "step", 100*time.Millisecond)), err
+	}
+
+	c.AddCommand("Animate", func(params map[string]interface{}) interface{} {
+		name, _ := params["effect"].(string)
+		return a.AnimateEffect(name, params)
+	})
+	c.AddCommand("StopAnimation", func(params map[string]interface{}) interface{} {
+		a.StopAnimation()
+		return nil
+	})
+
+	return a
+}
+
+// AddEffect makes e available by name to AnimateEffect and the "Animate"
+// command
+func (a *LedAnimator) AddEffect(name string, e LedEffect) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.effects[name] = func(map[string]interface{}) (LedEffect, error) {
+		return e, nil
+	}
+}
+
+// Animate stops any running animation and starts running e in the background
+func (a *LedAnimator) Animate(e LedEffect) {
+	a.animate("", e)
+}
+
+// AnimateEffect stops any running animation and starts running the effect
+// called name, built from params, in the background
+func (a *LedAnimator) AnimateEffect(name string, params map[string]interface{}) (err error) {
+	a.mutex.Lock()
+	factory, ok := a.effects[name]
+	a.mutex.Unlock()
+	if !ok {
+		return ErrUnknownEffect
+	}
+	e, err := factory(params)
+	if err != nil {
+		return
+	}
+	a.animate(name, e)
+	return
+}
+
+// Animating returns true while an animation is running
+func (a *LedAnimator) Animating() bool { return a.task.running() }
+
+// StopAnimation stops any running animation, leaving the LEDs as they are
+func (a *LedAnimator) StopAnimation() { a.task.stop() }
+
+func (a *LedAnimator) animate(name string, e LedEffect) {
+	a.task.start(a.eventer, func(halt chan bool) (string, interface{}) {
+		return a.run(name, e, halt)
+	})
+}
+
+// run draws frames of the effect name until it finishes, fails or halt is
+// closed, and returns the event to publish for how it ended
+func (a *LedAnimator) run(name string, e LedEffect, halt chan bool) (event string, data interface{}) {
+	start := time.Now()
+	for {
+		running := e(a.pixels, time.Since(start))
+		if err := a.pixels.Draw(); err != nil {
+			return Error, err
+		}
+		if !running {
+			return Finished, name
+		}
+		select {
+		case <-halt:
+			return "", nil
+		case <-time.After(a.Interval):
+		}
+	}
+}
+
+func colorParam(params map[string]interface{}, key string, def color.RGBA) (color.RGBA, error) {
+	if s, ok := params[key].(string); ok {
+		return ParseHexColor(s)
+	}
+	return def, nil
+}
+
+func durationParam(params map[string]interface{}, key string, def time.Duration) time.Duration {
+	if ms, ok := params[key].(float64); ok && ms > 0 {
+		return clampPeriod(time.Duration(ms * float64(time.Millisecond)))
+	}
+	return def
+}
+
+// clampPeriod returns d, or minEffectPeriod if d is shorter
+func clampPeriod(d time.Duration) time.Duration {
+	if d < minEffectPeriod {
+		return minEffectPeriod
+	}
+	return d
+}
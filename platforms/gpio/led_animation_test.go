@@ -0,0 +1,185 @@
+package gpio
+
+import (
+	"errors"
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var (
+	red   = color.RGBA{R: 255, A: 255}
+	black = color.RGBA{A: 255}
+)
+
+type testPixels struct {
+	vals  []color.RGBA
+	draws int
+}
+
+func (p *testPixels) Len() int                    { return len(p.vals) }
+func (p *testPixels) SetRGBA(i int, c color.RGBA) { p.vals[i] = c }
+func (p *testPixels) Draw() error                 { p.draws++; return nil }
+
+func newTestPixels(n int) *testPixels {
+	return &testPixels{vals: make([]color.RGBA, n)}
+}
+
+func TestFadeEffect(t *testing.T) {
+	p := newTestPixels(2)
+	e := FadeEffect(black, red, 100*time.Millisecond)
+
+	gobot.Assert(t, e(p, 0), true)
+	gobot.Assert(t, p.vals, []color.RGBA{black, black})
+	gobot.Assert(t, e(p, 50*time.Millisecond), true)
+	gobot.Assert(t, p.vals[1], color.RGBA{R: 128, A: 255})
+	gobot.Assert(t, e(p, 100*time.Millisecond), false)
+	gobot.Assert(t, p.vals, []color.RGBA{red, red})
+}
+
+func TestBlinkEffect(t *testing.T) {
+	p := newTestPixels(1)
+	e := BlinkEffect(red, 100*time.Millisecond)
+
+	gobot.Assert(t, e(p, 10*time.Millisecond), true)
+	gobot.Assert(t, p.vals[0], red)
+	e(p, 60*time.Millisecond)
+	gobot.Assert(t, p.vals[0], black)
+	e(p, 110*time.Millisecond)
+	gobot.Assert(t, p.vals[0], red)
+}
+
+func TestPulseEffect(t *testing.T) {
+	p := newTestPixels(1)
+	e := PulseEffect(red, 100*time.Millisecond)
+
+	gobot.Assert(t, e(p, 0), true)
+	gobot.Assert(t, p.vals[0], black)
+	e(p, 25*time.Millisecond)
+	gobot.Assert(t, p.vals[0], color.RGBA{R: 127, A: 255})
+	e(p, 50*time.Millisecond)
+	gobot.Assert(t, p.vals[0], red)
+}
+
+func TestRainbowEffect(t *testing.T) {
+	p := newTestPixels(3)
+	e := RainbowEffect(300 * time.Millisecond)
+
+	gobot.Assert(t, e(p, 0), true)
+	gobot.Assert(t, p.vals, []color.RGBA{HSV(0, 1, 1), HSV(120, 1, 1), HSV(240, 1, 1)})
+	e(p, 100*time.Millisecond)
+	gobot.Assert(t, p.vals, []color.RGBA{HSV(120, 1, 1), HSV(240, 1, 1), HSV(0, 1, 1)})
+}
+
+func TestChaseEffect(t *testing.T) {
+	p := newTestPixels(3)
+	e := ChaseEffect(red, 10*time.Millisecond)
+
+	gobot.Assert(t, e(p, 0), true)
+	gobot.Assert(t, p.vals, []color.RGBA{red, black, black})
+	e(p, 15*time.Millisecond)
+	gobot.Assert(t, p.vals, []color.RGBA{black, red, black})
+	e(p, 35*time.Millisecond)
+	gobot.Assert(t, p.vals, []color.RGBA{red, black, black})
+
+	gobot.Assert(t, e(newTestPixels(0), 0), true)
+}
+
+func TestEffectsShortPeriods(t *testing.T) {
+	p := newTestPixels(3)
+	// periods and steps shorter than 1ms are lengthened, rather than divided by
+	for _, e := range []LedEffect{
+		BlinkEffect(red, 0),
+		PulseEffect(red, 0),
+		RainbowEffect(0),
+		ChaseEffect(red, 0),
+	} {
+		gobot.Assert(t, e(p, 3*time.Millisecond), true)
+	}
+
+	params := map[string]interface{}{"period": 1e-9}
+	gobot.Assert(t, durationParam(params, "period", time.Second), time.Millisecond)
+	gobot.Assert(t, durationParam(params, "step", time.Second), time.Second)
+}
+
+func TestLedAnimatorFinished(t *testing.T) {
+	d := NewLedStripDriver(newGpioTestPixelWriter(), "strip", "6", 2)
+	d.Interval = time.Millisecond
+	finished := make(chan interface{}, 1)
+	gobot.On(d.Event(Finished), func(data interface{}) {
+		finished <- data
+	})
+
+	gobot.Assert(t, d.AnimateEffect("fade", map[string]interface{}{
+		"to":       "#ff0000",
+		"duration": 10.0,
+	}), nil)
+	gobot.Assert(t, d.Animating(), true)
+
+	select {
+	case data := <-finished:
+		gobot.Assert(t, data, "fade")
+	case <-time.After(time.Second):
+		t.Errorf("finished was not published")
+	}
+	gobot.Assert(t, d.Animating(), false)
+	gobot.Assert(t, d.vals, []color.RGBA{red, red})
+}
+
+func TestLedAnimatorStop(t *testing.T) {
+	a := newGpioTestPixelWriter()
+	d := NewLedStripDriver(a, "strip", "6", 2)
+	d.Interval = time.Millisecond
+
+	d.Animate(BlinkEffect(red, 10*time.Millisecond))
+	<-time.After(10 * time.Millisecond)
+	d.StopAnimation()
+	gobot.Assert(t, d.Animating(), false)
+
+	frames := len(a.history("6"))
+	gobot.Refute(t, frames, 0)
+	<-time.After(10 * time.Millisecond)
+	gobot.Assert(t, len(a.history("6")), frames)
+
+	// stopping twice is harmless
+	d.StopAnimation()
+}
+
+func TestLedAnimatorError(t *testing.T) {
+	a := newGpioTestPixelWriter()
+	a.err = errors.New("write error")
+	d := NewLedStripDriver(a, "strip", "6", 2)
+	errs := make(chan interface{}, 1)
+	gobot.On(d.Event(Error), func(data interface{}) {
+		errs <- data
+	})
+
+	d.Animate(RainbowEffect(time.Second))
+	select {
+	case data := <-errs:
+		gobot.Assert(t, data, errors.New("write error"))
+	case <-time.After(time.Second):
+		t.Errorf("error was not published")
+	}
+}
+
+func TestLedAnimatorEffects(t *testing.T) {
+	d := NewLedStripDriver(newGpioTestPixelWriter(), "strip", "6", 2)
+	defer d.StopAnimation()
+
+	for _, name := range []string{"fade", "blink", "pulse", "rainbow", "chase"} {
+		gobot.Assert(t, d.AnimateEffect(name, map[string]interface{}{}), nil)
+	}
+	gobot.Assert(t, d.AnimateEffect("sparkle", nil), ErrUnknownEffect)
+	gobot.Assert(t, d.AnimateEffect("blink", map[string]interface{}{"color": "red"}), ErrInvalidColor)
+	gobot.Assert(t, d.AnimateEffect("fade", map[string]interface{}{"from": "red"}), ErrInvalidColor)
+
+	d.AddEffect("sparkle", func(p Pixels, t time.Duration) bool {
+		p.SetRGBA(1, red)
+		return false
+	})
+	gobot.Assert(t, d.Command("Animate")(map[string]interface{}{"effect": "sparkle"}), nil)
+	gobot.Assert(t, d.Command("StopAnimation")(nil), nil)
+}
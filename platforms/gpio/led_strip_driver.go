@@ -0,0 +1,72 @@
+package gpio
+
+import (
+	"image/color"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*LedStripDriver)(nil)
+
+var _ Pixels = (*LedStripDriver)(nil)
+
+// LedStripDriver represents a strip of addressable RGB LEDs, such as WS2812
+// (NeoPixel), on a pin of a PixelWriter
+type LedStripDriver struct {
+	name       string
+	pin        string
+	connection PixelWriter
+	gobot.Eventer
+	gobot.Commander
+	*PixelBuffer
+}
+
+// NewLedStripDriver returns a new LedStripDriver given a PixelWriter, name,
+// pin and the number of LEDs on the strip.
+//
+// Adds the following API Commands:
+//	"SetRGBA" - See PixelBuffer.SetRGBA
+//	"SetHex" - See PixelBuffer.SetHex
+//	"Fill" - See PixelBuffer.Fill, the color is given as "hex"
+//	"Draw" - See PixelBuffer.Draw
+//	"Clear" - See PixelBuffer.Clear
+//	"Animate" - See LedAnimator.AnimateEffect
+//	"StopAnimation" - See LedAnimator.StopAnimation
+//
+// Emits the Events:
+//	"finished" - the name of an effect which has finished
+//	"error" - an error writing a frame of an animation
+func NewLedStripDriver(a PixelWriter, name string, pin string, count int) *LedStripDriver {
+	d := &LedStripDriver{
+		name:       name,
+		pin:        pin,
+		connection: a,
+		Eventer:    gobot.NewEventer(),
+		Commander:  gobot.NewCommander(),
+	}
+	d.PixelBuffer = NewPixelBuffer(count, func(colors []color.RGBA) error {
+		return d.connection.PixelWrite(d.pin, colors)
+	}, d.Eventer, d.Commander)
+
+	return d
+}
+
+// Name returns the LedStripDrivers name
+func (d *LedStripDriver) Name() string { return d.name }
+
+// Pin returns the LedStripDrivers pin
+func (d *LedStripDriver) Pin() string { return d.pin }
+
+// Connection returns the LedStripDrivers connection
+func (d *LedStripDriver) Connection() gobot.Connection {
+	return d.connection.(gobot.Connection)
+}
+
+// Start implements the Driver interface
+func (d *LedStripDriver) Start() (errs []error) { return }
+
+// Halt stops any running animation
+func (d *LedStripDriver) Halt() (errs []error) {
+	d.StopAnimation()
+	return
+}
@@ -0,0 +1,72 @@
+package gpio
+
+import (
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func initTestLedStripDriver() (*LedStripDriver, *gpioTestPixelWriter) {
+	a := newGpioTestPixelWriter()
+	return NewLedStripDriver(a, "bot", "6", 3), a
+}
+
+func TestLedStripDriver(t *testing.T) {
+	d, _ := initTestLedStripDriver()
+	gobot.Assert(t, d.Name(), "bot")
+	gobot.Assert(t, d.Pin(), "6")
+	gobot.Assert(t, d.Len(), 3)
+	gobot.Assert(t, d.Connection().Name(), "")
+	gobot.Assert(t, len(d.Start()), 0)
+}
+
+func TestLedStripDriverHalt(t *testing.T) {
+	d, _ := initTestLedStripDriver()
+	d.Animate(ChaseEffect(red, time.Second))
+	gobot.Assert(t, len(d.Halt()), 0)
+	gobot.Assert(t, d.Animating(), false)
+}
+
+func TestLedStripDriverDraw(t *testing.T) {
+	d, a := initTestLedStripDriver()
+	d.SetRGBA(0, red)
+	d.SetRGBA(3, red)
+	gobot.Assert(t, d.SetHex(2, "#008000"), nil)
+	gobot.Assert(t, d.SetHex(2, "green"), ErrInvalidColor)
+	gobot.Assert(t, d.Draw(), nil)
+
+	d.Gamma = 2
+	gobot.Assert(t, d.Draw(), nil)
+
+	gobot.Assert(t, d.Fill(red), nil)
+	gobot.Assert(t, d.Clear(), nil)
+
+	gobot.Assert(t, a.history("6"), [][]color.RGBA{
+		{red, {}, {G: 128, A: 255}},
+		{red, {}, {G: 64, A: 255}},
+		{red, red, red},
+		{{}, {}, {}},
+	})
+}
+
+func TestLedStripDriverCommands(t *testing.T) {
+	d, a := initTestLedStripDriver()
+
+	d.Command("SetRGBA")(map[string]interface{}{
+		"index": 1.0, "red": 1.0, "green": 2.0, "blue": 3.0,
+	})
+	gobot.Assert(t, d.Command("SetHex")(map[string]interface{}{
+		"index": 2.0, "hex": "#040506",
+	}), nil)
+	gobot.Assert(t, d.Command("Draw")(nil), nil)
+	gobot.Assert(t, d.Command("Fill")(map[string]interface{}{"hex": "#ff0000"}), nil)
+	gobot.Assert(t, d.Command("Fill")(map[string]interface{}{"hex": "red"}), ErrInvalidColor)
+	gobot.Assert(t, d.Command("Clear")(nil), nil)
+
+	gobot.Assert(t, a.history("6")[:2], [][]color.RGBA{
+		{{}, {R: 1, G: 2, B: 3, A: 255}, {R: 4, G: 5, B: 6, A: 255}},
+		{red, red, red},
+	})
+}
@@ -16,23 +16,27 @@ type MakeyButtonDriver struct {
 	connection DigitalReader
 	Active     bool
 	interval   time.Duration
+	buttonGestures
 	gobot.Eventer
 }
 
 // NewMakeyButtonDriver returns a new MakeyButtonDriver with a polling interval of
 // 10 Milliseconds given a DigitalReader, name and pin.
 //
+// Debouncing and gesture detection are configured as for the ButtonDriver.
+//
 // Optinally accepts:
 //  time.Duration: Interval at which the ButtonDriver is polled for new information
 func NewMakeyButtonDriver(a DigitalReader, name string, pin string, v ...time.Duration) *MakeyButtonDriver {
 	m := &MakeyButtonDriver{
-		name:       name,
-		connection: a,
-		pin:        pin,
-		Active:     false,
-		Eventer:    gobot.NewEventer(),
-		interval:   10 * time.Millisecond,
-		halt:       make(chan bool),
+		name:           name,
+		connection:     a,
+		pin:            pin,
+		Active:         false,
+		buttonGestures: newButtonGestures(),
+		Eventer:        gobot.NewEventer(),
+		interval:       10 * time.Millisecond,
+		halt:           make(chan bool),
 	}
 
 	if len(v) > 0 {
@@ -42,6 +46,9 @@ func NewMakeyButtonDriver(a DigitalReader, name string, pin string, v ...time.Du
 	m.AddEvent(Error)
 	m.AddEvent(Push)
 	m.AddEvent(Release)
+	m.AddEvent(LongPress)
+	m.AddEvent(Hold)
+	m.AddEvent(DoubleClick)
 
 	return m
 }
@@ -60,23 +67,18 @@ func (b *MakeyButtonDriver) Connection() gobot.Connection { return b.connection.
 // Emits the Events:
 // 	Push int - On button push
 //	Release int - On button release
+//	LongPress time.Duration - On button held down for LongPressTime
+//	Hold time.Duration - Every HoldInterval while held down after a LongPress
+//	DoubleClick int - On button push within DoubleClickTime of the previous push
 //	Error error - On button error
 func (b *MakeyButtonDriver) Start() (errs []error) {
-	state := 1
 	go func() {
 		for {
 			newValue, err := b.connection.DigitalRead(b.Pin())
 			if err != nil {
 				gobot.Publish(b.Event(Error), err)
-			} else if newValue != state && newValue != -1 {
-				state = newValue
-				if newValue == 0 {
-					b.Active = true
-					gobot.Publish(b.Event(Push), newValue)
-				} else {
-					b.Active = false
-					gobot.Publish(b.Event(Release), newValue)
-				}
+			} else if newValue != -1 {
+				b.update(newValue)
 			}
 			select {
 			case <-time.After(b.interval):
@@ -93,3 +95,22 @@ func (b *MakeyButtonDriver) Halt() (errs []error) {
 	b.halt <- true
 	return
 }
+
+// update publishes the events caused by newValue, the makey button reads 0
+// when pushed
+func (b *MakeyButtonDriver) update(newValue int) {
+	for _, e := range b.sample(newValue == 0, time.Now()) {
+		switch e.name {
+		case Push:
+			b.Active = true
+			gobot.Publish(b.Event(Push), newValue)
+		case Release:
+			b.Active = false
+			gobot.Publish(b.Event(Release), newValue)
+		case DoubleClick:
+			gobot.Publish(b.Event(DoubleClick), newValue)
+		default:
+			gobot.Publish(b.Event(e.name), e.held)
+		}
+	}
+}
@@ -97,3 +97,17 @@ func TestMakeyButtonDriverStart(t *testing.T) {
 	case <-time.After(30 * time.Millisecond):
 	}
 }
+
+func TestMakeyButtonDriverDebounce(t *testing.T) {
+	d := initTestMakeyButtonDriver()
+	d.DebounceTime = time.Hour
+
+	d.update(0)
+	gobot.Assert(t, d.Active, false)
+
+	d.DebounceTime = 0
+	d.update(0)
+	gobot.Assert(t, d.Active, true)
+	d.update(1)
+	gobot.Assert(t, d.Active, false)
+}
@@ -0,0 +1,345 @@
+package gpio
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*MAX7219Driver)(nil)
+
+// MAX7219 registers
+const (
+	max7219Digit0      = 0x01
+	max7219DecodeMode  = 0x09
+	max7219Intensity   = 0x0A
+	max7219ScanLimit   = 0x0B
+	max7219Shutdown    = 0x0C
+	max7219DisplayTest = 0x0F
+)
+
+// MAX7219Driver represents a chain of MAX7219 LED drivers, each driving eight
+// 7-segment digits or an 8x8 LED matrix. The first MAX7219 of the chain, the
+// one connected to the data pin, is the leftmost.
+//
+// On a matrix, digit register n drives row n from the top, with bit 7 the
+// leftmost column, as on the common generic modules.
+type MAX7219Driver struct {
+	name       string
+	dataPin    string
+	clockPin   string
+	csPin      string
+	connection DigitalWriter
+	mutex      sync.Mutex
+	buffer     [][8]byte
+	task       backgroundTask
+	gobot.Eventer
+	gobot.Commander
+}
+
+// NewMAX7219Driver returns a new MAX7219Driver given a DigitalWriter, name and
+// the pins connected to the DIN, CLK and CS (LOAD) inputs of the first
+// MAX7219.
+//
+// Optionally accepts:
+//  int: number of chained MAX7219s, 1 by default
+//
+// Adds the following API Commands:
+//	"WriteDigits" - See MAX7219Driver.WriteDigits, the text is given as "text"
+//	"WriteText" - See MAX7219Driver.WriteText, the text is given as "text"
+//	"ScrollText" - See MAX7219Driver.ScrollText, given "text" and "step" in ms
+//	"SetIntensity" - See MAX7219Driver.SetIntensity
+//	"Clear" - See MAX7219Driver.Clear
+//
+// Emits the Events:
+//	"finished" - the text which has finished scrolling
+//	"error" - an error writing a step of scrolling text
+func NewMAX7219Driver(a DigitalWriter, name string, dataPin string, clockPin string, csPin string, v ...int) *MAX7219Driver {
+	count := 1
+	if len(v) > 0 && v[0] > 0 {
+		count = v[0]
+	}
+	m := &MAX7219Driver{
+		name:       name,
+		dataPin:    dataPin,
+		clockPin:   clockPin,
+		csPin:      csPin,
+		connection: a,
+		buffer:     make([][8]byte, count),
+		Eventer:    gobot.NewEventer(),
+		Commander:  gobot.NewCommander(),
+	}
+
+	m.AddEvent(Finished)
+	m.AddEvent(Error)
+
+	m.AddCommand("WriteDigits", func(params map[string]interface{}) interface{} {
+		return m.WriteDigits(params["text"].(string))
+	})
+	m.AddCommand("WriteText", func(params map[string]interface{}) interface{} {
+		return m.WriteText(params["text"].(string))
+	})
+	m.AddCommand("ScrollText", func(params map[string]interface{}) interface{} {
+		step := 100 * time.Millisecond
+		if ms, ok := params["step"].(float64); ok {
+			step = time.Duration(ms * float64(time.Millisecond))
+		}
+		m.ScrollText(params["text"].(string), step)
+		return nil
+	})
+	m.AddCommand("SetIntensity", func(params map[string]interface{}) interface{} {
+		return m.SetIntensity(byte(params["level"].(float64)))
+	})
+	m.AddCommand("Clear", func(params map[string]interface{}) interface{} {
+		return m.Clear()
+	})
+
+	return m
+}
+
+// Name returns the MAX7219Drivers name
+func (m *MAX7219Driver) Name() string { return m.name }
+
+// Connection returns the MAX7219Drivers connection
+func (m *MAX7219Driver) Connection() gobot.Connection {
+	return m.connection.(gobot.Connection)
+}
+
+// Start sets up every MAX7219 to drive all eight digits or rows without
+// decoding, at medium intensity, and clears them
+func (m *MAX7219Driver) Start() (errs []error) {
+	for _, reg := range [][2]byte{
+		{max7219DisplayTest, 0},
+		{max7219ScanLimit, 7},
+		{max7219DecodeMode, 0},
+		{max7219Intensity, 7},
+		{max7219Shutdown, 1},
+	} {
+		if err := m.writeAll(reg[0], reg[1]); err != nil {
+			return []error{err}
+		}
+	}
+	if err := m.Clear(); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Halt stops any scrolling text
+func (m *MAX7219Driver) Halt() (errs []error) {
+	m.StopScrolling()
+	return
+}
+
+// Len returns the number of chained MAX7219s
+func (m *MAX7219Driver) Len() int { return len(m.buffer) }
+
+// Width returns the number of columns of the matrices
+func (m *MAX7219Driver) Width() int { return 8 * len(m.buffer) }
+
+// SetIntensity sets the brightness of every MAX7219, 0-15
+func (m *MAX7219Driver) SetIntensity(level byte) (err error) {
+	if level > 15 {
+		level = 15
+	}
+	return m.writeAll(max7219Intensity, level)
+}
+
+// On wakes every MAX7219, showing what they were showing before Off
+func (m *MAX7219Driver) On() (err error) {
+	return m.writeAll(max7219Shutdown, 1)
+}
+
+// Off puts every MAX7219 into shutdown, turning the LEDs off
+func (m *MAX7219Driver) Off() (err error) {
+	return m.writeAll(max7219Shutdown, 0)
+}
+
+// SetRow sets the digit or row of the MAX7219 at index device to bits, on a
+// digit DP A B C D E F G from bit 7 to bit 0. The change is shown at once.
+func (m *MAX7219Driver) SetRow(device int, row int, bits byte) (err error) {
+	if device < 0 || device >= len(m.buffer) || row < 0 || row > 7 {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.buffer[device][row] = bits
+	return m.writeRow(row)
+}
+
+// SetPixel turns the LED at column x, counted from the left of the first
+// matrix, and row y, counted from the top, on or off. The change is not
+// shown until Draw is called.
+func (m *MAX7219Driver) SetPixel(x int, y int, on bool) {
+	if x < 0 || x >= m.Width() || y < 0 || y > 7 {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if on {
+		m.buffer[x/8][y] |= 0x80 >> uint(x%8)
+	} else {
+		m.buffer[x/8][y] &^= 0x80 >> uint(x%8)
+	}
+}
+
+// Draw shows the pixels which have been set
+func (m *MAX7219Driver) Draw() (err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.draw()
+}
+
+// Clear stops any scrolling text and turns every LED off
+func (m *MAX7219Driver) Clear() (err error) {
+	m.StopScrolling()
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for i := range m.buffer {
+		m.buffer[i] = [8]byte{}
+	}
+	return m.draw()
+}
+
+// WriteDigits stops any scrolling text and shows text on 7-segment digits,
+// from the leftmost digit of the first MAX7219. A '.' lights the decimal
+// point of the digit before it. Characters which can not be drawn on 7
+// segments are left blank.
+func (m *MAX7219Driver) WriteDigits(text string) (err error) {
+	m.StopScrolling()
+
+	digits := []byte{}
+	for _, r := range text {
+		last := len(digits) - 1
+		switch {
+		case r == '.' && last >= 0 && digits[last]&0x80 == 0:
+			digits[last] |= 0x80
+		case r == '.':
+			digits = append(digits, 0x80)
+		default:
+			digits = append(digits, segments(r))
+		}
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for device := range m.buffer {
+		for i := 0; i < 8; i++ {
+			var bits byte
+			if n := 8*device + i; n < len(digits) {
+				bits = digits[n]
+			}
+			// digit 0 is the rightmost
+			m.buffer[device][7-i] = bits
+		}
+	}
+	return m.draw()
+}
+
+// WriteText stops any scrolling text and shows text on the matrices in a 5x7
+// font, from the left of the first matrix
+func (m *MAX7219Driver) WriteText(text string) (err error) {
+	m.StopScrolling()
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.setColumns(fontColumns(text), 0)
+	return m.draw()
+}
+
+// ScrollText stops any scrolling text and scrolls text across the matrices
+// in a 5x7 font in the background, moving one column every step. The text
+// comes in from the right and the "finished" event is published once it
+// has left on the left.
+func (m *MAX7219Driver) ScrollText(text string, step time.Duration) {
+	columns := fontColumns(text)
+	m.task.start(m, func(halt chan bool) (string, interface{}) {
+		return m.scroll(text, columns, step, halt)
+	})
+}
+
+// Scrolling returns true while text is scrolling
+func (m *MAX7219Driver) Scrolling() bool { return m.task.running() }
+
+// StopScrolling stops any scrolling text, leaving the matrices as they are
+func (m *MAX7219Driver) StopScrolling() { m.task.stop() }
+
+// scroll moves columns across the matrices until they have passed or halt is
+// closed, and returns the event to publish for how it ended
+func (m *MAX7219Driver) scroll(text string, columns []byte, step time.Duration, halt chan bool) (event string, data interface{}) {
+	for offset := m.Width(); offset >= -len(columns); offset-- {
+		m.mutex.Lock()
+		m.setColumns(columns, offset)
+		err := m.draw()
+		m.mutex.Unlock()
+		if err != nil {
+			return Error, err
+		}
+		select {
+		case <-halt:
+			return "", nil
+		case <-time.After(step):
+		}
+	}
+	return Finished, text
+}
+
+// setColumns fills the buffer with columns starting at column offset, which
+// may be off either side of the matrices
+func (m *MAX7219Driver) setColumns(columns []byte, offset int) {
+	for i := range m.buffer {
+		m.buffer[i] = [8]byte{}
+	}
+	for i, column := range columns {
+		x := offset + i
+		if x < 0 || x >= m.Width() {
+			continue
+		}
+		for y := uint(0); y < 8; y++ {
+			if column&(1<<y) != 0 {
+				m.buffer[x/8][y] |= 0x80 >> uint(x%8)
+			}
+		}
+	}
+}
+
+// draw writes every row of the buffer
+func (m *MAX7219Driver) draw() (err error) {
+	for row := 0; row < 8; row++ {
+		if err = m.writeRow(row); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// writeRow writes row of the buffer to every MAX7219
+func (m *MAX7219Driver) writeRow(row int) (err error) {
+	data := make([]byte, 0, 2*len(m.buffer))
+	// the first MAX7219 takes the last data shifted in
+	for device := len(m.buffer) - 1; device >= 0; device-- {
+		data = append(data, byte(max7219Digit0+row), m.buffer[device][row])
+	}
+	return m.write(data)
+}
+
+// writeAll writes value to register of every MAX7219
+func (m *MAX7219Driver) writeAll(register byte, value byte) (err error) {
+	data := make([]byte, 0, 2*len(m.buffer))
+	for range m.buffer {
+		data = append(data, register, value)
+	}
+	return m.write(data)
+}
+
+// write shifts data through the chain and latches it on the rising edge of
+// the chip select
+func (m *MAX7219Driver) write(data []byte) (err error) {
+	if err = m.connection.DigitalWrite(m.csPin, 0); err != nil {
+		return
+	}
+	if err = shiftOut(m.connection, m.dataPin, m.clockPin, data); err != nil {
+		return
+	}
+	return m.connection.DigitalWrite(m.csPin, 1)
+}
@@ -0,0 +1,163 @@
+package gpio
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func initTestMAX7219Driver(v ...int) (*MAX7219Driver, *gpioTestShiftReceiver) {
+	a := &gpioTestShiftReceiver{}
+	return NewMAX7219Driver(a, "bot", "d", "c", "l", v...), a
+}
+
+// max7219Rows returns the values written to the digit registers, for each frame
+// which writes them, of the MAX7219 at index device of count
+func max7219Rows(frames [][]byte, device int, count int) (vals []byte) {
+	for _, frame := range frames {
+		i := 2 * (count - 1 - device)
+		if frame[i] >= max7219Digit0 && frame[i] < max7219Digit0+8 {
+			vals = append(vals, frame[i+1])
+		}
+	}
+	return
+}
+
+func TestMAX7219Driver(t *testing.T) {
+	d, _ := initTestMAX7219Driver(4)
+	gobot.Assert(t, d.Name(), "bot")
+	gobot.Assert(t, d.Connection().Name(), "")
+	gobot.Assert(t, d.Len(), 4)
+	gobot.Assert(t, d.Width(), 32)
+	gobot.Assert(t, len(d.Halt()), 0)
+}
+
+func TestMAX7219DriverStart(t *testing.T) {
+	d, a := initTestMAX7219Driver(2)
+	gobot.Assert(t, len(d.Start()), 0)
+	gobot.Assert(t, a.frames[:5], [][]byte{
+		{0x0F, 0, 0x0F, 0},
+		{0x0B, 7, 0x0B, 7},
+		{0x09, 0, 0x09, 0},
+		{0x0A, 7, 0x0A, 7},
+		{0x0C, 1, 0x0C, 1},
+	})
+	gobot.Assert(t, len(a.frames), 13)
+	gobot.Assert(t, a.frames[5], []byte{0x01, 0, 0x01, 0})
+
+	d = NewMAX7219Driver(newGpioTestAdaptor("adaptor"), "bot", "d", "c", "l")
+	testAdaptorDigitalWrite = func() (err error) {
+		return errors.New("write error")
+	}
+	defer func() { testAdaptorDigitalWrite = func() (err error) { return nil } }()
+	gobot.Assert(t, d.Start()[0], errors.New("write error"))
+}
+
+func TestMAX7219DriverIntensity(t *testing.T) {
+	d, a := initTestMAX7219Driver()
+	gobot.Assert(t, d.SetIntensity(3), nil)
+	gobot.Assert(t, d.SetIntensity(20), nil)
+	gobot.Assert(t, d.Off(), nil)
+	gobot.Assert(t, d.On(), nil)
+	gobot.Assert(t, a.frames, [][]byte{{0x0A, 3}, {0x0A, 15}, {0x0C, 0}, {0x0C, 1}})
+}
+
+func TestMAX7219DriverWriteDigits(t *testing.T) {
+	d, a := initTestMAX7219Driver(2)
+
+	gobot.Assert(t, d.WriteDigits("-12.5..Hi!"), nil)
+	// digit 7 of the first MAX7219 first
+	gobot.Assert(t, a.frames[7], []byte{0x08, 0x00, 0x08, 0x01})
+	gobot.Assert(t, max7219Rows(a.frames, 0, 2), []byte{0x00, 0x06, 0x37, 0x80, 0xDB, 0xED, 0x30, 0x01})
+	gobot.Assert(t, max7219Rows(a.frames, 1, 2), make([]byte, 8))
+}
+
+func TestMAX7219DriverSetRow(t *testing.T) {
+	d, a := initTestMAX7219Driver(2)
+	gobot.Assert(t, d.SetRow(1, 2, 0xAA), nil)
+	gobot.Assert(t, d.SetRow(2, 2, 0xAA), nil)
+	gobot.Assert(t, a.frames, [][]byte{{0x03, 0xAA, 0x03, 0x00}})
+}
+
+func TestMAX7219DriverPixels(t *testing.T) {
+	d, a := initTestMAX7219Driver(2)
+	d.SetPixel(0, 0, true)
+	d.SetPixel(9, 0, true)
+	d.SetPixel(15, 7, true)
+	d.SetPixel(16, 0, true)
+	d.SetPixel(9, 0, false)
+	gobot.Assert(t, d.Draw(), nil)
+
+	gobot.Assert(t, a.frames[0], []byte{0x01, 0x00, 0x01, 0x80})
+	gobot.Assert(t, a.frames[7], []byte{0x08, 0x01, 0x08, 0x00})
+
+	gobot.Assert(t, d.Clear(), nil)
+	gobot.Assert(t, a.frames[8], []byte{0x01, 0x00, 0x01, 0x00})
+}
+
+func TestMAX7219DriverWriteText(t *testing.T) {
+	d, a := initTestMAX7219Driver()
+
+	// 'T' has its top row across all 5 columns and its middle column below
+	gobot.Assert(t, d.WriteText("T"), nil)
+	gobot.Assert(t, max7219Rows(a.frames, 0, 1), []byte{0xF8, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x00})
+}
+
+func TestFontColumns(t *testing.T) {
+	gobot.Assert(t, fontColumns("1"), []byte{0x00, 0x42, 0x7F, 0x40, 0x00, 0x00})
+	gobot.Assert(t, fontColumns("\n"), fontColumns("?"))
+	gobot.Assert(t, len(fontColumns("ab")), 12)
+	gobot.Assert(t, len(font5x7), int('~'-' '+1))
+}
+
+func TestSegments(t *testing.T) {
+	gobot.Assert(t, segments('8'), byte(0x7F))
+	gobot.Assert(t, segments('a'), byte(0x77))
+	gobot.Assert(t, segments('B'), byte(0x1F))
+	gobot.Assert(t, segments('!'), byte(0x00))
+}
+
+func TestMAX7219DriverScrollText(t *testing.T) {
+	d, a := initTestMAX7219Driver()
+	finished := make(chan interface{}, 1)
+	gobot.On(d.Event(Finished), func(data interface{}) {
+		finished <- data
+	})
+
+	d.ScrollText("I", time.Millisecond)
+	gobot.Assert(t, d.Scrolling(), true)
+	select {
+	case data := <-finished:
+		gobot.Assert(t, data, "I")
+	case <-time.After(time.Second):
+		t.Errorf("finished was not published")
+	}
+	gobot.Assert(t, d.Scrolling(), false)
+
+	// from off the right, through every column, to off the left
+	gobot.Assert(t, len(a.frames), 8*(8+6+1))
+	top := max7219Rows(a.frames, 0, 1)
+	gobot.Assert(t, top[0], byte(0x00))
+	gobot.Assert(t, top[8*4], byte(0x07))
+	gobot.Assert(t, top[len(top)-8], byte(0x00))
+
+	d.ScrollText("long text", time.Second)
+	gobot.Assert(t, d.Command("Clear")(nil), nil)
+	gobot.Assert(t, d.Scrolling(), false)
+}
+
+func TestMAX7219DriverCommands(t *testing.T) {
+	d, a := initTestMAX7219Driver()
+
+	gobot.Assert(t, d.Command("WriteDigits")(map[string]interface{}{"text": "1"}), nil)
+	gobot.Assert(t, d.Command("WriteText")(map[string]interface{}{"text": "1"}), nil)
+	gobot.Assert(t, d.Command("SetIntensity")(map[string]interface{}{"level": 5.0}), nil)
+	gobot.Assert(t, a.frames[16], []byte{0x0A, 5})
+
+	gobot.Assert(t, d.Command("ScrollText")(map[string]interface{}{"text": "hi", "step": 1000.0}), nil)
+	gobot.Assert(t, d.Scrolling(), true)
+	gobot.Assert(t, len(d.Halt()), 0)
+	gobot.Assert(t, d.Scrolling(), false)
+}
@@ -1,12 +1,22 @@
 package gpio
 
 import (
+	"errors"
+
 	"github.com/hybridgroup/gobot"
 )
 
 var _ gobot.Driver = (*MotorDriver)(nil)
 
+// ErrMotorBrakeUnsupported is the error resulting when a motor without both
+// a ForwardPin and a BackwardPin is asked to brake
+var ErrMotorBrakeUnsupported = errors.New("Brake requires a ForwardPin and a BackwardPin")
+
 // MotorDriver Represents a Motor
+//
+// Its CurrentMode is "digital" while it is switched on and off, "analog" once
+// its speed is set, and "brake" or "coast" after Brake or Coast until its
+// Direction is set again.
 type MotorDriver struct {
 	name             string
 	connection       DigitalWriter
@@ -141,6 +151,12 @@ func (m *MotorDriver) Backward(speed byte) (err error) {
 // Direction sets the direction pin to the specified speed
 func (m *MotorDriver) Direction(direction string) (err error) {
 	m.CurrentDirection = direction
+	if m.CurrentMode == "brake" || m.CurrentMode == "coast" {
+		m.CurrentMode = "digital"
+		if m.SpeedPin != "" {
+			m.CurrentMode = "analog"
+		}
+	}
 	if m.DirectionPin != "" {
 		var level byte
 		if direction == "forward" {
@@ -174,6 +190,40 @@ func (m *MotorDriver) Direction(direction string) (err error) {
 	return
 }
 
+// Brake stops the motor quickly by shorting its windings through the H-bridge,
+// driving both the forward and backward pins high at full speed
+func (m *MotorDriver) Brake() (err error) {
+	if m.ForwardPin == "" || m.BackwardPin == "" {
+		return ErrMotorBrakeUnsupported
+	}
+	m.CurrentDirection = "brake"
+	if err = m.connection.DigitalWrite(m.ForwardPin, 1); err != nil {
+		return
+	}
+	if err = m.connection.DigitalWrite(m.BackwardPin, 1); err != nil {
+		return
+	}
+	if m.SpeedPin != "" {
+		err = m.Speed(255)
+	}
+	m.CurrentMode = "brake"
+	return
+}
+
+// Coast lets the motor spin down freely by switching the H-bridge off
+func (m *MotorDriver) Coast() (err error) {
+	if m.ForwardPin != "" {
+		if err = m.Direction("none"); err != nil {
+			return
+		}
+	}
+	if m.SpeedPin != "" {
+		err = m.Speed(0)
+	}
+	m.CurrentMode = "coast"
+	return
+}
+
 func (m *MotorDriver) isDigital() bool {
 	if m.CurrentMode == "digital" {
 		return true
@@ -108,3 +108,47 @@ func TestMotorDriverDirection(t *testing.T) {
 	d.Direction("forward")
 	d.Direction("backward")
 }
+
+func TestMotorDriverBrake(t *testing.T) {
+	testAdaptorPwmWrite = func() (err error) { return nil }
+	a := newGpioTestPinWriter()
+	d := NewMotorDriver(a, "bot", "")
+	gobot.Assert(t, d.Brake(), ErrMotorBrakeUnsupported)
+
+	d.ForwardPin = "1"
+	d.BackwardPin = "2"
+	gobot.Assert(t, d.Brake(), nil)
+	gobot.Assert(t, d.CurrentDirection, "brake")
+	gobot.Assert(t, a.history("1"), []byte{1})
+	gobot.Assert(t, a.history("2"), []byte{1})
+
+	d = NewMotorDriver(newGpioTestAdaptor("adaptor"), "bot", "3")
+	d.ForwardPin = "1"
+	d.BackwardPin = "2"
+	gobot.Assert(t, d.Brake(), nil)
+	gobot.Assert(t, d.CurrentSpeed, uint8(255))
+	gobot.Assert(t, d.CurrentMode, "brake")
+	gobot.Assert(t, d.Forward(100), nil)
+	gobot.Assert(t, d.CurrentMode, "analog")
+}
+
+func TestMotorDriverCoast(t *testing.T) {
+	testAdaptorPwmWrite = func() (err error) { return nil }
+	a := newGpioTestPinWriter()
+	d := NewMotorDriver(a, "bot", "")
+	d.ForwardPin = "1"
+	d.BackwardPin = "2"
+	gobot.Assert(t, d.Coast(), nil)
+	gobot.Assert(t, d.CurrentDirection, "none")
+	gobot.Assert(t, a.history("1"), []byte{0})
+	gobot.Assert(t, a.history("2"), []byte{0})
+	gobot.Assert(t, d.CurrentMode, "coast")
+	gobot.Assert(t, d.Direction("forward"), nil)
+	gobot.Assert(t, d.CurrentMode, "digital")
+
+	d = NewMotorDriver(newGpioTestAdaptor("adaptor"), "bot", "3")
+	d.CurrentSpeed = 100
+	gobot.Assert(t, d.Coast(), nil)
+	gobot.Assert(t, d.CurrentSpeed, uint8(0))
+	gobot.Assert(t, d.CurrentMode, "coast")
+}
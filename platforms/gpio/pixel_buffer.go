@@ -0,0 +1,127 @@
+package gpio
+
+import (
+	"image/color"
+	"sync"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ Pixels = (*PixelBuffer)(nil)
+
+// PixelBuffer holds the colors of a strip of addressable RGB LEDs until they
+// are drawn, and animates them with its LedAnimator. Strip drivers embed it to
+// share its methods and commands, and only write the colors out themselves.
+type PixelBuffer struct {
+	mutex sync.Mutex
+	vals  []color.RGBA
+	write func(colors []color.RGBA) error
+	*LedAnimator
+	// Gamma corrects colors before they are written, see GammaCorrect
+	Gamma float64
+}
+
+// NewPixelBuffer returns a new PixelBuffer of count LEDs, which Draw passes to
+// write, publishing to e and adding its commands to c.
+//
+// Adds the following API Commands:
+//	"SetRGBA" - See PixelBuffer.SetRGBA, alpha is 255 unless given
+//	"SetHex" - See PixelBuffer.SetHex
+//	"Fill" - See PixelBuffer.Fill, the color is given as "hex"
+//	"Draw" - See PixelBuffer.Draw
+//	"Clear" - See PixelBuffer.Clear
+//	"Animate" - See LedAnimator.AnimateEffect
+//	"StopAnimation" - See LedAnimator.StopAnimation
+//
+// Emits the Events:
+//	"finished" - the name of an effect which has finished
+//	"error" - an error writing a frame of an animation
+func NewPixelBuffer(count int, write func(colors []color.RGBA) error, e gobot.Eventer, c gobot.Commander) *PixelBuffer {
+	b := &PixelBuffer{
+		vals:  make([]color.RGBA, count),
+		write: write,
+	}
+	b.LedAnimator = NewLedAnimator(b, e, c)
+
+	c.AddCommand("SetRGBA", func(params map[string]interface{}) interface{} {
+		i := int(params["index"].(float64))
+		col := color.RGBA{
+			R: byte(params["red"].(float64)),
+			G: byte(params["green"].(float64)),
+			B: byte(params["blue"].(float64)),
+			A: 255,
+		}
+		if alpha, ok := params["alpha"].(float64); ok {
+			col.A = byte(alpha)
+		}
+		b.SetRGBA(i, col)
+		return nil
+	})
+	c.AddCommand("SetHex", func(params map[string]interface{}) interface{} {
+		i := int(params["index"].(float64))
+		return b.SetHex(i, params["hex"].(string))
+	})
+	c.AddCommand("Fill", func(params map[string]interface{}) interface{} {
+		col, err := ParseHexColor(params["hex"].(string))
+		if err != nil {
+			return err
+		}
+		return b.Fill(col)
+	})
+	c.AddCommand("Draw", func(params map[string]interface{}) interface{} {
+		return b.Draw()
+	})
+	c.AddCommand("Clear", func(params map[string]interface{}) interface{} {
+		return b.Clear()
+	})
+
+	return b
+}
+
+// Len returns the number of LEDs on the strip
+func (b *PixelBuffer) Len() int { return len(b.vals) }
+
+// SetRGBA sets the color of the LED at index i. The strip is not updated
+// until Draw is called.
+func (b *PixelBuffer) SetRGBA(i int, c color.RGBA) {
+	if i < 0 || i >= len(b.vals) {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.vals[i] = c
+}
+
+// SetHex sets the color of the LED at index i to a color written in hex, such
+// as "#ff8800". The strip is not updated until Draw is called.
+func (b *PixelBuffer) SetHex(i int, hex string) (err error) {
+	c, err := ParseHexColor(hex)
+	if err != nil {
+		return
+	}
+	b.SetRGBA(i, c)
+	return
+}
+
+// Fill stops any running animation and sets every LED on the strip to c
+func (b *PixelBuffer) Fill(c color.RGBA) (err error) {
+	b.StopAnimation()
+	fill(b, c)
+	return b.Draw()
+}
+
+// Clear stops any running animation and turns every LED on the strip off
+func (b *PixelBuffer) Clear() (err error) {
+	return b.Fill(color.RGBA{})
+}
+
+// Draw writes the current colors to the strip, corrected by Gamma
+func (b *PixelBuffer) Draw() (err error) {
+	b.mutex.Lock()
+	colors := make([]color.RGBA, len(b.vals))
+	for i, c := range b.vals {
+		colors[i] = GammaCorrect(c, b.Gamma)
+	}
+	b.mutex.Unlock()
+	return b.write(colors)
+}
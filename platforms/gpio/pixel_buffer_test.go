@@ -0,0 +1,57 @@
+package gpio
+
+import (
+	"image/color"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func initTestPixelBuffer() (*PixelBuffer, gobot.Commander, func() [][]color.RGBA) {
+	var mutex sync.Mutex
+	writes := [][]color.RGBA{}
+	c := gobot.NewCommander()
+	b := NewPixelBuffer(2, func(colors []color.RGBA) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		writes = append(writes, colors)
+		return nil
+	}, gobot.NewEventer(), c)
+	return b, c, func() [][]color.RGBA {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return writes
+	}
+}
+
+func TestPixelBufferSetRGBACommand(t *testing.T) {
+	b, c, writes := initTestPixelBuffer()
+	gobot.Assert(t, b.Len(), 2)
+
+	c.Command("SetRGBA")(map[string]interface{}{
+		"index": 0.0, "red": 1.0, "green": 2.0, "blue": 3.0,
+	})
+	c.Command("SetRGBA")(map[string]interface{}{
+		"index": 1.0, "red": 4.0, "green": 5.0, "blue": 6.0, "alpha": 7.0,
+	})
+	gobot.Assert(t, b.Draw(), nil)
+	gobot.Assert(t, writes(), [][]color.RGBA{
+		{{R: 1, G: 2, B: 3, A: 255}, {R: 4, G: 5, B: 6, A: 7}},
+	})
+}
+
+func TestPixelBufferAnimating(t *testing.T) {
+	b, _, writes := initTestPixelBuffer()
+	b.Interval = time.Millisecond
+	b.Animate(RainbowEffect(time.Second))
+
+	// the colors are set by the caller while the animation sets them too
+	for i := 0; i < 20; i++ {
+		b.SetRGBA(i%2, red)
+		time.Sleep(100 * time.Microsecond)
+	}
+	b.StopAnimation()
+	gobot.Assert(t, len(writes()) > 0, true)
+}
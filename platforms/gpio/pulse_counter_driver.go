@@ -0,0 +1,147 @@
+package gpio
+
+import (
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*PulseCounterDriver)(nil)
+
+// PulseCounterData is the Data published by a PulseCounterDriver
+type PulseCounterData struct {
+	// Count is the number of pulses counted since the start
+	Count int
+	// Frequency is the number of pulses per second
+	Frequency float64
+}
+
+// PulseCounterDriver counts the pulses on a digital pin, eg. of a flow meter
+// or an anemometer, and measures their frequency
+type PulseCounterDriver struct {
+	name       string
+	pin        string
+	level      int
+	count      int
+	lastCount  int
+	lastReport time.Time
+	frequency  float64
+	digitalWatcher
+	gobot.Eventer
+	gobot.Commander
+}
+
+// NewPulseCounterDriver returns a new PulseCounterDriver given a
+// DigitalReader, name and pin. If the DigitalReader is a DigitalNotifier, the
+// pin is read whenever it changes, otherwise it is polled every Millisecond.
+//
+// Optionally accepts:
+// 	time.Duration: Interval at which the pin is polled
+//
+// Adds the following API Commands:
+// 	"Count" - See PulseCounterDriver.Count
+// 	"Frequency" - See PulseCounterDriver.Frequency
+// 	"Reset" - See PulseCounterDriver.Reset
+func NewPulseCounterDriver(a DigitalReader, name string, pin string, v ...time.Duration) *PulseCounterDriver {
+	interval := 1 * time.Millisecond
+	if len(v) > 0 {
+		interval = v[0]
+	}
+
+	p := &PulseCounterDriver{
+		name:           name,
+		pin:            pin,
+		level:          -1,
+		digitalWatcher: newDigitalWatcher(a, []string{pin}, interval),
+		Eventer:        gobot.NewEventer(),
+		Commander:      gobot.NewCommander(),
+	}
+
+	p.AddEvent(Data)
+	p.AddEvent(Error)
+
+	p.AddCommand("Count", func(params map[string]interface{}) interface{} {
+		return p.Count()
+	})
+	p.AddCommand("Frequency", func(params map[string]interface{}) interface{} {
+		return p.Frequency()
+	})
+	p.AddCommand("Reset", func(params map[string]interface{}) interface{} {
+		p.Reset()
+		return nil
+	})
+
+	return p
+}
+
+// Name returns the PulseCounterDrivers name
+func (p *PulseCounterDriver) Name() string { return p.name }
+
+// Pin returns the PulseCounterDrivers pin
+func (p *PulseCounterDriver) Pin() string { return p.pin }
+
+// Connection returns the PulseCounterDrivers Connection
+func (p *PulseCounterDriver) Connection() gobot.Connection {
+	return p.connection.(gobot.Connection)
+}
+
+// Start starts counting pulses.
+//
+// Emits the Events:
+// 	Data PulseCounterData - Every ReportInterval while the count or frequency changes
+//	Error error - On error reading the pin
+func (p *PulseCounterDriver) Start() (errs []error) {
+	p.lastReport = time.Now()
+	if err := p.start(p.Event(Error), p.sample, p.report); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Halt stops counting pulses
+func (p *PulseCounterDriver) Halt() (errs []error) {
+	p.stop()
+	return
+}
+
+// Count returns the number of pulses counted since the start or last reset
+func (p *PulseCounterDriver) Count() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.count
+}
+
+// Frequency returns the number of pulses per second over the last
+// ReportInterval
+func (p *PulseCounterDriver) Frequency() float64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.frequency
+}
+
+// Reset sets the count to 0
+func (p *PulseCounterDriver) Reset() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.count = 0
+	p.lastCount = 0
+}
+
+// sample counts rising edges of the pin
+func (p *PulseCounterDriver) sample(vals []int, now time.Time) {
+	if p.level == 0 && vals[0] == 1 {
+		p.count++
+	}
+	p.level = vals[0]
+}
+
+// report updates the frequency and publishes Data if anything changed
+func (p *PulseCounterDriver) report(now time.Time) {
+	frequency := float64(p.count-p.lastCount) / now.Sub(p.lastReport).Seconds()
+	changed := p.count != p.lastCount || frequency != p.frequency
+	p.lastCount, p.lastReport, p.frequency = p.count, now, frequency
+
+	if changed {
+		gobot.Publish(p.Event(Data), PulseCounterData{Count: p.count, Frequency: frequency})
+	}
+}
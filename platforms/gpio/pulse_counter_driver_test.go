@@ -0,0 +1,81 @@
+package gpio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func initTestPulseCounterDriver() *PulseCounterDriver {
+	return NewPulseCounterDriver(newGpioTestAdaptor("adaptor"), "counter", "1")
+}
+
+func TestPulseCounterDriver(t *testing.T) {
+	d := initTestPulseCounterDriver()
+	gobot.Assert(t, d.Name(), "counter")
+	gobot.Assert(t, d.Pin(), "1")
+	gobot.Assert(t, d.Connection().Name(), "adaptor")
+	gobot.Assert(t, d.interval, 1*time.Millisecond)
+
+	d = NewPulseCounterDriver(newGpioTestAdaptor("adaptor"), "counter", "1", 5*time.Millisecond)
+	gobot.Assert(t, d.interval, 5*time.Millisecond)
+
+	gobot.Assert(t, d.Command("Count")(nil), 0)
+	gobot.Assert(t, d.Command("Frequency")(nil), 0.0)
+}
+
+func TestPulseCounterDriverSample(t *testing.T) {
+	d := initTestPulseCounterDriver()
+	now := time.Now()
+
+	// a pin which is high from the start is not a pulse
+	for _, v := range []int{1, 0, 1, 1, 0, 1, 0} {
+		d.sample([]int{v}, now)
+	}
+	gobot.Assert(t, d.Count(), 2)
+
+	d.Command("Reset")(nil)
+	gobot.Assert(t, d.Count(), 0)
+}
+
+func TestPulseCounterDriverReport(t *testing.T) {
+	d := initTestPulseCounterDriver()
+	sem := make(chan PulseCounterData, 1)
+	gobot.On(d.Event(Data), func(data interface{}) {
+		sem <- data.(PulseCounterData)
+	})
+
+	now := time.Now()
+	d.lastReport = now
+	for _, v := range []int{0, 1, 0, 1, 0, 1} {
+		d.sample([]int{v}, now)
+	}
+	d.report(now.Add(100 * time.Millisecond))
+	gobot.Assert(t, <-sem, PulseCounterData{Count: 3, Frequency: 30})
+	gobot.Assert(t, d.Frequency(), 30.0)
+}
+
+func TestPulseCounterDriverStart(t *testing.T) {
+	a := newGpioTestDigitalNotifier()
+	d := NewPulseCounterDriver(a, "counter", "1")
+	d.ReportInterval = 10 * time.Millisecond
+	sem := make(chan PulseCounterData, 10)
+	gobot.On(d.Event(Data), func(data interface{}) {
+		sem <- data.(PulseCounterData)
+	})
+
+	gobot.Assert(t, len(d.Start()), 0)
+	a.set("1", 1)
+	a.notify("1", 0)
+	a.notify("1", 1)
+
+	select {
+	case data := <-sem:
+		gobot.Assert(t, data.Count, 2)
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("Data was not published")
+	}
+	gobot.Assert(t, len(d.Halt()), 0)
+	gobot.Assert(t, a.notified("1"), false)
+}
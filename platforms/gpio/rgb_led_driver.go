@@ -0,0 +1,182 @@
+package gpio
+
+import (
+	"image/color"
+	"sync"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*RgbLedDriver)(nil)
+
+var _ Pixels = (*RgbLedDriver)(nil)
+
+// RgbLedDriver represents an RGB LED with its red, green and blue legs on
+// three PWM pins
+type RgbLedDriver struct {
+	name       string
+	pinRed     string
+	pinGreen   string
+	pinBlue    string
+	connection PwmWriter
+	mutex      sync.Mutex
+	color      color.RGBA
+	gobot.Eventer
+	gobot.Commander
+	*LedAnimator
+	// CommonAnode inverts the values written to the pins, for LEDs whose
+	// legs sink current from a shared positive leg
+	CommonAnode bool
+	// Gamma corrects colors before they are written, see GammaCorrect
+	Gamma float64
+}
+
+// NewRgbLedDriver returns a new RgbLedDriver given a PwmWriter, name and the
+// pins of the red, green and blue legs. The LED is common cathode unless
+// CommonAnode is set.
+//
+// Adds the following API Commands:
+//	"SetRGB" - See RgbLedDriver.SetRGB
+//	"SetHex" - See RgbLedDriver.SetHex
+//	"SetHSV" - See RgbLedDriver.SetHSV
+//	"Off" - See RgbLedDriver.Off
+//	"Animate" - See LedAnimator.AnimateEffect
+//	"StopAnimation" - See LedAnimator.StopAnimation
+//
+// Emits the Events:
+//	"finished" - the name of an effect which has finished
+//	"error" - an error writing a frame of an animation
+func NewRgbLedDriver(a PwmWriter, name string, redPin string, greenPin string, bluePin string) *RgbLedDriver {
+	l := &RgbLedDriver{
+		name:       name,
+		pinRed:     redPin,
+		pinGreen:   greenPin,
+		pinBlue:    bluePin,
+		connection: a,
+		Eventer:    gobot.NewEventer(),
+		Commander:  gobot.NewCommander(),
+	}
+	l.LedAnimator = NewLedAnimator(l, l.Eventer, l.Commander)
+
+	l.AddCommand("SetRGB", func(params map[string]interface{}) interface{} {
+		r := byte(params["red"].(float64))
+		g := byte(params["green"].(float64))
+		b := byte(params["blue"].(float64))
+		return l.SetRGB(r, g, b)
+	})
+	l.AddCommand("SetHex", func(params map[string]interface{}) interface{} {
+		return l.SetHex(params["hex"].(string))
+	})
+	l.AddCommand("SetHSV", func(params map[string]interface{}) interface{} {
+		h := params["hue"].(float64)
+		s := params["saturation"].(float64)
+		v := params["value"].(float64)
+		return l.SetHSV(h, s, v)
+	})
+	l.AddCommand("Off", func(params map[string]interface{}) interface{} {
+		return l.Off()
+	})
+
+	return l
+}
+
+// Name returns the RgbLedDrivers name
+func (l *RgbLedDriver) Name() string { return l.name }
+
+// RedPin returns the RgbLedDrivers red pin
+func (l *RgbLedDriver) RedPin() string { return l.pinRed }
+
+// GreenPin returns the RgbLedDrivers green pin
+func (l *RgbLedDriver) GreenPin() string { return l.pinGreen }
+
+// BluePin returns the RgbLedDrivers blue pin
+func (l *RgbLedDriver) BluePin() string { return l.pinBlue }
+
+// Connection returns the RgbLedDrivers connection
+func (l *RgbLedDriver) Connection() gobot.Connection {
+	return l.connection.(gobot.Connection)
+}
+
+// Start implements the Driver interface
+func (l *RgbLedDriver) Start() (errs []error) { return }
+
+// Halt stops any running animation
+func (l *RgbLedDriver) Halt() (errs []error) {
+	l.StopAnimation()
+	return
+}
+
+// Color returns the current color of the LED
+func (l *RgbLedDriver) Color() color.RGBA {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.color
+}
+
+// SetColor stops any running animation and sets the LED to c
+func (l *RgbLedDriver) SetColor(c color.RGBA) (err error) {
+	l.StopAnimation()
+	l.SetRGBA(0, c)
+	return l.Draw()
+}
+
+// SetRGB sets the LED to the color with red r, green g and blue b
+func (l *RgbLedDriver) SetRGB(r, g, b byte) (err error) {
+	return l.SetColor(color.RGBA{R: r, G: g, B: b, A: 255})
+}
+
+// SetHex sets the LED to a color written in hex, such as "#ff8800"
+func (l *RgbLedDriver) SetHex(hex string) (err error) {
+	c, err := ParseHexColor(hex)
+	if err != nil {
+		return
+	}
+	return l.SetColor(c)
+}
+
+// SetHSV sets the LED to the color of hue h in degrees, saturation s and
+// value v, both 0-1
+func (l *RgbLedDriver) SetHSV(h, s, v float64) (err error) {
+	return l.SetColor(HSV(h, s, v))
+}
+
+// Off turns the LED off
+func (l *RgbLedDriver) Off() (err error) {
+	return l.SetRGB(0, 0, 0)
+}
+
+// Len returns 1, the RgbLedDriver is a single LED
+func (l *RgbLedDriver) Len() int { return 1 }
+
+// SetRGBA sets the color of the LED, shown once Draw is called. The alpha
+// channel is ignored.
+func (l *RgbLedDriver) SetRGBA(i int, c color.RGBA) {
+	if i != 0 {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.color = c
+}
+
+// Draw writes the current color to the pins
+func (l *RgbLedDriver) Draw() (err error) {
+	c := GammaCorrect(l.Color(), l.Gamma)
+	for _, w := range []struct {
+		pin   string
+		level byte
+	}{
+		{l.pinRed, c.R},
+		{l.pinGreen, c.G},
+		{l.pinBlue, c.B},
+	} {
+		level := w.level
+		if l.CommonAnode {
+			level = 255 - level
+		}
+		if err = l.connection.PwmWrite(w.pin, level); err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,102 @@
+package gpio
+
+import (
+	"errors"
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func initTestRgbLedDriver() (*RgbLedDriver, *gpioTestPinWriter) {
+	a := newGpioTestPinWriter()
+	return NewRgbLedDriver(a, "bot", "1", "2", "3"), a
+}
+
+func TestRgbLedDriver(t *testing.T) {
+	d, _ := initTestRgbLedDriver()
+	gobot.Assert(t, d.Name(), "bot")
+	gobot.Assert(t, d.RedPin(), "1")
+	gobot.Assert(t, d.GreenPin(), "2")
+	gobot.Assert(t, d.BluePin(), "3")
+	gobot.Assert(t, d.Len(), 1)
+
+	d = NewRgbLedDriver(newGpioTestAdaptor("adaptor"), "bot", "1", "2", "3")
+	gobot.Assert(t, d.Connection().Name(), "adaptor")
+}
+
+func TestRgbLedDriverStart(t *testing.T) {
+	d, _ := initTestRgbLedDriver()
+	gobot.Assert(t, len(d.Start()), 0)
+}
+
+func TestRgbLedDriverHalt(t *testing.T) {
+	d, _ := initTestRgbLedDriver()
+	d.Animate(RainbowEffect(time.Second))
+	gobot.Assert(t, len(d.Halt()), 0)
+	gobot.Assert(t, d.Animating(), false)
+}
+
+func TestRgbLedDriverSetColor(t *testing.T) {
+	d, a := initTestRgbLedDriver()
+
+	gobot.Assert(t, d.SetRGB(10, 20, 30), nil)
+	gobot.Assert(t, d.Color(), color.RGBA{R: 10, G: 20, B: 30, A: 255})
+	gobot.Assert(t, d.SetHex("#ff8800"), nil)
+	gobot.Assert(t, d.SetHex("orange"), ErrInvalidColor)
+	gobot.Assert(t, d.SetHSV(240, 1, 1), nil)
+	gobot.Assert(t, d.Off(), nil)
+
+	gobot.Assert(t, a.history("1"), []byte{10, 255, 0, 0})
+	gobot.Assert(t, a.history("2"), []byte{20, 136, 0, 0})
+	gobot.Assert(t, a.history("3"), []byte{30, 0, 255, 0})
+}
+
+func TestRgbLedDriverCommonAnode(t *testing.T) {
+	d, a := initTestRgbLedDriver()
+	d.CommonAnode = true
+
+	gobot.Assert(t, d.SetRGB(255, 128, 0), nil)
+	gobot.Assert(t, a.history("1"), []byte{0})
+	gobot.Assert(t, a.history("2"), []byte{127})
+	gobot.Assert(t, a.history("3"), []byte{255})
+}
+
+func TestRgbLedDriverGamma(t *testing.T) {
+	d, a := initTestRgbLedDriver()
+	d.Gamma = 2
+
+	gobot.Assert(t, d.SetRGB(255, 128, 0), nil)
+	gobot.Assert(t, a.history("2"), []byte{64})
+	gobot.Assert(t, d.Color(), color.RGBA{R: 255, G: 128, A: 255})
+}
+
+func TestRgbLedDriverPwmWriteError(t *testing.T) {
+	d := NewRgbLedDriver(newGpioTestAdaptor("adaptor"), "bot", "1", "2", "3")
+	testAdaptorPwmWrite = func() (err error) {
+		return errors.New("pwm error")
+	}
+	defer func() { testAdaptorPwmWrite = func() (err error) { return nil } }()
+
+	gobot.Assert(t, d.SetRGB(1, 2, 3), errors.New("pwm error"))
+}
+
+func TestRgbLedDriverCommands(t *testing.T) {
+	d, a := initTestRgbLedDriver()
+
+	gobot.Assert(t, d.Command("SetRGB")(map[string]interface{}{
+		"red": 1.0, "green": 2.0, "blue": 3.0,
+	}), nil)
+	gobot.Assert(t, d.Command("SetHex")(map[string]interface{}{"hex": "#040506"}), nil)
+	gobot.Assert(t, d.Command("SetHSV")(map[string]interface{}{
+		"hue": 0.0, "saturation": 1.0, "value": 1.0,
+	}), nil)
+	gobot.Assert(t, d.Command("Off")(nil), nil)
+	gobot.Assert(t, a.history("1"), []byte{1, 4, 255, 0})
+
+	gobot.Assert(t, d.Command("Animate")(map[string]interface{}{"effect": "pulse"}), nil)
+	gobot.Assert(t, d.Animating(), true)
+	gobot.Assert(t, d.Command("StopAnimation")(nil), nil)
+	gobot.Assert(t, d.Animating(), false)
+}
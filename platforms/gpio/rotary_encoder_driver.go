@@ -0,0 +1,156 @@
+package gpio
+
+import (
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*RotaryEncoderDriver)(nil)
+
+// quadrature maps the previous and current AB state of an encoder, as
+// prev<<2 | cur, onto the step it took
+var quadrature = [16]int{0, -1, 1, 0, 1, 0, 0, -1, -1, 0, 0, 1, 0, 1, -1, 0}
+
+// RotaryEncoderData is the Data published by a RotaryEncoderDriver
+type RotaryEncoderData struct {
+	// Position is the number of counts turned since the start, 4 per cycle
+	Position int
+	// Velocity is the speed of the encoder in counts per second
+	Velocity float64
+}
+
+// RotaryEncoderDriver represents an incremental rotary encoder with two
+// phase quadrature outputs
+type RotaryEncoderDriver struct {
+	name         string
+	pinA         string
+	pinB         string
+	state        int
+	position     int
+	lastPosition int
+	lastReport   time.Time
+	velocity     float64
+	digitalWatcher
+	gobot.Eventer
+	gobot.Commander
+}
+
+// NewRotaryEncoderDriver returns a new RotaryEncoderDriver given a
+// DigitalReader, name and its A and B pins. If the DigitalReader is a
+// DigitalNotifier, the encoder is read whenever its pins change, otherwise
+// they are polled every Millisecond.
+//
+// Optionally accepts:
+// 	time.Duration: Interval at which the pins are polled
+//
+// Adds the following API Commands:
+// 	"Position" - See RotaryEncoderDriver.Position
+// 	"Velocity" - See RotaryEncoderDriver.Velocity
+// 	"Reset" - See RotaryEncoderDriver.Reset
+func NewRotaryEncoderDriver(a DigitalReader, name string, pinA string, pinB string, v ...time.Duration) *RotaryEncoderDriver {
+	interval := 1 * time.Millisecond
+	if len(v) > 0 {
+		interval = v[0]
+	}
+
+	r := &RotaryEncoderDriver{
+		name:           name,
+		pinA:           pinA,
+		pinB:           pinB,
+		state:          -1,
+		digitalWatcher: newDigitalWatcher(a, []string{pinA, pinB}, interval),
+		Eventer:        gobot.NewEventer(),
+		Commander:      gobot.NewCommander(),
+	}
+
+	r.AddEvent(Data)
+	r.AddEvent(Error)
+
+	r.AddCommand("Position", func(params map[string]interface{}) interface{} {
+		return r.Position()
+	})
+	r.AddCommand("Velocity", func(params map[string]interface{}) interface{} {
+		return r.Velocity()
+	})
+	r.AddCommand("Reset", func(params map[string]interface{}) interface{} {
+		r.Reset()
+		return nil
+	})
+
+	return r
+}
+
+// Name returns the RotaryEncoderDrivers name
+func (r *RotaryEncoderDriver) Name() string { return r.name }
+
+// Pins returns the RotaryEncoderDrivers A and B pins
+func (r *RotaryEncoderDriver) Pins() (string, string) { return r.pinA, r.pinB }
+
+// Connection returns the RotaryEncoderDrivers Connection
+func (r *RotaryEncoderDriver) Connection() gobot.Connection {
+	return r.connection.(gobot.Connection)
+}
+
+// Start starts reading the encoder.
+//
+// Emits the Events:
+// 	Data RotaryEncoderData - Every ReportInterval while the position or velocity changes
+//	Error error - On error reading the pins
+func (r *RotaryEncoderDriver) Start() (errs []error) {
+	r.lastReport = time.Now()
+	if err := r.start(r.Event(Error), r.sample, r.report); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Halt stops reading the encoder
+func (r *RotaryEncoderDriver) Halt() (errs []error) {
+	r.stop()
+	return
+}
+
+// Position returns the number of counts the encoder turned since it started
+// or was reset, negative when turned backward. There are 4 counts per cycle.
+func (r *RotaryEncoderDriver) Position() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.position
+}
+
+// Velocity returns the speed of the encoder in counts per second over the
+// last ReportInterval
+func (r *RotaryEncoderDriver) Velocity() float64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.velocity
+}
+
+// Reset sets the position of the encoder to 0
+func (r *RotaryEncoderDriver) Reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.position = 0
+	r.lastPosition = 0
+}
+
+// sample decodes the values of the A and B pins
+func (r *RotaryEncoderDriver) sample(vals []int, now time.Time) {
+	state := (vals[0]&1)<<1 | vals[1]&1
+	if r.state != -1 {
+		r.position += quadrature[r.state<<2|state]
+	}
+	r.state = state
+}
+
+// report updates the velocity and publishes Data if anything changed
+func (r *RotaryEncoderDriver) report(now time.Time) {
+	velocity := float64(r.position-r.lastPosition) / now.Sub(r.lastReport).Seconds()
+	changed := r.position != r.lastPosition || velocity != r.velocity
+	r.lastPosition, r.lastReport, r.velocity = r.position, now, velocity
+
+	if changed {
+		gobot.Publish(r.Event(Data), RotaryEncoderData{Position: r.position, Velocity: velocity})
+	}
+}
@@ -0,0 +1,136 @@
+package gpio
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func initTestRotaryEncoderDriver() *RotaryEncoderDriver {
+	return NewRotaryEncoderDriver(newGpioTestAdaptor("adaptor"), "encoder", "1", "2")
+}
+
+func TestRotaryEncoderDriver(t *testing.T) {
+	d := initTestRotaryEncoderDriver()
+	gobot.Assert(t, d.Name(), "encoder")
+	gobot.Assert(t, d.Connection().Name(), "adaptor")
+	a, b := d.Pins()
+	gobot.Assert(t, a, "1")
+	gobot.Assert(t, b, "2")
+	gobot.Assert(t, d.interval, 1*time.Millisecond)
+	gobot.Assert(t, d.ReportInterval, 100*time.Millisecond)
+
+	d = NewRotaryEncoderDriver(newGpioTestAdaptor("adaptor"), "encoder", "1", "2", 5*time.Millisecond)
+	gobot.Assert(t, d.interval, 5*time.Millisecond)
+
+	gobot.Assert(t, d.Command("Position")(nil), 0)
+	gobot.Assert(t, d.Command("Velocity")(nil), 0.0)
+}
+
+func TestRotaryEncoderDriverSample(t *testing.T) {
+	d := initTestRotaryEncoderDriver()
+	now := time.Now()
+
+	// one full cycle forward, A leading B
+	for _, v := range [][]int{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}} {
+		d.sample(v, now)
+	}
+	gobot.Assert(t, d.Position(), 4)
+
+	// half a cycle backward, B leading A
+	for _, v := range [][]int{{0, 1}, {1, 1}} {
+		d.sample(v, now)
+	}
+	gobot.Assert(t, d.Position(), 2)
+
+	// a repeated state or a skipped state does not count
+	d.sample([]int{1, 1}, now)
+	d.sample([]int{0, 0}, now)
+	gobot.Assert(t, d.Position(), 2)
+
+	d.Command("Reset")(nil)
+	gobot.Assert(t, d.Position(), 0)
+}
+
+func TestRotaryEncoderDriverReport(t *testing.T) {
+	d := initTestRotaryEncoderDriver()
+	sem := make(chan RotaryEncoderData, 1)
+	gobot.On(d.Event(Data), func(data interface{}) {
+		sem <- data.(RotaryEncoderData)
+	})
+
+	now := time.Now()
+	d.lastReport = now
+	for _, v := range [][]int{{0, 0}, {1, 0}, {1, 1}} {
+		d.sample(v, now)
+	}
+	d.report(now.Add(500 * time.Millisecond))
+	gobot.Assert(t, <-sem, RotaryEncoderData{Position: 2, Velocity: 4})
+	gobot.Assert(t, d.Velocity(), 4.0)
+
+	// the encoder stopped, so the velocity dropped to 0
+	d.report(now.Add(time.Second))
+	gobot.Assert(t, <-sem, RotaryEncoderData{Position: 2, Velocity: 0})
+
+	// nothing changed
+	d.report(now.Add(1500 * time.Millisecond))
+	select {
+	case data := <-sem:
+		t.Errorf("unexpected Data %v", data)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestRotaryEncoderDriverStart(t *testing.T) {
+	a := newGpioTestDigitalNotifier()
+	d := NewRotaryEncoderDriver(a, "encoder", "1", "2")
+	d.ReportInterval = 10 * time.Millisecond
+	sem := make(chan RotaryEncoderData, 10)
+	gobot.On(d.Event(Data), func(data interface{}) {
+		sem <- data.(RotaryEncoderData)
+	})
+
+	gobot.Assert(t, len(d.Start()), 0)
+	a.set("1", 1)
+	a.set("2", 1)
+	// the value given to the callback is used, not read again
+	a.notify("1", 0)
+
+	select {
+	case data := <-sem:
+		gobot.Assert(t, data.Position, 3)
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("Data was not published")
+	}
+	gobot.Assert(t, len(d.Halt()), 0)
+	gobot.Assert(t, a.notified("1"), false)
+	gobot.Assert(t, a.notified("2"), false)
+}
+
+func TestRotaryEncoderDriverStartError(t *testing.T) {
+	d := initTestRotaryEncoderDriver()
+	sem := make(chan error, 1)
+	gobot.Once(d.Event(Error), func(data interface{}) {
+		sem <- data.(error)
+	})
+
+	testAdaptorDigitalRead = func() (val int, err error) {
+		return 0, errors.New("digital read error")
+	}
+	defer func() {
+		testAdaptorDigitalRead = func() (val int, err error) {
+			return 1, nil
+		}
+	}()
+
+	gobot.Assert(t, len(d.Start()), 0)
+	select {
+	case err := <-sem:
+		gobot.Assert(t, err, errors.New("digital read error"))
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("Error was not published")
+	}
+	gobot.Assert(t, len(d.Halt()), 0)
+}
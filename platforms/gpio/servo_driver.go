@@ -1,9 +1,30 @@
 package gpio
 
-import "github.com/hybridgroup/gobot"
+import (
+	"math"
+
+	"github.com/hybridgroup/gobot"
+)
 
 var _ gobot.Driver = (*ServoDriver)(nil)
 
+// ServoCalibration describes the travel of a servo
+type ServoCalibration struct {
+	// MinAngle and MaxAngle limit the angles the servo may be moved to
+	MinAngle, MaxAngle byte
+	// MinPulse and MaxPulse are the values written to the ServoWriter to move
+	// the servo to 0 and 180 degrees. Adjust them when the servo does not
+	// travel the full 180 degrees over the pulse range of the adaptor, or
+	// swap them to reverse the servo.
+	MinPulse, MaxPulse byte
+}
+
+// DefaultServoCalibration returns the calibration of a standard servo, which
+// travels 0-180 degrees over the pulse range of the adaptor
+func DefaultServoCalibration() ServoCalibration {
+	return ServoCalibration{MinAngle: 0, MaxAngle: 180, MinPulse: 0, MaxPulse: 180}
+}
+
 // ServoDriver Represents a Servo
 type ServoDriver struct {
 	name       string
@@ -11,6 +32,7 @@ type ServoDriver struct {
 	connection ServoWriter
 	gobot.Commander
 	CurrentAngle byte
+	Calibration  ServoCalibration
 }
 
 // NewServoDriver returns a new ServoDriver given a ServoWriter, name and pin.
@@ -20,6 +42,7 @@ type ServoDriver struct {
 //	"Min" - See ServoDriver.Min
 //	"Center" - See ServoDriver.Center
 //	"Max" - See ServoDriver.Max
+//	"MoveSmooth" - See ServoDriver.MoveSmooth
 func NewServoDriver(a ServoWriter, name string, pin string) *ServoDriver {
 	s := &ServoDriver{
 		name:         name,
@@ -27,6 +50,7 @@ func NewServoDriver(a ServoWriter, name string, pin string) *ServoDriver {
 		pin:          pin,
 		Commander:    gobot.NewCommander(),
 		CurrentAngle: 0,
+		Calibration:  DefaultServoCalibration(),
 	}
 
 	s.AddCommand("Move", func(params map[string]interface{}) interface{} {
@@ -42,6 +66,17 @@ func NewServoDriver(a ServoWriter, name string, pin string) *ServoDriver {
 	s.AddCommand("Max", func(params map[string]interface{}) interface{} {
 		return s.Max()
 	})
+	s.AddCommand("MoveSmooth", func(params map[string]interface{}) interface{} {
+		angle := byte(params["angle"].(float64))
+		p := MotionProfile{}
+		if speed, ok := params["speed"]; ok {
+			p.Speed = speed.(float64)
+		}
+		if acceleration, ok := params["acceleration"]; ok {
+			p.Acceleration = acceleration.(float64)
+		}
+		return s.MoveSmooth(angle, p)
+	})
 
 	return s
 
@@ -62,30 +97,52 @@ func (s *ServoDriver) Start() (errs []error) { return }
 // Halt implements the Driver interface
 func (s *ServoDriver) Halt() (errs []error) { return }
 
-// Move sets the servo to the specified angle. Acceptable angles are 0-180
+// Move sets the servo to the specified angle. Acceptable angles are 0-180,
+// or within the MinAngle and MaxAngle of the servo's Calibration
 func (s *ServoDriver) Move(angle uint8) (err error) {
-	if !(angle >= 0 && angle <= 180) {
+	if !s.inRange(angle) {
 		return ErrServoOutOfRange
 	}
 	s.CurrentAngle = angle
 	return s.connection.ServoWrite(s.Pin(), s.angleToSpan(angle))
 }
 
-// Min sets the servo to it's minimum position
+// MoveSmooth moves the servo from its current angle to the specified angle
+// following the MotionProfile p, and returns once the servo has arrived.
+func (s *ServoDriver) MoveSmooth(angle uint8, p MotionProfile) (err error) {
+	if !s.inRange(angle) {
+		return ErrServoOutOfRange
+	}
+	from, to := float64(s.CurrentAngle), float64(angle)
+	total, shape := p.plan(math.Abs(to - from))
+	return runServoMoves(
+		[]servoMove{{servo: s, from: from, to: to, shape: shape}},
+		total,
+		p.step(),
+	)
+}
+
+// Min sets the servo to it's minimum position, the MinAngle of its Calibration
 func (s *ServoDriver) Min() (err error) {
-	return s.Move(0)
+	return s.Move(s.Calibration.MinAngle)
 }
 
-// Center sets the servo to it's center position
+// Center sets the servo to it's center position, the middle of the MinAngle
+// and MaxAngle of its Calibration
 func (s *ServoDriver) Center() (err error) {
-	return s.Move(90)
+	return s.Move(byte((int(s.Calibration.MinAngle) + int(s.Calibration.MaxAngle)) / 2))
 }
 
-// Max sets the servo to its maximum position
+// Max sets the servo to its maximum position, the MaxAngle of its Calibration
 func (s *ServoDriver) Max() (err error) {
-	return s.Move(180)
+	return s.Move(s.Calibration.MaxAngle)
+}
+
+func (s *ServoDriver) inRange(angle byte) bool {
+	return angle >= s.Calibration.MinAngle && angle <= s.Calibration.MaxAngle && angle <= 180
 }
 
 func (s *ServoDriver) angleToSpan(angle byte) byte {
-	return byte(angle * (255 / 180))
+	min, max := float64(s.Calibration.MinPulse), float64(s.Calibration.MaxPulse)
+	return byte(math.Floor(min + (max-min)*float64(angle)/180 + 0.5))
 }
@@ -2,7 +2,9 @@ package gpio
 
 import (
 	"errors"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/hybridgroup/gobot"
 )
@@ -73,3 +75,99 @@ func TestServoDriverCenter(t *testing.T) {
 	d.Center()
 	gobot.Assert(t, d.CurrentAngle, uint8(90))
 }
+
+func TestServoDriverCalibration(t *testing.T) {
+	a := newGpioTestServoWriter()
+	d := NewServoDriver(a, "bot", "1")
+	d.Calibration = ServoCalibration{MinAngle: 20, MaxAngle: 160, MinPulse: 180, MaxPulse: 0}
+
+	gobot.Assert(t, d.Move(10), ErrServoOutOfRange)
+	gobot.Assert(t, d.Move(170), ErrServoOutOfRange)
+
+	d.Min()
+	gobot.Assert(t, d.CurrentAngle, uint8(20))
+	d.Center()
+	d.Max()
+	gobot.Assert(t, d.CurrentAngle, uint8(160))
+	gobot.Assert(t, a.writes["1"], []byte{160, 90, 20})
+
+	d.Calibration = ServoCalibration{MinAngle: 100, MaxAngle: 180, MinPulse: 0, MaxPulse: 180}
+	gobot.Assert(t, d.Center(), nil)
+	gobot.Assert(t, d.CurrentAngle, uint8(140))
+
+	a.writes["1"] = []byte{}
+	d.Calibration = ServoCalibration{MinAngle: 0, MaxAngle: 180, MinPulse: 10, MaxPulse: 170}
+	d.Move(0)
+	d.Move(90)
+	d.Move(180)
+	gobot.Assert(t, a.writes["1"], []byte{10, 90, 170})
+}
+
+func TestServoDriverMoveSmooth(t *testing.T) {
+	a := newGpioTestServoWriter()
+	d := NewServoDriver(a, "bot", "1")
+
+	// without a profile the servo jumps to the target
+	gobot.Assert(t, d.MoveSmooth(90, MotionProfile{}), nil)
+	gobot.Assert(t, a.writes["1"], []byte{90})
+
+	start := time.Now()
+	err := d.MoveSmooth(100, MotionProfile{Speed: 200, Step: time.Millisecond})
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, time.Since(start) >= 50*time.Millisecond, true)
+	gobot.Assert(t, d.CurrentAngle, uint8(100))
+
+	// the servo steps towards the target one degree at a time
+	writes := a.writes["1"][1:]
+	gobot.Assert(t, len(writes) > 2, true)
+	for i := 1; i < len(writes); i++ {
+		gobot.Assert(t, writes[i] > writes[i-1], true)
+	}
+	gobot.Assert(t, writes[len(writes)-1], uint8(100))
+
+	gobot.Assert(t, d.MoveSmooth(200, MotionProfile{}), ErrServoOutOfRange)
+
+	ret := d.Command("MoveSmooth")(map[string]interface{}{"angle": 95.0, "speed": 1000.0})
+	gobot.Assert(t, ret, nil)
+	gobot.Assert(t, d.CurrentAngle, uint8(95))
+}
+
+func TestMotionProfilePlan(t *testing.T) {
+	total, shape := MotionProfile{Speed: 90}.plan(45)
+	gobot.Assert(t, total, 500*time.Millisecond)
+	gobot.Assert(t, shape(0.5), 0.5)
+
+	// trapezoid: 1s to reach 90°/s over 45°, 1s at top speed, 1s to stop
+	total, shape = MotionProfile{Speed: 90, Acceleration: 90}.plan(180)
+	gobot.Assert(t, total, 3*time.Second)
+	gobot.Assert(t, shape(0), 0.0)
+	gobot.Assert(t, shape(1.0/3), 0.25)
+	gobot.Assert(t, shape(0.5), 0.5)
+	gobot.Assert(t, shape(2.0/3), 0.75)
+	gobot.Assert(t, shape(1), 1.0)
+
+	// triangle: top speed is never reached
+	total, shape = MotionProfile{Speed: 90, Acceleration: 90}.plan(40)
+	gobot.Assert(t, total, time.Duration(2*math.Sqrt(40.0/90)*float64(time.Second)))
+	gobot.Assert(t, shape(0.5), 0.5)
+	gobot.Assert(t, shape(0.25), 0.125)
+
+	total, shape = MotionProfile{Duration: time.Second, Easing: EaseInOut}.plan(90)
+	gobot.Assert(t, total, time.Second)
+	gobot.Assert(t, math.Abs(shape(0.5)-0.5) < 1e-9, true)
+
+	total, _ = MotionProfile{Speed: 90, Duration: 2 * time.Second}.plan(90)
+	gobot.Assert(t, total, 2*time.Second)
+
+	total, _ = MotionProfile{Duration: time.Second}.plan(0)
+	gobot.Assert(t, total, time.Duration(0))
+}
+
+func TestEasing(t *testing.T) {
+	for _, e := range []Easing{Linear, EaseIn, EaseOut, EaseInOut} {
+		gobot.Assert(t, math.Abs(e(0)) < 1e-9, true)
+		gobot.Assert(t, math.Abs(e(1)-1) < 1e-9, true)
+	}
+	gobot.Assert(t, EaseIn(0.5) < 0.5, true)
+	gobot.Assert(t, EaseOut(0.5) > 0.5, true)
+}
@@ -0,0 +1,64 @@
+package gpio
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrInvalidPose is the error resulting when a pose does not hold one angle
+// for every servo of a ServoGroup
+var ErrInvalidPose = errors.New("pose must hold one angle per servo")
+
+// ServoGroup moves several servos at once, so that they start and arrive
+// together, eg. the joints of a robotic arm.
+type ServoGroup struct {
+	servos []*ServoDriver
+	// Profile is the MotionProfile every servo of the group keeps within
+	Profile MotionProfile
+}
+
+// NewServoGroup returns a new ServoGroup given a MotionProfile and its servos
+func NewServoGroup(p MotionProfile, servos ...*ServoDriver) *ServoGroup {
+	return &ServoGroup{servos: servos, Profile: p}
+}
+
+// Servos returns the servos of the ServoGroup
+func (g *ServoGroup) Servos() []*ServoDriver { return g.servos }
+
+// Pose returns the current angle of every servo of the ServoGroup
+func (g *ServoGroup) Pose() []uint8 {
+	pose := make([]uint8, len(g.servos))
+	for i, s := range g.servos {
+		pose[i] = s.CurrentAngle
+	}
+	return pose
+}
+
+// Move moves every servo of the ServoGroup to its angle in pose and returns
+// once they have arrived. The move takes as long as the servo with the longest
+// move needs within the Profile, the other servos slow down to match it.
+func (g *ServoGroup) Move(pose ...uint8) (err error) {
+	if len(pose) != len(g.servos) {
+		return ErrInvalidPose
+	}
+
+	moves := make([]servoMove, len(g.servos))
+	plans := make([]float64, len(g.servos))
+	var longest float64
+	for i, s := range g.servos {
+		if !s.inRange(pose[i]) {
+			return ErrServoOutOfRange
+		}
+		moves[i] = servoMove{servo: s, from: float64(s.CurrentAngle), to: float64(pose[i])}
+		plans[i] = math.Abs(moves[i].to - moves[i].from)
+		if plans[i] > longest {
+			longest = plans[i]
+		}
+	}
+
+	total, _ := g.Profile.plan(longest)
+	for i := range moves {
+		_, moves[i].shape = g.Profile.plan(plans[i])
+	}
+	return runServoMoves(moves, total, g.Profile.step())
+}
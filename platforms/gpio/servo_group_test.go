@@ -0,0 +1,33 @@
+package gpio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestServoGroupMove(t *testing.T) {
+	a := newGpioTestServoWriter()
+	shoulder := NewServoDriver(a, "shoulder", "1")
+	elbow := NewServoDriver(a, "elbow", "2")
+	g := NewServoGroup(MotionProfile{Speed: 1000, Step: time.Millisecond}, shoulder, elbow)
+
+	gobot.Assert(t, len(g.Servos()), 2)
+	gobot.Assert(t, g.Pose(), []uint8{0, 0})
+
+	start := time.Now()
+	gobot.Assert(t, g.Move(100, 20), nil)
+	gobot.Assert(t, time.Since(start) >= 100*time.Millisecond, true)
+	gobot.Assert(t, g.Pose(), []uint8{100, 20})
+
+	// the elbow moves slower than the shoulder, so both arrive together
+	shoulders, elbows := a.writes["1"], a.writes["2"]
+	gobot.Assert(t, len(shoulders) > len(elbows), true)
+	gobot.Assert(t, shoulders[len(shoulders)-1], uint8(100))
+	gobot.Assert(t, elbows[len(elbows)-1], uint8(20))
+
+	gobot.Assert(t, g.Move(10), ErrInvalidPose)
+	gobot.Assert(t, g.Move(10, 200), ErrServoOutOfRange)
+	gobot.Assert(t, g.Pose(), []uint8{100, 20})
+}
@@ -0,0 +1,146 @@
+package gpio
+
+import (
+	"math"
+	"time"
+)
+
+// DefaultServoStep is the interval at which servos are updated during a
+// smooth move, the period of a standard servo signal
+const DefaultServoStep = 20 * time.Millisecond
+
+// Easing maps the progress of a move through time, from 0 to 1, onto the
+// progress of the servo towards its target, from 0 to 1
+type Easing func(t float64) float64
+
+// Linear moves at constant speed
+func Linear(t float64) float64 { return t }
+
+// EaseIn starts slowly and speeds up towards the end
+func EaseIn(t float64) float64 { return t * t * t }
+
+// EaseOut starts fast and slows down towards the end
+func EaseOut(t float64) float64 { return 1 - EaseIn(1-t) }
+
+// EaseInOut speeds up from the start and slows down towards the end
+func EaseInOut(t float64) float64 { return (1 - math.Cos(math.Pi*t)) / 2 }
+
+// MotionProfile describes how a servo moves from its current angle to a new
+// one. Without Easing, the servo follows a trapezoidal profile: it speeds up at
+// Acceleration until it reaches Speed, keeps that speed, then slows down at
+// Acceleration to stop at the target. A zero Speed or Acceleration is unlimited,
+// the zero MotionProfile jumps straight to the target like ServoDriver.Move.
+type MotionProfile struct {
+	// Speed is the top speed of the servo in degrees per second
+	Speed float64
+	// Acceleration is the acceleration and deceleration of the servo in
+	// degrees per second per second. It is ignored if Easing is set.
+	Acceleration float64
+	// Duration is the shortest time a move takes
+	Duration time.Duration
+	// Easing shapes the move instead of the trapezoidal profile. The move
+	// takes Duration, or longer if needed to stay below Speed on average.
+	Easing Easing
+	// Step is the interval between servo updates, DefaultServoStep if zero
+	Step time.Duration
+}
+
+// plan returns how long a move of distance degrees takes and the shape of
+// the move, as an Easing
+func (p MotionProfile) plan(distance float64) (time.Duration, Easing) {
+	var secs float64
+	shape := Easing(Linear)
+
+	switch {
+	case distance == 0:
+	case p.Easing != nil:
+		shape = p.Easing
+		if p.Speed > 0 {
+			secs = distance / p.Speed
+		}
+	case p.Acceleration > 0:
+		v, a := p.Speed, p.Acceleration
+		accelDist := v * v / (2 * a)
+		if v == 0 || 2*accelDist >= distance {
+			// triangular profile, top speed is never reached
+			secs = 2 * math.Sqrt(distance/a)
+			shape = func(t float64) float64 {
+				if t < 0.5 {
+					return 2 * t * t
+				}
+				return 1 - 2*(1-t)*(1-t)
+			}
+		} else {
+			accelTime := v / a
+			secs = 2*accelTime + (distance-2*accelDist)/v
+			total := secs
+			shape = func(t float64) float64 {
+				t *= total
+				switch {
+				case t < accelTime:
+					return a * t * t / 2 / distance
+				case t < total-accelTime:
+					return (accelDist + v*(t-accelTime)) / distance
+				default:
+					r := total - t
+					return 1 - a*r*r/2/distance
+				}
+			}
+		}
+	case p.Speed > 0:
+		secs = distance / p.Speed
+	}
+
+	d := time.Duration(secs * float64(time.Second))
+	if d < p.Duration && distance != 0 {
+		// stretching a trapezoid keeps it within its speed and acceleration
+		d = p.Duration
+	}
+	return d, shape
+}
+
+func (p MotionProfile) step() time.Duration {
+	if p.Step == 0 {
+		return DefaultServoStep
+	}
+	return p.Step
+}
+
+// servoMove is a planned move of a single servo
+type servoMove struct {
+	servo *ServoDriver
+	from  float64
+	to    float64
+	shape Easing
+}
+
+// angle returns where the servo should be when a move of duration total has
+// been under way for elapsed
+func (m servoMove) angle(elapsed, total time.Duration) uint8 {
+	if elapsed >= total {
+		return uint8(m.to)
+	}
+	progress := m.shape(float64(elapsed) / float64(total))
+	return uint8(math.Floor(m.from + (m.to-m.from)*progress + 0.5))
+}
+
+// runServoMoves steps every move together so they all arrive after total
+func runServoMoves(moves []servoMove, total time.Duration, step time.Duration) (err error) {
+	start := time.Now()
+	for {
+		elapsed := time.Since(start)
+		for _, m := range moves {
+			angle := m.angle(elapsed, total)
+			if angle == m.servo.CurrentAngle {
+				continue
+			}
+			if err = m.servo.Move(angle); err != nil {
+				return
+			}
+		}
+		if elapsed >= total {
+			return
+		}
+		time.Sleep(step)
+	}
+}
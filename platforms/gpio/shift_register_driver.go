@@ -0,0 +1,181 @@
+package gpio
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*ShiftRegisterDriver)(nil)
+
+var _ DigitalWriter = (*ShiftRegisterDriver)(nil)
+
+// ErrInvalidShiftRegisterPin is the error resulting when a pin which is not
+// an output of the ShiftRegisterDriver is written to
+var ErrInvalidShiftRegisterPin = errors.New("pin is not an output of the shift register")
+
+// ShiftRegisterDriver represents a chain of 74HC595 shift registers. It is
+// also a DigitalWriter whose pins "0", "1"... are the outputs of the
+// registers, so other drivers can be connected to them.
+type ShiftRegisterDriver struct {
+	name       string
+	dataPin    string
+	clockPin   string
+	latchPin   string
+	connection DigitalWriter
+	mutex      sync.Mutex
+	outputs    []byte
+	gobot.Commander
+}
+
+// NewShiftRegisterDriver returns a new ShiftRegisterDriver given a
+// DigitalWriter, name and the pins connected to the data (DS), clock (SHCP)
+// and latch (STCP) inputs of the first register.
+//
+// Optionally accepts:
+//  int: number of chained registers, 1 by default
+//
+// Adds the following API Commands:
+//	"DigitalWrite" - See ShiftRegisterDriver.DigitalWrite
+//	"Write" - See ShiftRegisterDriver.Write, the bytes are given as "data"
+//	"Clear" - See ShiftRegisterDriver.Clear
+func NewShiftRegisterDriver(a DigitalWriter, name string, dataPin string, clockPin string, latchPin string, v ...int) *ShiftRegisterDriver {
+	count := 1
+	if len(v) > 0 && v[0] > 0 {
+		count = v[0]
+	}
+	s := &ShiftRegisterDriver{
+		name:       name,
+		dataPin:    dataPin,
+		clockPin:   clockPin,
+		latchPin:   latchPin,
+		connection: a,
+		outputs:    make([]byte, count),
+		Commander:  gobot.NewCommander(),
+	}
+
+	s.AddCommand("DigitalWrite", func(params map[string]interface{}) interface{} {
+		pin := params["pin"].(string)
+		level := byte(params["level"].(float64))
+		return s.DigitalWrite(pin, level)
+	})
+	s.AddCommand("Write", func(params map[string]interface{}) interface{} {
+		data := []byte{}
+		for _, b := range params["data"].([]interface{}) {
+			data = append(data, byte(b.(float64)))
+		}
+		return s.Write(data)
+	})
+	s.AddCommand("Clear", func(params map[string]interface{}) interface{} {
+		return s.Clear()
+	})
+
+	return s
+}
+
+// Name returns the ShiftRegisterDrivers name
+func (s *ShiftRegisterDriver) Name() string { return s.name }
+
+// Connection returns the ShiftRegisterDrivers connection
+func (s *ShiftRegisterDriver) Connection() gobot.Connection {
+	return s.connection.(gobot.Connection)
+}
+
+// Start sets every output low
+func (s *ShiftRegisterDriver) Start() (errs []error) {
+	if err := s.Clear(); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Halt implements the Driver interface
+func (s *ShiftRegisterDriver) Halt() (errs []error) { return }
+
+// Connect implements the Adaptor interface, the ShiftRegisterDriver is
+// connected once its connection is
+func (s *ShiftRegisterDriver) Connect() (errs []error) { return }
+
+// Finalize implements the Adaptor interface
+func (s *ShiftRegisterDriver) Finalize() (errs []error) { return }
+
+// Len returns the number of outputs
+func (s *ShiftRegisterDriver) Len() int { return 8 * len(s.outputs) }
+
+// Outputs returns the levels of the outputs, one byte per register with
+// output Q0 as bit 0
+func (s *ShiftRegisterDriver) Outputs() []byte {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]byte{}, s.outputs...)
+}
+
+// DigitalWrite sets output pin, "0" to "7" on the first register, "8" to
+// "15" on the second and so on, to level
+func (s *ShiftRegisterDriver) DigitalWrite(pin string, level byte) (err error) {
+	p, err := strconv.Atoi(pin)
+	if err != nil || p < 0 || p >= s.Len() {
+		return ErrInvalidShiftRegisterPin
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if level == 0 {
+		s.outputs[p/8] &^= 1 << uint(p%8)
+	} else {
+		s.outputs[p/8] |= 1 << uint(p%8)
+	}
+	return s.latch()
+}
+
+// Write sets the outputs of the registers to data, one byte per register
+// starting with the first, with output Q0 as bit 0
+func (s *ShiftRegisterDriver) Write(data []byte) (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	copy(s.outputs, data)
+	return s.latch()
+}
+
+// Clear sets every output low
+func (s *ShiftRegisterDriver) Clear() (err error) {
+	return s.Write(make([]byte, len(s.outputs)))
+}
+
+// latch shifts the outputs into the registers, the last register first, and
+// latches them onto the output pins
+func (s *ShiftRegisterDriver) latch() (err error) {
+	data := make([]byte, len(s.outputs))
+	for i, b := range s.outputs {
+		data[len(data)-1-i] = b
+	}
+
+	if err = s.connection.DigitalWrite(s.latchPin, 0); err != nil {
+		return
+	}
+	if err = shiftOut(s.connection, s.dataPin, s.clockPin, data); err != nil {
+		return
+	}
+	return s.connection.DigitalWrite(s.latchPin, 1)
+}
+
+// shiftOut clocks data out on dataPin, most significant bit first, pulsing
+// clockPin high for each bit
+func shiftOut(w DigitalWriter, dataPin string, clockPin string, data []byte) (err error) {
+	for _, b := range data {
+		for i := 7; i >= 0; i-- {
+			if err = w.DigitalWrite(dataPin, (b>>uint(i))&1); err != nil {
+				return
+			}
+			if err = w.DigitalWrite(clockPin, 1); err != nil {
+				return
+			}
+			if err = w.DigitalWrite(clockPin, 0); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,82 @@
+package gpio
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func initTestShiftRegisterDriver(v ...int) (*ShiftRegisterDriver, *gpioTestShiftReceiver) {
+	a := &gpioTestShiftReceiver{}
+	return NewShiftRegisterDriver(a, "bot", "d", "c", "l", v...), a
+}
+
+func TestShiftRegisterDriver(t *testing.T) {
+	d, _ := initTestShiftRegisterDriver()
+	gobot.Assert(t, d.Name(), "bot")
+	gobot.Assert(t, d.Connection().Name(), "")
+	gobot.Assert(t, d.Len(), 8)
+	gobot.Assert(t, len(d.Halt()), 0)
+	gobot.Assert(t, len(d.Connect()), 0)
+	gobot.Assert(t, len(d.Finalize()), 0)
+
+	d, _ = initTestShiftRegisterDriver(3)
+	gobot.Assert(t, d.Len(), 24)
+}
+
+func TestShiftRegisterDriverStart(t *testing.T) {
+	d, a := initTestShiftRegisterDriver(2)
+	gobot.Assert(t, len(d.Start()), 0)
+	gobot.Assert(t, a.frames, [][]byte{{0, 0}})
+
+	d = NewShiftRegisterDriver(newGpioTestAdaptor("adaptor"), "bot", "d", "c", "l")
+	testAdaptorDigitalWrite = func() (err error) {
+		return errors.New("write error")
+	}
+	defer func() { testAdaptorDigitalWrite = func() (err error) { return nil } }()
+	gobot.Assert(t, d.Start()[0], errors.New("write error"))
+}
+
+func TestShiftRegisterDriverDigitalWrite(t *testing.T) {
+	d, a := initTestShiftRegisterDriver(2)
+
+	gobot.Assert(t, d.DigitalWrite("0", 1), nil)
+	gobot.Assert(t, d.DigitalWrite("9", 1), nil)
+	gobot.Assert(t, d.DigitalWrite("15", 1), nil)
+	gobot.Assert(t, d.DigitalWrite("0", 0), nil)
+	gobot.Assert(t, d.Outputs(), []byte{0x00, 0x82})
+
+	// the last register is shifted out first
+	gobot.Assert(t, a.frames, [][]byte{
+		{0x00, 0x01},
+		{0x02, 0x01},
+		{0x82, 0x01},
+		{0x82, 0x00},
+	})
+
+	gobot.Assert(t, d.DigitalWrite("16", 1), ErrInvalidShiftRegisterPin)
+	gobot.Assert(t, d.DigitalWrite("a", 1), ErrInvalidShiftRegisterPin)
+}
+
+func TestShiftRegisterDriverLed(t *testing.T) {
+	d, a := initTestShiftRegisterDriver()
+	led := NewLedDriver(d, "led", "3")
+
+	gobot.Assert(t, led.On(), nil)
+	gobot.Assert(t, led.Toggle(), nil)
+	gobot.Assert(t, a.frames, [][]byte{{0x08}, {0x00}})
+}
+
+func TestShiftRegisterDriverCommands(t *testing.T) {
+	d, a := initTestShiftRegisterDriver(2)
+
+	gobot.Assert(t, d.Command("Write")(map[string]interface{}{
+		"data": []interface{}{1.0, 2.0},
+	}), nil)
+	gobot.Assert(t, d.Command("DigitalWrite")(map[string]interface{}{
+		"pin": "2", "level": 1.0,
+	}), nil)
+	gobot.Assert(t, d.Command("Clear")(nil), nil)
+	gobot.Assert(t, a.frames, [][]byte{{2, 1}, {2, 5}, {0, 0}})
+}
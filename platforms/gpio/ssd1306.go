@@ -0,0 +1,353 @@
+package gpio
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"sync"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ draw.Image = (*SSD1306)(nil)
+
+// SSD1306 rotations, clockwise
+const (
+	SSD1306Rotate0   = 0
+	SSD1306Rotate90  = 90
+	SSD1306Rotate180 = 180
+	SSD1306Rotate270 = 270
+)
+
+// SSD1306 commands
+const (
+	ssd1306DisplayOff    = 0xAE
+	ssd1306DisplayOn     = 0xAF
+	ssd1306ClockDivide   = 0xD5
+	ssd1306Multiplex     = 0xA8
+	ssd1306Offset        = 0xD3
+	ssd1306StartLine     = 0x40
+	ssd1306ChargePump    = 0x8D
+	ssd1306MemoryMode    = 0x20
+	ssd1306SegmentRemap  = 0xA1
+	ssd1306ScanDown      = 0xC8
+	ssd1306ComPins       = 0xDA
+	ssd1306Contrast      = 0x81
+	ssd1306Precharge     = 0xD9
+	ssd1306Vcomh         = 0xDB
+	ssd1306ResumeRAM     = 0xA4
+	ssd1306Normal        = 0xA6
+	ssd1306Inverse       = 0xA7
+	ssd1306ColumnAddress = 0x21
+	ssd1306PageAddress   = 0x22
+)
+
+// SSD1306Model converts colors to those of an SSD1306, white when they are
+// at least half bright and black otherwise
+var SSD1306Model = color.ModelFunc(func(c color.Color) color.Color {
+	if ssd1306Lit(c) {
+		return color.White
+	}
+	return color.Black
+})
+
+// SSD1306Bus is the way an SSD1306 OLED controller is wired to the SSD1306
+// which drives it
+type SSD1306Bus interface {
+	// Command writes commands and their arguments
+	Command(c ...byte) error
+	// Data writes bytes to the display RAM
+	Data(d []byte) error
+}
+
+// SSD1306 drives an SSD1306 monochrome OLED controller over an SSD1306Bus.
+// It is also a draw.Image, a framebuffer which can be drawn on with the
+// image and image/draw packages and is written to the display by Flush,
+// which only writes what has changed since the last Flush.
+type SSD1306 struct {
+	bus      SSD1306Bus
+	mutex    sync.Mutex
+	width    int
+	height   int
+	rotation int
+	buffer   []byte
+	// the changed columns and pages, empty when min > max
+	minCol, maxCol   int
+	minPage, maxPage int
+}
+
+// NewSSD1306 returns a new SSD1306 given the bus it is wired to and the
+// width and height of the display in pixels, such as 128x64 or 128x32
+func NewSSD1306(bus SSD1306Bus, width int, height int) *SSD1306 {
+	s := &SSD1306{
+		bus:    bus,
+		width:  width,
+		height: height,
+		buffer: make([]byte, width*((height+7)/8)),
+	}
+	s.changeAll()
+	return s
+}
+
+// Init sets the display up for its size, with its charge pump on, and turns
+// it on. The framebuffer is written on the next Flush.
+func (s *SSD1306) Init() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	comPins := byte(0x02)
+	if s.height > 32 {
+		comPins = 0x12
+	}
+	s.changeAll()
+	return s.bus.Command(
+		ssd1306DisplayOff,
+		ssd1306ClockDivide, 0x80,
+		ssd1306Multiplex, byte(s.height-1),
+		ssd1306Offset, 0x00,
+		ssd1306StartLine,
+		ssd1306ChargePump, 0x14,
+		ssd1306MemoryMode, 0x00,
+		ssd1306SegmentRemap,
+		ssd1306ScanDown,
+		ssd1306ComPins, comPins,
+		ssd1306Contrast, 0xCF,
+		ssd1306Precharge, 0xF1,
+		ssd1306Vcomh, 0x40,
+		ssd1306ResumeRAM,
+		ssd1306Normal,
+		ssd1306DisplayOn,
+	)
+}
+
+// AddCommands adds the API Commands of the SSD1306 drivers to c:
+// 	"Clear" - See SSD1306.Clear
+// 	"Flush" - See SSD1306.Flush
+// 	"DrawText" - See SSD1306.DrawText, given "x", "y" and "text"
+// 	"SetContrast" - See SSD1306.SetContrast, given "contrast"
+func (s *SSD1306) AddCommands(c gobot.Commander) {
+	c.AddCommand("Clear", func(params map[string]interface{}) interface{} {
+		s.Clear()
+		return nil
+	})
+	c.AddCommand("Flush", func(params map[string]interface{}) interface{} {
+		return s.Flush()
+	})
+	c.AddCommand("DrawText", func(params map[string]interface{}) interface{} {
+		s.DrawText(int(params["x"].(float64)), int(params["y"].(float64)), params["text"].(string))
+		return nil
+	})
+	c.AddCommand("SetContrast", func(params map[string]interface{}) interface{} {
+		return s.SetContrast(byte(params["contrast"].(float64)))
+	})
+}
+
+// ColorModel implements draw.Image, see SSD1306Model
+func (s *SSD1306) ColorModel() color.Model { return SSD1306Model }
+
+// Bounds implements draw.Image, the width and height of the display as
+// rotated
+func (s *SSD1306) Bounds() image.Rectangle {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.rotation == SSD1306Rotate90 || s.rotation == SSD1306Rotate270 {
+		return image.Rect(0, 0, s.height, s.width)
+	}
+	return image.Rect(0, 0, s.width, s.height)
+}
+
+// At implements draw.Image, returning color.White for the pixels which are
+// on and color.Black otherwise
+func (s *SSD1306) At(x int, y int) color.Color {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.pixel(x, y) {
+		return color.White
+	}
+	return color.Black
+}
+
+// Set implements draw.Image, turning the pixel on when c is at least half
+// bright
+func (s *SSD1306) Set(x int, y int, c color.Color) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.setPixel(x, y, ssd1306Lit(c))
+}
+
+// SetPixel turns the pixel at x and y on or off
+func (s *SSD1306) SetPixel(x int, y int, on bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.setPixel(x, y, on)
+}
+
+// DrawText draws text in a 5x7 font, 6 pixels apart, with the top left of
+// its first character at x and y
+func (s *SSD1306) DrawText(x int, y int, text string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i, column := range fontColumns(text) {
+		for row := 0; row < 8; row++ {
+			s.setPixel(x+i, y+row, column>>uint(row)&1 == 1)
+		}
+	}
+}
+
+// Clear turns all the pixels off. The display is cleared on the next Flush.
+func (s *SSD1306) Clear() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i := range s.buffer {
+		s.buffer[i] = 0
+	}
+	s.changeAll()
+}
+
+// Flush writes the part of the framebuffer which has changed since it was
+// last written to the display
+func (s *SSD1306) Flush() (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.minCol > s.maxCol || s.minPage > s.maxPage {
+		return
+	}
+	if err = s.bus.Command(
+		ssd1306ColumnAddress, byte(s.minCol), byte(s.maxCol),
+		ssd1306PageAddress, byte(s.minPage), byte(s.maxPage),
+	); err != nil {
+		return
+	}
+	data := []byte{}
+	for page := s.minPage; page <= s.maxPage; page++ {
+		data = append(data, s.buffer[page*s.width+s.minCol:page*s.width+s.maxCol+1]...)
+	}
+	if err = s.bus.Data(data); err != nil {
+		return
+	}
+	s.minCol, s.maxCol = s.width, -1
+	s.minPage, s.maxPage = len(s.buffer)/s.width, -1
+	return
+}
+
+// SetContrast sets the brightness of the display, from 0 to 255
+func (s *SSD1306) SetContrast(contrast byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.bus.Command(ssd1306Contrast, contrast)
+}
+
+// Display turns the display on or off, keeping what is on it
+func (s *SSD1306) Display(on bool) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if on {
+		return s.bus.Command(ssd1306DisplayOn)
+	}
+	return s.bus.Command(ssd1306DisplayOff)
+}
+
+// Invert lights the pixels which are off and turns off those which are lit
+// when inverse is true, without changing the framebuffer
+func (s *SSD1306) Invert(inverse bool) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if inverse {
+		return s.bus.Command(ssd1306Inverse)
+	}
+	return s.bus.Command(ssd1306Normal)
+}
+
+// Rotation returns the clockwise rotation of the display in degrees
+func (s *SSD1306) Rotation() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.rotation
+}
+
+// SetRotation rotates what is drawn from then on clockwise by rotation,
+// one of SSD1306Rotate0, 90, 180 or 270, so the display can be mounted
+// either way up or on its side. Bounds is swapped at 90 and 270.
+func (s *SSD1306) SetRotation(rotation int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.rotation = (rotation%360 + 360) % 360 / 90 * 90
+}
+
+// transform returns the pixel of the display drawn at x and y
+func (s *SSD1306) transform(x int, y int) (int, int) {
+	switch s.rotation {
+	case SSD1306Rotate90:
+		return s.width - 1 - y, x
+	case SSD1306Rotate180:
+		return s.width - 1 - x, s.height - 1 - y
+	case SSD1306Rotate270:
+		return y, s.height - 1 - x
+	}
+	return x, y
+}
+
+// pixel returns whether the pixel drawn at x and y is on
+func (s *SSD1306) pixel(x int, y int) bool {
+	x, y = s.transform(x, y)
+	if x < 0 || x >= s.width || y < 0 || y >= s.height {
+		return false
+	}
+	return s.buffer[y/8*s.width+x]>>uint(y%8)&1 == 1
+}
+
+// setPixel turns the pixel drawn at x and y on or off, marking it changed
+func (s *SSD1306) setPixel(x int, y int, on bool) {
+	x, y = s.transform(x, y)
+	if x < 0 || x >= s.width || y < 0 || y >= s.height {
+		return
+	}
+	i, bit := y/8*s.width+x, byte(1)<<uint(y%8)
+	old := s.buffer[i]
+	if on {
+		s.buffer[i] |= bit
+	} else {
+		s.buffer[i] &^= bit
+	}
+	if s.buffer[i] == old {
+		return
+	}
+
+	page := y / 8
+	if x < s.minCol {
+		s.minCol = x
+	}
+	if x > s.maxCol {
+		s.maxCol = x
+	}
+	if page < s.minPage {
+		s.minPage = page
+	}
+	if page > s.maxPage {
+		s.maxPage = page
+	}
+}
+
+// changeAll marks the whole framebuffer changed
+func (s *SSD1306) changeAll() {
+	s.minCol, s.maxCol = 0, s.width-1
+	s.minPage, s.maxPage = 0, len(s.buffer)/s.width-1
+}
+
+// ssd1306Lit returns whether c is at least half bright
+func ssd1306Lit(c color.Color) bool {
+	r, g, b, a := c.RGBA()
+	if a < 0x8000 {
+		return false
+	}
+	// luminance as in color.GrayModel
+	y := (19595*r + 38470*g + 7471*b + 1<<15) >> 16
+	return y >= 0x8000
+}
@@ -0,0 +1,159 @@
+package gpio
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// ssd1306TestBus records the commands and data written to it
+type ssd1306TestBus struct {
+	commands [][]byte
+	data     [][]byte
+	err      error
+}
+
+func (b *ssd1306TestBus) Command(c ...byte) error {
+	b.commands = append(b.commands, c)
+	return b.err
+}
+
+func (b *ssd1306TestBus) Data(d []byte) error {
+	b.data = append(b.data, append([]byte{}, d...))
+	return b.err
+}
+
+func initTestSSD1306(width int, height int) (*SSD1306, *ssd1306TestBus) {
+	bus := &ssd1306TestBus{}
+	return NewSSD1306(bus, width, height), bus
+}
+
+func TestSSD1306Init(t *testing.T) {
+	s, bus := initTestSSD1306(128, 64)
+	gobot.Assert(t, s.Init(), nil)
+	gobot.Assert(t, bus.commands, [][]byte{{
+		0xAE, 0xD5, 0x80, 0xA8, 63, 0xD3, 0x00, 0x40, 0x8D, 0x14, 0x20, 0x00,
+		0xA1, 0xC8, 0xDA, 0x12, 0x81, 0xCF, 0xD9, 0xF1, 0xDB, 0x40, 0xA4,
+		0xA6, 0xAF,
+	}})
+	gobot.Assert(t, s.Bounds(), image.Rect(0, 0, 128, 64))
+
+	s, bus = initTestSSD1306(128, 32)
+	s.Init()
+	gobot.Assert(t, bus.commands[0][4], byte(31))
+	gobot.Assert(t, bus.commands[0][15], byte(0x02))
+}
+
+func TestSSD1306Flush(t *testing.T) {
+	s, bus := initTestSSD1306(128, 32)
+	// the whole framebuffer is written at first
+	gobot.Assert(t, s.Flush(), nil)
+	gobot.Assert(t, bus.commands, [][]byte{{0x21, 0, 127, 0x22, 0, 3}})
+	gobot.Assert(t, len(bus.data[0]), 512)
+
+	// then nothing until it changes
+	bus.commands, bus.data = nil, nil
+	s.Flush()
+	gobot.Assert(t, len(bus.commands), 0)
+
+	// and then only what has changed
+	s.SetPixel(10, 9, true)
+	s.Set(12, 17, color.White)
+	// unchanged pixels are not written
+	s.SetPixel(100, 0, false)
+	s.Flush()
+	gobot.Assert(t, bus.commands, [][]byte{{0x21, 10, 12, 0x22, 1, 2}})
+	gobot.Assert(t, bus.data, [][]byte{{0x02, 0, 0, 0, 0, 0x02}})
+
+	bus.err = errors.New("write error")
+	s.SetPixel(0, 0, true)
+	gobot.Assert(t, s.Flush(), errors.New("write error"))
+}
+
+func TestSSD1306SetAt(t *testing.T) {
+	s, _ := initTestSSD1306(128, 64)
+	s.Set(3, 4, color.Gray{0x90})
+	s.Set(4, 4, color.Gray{0x70})
+	s.Set(5, 4, color.RGBA{0xFF, 0xFF, 0xFF, 0x00})
+	gobot.Assert(t, s.At(3, 4), color.Color(color.White))
+	gobot.Assert(t, s.At(4, 4), color.Color(color.Black))
+	gobot.Assert(t, s.At(5, 4), color.Color(color.Black))
+	gobot.Assert(t, s.At(-1, 200), color.Color(color.Black))
+	gobot.Assert(t, s.ColorModel().Convert(color.Gray{0xFF}), color.Color(color.White))
+
+	// drawing with the standard library
+	draw.Draw(s, image.Rect(0, 8, 128, 16), image.NewUniform(color.White), image.ZP, draw.Src)
+	gobot.Assert(t, s.At(127, 15), color.Color(color.White))
+	gobot.Assert(t, s.At(127, 16), color.Color(color.Black))
+
+	s.Clear()
+	gobot.Assert(t, s.At(3, 4), color.Color(color.Black))
+}
+
+func TestSSD1306Rotation(t *testing.T) {
+	s, bus := initTestSSD1306(128, 32)
+	s.Flush()
+
+	s.SetRotation(SSD1306Rotate90)
+	gobot.Assert(t, s.Rotation(), 90)
+	gobot.Assert(t, s.Bounds(), image.Rect(0, 0, 32, 128))
+	// the top left is the top right of the display
+	s.SetPixel(0, 0, true)
+	gobot.Assert(t, s.At(0, 0), color.Color(color.White))
+	s.SetRotation(SSD1306Rotate0)
+	gobot.Assert(t, s.At(127, 0), color.Color(color.White))
+
+	s.SetRotation(SSD1306Rotate180)
+	gobot.Assert(t, s.Bounds(), image.Rect(0, 0, 128, 32))
+	gobot.Assert(t, s.At(0, 31), color.Color(color.White))
+
+	s.SetRotation(-90)
+	gobot.Assert(t, s.Rotation(), 270)
+	gobot.Assert(t, s.At(31, 127), color.Color(color.White))
+
+	bus.commands, bus.data = nil, nil
+	s.Flush()
+	gobot.Assert(t, bus.commands, [][]byte{{0x21, 127, 127, 0x22, 0, 0}})
+	gobot.Assert(t, bus.data, [][]byte{{0x01}})
+}
+
+func TestSSD1306DrawText(t *testing.T) {
+	s, bus := initTestSSD1306(128, 64)
+	s.Flush()
+	bus.commands, bus.data = nil, nil
+
+	s.DrawText(0, 8, "1")
+	s.Flush()
+	// the blank columns around the glyph are unchanged
+	gobot.Assert(t, bus.commands, [][]byte{{0x21, 1, 3, 0x22, 1, 1}})
+	gobot.Assert(t, bus.data, [][]byte{{0x42, 0x7F, 0x40}})
+}
+
+func TestSSD1306Commands(t *testing.T) {
+	s, bus := initTestSSD1306(128, 64)
+	s.SetContrast(0x10)
+	s.Invert(true)
+	s.Invert(false)
+	s.Display(false)
+	s.Display(true)
+	gobot.Assert(t, bus.commands, [][]byte{{0x81, 0x10}, {0xA7}, {0xA6}, {0xAE}, {0xAF}})
+}
+
+func TestSSD1306AddCommands(t *testing.T) {
+	s, bus := initTestSSD1306(128, 64)
+	c := gobot.NewCommander()
+	s.AddCommands(c)
+	gobot.Assert(t, len(c.Commands()), 4)
+
+	c.Command("SetContrast")(map[string]interface{}{"contrast": 128.0})
+	gobot.Assert(t, bus.commands, [][]byte{{0x81, 0x80}})
+	c.Command("DrawText")(map[string]interface{}{"x": 0.0, "y": 0.0, "text": "-"})
+	gobot.Assert(t, s.At(1, 3), color.White)
+	c.Command("Clear")(nil)
+	gobot.Assert(t, s.At(1, 3), color.Black)
+	gobot.Assert(t, c.Command("Flush")(nil), nil)
+}
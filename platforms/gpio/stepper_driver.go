@@ -0,0 +1,364 @@
+package gpio
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*StepperDriver)(nil)
+
+var (
+	// ErrInvalidStepperMode is the error resulting when a stepper is set to a
+	// mode its wiring does not support
+	ErrInvalidStepperMode = errors.New("invalid stepper mode for this wiring")
+	// ErrInvalidStepperSpeed is the error resulting when a stepper is set to a
+	// speed which is not positive
+	ErrInvalidStepperSpeed = errors.New("stepper speed must be greater than 0")
+)
+
+const (
+	// StepperModeWave energises one coil at a time
+	StepperModeWave = "wave"
+	// StepperModeFull energises two coils at a time for the most torque
+	StepperModeFull = "full"
+	// StepperModeHalf alternates between one and two coils, doubling the
+	// number of steps per revolution
+	StepperModeHalf = "half"
+	// StepperModeStepDir drives a STEP/DIR stepper driver board
+	StepperModeStepDir = "stepdir"
+)
+
+var stepperSequences = map[int]map[string][][]byte{
+	2: {
+		StepperModeFull: {
+			{0, 1},
+			{1, 1},
+			{1, 0},
+			{0, 0},
+		},
+	},
+	4: {
+		StepperModeWave: {
+			{1, 0, 0, 0},
+			{0, 1, 0, 0},
+			{0, 0, 1, 0},
+			{0, 0, 0, 1},
+		},
+		StepperModeFull: {
+			{1, 1, 0, 0},
+			{0, 1, 1, 0},
+			{0, 0, 1, 1},
+			{1, 0, 0, 1},
+		},
+		StepperModeHalf: {
+			{1, 0, 0, 0},
+			{1, 1, 0, 0},
+			{0, 1, 0, 0},
+			{0, 1, 1, 0},
+			{0, 0, 1, 0},
+			{0, 0, 1, 1},
+			{0, 0, 0, 1},
+			{1, 0, 0, 1},
+		},
+	},
+}
+
+// StepperDriver represents a stepper motor, either wired to 2 or 4 coil pins
+// through a driver such as the ULN2003 or L293D, or driven by a STEP/DIR driver
+// board such as the A4988 or DRV8825.
+type StepperDriver struct {
+	name               string
+	connection         DigitalWriter
+	pins               []string
+	mode               string
+	stepsPerRevolution int
+	rpm                float64
+	acceleration       float64
+	position           int
+	phase              int
+	// mutex guards position, phase, and halt and done of the move in progress
+	mutex sync.Mutex
+	// moves lets one move run at a time
+	moves sync.Mutex
+	halt  chan bool
+	done  chan bool
+	// EnablePin is the active low enable pin of a STEP/DIR driver board.
+	// If set it is pulled high to de-energise the coils on Halt and Release.
+	EnablePin string
+	gobot.Commander
+}
+
+// NewStepperDriver returns a new StepperDriver in StepperModeFull at 60 rpm
+// given a DigitalWriter, name, the 2 or 4 pins driving its coils in sequence,
+// and its number of full steps per revolution.
+//
+// Adds the following API Commands:
+// 	"Move" - See StepperDriver.Move
+//	"MoveTo" - See StepperDriver.MoveTo
+//	"SetSpeed" - See StepperDriver.SetSpeed
+//	"SetAcceleration" - See StepperDriver.SetAcceleration
+//	"SetMode" - See StepperDriver.SetMode
+//	"Position" - See StepperDriver.Position
+//	"Release" - See StepperDriver.Release
+func NewStepperDriver(a DigitalWriter, name string, pins []string, stepsPerRevolution int) *StepperDriver {
+	return newStepperDriver(a, name, pins, StepperModeFull, stepsPerRevolution)
+}
+
+// NewStepDirDriver returns a new StepperDriver in StepperModeStepDir at 60 rpm
+// given a DigitalWriter, name, the STEP and DIR pins of the driver board, and
+// the number of steps per revolution, taking microstepping into account.
+//
+// Adds the same API Commands as NewStepperDriver.
+func NewStepDirDriver(a DigitalWriter, name string, stepPin string, dirPin string, stepsPerRevolution int) *StepperDriver {
+	return newStepperDriver(a, name, []string{stepPin, dirPin}, StepperModeStepDir, stepsPerRevolution)
+}
+
+func newStepperDriver(a DigitalWriter, name string, pins []string, mode string, stepsPerRevolution int) *StepperDriver {
+	s := &StepperDriver{
+		name:               name,
+		connection:         a,
+		pins:               pins,
+		mode:               mode,
+		stepsPerRevolution: stepsPerRevolution,
+		rpm:                60,
+		Commander:          gobot.NewCommander(),
+	}
+
+	s.AddCommand("Move", func(params map[string]interface{}) interface{} {
+		steps := int(params["steps"].(float64))
+		return s.Move(steps)
+	})
+	s.AddCommand("MoveTo", func(params map[string]interface{}) interface{} {
+		position := int(params["position"].(float64))
+		return s.MoveTo(position)
+	})
+	s.AddCommand("SetSpeed", func(params map[string]interface{}) interface{} {
+		rpm := params["rpm"].(float64)
+		return s.SetSpeed(rpm)
+	})
+	s.AddCommand("SetAcceleration", func(params map[string]interface{}) interface{} {
+		s.SetAcceleration(params["acceleration"].(float64))
+		return nil
+	})
+	s.AddCommand("SetMode", func(params map[string]interface{}) interface{} {
+		return s.SetMode(params["mode"].(string))
+	})
+	s.AddCommand("Position", func(params map[string]interface{}) interface{} {
+		return s.Position()
+	})
+	s.AddCommand("Release", func(params map[string]interface{}) interface{} {
+		return s.Release()
+	})
+
+	return s
+}
+
+// Name returns the StepperDrivers name
+func (s *StepperDriver) Name() string { return s.name }
+
+// Pins returns the StepperDrivers coil pins, or its STEP and DIR pins
+func (s *StepperDriver) Pins() []string { return s.pins }
+
+// Connection returns the StepperDrivers Connection
+func (s *StepperDriver) Connection() gobot.Connection { return s.connection.(gobot.Connection) }
+
+// Start implements the Driver interface
+func (s *StepperDriver) Start() (errs []error) { return }
+
+// Halt stops any move in progress, waiting for it to stop, and de-energises
+// the coils
+func (s *StepperDriver) Halt() (errs []error) {
+	s.mutex.Lock()
+	halt, done := s.halt, s.done
+	s.halt, s.done = nil, nil
+	s.mutex.Unlock()
+
+	if halt != nil {
+		close(halt)
+		<-done
+	}
+	if err := s.Release(); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Mode returns the StepperDrivers mode
+func (s *StepperDriver) Mode() string { return s.mode }
+
+// SetMode sets the stepping sequence of a stepper wired to its coil pins to
+// StepperModeWave, StepperModeFull or StepperModeHalf. Steppers wired to 2
+// pins only support StepperModeFull.
+func (s *StepperDriver) SetMode(mode string) (err error) {
+	if s.mode == StepperModeStepDir {
+		return ErrInvalidStepperMode
+	}
+	if _, ok := stepperSequences[len(s.pins)][mode]; !ok {
+		return ErrInvalidStepperMode
+	}
+	s.mode = mode
+	s.mutex.Lock()
+	s.phase = 0
+	s.mutex.Unlock()
+	return
+}
+
+// SetSpeed sets the top speed of the stepper in revolutions per minute
+func (s *StepperDriver) SetSpeed(rpm float64) (err error) {
+	if rpm <= 0 {
+		return ErrInvalidStepperSpeed
+	}
+	s.rpm = rpm
+	return
+}
+
+// Speed returns the top speed of the stepper in revolutions per minute
+func (s *StepperDriver) Speed() float64 { return s.rpm }
+
+// SetAcceleration sets the acceleration and deceleration of the stepper in
+// steps per second per second. Moves start and stop at full speed if 0.
+func (s *StepperDriver) SetAcceleration(acceleration float64) {
+	s.acceleration = acceleration
+}
+
+// Position returns the position of the stepper in steps from where it started
+func (s *StepperDriver) Position() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.position
+}
+
+// SetPosition sets the current position of the stepper without moving it,
+// eg. to 0 once it reaches a limit switch
+func (s *StepperDriver) SetPosition(position int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.position = position
+}
+
+// MoveTo moves the stepper to the given position
+func (s *StepperDriver) MoveTo(position int) (err error) {
+	return s.Move(position - s.Position())
+}
+
+// Move moves the stepper the given number of steps, backwards if negative, and
+// returns once it has arrived or been halted. The stepper keeps its coils
+// energised afterwards to hold its position. A move waits for any move in
+// progress to finish first.
+func (s *StepperDriver) Move(steps int) (err error) {
+	s.moves.Lock()
+	defer s.moves.Unlock()
+
+	halt, done := make(chan bool), make(chan bool)
+	s.mutex.Lock()
+	s.halt, s.done = halt, done
+	s.mutex.Unlock()
+	defer func() {
+		s.mutex.Lock()
+		if s.done == done {
+			s.halt, s.done = nil, nil
+		}
+		s.mutex.Unlock()
+		close(done)
+	}()
+
+	if s.mode != StepperModeStepDir {
+		if _, ok := stepperSequences[len(s.pins)][s.mode]; !ok {
+			return ErrInvalidStepperMode
+		}
+	}
+
+	direction := 1
+	if steps < 0 {
+		direction = -1
+		steps = -steps
+	}
+
+	if s.mode == StepperModeStepDir {
+		if s.EnablePin != "" {
+			if err = s.connection.DigitalWrite(s.EnablePin, 0); err != nil {
+				return
+			}
+		}
+		if err = s.connection.DigitalWrite(s.pins[1], byte((direction+1)/2)); err != nil {
+			return
+		}
+	}
+
+	next := time.Now()
+	for i := 0; i < steps; i++ {
+		if err = s.step(direction); err != nil {
+			return
+		}
+		next = next.Add(s.stepDelay(i, steps))
+		select {
+		case <-time.After(next.Sub(time.Now())):
+		case <-halt:
+			return
+		}
+	}
+	return
+}
+
+// Release de-energises the coils, letting the stepper turn freely
+func (s *StepperDriver) Release() (err error) {
+	if s.mode == StepperModeStepDir {
+		if s.EnablePin != "" {
+			err = s.connection.DigitalWrite(s.EnablePin, 1)
+		}
+		return
+	}
+	for _, pin := range s.pins {
+		if err = s.connection.DigitalWrite(pin, 0); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// step takes a single step in direction
+func (s *StepperDriver) step(direction int) (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.mode == StepperModeStepDir {
+		if err = s.connection.DigitalWrite(s.pins[0], 1); err != nil {
+			return
+		}
+		if err = s.connection.DigitalWrite(s.pins[0], 0); err != nil {
+			return
+		}
+	} else {
+		sequence := stepperSequences[len(s.pins)][s.mode]
+		s.phase = (s.phase + direction + len(sequence)) % len(sequence)
+		for i, pin := range s.pins {
+			if err = s.connection.DigitalWrite(pin, sequence[s.phase][i]); err != nil {
+				return
+			}
+		}
+	}
+	s.position += direction
+	return
+}
+
+// stepDelay returns the time to wait after step i of a move of steps steps,
+// ramping the speed up and down at the acceleration
+func (s *StepperDriver) stepDelay(i int, steps int) time.Duration {
+	stepsPerRevolution := float64(s.stepsPerRevolution)
+	if s.mode == StepperModeHalf {
+		stepsPerRevolution *= 2
+	}
+	speed := s.rpm * stepsPerRevolution / 60
+
+	if s.acceleration > 0 {
+		// the speed reachable from a standstill within the steps taken so
+		// far, or which still allows stopping within the steps left
+		speed = math.Min(speed, math.Sqrt(2*s.acceleration*float64(i+1)))
+		speed = math.Min(speed, math.Sqrt(2*s.acceleration*float64(steps-i)))
+	}
+	return time.Duration(float64(time.Second) / speed)
+}
@@ -0,0 +1,173 @@
+package gpio
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func initTestStepperDriver() (*StepperDriver, *gpioTestPinWriter) {
+	a := newGpioTestPinWriter()
+	return NewStepperDriver(a, "bot", []string{"1", "2", "3", "4"}, 200), a
+}
+
+func TestStepperDriver(t *testing.T) {
+	d, _ := initTestStepperDriver()
+	gobot.Assert(t, d.Name(), "bot")
+	gobot.Assert(t, d.Pins(), []string{"1", "2", "3", "4"})
+	gobot.Assert(t, d.Mode(), StepperModeFull)
+	gobot.Assert(t, d.Speed(), 60.0)
+	gobot.Assert(t, len(d.Start()), 0)
+
+	d = NewStepperDriver(newGpioTestAdaptor("adaptor"), "bot", []string{"1", "2", "3", "4"}, 200)
+	gobot.Assert(t, d.Connection().Name(), "adaptor")
+}
+
+func TestStepperDriverMove(t *testing.T) {
+	d, a := initTestStepperDriver()
+	d.SetSpeed(600)
+
+	gobot.Assert(t, d.Move(4), nil)
+	gobot.Assert(t, d.Position(), 4)
+	gobot.Assert(t, a.history("1"), []byte{0, 0, 1, 1})
+	gobot.Assert(t, a.history("2"), []byte{1, 0, 0, 1})
+	gobot.Assert(t, a.history("3"), []byte{1, 1, 0, 0})
+	gobot.Assert(t, a.history("4"), []byte{0, 1, 1, 0})
+
+	gobot.Assert(t, d.Move(-2), nil)
+	gobot.Assert(t, d.Position(), 2)
+	gobot.Assert(t, a.history("1")[4:], []byte{1, 0})
+	gobot.Assert(t, a.history("3")[4:], []byte{0, 1})
+
+	gobot.Assert(t, d.MoveTo(-1), nil)
+	gobot.Assert(t, d.Position(), -1)
+
+	d.SetPosition(0)
+	gobot.Assert(t, d.Position(), 0)
+}
+
+func TestStepperDriverModes(t *testing.T) {
+	d, a := initTestStepperDriver()
+	d.SetSpeed(300)
+
+	gobot.Assert(t, d.SetMode(StepperModeHalf), nil)
+	d.Move(8)
+	gobot.Assert(t, a.history("1"), []byte{1, 0, 0, 0, 0, 0, 1, 1})
+
+	gobot.Assert(t, d.SetMode(StepperModeWave), nil)
+	d.Move(4)
+	gobot.Assert(t, a.history("2")[8:], []byte{1, 0, 0, 0})
+
+	gobot.Assert(t, d.SetMode("bogus"), ErrInvalidStepperMode)
+	gobot.Assert(t, d.SetMode(StepperModeStepDir), ErrInvalidStepperMode)
+
+	two := NewStepperDriver(a, "bot", []string{"5", "6"}, 48)
+	gobot.Assert(t, two.SetMode(StepperModeHalf), ErrInvalidStepperMode)
+	two.SetSpeed(600)
+	two.Move(4)
+	gobot.Assert(t, a.history("5"), []byte{1, 1, 0, 0})
+	gobot.Assert(t, a.history("6"), []byte{1, 0, 0, 1})
+
+	three := NewStepperDriver(a, "bot", []string{"7", "8", "9"}, 48)
+	gobot.Assert(t, three.Move(1), ErrInvalidStepperMode)
+}
+
+func TestStepperDriverStepDir(t *testing.T) {
+	a := newGpioTestPinWriter()
+	d := NewStepDirDriver(a, "bot", "step", "dir", 200)
+	d.EnablePin = "enable"
+	d.SetSpeed(600)
+
+	gobot.Assert(t, d.Mode(), StepperModeStepDir)
+	gobot.Assert(t, d.SetMode(StepperModeFull), ErrInvalidStepperMode)
+
+	gobot.Assert(t, d.Move(3), nil)
+	gobot.Assert(t, d.MoveTo(1), nil)
+	gobot.Assert(t, d.Position(), 1)
+	gobot.Assert(t, a.history("step"), []byte{1, 0, 1, 0, 1, 0, 1, 0, 1, 0})
+	gobot.Assert(t, a.history("dir"), []byte{1, 0})
+	gobot.Assert(t, a.history("enable"), []byte{0, 0})
+
+	gobot.Assert(t, len(d.Halt()), 0)
+	gobot.Assert(t, a.history("enable"), []byte{0, 0, 1})
+}
+
+func TestStepperDriverSpeed(t *testing.T) {
+	d, _ := initTestStepperDriver()
+	gobot.Assert(t, d.SetSpeed(0), ErrInvalidStepperSpeed)
+
+	// 60 rpm at 200 steps per revolution is 200 steps per second
+	gobot.Assert(t, d.stepDelay(0, 10), 5*time.Millisecond)
+	d.SetMode(StepperModeHalf)
+	gobot.Assert(t, d.stepDelay(0, 10), 2500*time.Microsecond)
+	d.SetMode(StepperModeFull)
+
+	// ramps up from and down to a standstill at 50 steps/s²
+	d.SetAcceleration(50)
+	gobot.Assert(t, d.stepDelay(0, 1000), 100*time.Millisecond)
+	gobot.Assert(t, d.stepDelay(500, 1000), 5*time.Millisecond)
+	gobot.Assert(t, d.stepDelay(999, 1000), 100*time.Millisecond)
+
+	start := time.Now()
+	d.SetAcceleration(0)
+	d.Move(10)
+	gobot.Assert(t, time.Since(start) >= 50*time.Millisecond, true)
+}
+
+func TestStepperDriverHalt(t *testing.T) {
+	d, a := initTestStepperDriver()
+	d.SetSpeed(1)
+
+	done := make(chan error)
+	go func() {
+		done <- d.Move(100)
+	}()
+	<-time.After(10 * time.Millisecond)
+	gobot.Assert(t, len(d.Halt()), 0)
+
+	select {
+	case err := <-done:
+		gobot.Assert(t, err, nil)
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("StepperDriver did not halt")
+	}
+	gobot.Assert(t, d.Position(), 1)
+	for _, pin := range d.Pins() {
+		h := a.history(pin)
+		gobot.Assert(t, h[len(h)-1], byte(0))
+	}
+
+	// a halt with no move in progress does not stop the next move
+	gobot.Assert(t, len(d.Halt()), 0)
+	d.SetSpeed(600)
+	gobot.Assert(t, d.Move(3), nil)
+	gobot.Assert(t, d.Position(), 4)
+}
+
+func TestStepperDriverCommands(t *testing.T) {
+	d, _ := initTestStepperDriver()
+
+	gobot.Assert(t, d.Command("SetSpeed")(map[string]interface{}{"rpm": 600.0}), nil)
+	gobot.Assert(t, d.Command("SetAcceleration")(map[string]interface{}{"acceleration": 0.0}), nil)
+	gobot.Assert(t, d.Command("SetMode")(map[string]interface{}{"mode": "half"}), nil)
+	gobot.Assert(t, d.Command("Move")(map[string]interface{}{"steps": 2.0}), nil)
+	gobot.Assert(t, d.Command("MoveTo")(map[string]interface{}{"position": 1.0}), nil)
+	gobot.Assert(t, d.Command("Position")(nil), 1)
+	gobot.Assert(t, d.Command("Release")(nil), nil)
+}
+
+func TestStepperDriverWriteError(t *testing.T) {
+	d := NewStepperDriver(newGpioTestAdaptor("adaptor"), "bot", []string{"1", "2", "3", "4"}, 200)
+	testAdaptorDigitalWrite = func() (err error) {
+		return errors.New("write error")
+	}
+	defer func() {
+		testAdaptorDigitalWrite = func() (err error) { return nil }
+	}()
+
+	gobot.Assert(t, d.Move(1), errors.New("write error"))
+	gobot.Assert(t, d.Position(), 0)
+	gobot.Assert(t, d.Halt()[0], errors.New("write error"))
+}
@@ -0,0 +1,216 @@
+package i2c
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+)
+
+var _ gobot.Driver = (*ADS1115Driver)(nil)
+
+// The channels of the ADS1115 can be used by gpio drivers
+var _ gpio.AnalogReader = (*ADS1115Driver)(nil)
+
+// ErrInvalidADS1115Pin is the error resulting when a pin name is not one of
+// the ADS1115 inputs "0" to "3" or differential pairs "0-1", "0-3", "1-3" and
+// "2-3"
+var ErrInvalidADS1115Pin = errors.New("ADS1115 pins are 0 to 3, 0-1, 0-3, 1-3 and 2-3")
+
+const ads1115Address = 0x48
+
+// ADS1115 registers
+const (
+	ads1115Conversion = 0x00
+	ads1115Config     = 0x01
+)
+
+// ads1115Mux are the multiplexer settings of the pins
+var ads1115Mux = map[string]uint16{
+	"0-1": 0x0,
+	"0-3": 0x1,
+	"1-3": 0x2,
+	"2-3": 0x3,
+	"0":   0x4,
+	"1":   0x5,
+	"2":   0x6,
+	"3":   0x7,
+}
+
+// ADS1115 full scale ranges
+const (
+	// ADS1115Range6_144 measures ±6.144V, though no more than the supply
+	ADS1115Range6_144 = iota
+	// ADS1115Range4_096 measures ±4.096V
+	ADS1115Range4_096
+	// ADS1115Range2_048 measures ±2.048V
+	ADS1115Range2_048
+	// ADS1115Range1_024 measures ±1.024V
+	ADS1115Range1_024
+	// ADS1115Range0_512 measures ±0.512V
+	ADS1115Range0_512
+	// ADS1115Range0_256 measures ±0.256V
+	ADS1115Range0_256
+)
+
+// ads1115FullScales are the voltages the ranges measure
+var ads1115FullScales = []float64{6.144, 4.096, 2.048, 1.024, 0.512, 0.256}
+
+// ads1115SampleRates are the samples per second the ADS1115 can take
+var ads1115SampleRates = []int{8, 16, 32, 64, 128, 250, 475, 860}
+
+// ADS1115Driver represents an ADS1115 16-bit analog to digital converter. It
+// is also an AnalogReader whose pins "0" to "3" are its inputs, and "0-1",
+// "0-3", "1-3" and "2-3" the differences between them, so AnalogSensorDriver
+// can read them.
+type ADS1115Driver struct {
+	name       string
+	connection I2c
+	mutex      sync.Mutex
+	// Address is the i2c address of the converter, 0x48 by default, or 0x49
+	// to 0x4B depending on its ADDR pin
+	Address int
+	// Range is the full scale range of the readings, one of
+	// ADS1115Range6_144 to ADS1115Range0_256, ADS1115Range2_048 by default
+	Range byte
+	// SampleRate is the number of samples per second, from 8 to 860, 128 by
+	// default. Slower rates are less noisy.
+	SampleRate int
+	// Timeout is how long to wait for a conversion, 100ms by default
+	Timeout time.Duration
+	gobot.Commander
+}
+
+// NewADS1115Driver returns a new ADS1115Driver given an I2c adaptor and name.
+//
+// Adds the following API Commands:
+//	"AnalogRead" - See ADS1115Driver.AnalogRead
+//	"Voltage" - See ADS1115Driver.Voltage
+func NewADS1115Driver(a I2c, name string) *ADS1115Driver {
+	d := &ADS1115Driver{
+		name:       name,
+		connection: a,
+		Address:    ads1115Address,
+		Range:      ADS1115Range2_048,
+		SampleRate: 128,
+		Timeout:    100 * time.Millisecond,
+		Commander:  gobot.NewCommander(),
+	}
+
+	d.AddCommand("AnalogRead", func(params map[string]interface{}) interface{} {
+		val, err := d.AnalogRead(params["pin"].(string))
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	d.AddCommand("Voltage", func(params map[string]interface{}) interface{} {
+		val, err := d.Voltage(params["pin"].(string))
+		return map[string]interface{}{"val": val, "err": err}
+	})
+
+	return d
+}
+
+// Name returns the ADS1115Drivers name
+func (d *ADS1115Driver) Name() string { return d.name }
+
+// Connection returns the ADS1115Drivers connection
+func (d *ADS1115Driver) Connection() gobot.Connection { return d.connection.(gobot.Connection) }
+
+// Start initializes the i2c connection to the ADS1115
+func (d *ADS1115Driver) Start() (errs []error) {
+	if err := d.connection.I2cStart(d.Address); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Halt implements the Driver interface
+func (d *ADS1115Driver) Halt() (errs []error) { return }
+
+// Connect implements the Adaptor interface, the ADS1115Driver is connected
+// once its connection is
+func (d *ADS1115Driver) Connect() (errs []error) { return }
+
+// Finalize implements the Adaptor interface
+func (d *ADS1115Driver) Finalize() (errs []error) { return }
+
+// AnalogRead converts the voltage of pin once and returns it as a signed
+// value from -32768 to 32767 of the full scale range
+func (d *ADS1115Driver) AnalogRead(pin string) (val int, err error) {
+	mux, ok := ads1115Mux[pin]
+	if !ok {
+		return 0, ErrInvalidADS1115Pin
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// start a single conversion with the comparator disabled
+	config := 0x8000 | mux<<12 | uint16(d.Range&0x07)<<9 | 0x0100 |
+		uint16(d.dataRate())<<5 | 0x0003
+	if err = d.connection.I2cWrite(d.Address, []byte{ads1115Config, byte(config >> 8), byte(config)}); err != nil {
+		return
+	}
+
+	timeout := time.After(d.Timeout)
+	for {
+		ret, err := d.readRegister(ads1115Config)
+		if err != nil {
+			return 0, err
+		}
+		// the conversion is done once OS reads 1
+		if ret&0x8000 != 0 {
+			break
+		}
+		select {
+		case <-timeout:
+			return 0, ErrNotReady
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	ret, err := d.readRegister(ads1115Conversion)
+	return int(int16(ret)), err
+}
+
+// Voltage converts the voltage of pin once and returns it in V
+func (d *ADS1115Driver) Voltage(pin string) (voltage float64, err error) {
+	val, err := d.AnalogRead(pin)
+	if err != nil {
+		return
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	r := int(d.Range)
+	if r >= len(ads1115FullScales) {
+		r = len(ads1115FullScales) - 1
+	}
+	return float64(val) * ads1115FullScales[r] / 32768, nil
+}
+
+// readRegister reads the 16-bit register reg
+func (d *ADS1115Driver) readRegister(reg byte) (val uint16, err error) {
+	if err = d.connection.I2cWrite(d.Address, []byte{reg}); err != nil {
+		return
+	}
+	ret, err := d.connection.I2cRead(d.Address, 2)
+	if err != nil {
+		return
+	}
+	if len(ret) != 2 {
+		return 0, ErrNotEnoughBytes
+	}
+	return uint16(ret[0])<<8 | uint16(ret[1]), nil
+}
+
+// dataRate returns the setting of the slowest sample rate at least as fast as
+// SampleRate
+func (d *ADS1115Driver) dataRate() int {
+	for i, rate := range ads1115SampleRates {
+		if rate >= d.SampleRate {
+			return i
+		}
+	}
+	return len(ads1115SampleRates) - 1
+}
@@ -0,0 +1,101 @@
+package i2c
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+)
+
+func initTestADS1115Driver() (*ADS1115Driver, *i2cTestRegisters) {
+	adaptor := newI2cTestRegisters(2, 0xFF)
+	return NewADS1115Driver(adaptor, "bot"), adaptor
+}
+
+func TestADS1115Driver(t *testing.T) {
+	a, _ := initTestADS1115Driver()
+	gobot.Assert(t, a.Name(), "bot")
+	gobot.Assert(t, a.Connection().Name(), "adaptor")
+	gobot.Assert(t, a.Address, 0x48)
+	gobot.Assert(t, a.SampleRate, 128)
+	gobot.Assert(t, len(a.Connect()), 0)
+	gobot.Assert(t, len(a.Finalize()), 0)
+	gobot.Refute(t, a.Command("AnalogRead"), nil)
+	gobot.Refute(t, a.Command("Voltage"), nil)
+}
+
+func TestADS1115DriverStart(t *testing.T) {
+	a, adaptor := initTestADS1115Driver()
+	gobot.Assert(t, len(a.Start()), 0)
+	gobot.Assert(t, len(a.Halt()), 0)
+
+	adaptor.i2cStartImpl = func() error {
+		return errors.New("start error")
+	}
+	gobot.Assert(t, a.Start()[0], errors.New("start error"))
+}
+
+func TestADS1115DriverAnalogRead(t *testing.T) {
+	a, adaptor := initTestADS1115Driver()
+	adaptor.set(ads1115Conversion, 0x12, 0x34)
+
+	val, err := a.AnalogRead("2")
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, val, 0x1234)
+	// single shot of AIN2 at ±2.048V and 128SPS, comparator disabled
+	gobot.Assert(t, adaptor.writes[0], []byte{0x01, 0xE5, 0x83})
+
+	a.Range = ADS1115Range6_144
+	a.SampleRate = 860
+	adaptor.set(ads1115Conversion, 0xFF, 0xFE)
+	val, err = a.AnalogRead("0-1")
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, val, -2)
+	gobot.Assert(t, adaptor.writes[3], []byte{0x01, 0x81, 0xE3})
+
+	_, err = a.AnalogRead("4")
+	gobot.Assert(t, err, ErrInvalidADS1115Pin)
+
+	adaptor.i2cWriteImpl = func() error {
+		return errors.New("write error")
+	}
+	_, err = a.AnalogRead("0")
+	gobot.Assert(t, err, errors.New("write error"))
+}
+
+func TestADS1115DriverAnalogReadTimeout(t *testing.T) {
+	a, adaptor := initTestADS1115Driver()
+	a.Timeout = 10 * time.Millisecond
+	// the conversion never finishes
+	adaptor.onWrite = func(buf []byte) {
+		adaptor.regs[ads1115Config*2] &= 0x7F
+	}
+	_, err := a.AnalogRead("0")
+	gobot.Assert(t, err, ErrNotReady)
+}
+
+func TestADS1115DriverVoltage(t *testing.T) {
+	a, adaptor := initTestADS1115Driver()
+	adaptor.set(ads1115Conversion, 0x40, 0x00)
+	voltage, err := a.Voltage("1")
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, voltage, 1.024)
+
+	ret := a.Command("Voltage")(map[string]interface{}{"pin": "1"}).(map[string]interface{})
+	gobot.Assert(t, ret["val"].(float64), 1.024)
+	gobot.Assert(t, ret["err"], nil)
+
+	_, err = a.Voltage("5")
+	gobot.Assert(t, err, ErrInvalidADS1115Pin)
+}
+
+func TestADS1115DriverAnalogSensor(t *testing.T) {
+	a, adaptor := initTestADS1115Driver()
+	adaptor.set(ads1115Conversion, 0x01, 0x00)
+	s := gpio.NewAnalogSensorDriver(a, "sensor", "3")
+	val, err := s.Read()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, val, 256)
+}
@@ -0,0 +1,149 @@
+package i2c
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*AT24CDriver)(nil)
+var _ io.ReaderAt = (*AT24CDriver)(nil)
+var _ io.WriterAt = (*AT24CDriver)(nil)
+
+const at24cAddress = 0x50
+
+// AT24CModel is the size and page size of an AT24C EEPROM
+type AT24CModel struct {
+	// Size is the number of bytes of the EEPROM
+	Size int
+	// PageSize is the most bytes written at once, which must all be in the
+	// same page
+	PageSize int
+}
+
+// The AT24C EEPROMs with 2 byte memory addresses, and the many compatible
+// ones
+var (
+	AT24C32  = AT24CModel{Size: 4096, PageSize: 32}
+	AT24C64  = AT24CModel{Size: 8192, PageSize: 32}
+	AT24C128 = AT24CModel{Size: 16384, PageSize: 64}
+	AT24C256 = AT24CModel{Size: 32768, PageSize: 64}
+	AT24C512 = AT24CModel{Size: 65536, PageSize: 128}
+)
+
+// AT24CDriver represents an AT24C serial EEPROM, such as the AT24C32 found
+// alongside many DS1307 and DS3231 clocks. It is an io.ReaderAt and
+// io.WriterAt, so io.SectionReader and encoding/binary can be used to keep
+// data such as calibrations in it.
+type AT24CDriver struct {
+	name       string
+	connection I2c
+	model      AT24CModel
+	mutex      sync.Mutex
+	// Address is the i2c address of the EEPROM, 0x50 to 0x57 as set by its
+	// A0 to A2 pins, 0x50 by default
+	Address int
+	// WriteTime is how long the EEPROM takes to write a page, 5ms by default
+	WriteTime time.Duration
+}
+
+// NewAT24CDriver returns a new AT24CDriver given an I2c adaptor, name and
+// model, such as AT24C32
+func NewAT24CDriver(a I2c, name string, model AT24CModel) *AT24CDriver {
+	return &AT24CDriver{
+		name:       name,
+		connection: a,
+		model:      model,
+		Address:    at24cAddress,
+		WriteTime:  5 * time.Millisecond,
+	}
+}
+
+// Name returns the AT24CDrivers name
+func (e *AT24CDriver) Name() string { return e.name }
+
+// Connection returns the AT24CDrivers connection
+func (e *AT24CDriver) Connection() gobot.Connection { return e.connection.(gobot.Connection) }
+
+// Start implements the Driver interface
+func (e *AT24CDriver) Start() (errs []error) {
+	if err := e.connection.I2cStart(e.Address); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Halt implements the Driver interface
+func (e *AT24CDriver) Halt() (errs []error) { return }
+
+// Size returns the number of bytes of the EEPROM
+func (e *AT24CDriver) Size() int64 { return int64(e.model.Size) }
+
+// ReadAt implements io.ReaderAt, reading len(p) bytes from off, and
+// returning io.EOF when reading past the end of the EEPROM
+func (e *AT24CDriver) ReadAt(p []byte, off int64) (n int, err error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if off < 0 {
+		return 0, ErrInvalidPosition
+	}
+	for n < len(p) {
+		if off+int64(n) >= e.Size() {
+			return n, io.EOF
+		}
+		// read a page at a time, as some adaptors can only read so much
+		size := len(p) - n
+		if size > e.model.PageSize {
+			size = e.model.PageSize
+		}
+		if remaining := int(e.Size() - off - int64(n)); size > remaining {
+			size = remaining
+		}
+		at := off + int64(n)
+		if err = e.connection.I2cWrite(e.Address, []byte{byte(at >> 8), byte(at)}); err != nil {
+			return
+		}
+		var data []byte
+		if data, err = e.connection.I2cRead(e.Address, size); err != nil {
+			return
+		}
+		if len(data) != size {
+			return n, ErrNotEnoughBytes
+		}
+		n += copy(p[n:], data)
+	}
+	return
+}
+
+// WriteAt implements io.WriterAt, writing p at off a page at a time, and
+// returning io.ErrShortWrite when writing past the end of the EEPROM
+func (e *AT24CDriver) WriteAt(p []byte, off int64) (n int, err error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if off < 0 {
+		return 0, ErrInvalidPosition
+	}
+	for n < len(p) {
+		at := off + int64(n)
+		if at >= e.Size() {
+			return n, io.ErrShortWrite
+		}
+		// a write wraps around within its page, so stop at the end of it
+		size := e.model.PageSize - int(at)%e.model.PageSize
+		if size > len(p)-n {
+			size = len(p) - n
+		}
+		buf := append([]byte{byte(at >> 8), byte(at)}, p[n:n+size]...)
+		if err = e.connection.I2cWrite(e.Address, buf); err != nil {
+			return
+		}
+		<-time.After(e.WriteTime)
+		n += size
+	}
+	return
+}
+
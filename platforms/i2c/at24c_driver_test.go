@@ -0,0 +1,125 @@
+package i2c
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// at24cTestAdaptor fakes an EEPROM with 2 byte addresses, whose writes wrap
+// around within their page
+type at24cTestAdaptor struct {
+	i2cTestAdaptor
+	memory   []byte
+	pageSize int
+	pointer  int
+	writes   [][]byte
+}
+
+func newAT24CTestAdaptor(model AT24CModel) *at24cTestAdaptor {
+	return &at24cTestAdaptor{
+		i2cTestAdaptor: *newI2cTestAdaptor("adaptor"),
+		memory:         make([]byte, model.Size),
+		pageSize:       model.PageSize,
+	}
+}
+
+func (t *at24cTestAdaptor) I2cWrite(address int, buf []byte) (err error) {
+	t.writes = append(t.writes, append([]byte{}, buf...))
+	t.pointer = int(buf[0])<<8 | int(buf[1])
+	page := t.pointer / t.pageSize * t.pageSize
+	for i, b := range buf[2:] {
+		t.memory[page+(t.pointer-page+i)%t.pageSize] = b
+	}
+	return t.i2cWriteImpl()
+}
+
+func (t *at24cTestAdaptor) I2cRead(address int, n int) (data []byte, err error) {
+	data = append([]byte{}, t.memory[t.pointer:t.pointer+n]...)
+	t.pointer += n
+	return
+}
+
+func TestAT24CDriver(t *testing.T) {
+	e := NewAT24CDriver(newI2cTestAdaptor("adaptor"), "bot", AT24C32)
+	gobot.Assert(t, e.Name(), "bot")
+	gobot.Assert(t, e.Connection().Name(), "adaptor")
+	gobot.Assert(t, e.Address, 0x50)
+	gobot.Assert(t, e.Size(), int64(4096))
+	gobot.Assert(t, len(e.Start()), 0)
+	gobot.Assert(t, len(e.Halt()), 0)
+}
+
+func TestAT24CDriverWriteAt(t *testing.T) {
+	adaptor := newAT24CTestAdaptor(AT24C32)
+	e := NewAT24CDriver(adaptor, "bot", AT24C32)
+	e.WriteTime = 0
+
+	data := make([]byte, 40)
+	for i := range data {
+		data[i] = byte(i + 1)
+	}
+	// split at the ends of the pages
+	n, err := e.WriteAt(data, 30)
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, n, 40)
+	gobot.Assert(t, len(adaptor.writes), 3)
+	gobot.Assert(t, adaptor.writes[0][:2], []byte{0x00, 30})
+	gobot.Assert(t, len(adaptor.writes[0]), 2+2)
+	gobot.Assert(t, adaptor.writes[1][:2], []byte{0x00, 32})
+	gobot.Assert(t, len(adaptor.writes[1]), 2+32)
+	gobot.Assert(t, adaptor.writes[2][:2], []byte{0x00, 64})
+	gobot.Assert(t, len(adaptor.writes[2]), 2+6)
+	gobot.Assert(t, adaptor.memory[30:70], data)
+
+	n, err = e.WriteAt([]byte{1, 2, 3}, 4094)
+	gobot.Assert(t, err, io.ErrShortWrite)
+	gobot.Assert(t, n, 2)
+	gobot.Assert(t, adaptor.writes[3], []byte{0x0F, 0xFE, 1, 2})
+
+	_, err = e.WriteAt([]byte{1}, -1)
+	gobot.Assert(t, err, ErrInvalidPosition)
+}
+
+func TestAT24CDriverReadAt(t *testing.T) {
+	adaptor := newAT24CTestAdaptor(AT24C32)
+	for i := range adaptor.memory {
+		adaptor.memory[i] = byte(i)
+	}
+	e := NewAT24CDriver(adaptor, "bot", AT24C32)
+
+	p := make([]byte, 70)
+	n, err := e.ReadAt(p, 0x100)
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, n, 70)
+	gobot.Assert(t, p, adaptor.memory[0x100:0x146])
+	// a page at a time
+	gobot.Assert(t, adaptor.writes, [][]byte{{0x01, 0x00}, {0x01, 0x20}, {0x01, 0x40}})
+
+	n, err = e.ReadAt(p[:4], 4094)
+	gobot.Assert(t, err, io.EOF)
+	gobot.Assert(t, n, 2)
+	gobot.Assert(t, p[:2], []byte{0xFE, 0xFF})
+
+	_, err = e.ReadAt(p, -1)
+	gobot.Assert(t, err, ErrInvalidPosition)
+}
+
+func TestAT24CDriverBinary(t *testing.T) {
+	e := NewAT24CDriver(newAT24CTestAdaptor(AT24C256), "bot", AT24C256)
+	e.WriteTime = 0
+
+	offset := ThreeDData{X: -120, Y: 31, Z: 16000}
+	buf := new(bytes.Buffer)
+	binary.Write(buf, binary.LittleEndian, offset)
+	_, err := e.WriteAt(buf.Bytes(), 0x40)
+	gobot.Assert(t, err, nil)
+
+	var read ThreeDData
+	err = binary.Read(io.NewSectionReader(e, 0x40, 6), binary.LittleEndian, &read)
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, read, offset)
+}
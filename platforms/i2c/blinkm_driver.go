@@ -1,18 +1,79 @@
 package i2c
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hybridgroup/gobot"
 )
 
 var _ gobot.Driver = (*BlinkMDriver)(nil)
 
+var (
+	// ErrInvalidBlinkMParam is the error resulting when a BlinkM API command
+	// is given a parameter which is not a number from 0 to 255
+	ErrInvalidBlinkMParam = errors.New("BlinkM parameters must be numbers from 0 to 255")
+	// ErrInvalidBlinkMScript is the error resulting when a script other than
+	// the EEPROM script 0 or the built in scripts 1 to 18 is played
+	ErrInvalidBlinkMScript = errors.New("BlinkM scripts are 0 to 18")
+	// ErrInvalidBlinkMScriptLine is the error resulting when a line of the
+	// EEPROM script beyond its 49 lines is written or read
+	ErrInvalidBlinkMScriptLine = errors.New("BlinkM script lines are 0 to 48")
+	// ErrInvalidBlinkMFadeSpeed is the error resulting when the fade speed
+	// is set to 0
+	ErrInvalidBlinkMFadeSpeed = errors.New("BlinkM fade speed must be 1 to 255")
+	// ErrInvalidBlinkMAddress is the error resulting when a BlinkM is given
+	// an address outside of 0x01 to 0x7F
+	ErrInvalidBlinkMAddress = errors.New("BlinkM addresses are 0x01 to 0x7F")
+)
+
 const blinkmAddress = 0x09
 
+// The light scripts a BlinkM can play, the one written to its EEPROM and
+// those built in
+const (
+	BlinkMScriptEEPROM = iota
+	BlinkMScriptRGB
+	BlinkMScriptWhiteFlash
+	BlinkMScriptRedFlash
+	BlinkMScriptGreenFlash
+	BlinkMScriptBlueFlash
+	BlinkMScriptCyanFlash
+	BlinkMScriptMagentaFlash
+	BlinkMScriptYellowFlash
+	BlinkMScriptBlack
+	BlinkMScriptHueCycle
+	BlinkMScriptMoodLight
+	BlinkMScriptVirtualCandle
+	BlinkMScriptWaterReflections
+	BlinkMScriptOldNeon
+	BlinkMScriptTheSeasons
+	BlinkMScriptThunderstorm
+	BlinkMScriptStopLight
+	BlinkMScriptMorseCode
+)
+
+// blinkmScriptLength is the number of lines of the EEPROM script
+const blinkmScriptLength = 49
+
+// BlinkMScriptLine is a line of a light script, a command run for a
+// duration
+type BlinkMScriptLine struct {
+	// Duration is the time until the next line, in ticks of 1/30s
+	Duration byte
+	// Command is one of the BlinkM commands which sets a color, such as 'n',
+	// 'c', 'h', 'C' and 'H', or 'f' or 't'
+	Command byte
+	// Args are the arguments of the command
+	Args [3]byte
+}
+
 type BlinkMDriver struct {
 	name       string
 	connection I2c
+	// Address is the i2c address of the BlinkM, 0x09 by default
+	Address int
 	gobot.Commander
 }
 
@@ -21,26 +82,135 @@ type BlinkMDriver struct {
 // Adds the following API commands:
 //	Rgb - sets RGB color
 //	Fade - fades the RGB color
+//	FadeHsb - fades to the "hue", "saturation" and "brightness"
+//	FadeRandomRgb - fades to a random RGB color
+//	FadeRandomHsb - fades to a random HSB color
+//	SetFadeSpeed - sets the "speed" of fades
+//	SetTimeAdjust - sets the "adjust" of script durations, from -128 to 127
+//	PlayScript - plays the "script" with "repeats" from "line"
+//	StopScript - stops the script playing
+//	WriteScriptLine - writes the "line" of "duration", "command" and "args"
+//	ReadScriptLine - returns the "line" of the EEPROM script
+//	SetScriptLength - sets the "length" and "repeats" of the EEPROM script
+//	SetStartup - sets "play", "script", "repeats", "speed" and "adjust" at boot
+//	SetAddress - changes the i2c "address"
+//	ReadAddress - returns the i2c address
 //	FirmwareVersion - returns the version of the current Frimware
 //	Color - returns the color of the LED.
+//
+// The red, green, blue, hue, saturation and brightness of the color
+// commands, and their random amounts, are given as numbers from 0 to 255.
 func NewBlinkMDriver(a I2c, name string) *BlinkMDriver {
 	b := &BlinkMDriver{
 		name:       name,
 		connection: a,
+		Address:    blinkmAddress,
 		Commander:  gobot.NewCommander(),
 	}
 
 	b.AddCommand("Rgb", func(params map[string]interface{}) interface{} {
-		red := byte(params["red"].(float64))
-		green := byte(params["green"].(float64))
-		blue := byte(params["blue"].(float64))
-		return b.Rgb(red, green, blue)
+		return blinkmParams(params, func(p ...byte) error {
+			return b.Rgb(p[0], p[1], p[2])
+		}, "red", "green", "blue")
 	})
 	b.AddCommand("Fade", func(params map[string]interface{}) interface{} {
-		red := byte(params["red"].(float64))
-		green := byte(params["green"].(float64))
-		blue := byte(params["blue"].(float64))
-		return b.Fade(red, green, blue)
+		return blinkmParams(params, func(p ...byte) error {
+			return b.Fade(p[0], p[1], p[2])
+		}, "red", "green", "blue")
+	})
+	b.AddCommand("FadeHsb", func(params map[string]interface{}) interface{} {
+		return blinkmParams(params, func(p ...byte) error {
+			return b.FadeHsb(p[0], p[1], p[2])
+		}, "hue", "saturation", "brightness")
+	})
+	b.AddCommand("FadeRandomRgb", func(params map[string]interface{}) interface{} {
+		return blinkmParams(params, func(p ...byte) error {
+			return b.FadeRandomRgb(p[0], p[1], p[2])
+		}, "red", "green", "blue")
+	})
+	b.AddCommand("FadeRandomHsb", func(params map[string]interface{}) interface{} {
+		return blinkmParams(params, func(p ...byte) error {
+			return b.FadeRandomHsb(p[0], p[1], p[2])
+		}, "hue", "saturation", "brightness")
+	})
+	b.AddCommand("SetFadeSpeed", func(params map[string]interface{}) interface{} {
+		return blinkmParams(params, func(p ...byte) error {
+			return b.SetFadeSpeed(p[0])
+		}, "speed")
+	})
+	b.AddCommand("SetTimeAdjust", func(params map[string]interface{}) interface{} {
+		adjust, err := blinkmAdjustParam(params)
+		if err != nil {
+			return err
+		}
+		return b.SetTimeAdjust(adjust)
+	})
+	b.AddCommand("PlayScript", func(params map[string]interface{}) interface{} {
+		return blinkmParams(params, func(p ...byte) error {
+			return b.PlayScript(p[0], p[1], p[2])
+		}, "script", "repeats", "line")
+	})
+	b.AddCommand("StopScript", func(params map[string]interface{}) interface{} {
+		return b.StopScript()
+	})
+	b.AddCommand("WriteScriptLine", func(params map[string]interface{}) interface{} {
+		command, ok := params["command"].(string)
+		if !ok || len(command) != 1 {
+			return ErrInvalidBlinkMParam
+		}
+		args, ok := params["args"].([]interface{})
+		if !ok || len(args) != 3 {
+			return ErrInvalidBlinkMParam
+		}
+		p := map[string]interface{}{
+			"line":     params["line"],
+			"duration": params["duration"],
+			"arg1":     args[0],
+			"arg2":     args[1],
+			"arg3":     args[2],
+		}
+		return blinkmParams(p, func(p ...byte) error {
+			return b.WriteScriptLine(p[0], BlinkMScriptLine{
+				Duration: p[1],
+				Command:  command[0],
+				Args:     [3]byte{p[2], p[3], p[4]},
+			})
+		}, "line", "duration", "arg1", "arg2", "arg3")
+	})
+	b.AddCommand("ReadScriptLine", func(params map[string]interface{}) interface{} {
+		var line BlinkMScriptLine
+		err := blinkmParams(params, func(p ...byte) (err error) {
+			line, err = b.ReadScriptLine(p[0])
+			return
+		}, "line")
+		return map[string]interface{}{"line": line, "err": err}
+	})
+	b.AddCommand("SetScriptLength", func(params map[string]interface{}) interface{} {
+		return blinkmParams(params, func(p ...byte) error {
+			return b.SetScriptLength(p[0], p[1])
+		}, "length", "repeats")
+	})
+	b.AddCommand("SetStartup", func(params map[string]interface{}) interface{} {
+		play, ok := params["play"].(bool)
+		if !ok {
+			return ErrInvalidBlinkMParam
+		}
+		adjust, err := blinkmAdjustParam(params)
+		if err != nil {
+			return err
+		}
+		return blinkmParams(params, func(p ...byte) error {
+			return b.SetStartup(play, p[0], p[1], p[2], adjust)
+		}, "script", "repeats", "speed")
+	})
+	b.AddCommand("SetAddress", func(params map[string]interface{}) interface{} {
+		return blinkmParams(params, func(p ...byte) error {
+			return b.SetAddress(int(p[0]))
+		}, "address")
+	})
+	b.AddCommand("ReadAddress", func(params map[string]interface{}) interface{} {
+		address, err := b.ReadAddress()
+		return map[string]interface{}{"address": address, "err": err}
 	})
 	b.AddCommand("FirmwareVersion", func(params map[string]interface{}) interface{} {
 		version, err := b.FirmwareVersion()
@@ -60,10 +230,10 @@ func (b *BlinkMDriver) Connection() gobot.Connection { return b.connection.(gobo
 
 // Start writes start bytes
 func (b *BlinkMDriver) Start() (errs []error) {
-	if err := b.connection.I2cStart(blinkmAddress); err != nil {
+	if err := b.connection.I2cStart(b.Address); err != nil {
 		return []error{err}
 	}
-	if err := b.connection.I2cWrite(blinkmAddress, []byte("o")); err != nil {
+	if err := b.StopScript(); err != nil {
 		return []error{err}
 	}
 	return
@@ -74,28 +244,169 @@ func (b *BlinkMDriver) Halt() (errs []error) { return }
 
 // Rgb sets color using r,g,b params
 func (b *BlinkMDriver) Rgb(red byte, green byte, blue byte) (err error) {
-	if err = b.connection.I2cWrite(blinkmAddress, []byte("n")); err != nil {
-		return
-	}
-	err = b.connection.I2cWrite(blinkmAddress, []byte{red, green, blue})
-	return
+	return b.command('n', red, green, blue)
 }
 
 // Fade removes color using r,g,b params
 func (b *BlinkMDriver) Fade(red byte, green byte, blue byte) (err error) {
-	if err = b.connection.I2cWrite(blinkmAddress, []byte("c")); err != nil {
+	return b.command('c', red, green, blue)
+}
+
+// FadeHsb fades to the color of hue, saturation and brightness, each 0 to
+// 255
+func (b *BlinkMDriver) FadeHsb(hue byte, saturation byte, brightness byte) (err error) {
+	return b.command('h', hue, saturation, brightness)
+}
+
+// FadeRandomRgb fades to a color up to red, green and blue away from the
+// current one, at random
+func (b *BlinkMDriver) FadeRandomRgb(red byte, green byte, blue byte) (err error) {
+	return b.command('C', red, green, blue)
+}
+
+// FadeRandomHsb fades to a color up to hue, saturation and brightness away
+// from the current one, at random
+func (b *BlinkMDriver) FadeRandomHsb(hue byte, saturation byte, brightness byte) (err error) {
+	return b.command('H', hue, saturation, brightness)
+}
+
+// SetFadeSpeed sets how fast fades are, from 1, the slowest, to 255, which
+// changes the color at once
+func (b *BlinkMDriver) SetFadeSpeed(speed byte) (err error) {
+	if speed == 0 {
+		return ErrInvalidBlinkMFadeSpeed
+	}
+	return b.command('f', speed)
+}
+
+// SetTimeAdjust adds adjust to the durations of the lines of the script
+// playing, slowing it down or, when negative, speeding it up
+func (b *BlinkMDriver) SetTimeAdjust(adjust int8) (err error) {
+	return b.command('t', byte(adjust))
+}
+
+// PlayScript plays script, BlinkMScriptEEPROM or one of those built in,
+// repeats times, or forever if repeats is 0, from line
+func (b *BlinkMDriver) PlayScript(script byte, repeats byte, line byte) (err error) {
+	if script > BlinkMScriptMorseCode {
+		return ErrInvalidBlinkMScript
+	}
+	return b.command('p', script, repeats, line)
+}
+
+// StopScript stops the script playing
+func (b *BlinkMDriver) StopScript() (err error) {
+	return b.command('o')
+}
+
+// WriteScriptLine writes a line, from 0 to 48, of the EEPROM script
+func (b *BlinkMDriver) WriteScriptLine(line byte, l BlinkMScriptLine) (err error) {
+	if line >= blinkmScriptLength {
+		return ErrInvalidBlinkMScriptLine
+	}
+	if err = b.command('W', BlinkMScriptEEPROM, line, l.Duration, l.Command, l.Args[0], l.Args[1], l.Args[2]); err != nil {
 		return
 	}
-	err = b.connection.I2cWrite(blinkmAddress, []byte{red, green, blue})
+	// the EEPROM takes a while to write
+	<-time.After(20 * time.Millisecond)
 	return
 }
 
+// ReadScriptLine reads a line, from 0 to 48, of the EEPROM script
+func (b *BlinkMDriver) ReadScriptLine(line byte) (l BlinkMScriptLine, err error) {
+	if line >= blinkmScriptLength {
+		return l, ErrInvalidBlinkMScriptLine
+	}
+	if err = b.command('R', BlinkMScriptEEPROM, line); err != nil {
+		return
+	}
+	data, err := b.connection.I2cRead(b.Address, 5)
+	if err != nil {
+		return
+	}
+	if len(data) != 5 {
+		return l, ErrNotEnoughBytes
+	}
+	return BlinkMScriptLine{
+		Duration: data[0],
+		Command:  data[1],
+		Args:     [3]byte{data[2], data[3], data[4]},
+	}, nil
+}
+
+// WriteScript writes lines as the EEPROM script, played repeats times, or
+// forever if repeats is 0
+func (b *BlinkMDriver) WriteScript(lines []BlinkMScriptLine, repeats byte) (err error) {
+	if len(lines) > blinkmScriptLength {
+		return ErrInvalidBlinkMScriptLine
+	}
+	for i, l := range lines {
+		if err = b.WriteScriptLine(byte(i), l); err != nil {
+			return
+		}
+	}
+	return b.SetScriptLength(byte(len(lines)), repeats)
+}
+
+// SetScriptLength sets the number of lines of the EEPROM script, and the
+// times it repeats, forever if repeats is 0
+func (b *BlinkMDriver) SetScriptLength(length byte, repeats byte) (err error) {
+	if length > blinkmScriptLength {
+		return ErrInvalidBlinkMScriptLine
+	}
+	return b.command('L', BlinkMScriptEEPROM, length, repeats)
+}
+
+// SetStartup sets what the BlinkM does at power up, playing script repeats
+// times at speed and adjust when play is true, or nothing otherwise
+func (b *BlinkMDriver) SetStartup(play bool, script byte, repeats byte, speed byte, adjust int8) (err error) {
+	if script > BlinkMScriptMorseCode {
+		return ErrInvalidBlinkMScript
+	}
+	if speed == 0 {
+		return ErrInvalidBlinkMFadeSpeed
+	}
+	mode := byte(0)
+	if play {
+		mode = 1
+	}
+	return b.command('B', mode, script, repeats, speed, byte(adjust))
+}
+
+// SetAddress changes the i2c address of the BlinkM, which it keeps from
+// then on, and the Address of the driver to it
+func (b *BlinkMDriver) SetAddress(address int) (err error) {
+	if address < 0x01 || address > 0x7F {
+		return ErrInvalidBlinkMAddress
+	}
+	if err = b.command('A', byte(address), 0xD0, 0x0D, byte(address)); err != nil {
+		return
+	}
+	b.Address = address
+	return b.connection.I2cStart(address)
+}
+
+// ReadAddress returns the i2c address of the BlinkM
+func (b *BlinkMDriver) ReadAddress() (address int, err error) {
+	if err = b.command('a'); err != nil {
+		return
+	}
+	data, err := b.connection.I2cRead(b.Address, 1)
+	if err != nil {
+		return
+	}
+	if len(data) != 1 {
+		return 0, ErrNotEnoughBytes
+	}
+	return int(data[0]), nil
+}
+
 // FirmwareVersion returns version with MAYOR.minor format
 func (b *BlinkMDriver) FirmwareVersion() (version string, err error) {
-	if err = b.connection.I2cWrite(blinkmAddress, []byte("Z")); err != nil {
+	if err = b.command('Z'); err != nil {
 		return
 	}
-	data, err := b.connection.I2cRead(blinkmAddress, 2)
+	data, err := b.connection.I2cRead(b.Address, 2)
 	if len(data) != 2 || err != nil {
 		return
 	}
@@ -104,12 +415,41 @@ func (b *BlinkMDriver) FirmwareVersion() (version string, err error) {
 
 // Color returns an array with current rgb color
 func (b *BlinkMDriver) Color() (color []byte, err error) {
-	if err = b.connection.I2cWrite(blinkmAddress, []byte("g")); err != nil {
+	if err = b.command('g'); err != nil {
 		return
 	}
-	data, err := b.connection.I2cRead(blinkmAddress, 3)
+	data, err := b.connection.I2cRead(b.Address, 3)
 	if len(data) != 3 || err != nil {
 		return []byte{}, err
 	}
 	return []byte{data[0], data[1], data[2]}, nil
 }
+
+// command writes cmd and its args in one write
+func (b *BlinkMDriver) command(cmd byte, args ...byte) error {
+	return b.connection.I2cWrite(b.Address, append([]byte{cmd}, args...))
+}
+
+// blinkmParams calls f with the params named by keys, once they are all
+// checked to be numbers from 0 to 255
+func blinkmParams(params map[string]interface{}, f func(p ...byte) error, keys ...string) error {
+	p := []byte{}
+	for _, key := range keys {
+		val, ok := params[key].(float64)
+		if !ok || val < 0 || val > 255 || val != float64(int(val)) {
+			return ErrInvalidBlinkMParam
+		}
+		p = append(p, byte(val))
+	}
+	return f(p...)
+}
+
+// blinkmAdjustParam returns the time adjust of params, checked to be a
+// number from -128 to 127
+func blinkmAdjustParam(params map[string]interface{}) (adjust int8, err error) {
+	val, ok := params["adjust"].(float64)
+	if !ok || val < -128 || val > 127 || val != float64(int(val)) {
+		return 0, ErrInvalidBlinkMParam
+	}
+	return int8(val), nil
+}
@@ -190,3 +190,135 @@ func TestBlinkMDriverRGB(t *testing.T) {
 	gobot.Assert(t, err, errors.New("write error"))
 
 }
+
+func TestBlinkMDriverCommandWrites(t *testing.T) {
+	adaptor := newI2cTestRegisters(1, 0x00)
+	blinkM := NewBlinkMDriver(adaptor, "bot")
+
+	gobot.Assert(t, blinkM.Rgb(1, 2, 3), nil)
+	gobot.Assert(t, blinkM.FadeHsb(4, 5, 6), nil)
+	gobot.Assert(t, blinkM.FadeRandomRgb(7, 8, 9), nil)
+	gobot.Assert(t, blinkM.FadeRandomHsb(10, 11, 12), nil)
+	gobot.Assert(t, blinkM.SetFadeSpeed(20), nil)
+	gobot.Assert(t, blinkM.SetTimeAdjust(-2), nil)
+	gobot.Assert(t, blinkM.PlayScript(BlinkMScriptHueCycle, 0, 1), nil)
+	gobot.Assert(t, blinkM.StopScript(), nil)
+	gobot.Assert(t, blinkM.SetStartup(true, BlinkMScriptEEPROM, 3, 8, 1), nil)
+	gobot.Assert(t, adaptor.writes, [][]byte{
+		{'n', 1, 2, 3},
+		{'h', 4, 5, 6},
+		{'C', 7, 8, 9},
+		{'H', 10, 11, 12},
+		{'f', 20},
+		{'t', 0xFE},
+		{'p', 10, 0, 1},
+		{'o'},
+		{'B', 1, 0, 3, 8, 1},
+	})
+
+	gobot.Assert(t, blinkM.SetFadeSpeed(0), ErrInvalidBlinkMFadeSpeed)
+	gobot.Assert(t, blinkM.PlayScript(19, 0, 0), ErrInvalidBlinkMScript)
+	gobot.Assert(t, blinkM.SetStartup(false, 19, 0, 8, 0), ErrInvalidBlinkMScript)
+	gobot.Assert(t, len(adaptor.writes), 9)
+}
+
+func TestBlinkMDriverScript(t *testing.T) {
+	adaptor := newI2cTestRegisters(1, 0x00)
+	adaptor.onWrite = func(buf []byte) {
+		if buf[0] == 'R' {
+			copy(adaptor.regs[:], []byte{30, 'c', 0xFF, 0x00, 0x80})
+		}
+	}
+	blinkM := NewBlinkMDriver(adaptor, "bot")
+
+	err := blinkM.WriteScript([]BlinkMScriptLine{
+		{Duration: 30, Command: 'c', Args: [3]byte{0xFF, 0x00, 0x00}},
+		{Duration: 30, Command: 'c', Args: [3]byte{0x00, 0x00, 0xFF}},
+	}, 0)
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, adaptor.writes, [][]byte{
+		{'W', 0, 0, 30, 'c', 0xFF, 0x00, 0x00},
+		{'W', 0, 1, 30, 'c', 0x00, 0x00, 0xFF},
+		{'L', 0, 2, 0},
+	})
+
+	line, err := blinkM.ReadScriptLine(1)
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, adaptor.writes[3], []byte{'R', 0, 1})
+	gobot.Assert(t, line, BlinkMScriptLine{Duration: 30, Command: 'c', Args: [3]byte{0xFF, 0x00, 0x80}})
+
+	_, err = blinkM.ReadScriptLine(49)
+	gobot.Assert(t, err, ErrInvalidBlinkMScriptLine)
+	gobot.Assert(t, blinkM.WriteScriptLine(49, BlinkMScriptLine{}), ErrInvalidBlinkMScriptLine)
+	gobot.Assert(t, blinkM.SetScriptLength(50, 0), ErrInvalidBlinkMScriptLine)
+}
+
+func TestBlinkMDriverAddress(t *testing.T) {
+	adaptor := newI2cTestRegisters(1, 0x00)
+	adaptor.onWrite = func(buf []byte) {
+		if buf[0] == 'a' {
+			adaptor.regs[0] = 0x0A
+		}
+	}
+	blinkM := NewBlinkMDriver(adaptor, "bot")
+
+	gobot.Assert(t, blinkM.Address, 0x09)
+	gobot.Assert(t, blinkM.SetAddress(0x0A), nil)
+	gobot.Assert(t, adaptor.writes[0], []byte{'A', 0x0A, 0xD0, 0x0D, 0x0A})
+	gobot.Assert(t, blinkM.Address, 0x0A)
+
+	address, err := blinkM.ReadAddress()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, address, 0x0A)
+
+	gobot.Assert(t, blinkM.SetAddress(0x00), ErrInvalidBlinkMAddress)
+	gobot.Assert(t, blinkM.SetAddress(0x80), ErrInvalidBlinkMAddress)
+	gobot.Assert(t, blinkM.Address, 0x0A)
+}
+
+func TestBlinkMDriverCommandParams(t *testing.T) {
+	adaptor := newI2cTestRegisters(1, 0x00)
+	blinkM := NewBlinkMDriver(adaptor, "bot")
+
+	result := blinkM.Command("FadeHsb")(map[string]interface{}{"hue": 10.0, "saturation": 255.0, "brightness": 0.0})
+	gobot.Assert(t, result, nil)
+	result = blinkM.Command("SetTimeAdjust")(map[string]interface{}{"adjust": -10.0})
+	gobot.Assert(t, result, nil)
+	result = blinkM.Command("PlayScript")(map[string]interface{}{"script": 3.0, "repeats": 2.0, "line": 0.0})
+	gobot.Assert(t, result, nil)
+	result = blinkM.Command("WriteScriptLine")(map[string]interface{}{
+		"line": 4.0, "duration": 15.0, "command": "c", "args": []interface{}{1.0, 2.0, 3.0},
+	})
+	gobot.Assert(t, result, nil)
+	result = blinkM.Command("SetStartup")(map[string]interface{}{
+		"play": true, "script": 0.0, "repeats": 0.0, "speed": 8.0, "adjust": 0.0,
+	})
+	gobot.Assert(t, result, nil)
+	gobot.Assert(t, adaptor.writes, [][]byte{
+		{'h', 10, 255, 0},
+		{'t', 0xF6},
+		{'p', 3, 2, 0},
+		{'W', 0, 4, 15, 'c', 1, 2, 3},
+		{'B', 1, 0, 0, 8, 0},
+	})
+
+	result = blinkM.Command("Rgb")(map[string]interface{}{"red": 256.0, "green": 0.0, "blue": 0.0})
+	gobot.Assert(t, result, ErrInvalidBlinkMParam)
+	result = blinkM.Command("Fade")(map[string]interface{}{"red": 1.5, "green": 0.0, "blue": 0.0})
+	gobot.Assert(t, result, ErrInvalidBlinkMParam)
+	result = blinkM.Command("SetFadeSpeed")(map[string]interface{}{})
+	gobot.Assert(t, result, ErrInvalidBlinkMParam)
+	result = blinkM.Command("SetTimeAdjust")(map[string]interface{}{"adjust": 128.0})
+	gobot.Assert(t, result, ErrInvalidBlinkMParam)
+	result = blinkM.Command("WriteScriptLine")(map[string]interface{}{
+		"line": 4.0, "duration": 15.0, "command": "cc", "args": []interface{}{1.0, 2.0, 3.0},
+	})
+	gobot.Assert(t, result, ErrInvalidBlinkMParam)
+	result = blinkM.Command("SetStartup")(map[string]interface{}{
+		"script": 0.0, "repeats": 0.0, "speed": 8.0, "adjust": 0.0,
+	})
+	gobot.Assert(t, result, ErrInvalidBlinkMParam)
+	result = blinkM.Command("SetAddress")(map[string]interface{}{"address": 0.0})
+	gobot.Assert(t, result, ErrInvalidBlinkMAddress)
+	gobot.Assert(t, len(adaptor.writes), 5)
+}
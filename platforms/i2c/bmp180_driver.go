@@ -0,0 +1,231 @@
+package i2c
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*BMP180Driver)(nil)
+
+// ErrInvalidBMP180Calibration is the error resulting when the calibration
+// coefficients of a BMP180 are not valid, such as when they are all 0x0000 or
+// 0xFFFF, so its readings can not be compensated
+var ErrInvalidBMP180Calibration = errors.New("Invalid BMP180 calibration coefficients")
+
+const bmp180Address = 0x77
+
+// BMP180 registers and commands
+const (
+	bmp180Calibration = 0xAA
+	bmp180Control     = 0xF4
+	bmp180Data        = 0xF6
+	bmp180Temperature = 0x2E
+	bmp180Pressure    = 0x34
+)
+
+// BMP180Data is a reading of a BMP180
+type BMP180Data struct {
+	// Temperature is the temperature in degrees Celsius
+	Temperature float64
+	// Pressure is the pressure in Pa
+	Pressure float64
+}
+
+// bmp180Coefficients are the calibration coefficients in the EEPROM of a
+// BMP180
+type bmp180Coefficients struct {
+	AC1 int16
+	AC2 int16
+	AC3 int16
+	AC4 uint16
+	AC5 uint16
+	AC6 uint16
+	B1  int16
+	B2  int16
+	MB  int16
+	MC  int16
+	MD  int16
+}
+
+// BMP180Driver represents a BMP180 (or BMP085) barometric pressure and
+// temperature sensor
+type BMP180Driver struct {
+	name         string
+	connection   I2c
+	mutex        sync.Mutex
+	coefficients bmp180Coefficients
+	// Oversampling is the number of samples, 1, 2, 4 or 8 for 0 to 3, the
+	// BMP180 averages for each pressure reading, trading time and power for
+	// noise. It is 0 by default.
+	Oversampling byte
+	gobot.Eventer
+	*Poller
+}
+
+// NewBMP180Driver returns a new BMP180Driver given an I2c adaptor and name.
+//
+// Optionally accepts:
+//  time.Duration: interval at which the BMP180 is read, 1s by default
+//
+// Emits the Events:
+//	"data" - a BMP180Data every interval
+//	"error" - an error reading the BMP180
+func NewBMP180Driver(a I2c, name string, v ...time.Duration) *BMP180Driver {
+	b := &BMP180Driver{
+		name:       name,
+		connection: a,
+		Eventer:    gobot.NewEventer(),
+	}
+
+	interval := time.Second
+	if len(v) > 0 {
+		interval = v[0]
+	}
+
+	b.Poller = NewPoller(b, interval, func() (interface{}, error) {
+		return b.read()
+	})
+	return b
+}
+
+// Name returns the BMP180Drivers name
+func (b *BMP180Driver) Name() string { return b.name }
+
+// Connection returns the BMP180Drivers connection
+func (b *BMP180Driver) Connection() gobot.Connection { return b.connection.(gobot.Connection) }
+
+// Start reads the calibration coefficients of the BMP180 and starts reading
+// it every interval
+func (b *BMP180Driver) Start() (errs []error) {
+	if err := b.connection.I2cStart(bmp180Address); err != nil {
+		return []error{err}
+	}
+	if err := b.connection.I2cWrite(bmp180Address, []byte{bmp180Calibration}); err != nil {
+		return []error{err}
+	}
+	ret, err := b.connection.I2cRead(bmp180Address, 22)
+	if err != nil {
+		return []error{err}
+	}
+	if len(ret) != 22 {
+		return []error{ErrNotEnoughBytes}
+	}
+	// the datasheet checks that no coefficient is 0x0000 or 0xFFFF, as when
+	// the EEPROM is not read correctly
+	for i := 0; i < len(ret); i += 2 {
+		if word := uint16(ret[i])<<8 | uint16(ret[i+1]); word == 0x0000 || word == 0xFFFF {
+			return []error{ErrInvalidBMP180Calibration}
+		}
+	}
+
+	b.mutex.Lock()
+	binary.Read(bytes.NewBuffer(ret), binary.BigEndian, &b.coefficients)
+	b.mutex.Unlock()
+
+	b.StartPolling()
+	return
+}
+
+// Halt stops reading the BMP180
+func (b *BMP180Driver) Halt() (errs []error) {
+	b.StopPolling()
+	return
+}
+
+// Reading returns the last reading of the BMP180, and the time it was made
+func (b *BMP180Driver) Reading() (data BMP180Data, timestamp time.Time) {
+	value, timestamp := b.Latest()
+	data, _ = value.(BMP180Data)
+	return
+}
+
+// read measures the temperature and then the pressure
+func (b *BMP180Driver) read() (data BMP180Data, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	oss := b.Oversampling & 0x03
+	ret, err := b.measure(bmp180Temperature, 5*time.Millisecond, 2)
+	if err != nil {
+		return
+	}
+	ut := int32(ret[0])<<8 | int32(ret[1])
+
+	ret, err = b.measure(bmp180Pressure+oss<<6, time.Duration(2+3<<oss)*time.Millisecond+500*time.Microsecond, 3)
+	if err != nil {
+		return
+	}
+	up := (int32(ret[0])<<16 | int32(ret[1])<<8 | int32(ret[2])) >> (8 - oss)
+
+	temperature, pressure, err := b.compensate(ut, up, oss)
+	if err != nil {
+		return
+	}
+	return BMP180Data{
+		Temperature: float64(temperature) / 10,
+		Pressure:    float64(pressure),
+	}, nil
+}
+
+// measure starts a measurement, waits for it and reads n bytes of result
+func (b *BMP180Driver) measure(command byte, wait time.Duration, n int) (ret []byte, err error) {
+	if err = b.connection.I2cWrite(bmp180Address, []byte{bmp180Control, command}); err != nil {
+		return
+	}
+	<-time.After(wait)
+	if err = b.connection.I2cWrite(bmp180Address, []byte{bmp180Data}); err != nil {
+		return
+	}
+	if ret, err = b.connection.I2cRead(bmp180Address, n); err != nil {
+		return
+	}
+	if len(ret) != n {
+		return nil, ErrNotEnoughBytes
+	}
+	return
+}
+
+// compensate returns the temperature in 0.1 degrees Celsius and the pressure
+// in Pa, given the uncompensated readings, as in the datasheet. It returns
+// ErrInvalidBMP180Calibration when the coefficients would divide by zero.
+func (b *BMP180Driver) compensate(ut int32, up int32, oss byte) (temperature int32, pressure int32, err error) {
+	c := b.coefficients
+
+	x1 := (ut - int32(c.AC6)) * int32(c.AC5) >> 15
+	if x1+int32(c.MD) == 0 {
+		return 0, 0, ErrInvalidBMP180Calibration
+	}
+	x2 := int32(c.MC) << 11 / (x1 + int32(c.MD))
+	b5 := x1 + x2
+	temperature = (b5 + 8) >> 4
+
+	b6 := b5 - 4000
+	x1 = (int32(c.B2) * (b6 * b6 >> 12)) >> 11
+	x2 = int32(c.AC2) * b6 >> 11
+	x3 := x1 + x2
+	b3 := ((int32(c.AC1)*4+x3)<<oss + 2) / 4
+	x1 = int32(c.AC3) * b6 >> 13
+	x2 = (int32(c.B1) * (b6 * b6 >> 12)) >> 16
+	x3 = (x1 + x2 + 2) >> 2
+	b4 := uint32(c.AC4) * uint32(x3+32768) >> 15
+	if b4 == 0 {
+		return 0, 0, ErrInvalidBMP180Calibration
+	}
+	b7 := uint32(up-b3) * (50000 >> oss)
+	var p int32
+	if b7 < 0x80000000 {
+		p = int32(b7 * 2 / b4)
+	} else {
+		p = int32(b7 / b4 * 2)
+	}
+	x1 = (p >> 8) * (p >> 8)
+	x1 = (x1 * 3038) >> 16
+	x2 = (-7357 * p) >> 16
+	pressure = p + (x1+x2+3791)>>4
+	return
+}
@@ -0,0 +1,107 @@
+package i2c
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// the calibration coefficients of the example in the datasheet
+var bmp180TestCoefficients = []byte{
+	0x01, 0x98, 0xFF, 0xB8, 0xC7, 0xD1, 0x7F, 0xE5, 0x7F, 0xF5, 0x5A, 0x71,
+	0x18, 0x2E, 0x00, 0x04, 0x80, 0x00, 0xDD, 0xF9, 0x0B, 0x34,
+}
+
+func initTestBMP180Driver() (*BMP180Driver, *i2cTestRegisters) {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	adaptor.set(bmp180Calibration, bmp180TestCoefficients...)
+	return NewBMP180Driver(adaptor, "bot"), adaptor
+}
+
+func TestBMP180Driver(t *testing.T) {
+	b, _ := initTestBMP180Driver()
+	gobot.Assert(t, b.Name(), "bot")
+	gobot.Assert(t, b.Connection().Name(), "adaptor")
+	gobot.Assert(t, b.Interval(), time.Second)
+
+	b = NewBMP180Driver(newI2cTestAdaptor("adaptor"), "bot", time.Minute)
+	gobot.Assert(t, b.Interval(), time.Minute)
+}
+
+func TestBMP180DriverStart(t *testing.T) {
+	b, adaptor := initTestBMP180Driver()
+	gobot.Assert(t, len(b.Start()), 0)
+	gobot.Assert(t, len(b.Halt()), 0)
+	gobot.Assert(t, b.coefficients.AC1, int16(408))
+	gobot.Assert(t, b.coefficients.AC4, uint16(32741))
+	gobot.Assert(t, b.coefficients.MD, int16(2868))
+
+	adaptor.i2cWriteImpl = func() error {
+		return errors.New("write error")
+	}
+	gobot.Assert(t, b.Start()[0], errors.New("write error"))
+	adaptor.i2cStartImpl = func() error {
+		return errors.New("start error")
+	}
+	gobot.Assert(t, b.Start()[0], errors.New("start error"))
+
+	b = NewBMP180Driver(newI2cTestAdaptor("adaptor"), "bot")
+	gobot.Assert(t, b.Start()[0], ErrNotEnoughBytes)
+
+	// calibration words of 0x0000 or 0xFFFF are rejected
+	b, adaptor = initTestBMP180Driver()
+	adaptor.set(bmp180Calibration+20, 0x00, 0x00)
+	gobot.Assert(t, b.Start()[0], ErrInvalidBMP180Calibration)
+	adaptor.set(bmp180Calibration+20, 0xFF, 0xFF)
+	gobot.Assert(t, b.Start()[0], ErrInvalidBMP180Calibration)
+}
+
+func TestBMP180DriverCompensate(t *testing.T) {
+	b, _ := initTestBMP180Driver()
+	gobot.Assert(t, len(b.Start()), 0)
+	b.Halt()
+
+	temperature, pressure, err := b.compensate(27898, 23843, 0)
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, temperature, int32(150))
+	gobot.Assert(t, pressure, int32(69964))
+
+	// coefficients which would divide by zero are an error rather than a
+	// panic
+	b.coefficients = bmp180Coefficients{}
+	_, _, err = b.compensate(27898, 23843, 0)
+	gobot.Assert(t, err, ErrInvalidBMP180Calibration)
+	b.coefficients = bmp180Coefficients{MD: 1}
+	_, _, err = b.compensate(27898, 23843, 0)
+	gobot.Assert(t, err, ErrInvalidBMP180Calibration)
+}
+
+func TestBMP180DriverRead(t *testing.T) {
+	b, adaptor := initTestBMP180Driver()
+	gobot.Assert(t, len(b.Start()), 0)
+	b.Halt()
+	b.Oversampling = 3
+	adaptor.writes = nil
+
+	// UT is 27898, and UP 23843 of the datasheet taken 8 times
+	adaptor.onWrite = func(buf []byte) {
+		switch {
+		case buf[0] == bmp180Control && buf[1] == bmp180Temperature:
+			copy(adaptor.regs[bmp180Data:], []byte{0x6C, 0xFA})
+		case buf[0] == bmp180Control:
+			copy(adaptor.regs[bmp180Data:], []byte{0x5D, 0x23, 0x00})
+		}
+	}
+	data, err := b.read()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, adaptor.writes[0], []byte{bmp180Control, 0x2E})
+	gobot.Assert(t, adaptor.writes[2], []byte{bmp180Control, 0xF4})
+	gobot.Assert(t, data.Temperature, 15.0)
+	gobot.Assert(t, data.Pressure, 69963.0)
+
+	b = NewBMP180Driver(newI2cTestAdaptor("adaptor"), "bot")
+	_, err = b.read()
+	gobot.Assert(t, err, ErrNotEnoughBytes)
+}
@@ -0,0 +1,285 @@
+package i2c
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*BMP280Driver)(nil)
+
+const bmp280Address = 0x77
+
+// BMP280 and BME280 registers
+const (
+	bmp280ChipID       = 0xD0
+	bmp280Calibration  = 0x88
+	bme280CalibrationA = 0xA1
+	bme280CalibrationB = 0xE1
+	bme280CtrlHum      = 0xF2
+	bmp280CtrlMeas     = 0xF4
+	bmp280Config       = 0xF5
+	bmp280Data         = 0xF7
+)
+
+// bme280ID is the chip ID of the BME280, which also measures humidity
+const bme280ID = 0x60
+
+// BMP280Data is a reading of a BMP280 or BME280
+type BMP280Data struct {
+	// Temperature is the temperature in degrees Celsius
+	Temperature float64
+	// Pressure is the pressure in Pa
+	Pressure float64
+	// Humidity is the relative humidity in percent, always 0 on a BMP280
+	Humidity float64
+}
+
+// bmp280Coefficients are the calibration coefficients in the NVM of a BMP280
+type bmp280Coefficients struct {
+	T1 uint16
+	T2 int16
+	T3 int16
+	P1 uint16
+	P2 int16
+	P3 int16
+	P4 int16
+	P5 int16
+	P6 int16
+	P7 int16
+	P8 int16
+	P9 int16
+}
+
+// bme280Coefficients are the humidity calibration coefficients of a BME280
+type bme280Coefficients struct {
+	H1 uint8
+	H2 int16
+	H3 uint8
+	H4 int16
+	H5 int16
+	H6 int8
+}
+
+// BMP280Driver represents a BMP280 barometric pressure and temperature
+// sensor, or a BME280, which also measures humidity
+type BMP280Driver struct {
+	name       string
+	connection I2c
+	mutex      sync.Mutex
+	humidity   bool
+	bmp280     bmp280Coefficients
+	bme280     bme280Coefficients
+	// Address is the i2c address of the sensor, 0x77 by default, or 0x76
+	// when its SDO pin is low
+	Address int
+	gobot.Eventer
+	*Poller
+}
+
+// NewBMP280Driver returns a new BMP280Driver given an I2c adaptor and name.
+// Whether it is a BMP280 or a BME280 is found from its chip ID by Start.
+//
+// Optionally accepts:
+//  time.Duration: interval at which the sensor is read, 1s by default
+//
+// Emits the Events:
+//	"data" - a BMP280Data every interval
+//	"error" - an error reading the sensor
+func NewBMP280Driver(a I2c, name string, v ...time.Duration) *BMP280Driver {
+	b := &BMP280Driver{
+		name:       name,
+		connection: a,
+		Address:    bmp280Address,
+		Eventer:    gobot.NewEventer(),
+	}
+
+	interval := time.Second
+	if len(v) > 0 {
+		interval = v[0]
+	}
+
+	b.Poller = NewPoller(b, interval, func() (interface{}, error) {
+		return b.read()
+	})
+	return b
+}
+
+// Name returns the BMP280Drivers name
+func (b *BMP280Driver) Name() string { return b.name }
+
+// Connection returns the BMP280Drivers connection
+func (b *BMP280Driver) Connection() gobot.Connection { return b.connection.(gobot.Connection) }
+
+// Start reads the calibration coefficients of the sensor, sets it measuring
+// continuously and starts reading it every interval
+func (b *BMP280Driver) Start() (errs []error) {
+	if err := b.connection.I2cStart(b.Address); err != nil {
+		return []error{err}
+	}
+	if err := b.initialize(); err != nil {
+		return []error{err}
+	}
+	b.StartPolling()
+	return
+}
+
+// Halt stops reading the sensor
+func (b *BMP280Driver) Halt() (errs []error) {
+	b.StopPolling()
+	return
+}
+
+// HasHumidity returns true when the sensor is a BME280, once started
+func (b *BMP280Driver) HasHumidity() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.humidity
+}
+
+// Reading returns the last reading of the sensor, and the time it was made
+func (b *BMP280Driver) Reading() (data BMP280Data, timestamp time.Time) {
+	value, timestamp := b.Latest()
+	data, _ = value.(BMP280Data)
+	return
+}
+
+// initialize reads the chip ID and calibration coefficients, and sets the
+// sensor to measure every 62.5ms with 1 sample of each value
+func (b *BMP280Driver) initialize() (err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	id, err := b.readRegisters(bmp280ChipID, 1)
+	if err != nil {
+		return
+	}
+	b.humidity = id[0] == bme280ID
+
+	ret, err := b.readRegisters(bmp280Calibration, 24)
+	if err != nil {
+		return
+	}
+	binary.Read(bytes.NewBuffer(ret), binary.LittleEndian, &b.bmp280)
+
+	if b.humidity {
+		var h1, h []byte
+		if h1, err = b.readRegisters(bme280CalibrationA, 1); err != nil {
+			return
+		}
+		if h, err = b.readRegisters(bme280CalibrationB, 7); err != nil {
+			return
+		}
+		b.bme280 = bme280Coefficients{
+			H1: h1[0],
+			H2: int16(h[1])<<8 | int16(h[0]),
+			H3: h[2],
+			H4: int16(int8(h[3]))<<4 | int16(h[4]&0x0F),
+			H5: int16(int8(h[5]))<<4 | int16(h[4]>>4),
+			H6: int8(h[6]),
+		}
+		// the humidity setting applies once ctrl_meas is written
+		if err = b.connection.I2cWrite(b.Address, []byte{bme280CtrlHum, 0x01}); err != nil {
+			return
+		}
+	}
+
+	// 62.5ms standby, no filter
+	if err = b.connection.I2cWrite(b.Address, []byte{bmp280Config, 0x20}); err != nil {
+		return
+	}
+	// 1 sample of temperature and pressure, normal mode
+	return b.connection.I2cWrite(b.Address, []byte{bmp280CtrlMeas, 0x27})
+}
+
+// read reads the last measurement of the sensor
+func (b *BMP280Driver) read() (data BMP280Data, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	n := 6
+	if b.humidity {
+		n = 8
+	}
+	ret, err := b.readRegisters(bmp280Data, n)
+	if err != nil {
+		return
+	}
+	adcP := int32(ret[0])<<12 | int32(ret[1])<<4 | int32(ret[2])>>4
+	adcT := int32(ret[3])<<12 | int32(ret[4])<<4 | int32(ret[5])>>4
+
+	temperature, tFine := b.compensateTemperature(adcT)
+	data = BMP280Data{
+		Temperature: temperature,
+		Pressure:    b.compensatePressure(adcP, tFine),
+	}
+	if b.humidity {
+		data.Humidity = b.compensateHumidity(int32(ret[6])<<8|int32(ret[7]), tFine)
+	}
+	return
+}
+
+// readRegisters reads n registers from reg on
+func (b *BMP280Driver) readRegisters(reg byte, n int) (ret []byte, err error) {
+	if err = b.connection.I2cWrite(b.Address, []byte{reg}); err != nil {
+		return
+	}
+	if ret, err = b.connection.I2cRead(b.Address, n); err != nil {
+		return
+	}
+	if len(ret) != n {
+		return nil, ErrNotEnoughBytes
+	}
+	return
+}
+
+// compensateTemperature returns the temperature in degrees Celsius, and the
+// fine temperature the other values are compensated with, as in the
+// datasheet
+func (b *BMP280Driver) compensateTemperature(adcT int32) (temperature float64, tFine float64) {
+	c := b.bmp280
+	var1 := (float64(adcT)/16384 - float64(c.T1)/1024) * float64(c.T2)
+	var2 := float64(adcT)/131072 - float64(c.T1)/8192
+	var2 = var2 * var2 * float64(c.T3)
+	tFine = var1 + var2
+	return tFine / 5120, tFine
+}
+
+// compensatePressure returns the pressure in Pa, as in the datasheet
+func (b *BMP280Driver) compensatePressure(adcP int32, tFine float64) float64 {
+	c := b.bmp280
+	var1 := tFine/2 - 64000
+	var2 := var1 * var1 * float64(c.P6) / 32768
+	var2 = var2 + var1*float64(c.P5)*2
+	var2 = var2/4 + float64(c.P4)*65536
+	var1 = (float64(c.P3)*var1*var1/524288 + float64(c.P2)*var1) / 524288
+	var1 = (1 + var1/32768) * float64(c.P1)
+	if var1 == 0 {
+		return 0
+	}
+	p := 1048576 - float64(adcP)
+	p = (p - var2/4096) * 6250 / var1
+	var1 = float64(c.P9) * p * p / 2147483648
+	var2 = p * float64(c.P8) / 32768
+	return p + (var1+var2+float64(c.P7))/16
+}
+
+// compensateHumidity returns the relative humidity in percent, as in the
+// datasheet of the BME280
+func (b *BMP280Driver) compensateHumidity(adcH int32, tFine float64) float64 {
+	c := b.bme280
+	h := tFine - 76800
+	h = (float64(adcH) - (float64(c.H4)*64 + float64(c.H5)/16384*h)) *
+		(float64(c.H2) / 65536 * (1 + float64(c.H6)/67108864*h*(1+float64(c.H3)/67108864*h)))
+	h = h * (1 - float64(c.H1)*h/524288)
+	switch {
+	case h > 100:
+		return 100
+	case h < 0:
+		return 0
+	}
+	return h
+}
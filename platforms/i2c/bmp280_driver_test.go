@@ -0,0 +1,88 @@
+package i2c
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// the calibration coefficients of the example in the BMP280 datasheet
+var bmp280TestCoefficients = []byte{
+	0x70, 0x6B, 0x43, 0x67, 0x18, 0xFC, 0x7D, 0x8E, 0x43, 0xD6, 0xD0, 0x0B,
+	0x27, 0x0B, 0x8C, 0x00, 0xF9, 0xFF, 0x8C, 0x3C, 0xF8, 0xC6, 0x70, 0x17,
+}
+
+func initTestBMP280Driver(id byte) (*BMP280Driver, *i2cTestRegisters) {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	adaptor.set(bmp280ChipID, id)
+	adaptor.set(bmp280Calibration, bmp280TestCoefficients...)
+	// adc_T of 519888 and adc_P of 415148
+	adaptor.set(bmp280Data, 0x65, 0x5A, 0xC0, 0x7E, 0xED, 0x00, 0x80, 0x00)
+	return NewBMP280Driver(adaptor, "bot"), adaptor
+}
+
+func TestBMP280Driver(t *testing.T) {
+	b, _ := initTestBMP280Driver(0x58)
+	gobot.Assert(t, b.Name(), "bot")
+	gobot.Assert(t, b.Connection().Name(), "adaptor")
+	gobot.Assert(t, b.Address, 0x77)
+	gobot.Assert(t, b.Interval(), time.Second)
+
+	b = NewBMP280Driver(newI2cTestAdaptor("adaptor"), "bot", time.Minute)
+	gobot.Assert(t, b.Interval(), time.Minute)
+}
+
+func TestBMP280DriverStart(t *testing.T) {
+	b, adaptor := initTestBMP280Driver(0x58)
+	gobot.Assert(t, len(b.Start()), 0)
+	gobot.Assert(t, len(b.Halt()), 0)
+	gobot.Assert(t, b.HasHumidity(), false)
+	gobot.Assert(t, b.bmp280.T1, uint16(27504))
+	gobot.Assert(t, b.bmp280.P8, int16(-14600))
+	gobot.Assert(t, adaptor.writes[2:4], [][]byte{{0xF5, 0x20}, {0xF4, 0x27}})
+
+	adaptor.i2cWriteImpl = func() error {
+		return errors.New("write error")
+	}
+	gobot.Assert(t, b.Start()[0], errors.New("write error"))
+	adaptor.i2cStartImpl = func() error {
+		return errors.New("start error")
+	}
+	gobot.Assert(t, b.Start()[0], errors.New("start error"))
+
+	b = NewBMP280Driver(newI2cTestAdaptor("adaptor"), "bot")
+	gobot.Assert(t, b.Start()[0], ErrNotEnoughBytes)
+}
+
+func TestBMP280DriverRead(t *testing.T) {
+	b, _ := initTestBMP280Driver(0x58)
+	gobot.Assert(t, b.initialize(), nil)
+
+	data, err := b.read()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, math.Abs(data.Temperature-25.08) < 0.01, true)
+	gobot.Assert(t, math.Abs(data.Pressure-100653.27) < 0.01, true)
+	gobot.Assert(t, data.Humidity, 0.0)
+}
+
+func TestBME280DriverRead(t *testing.T) {
+	b, adaptor := initTestBMP280Driver(0x60)
+	adaptor.set(bme280CalibrationA, 75)
+	adaptor.set(bme280CalibrationB, 0x6A, 0x01, 0x00, 0x13, 0x2A, 0x03, 0x1E)
+	gobot.Assert(t, b.initialize(), nil)
+	gobot.Assert(t, b.HasHumidity(), true)
+	gobot.Assert(t, b.bme280, bme280Coefficients{H1: 75, H2: 362, H3: 0, H4: 314, H5: 50, H6: 30})
+	gobot.Assert(t, adaptor.writes[len(adaptor.writes)-3], []byte{0xF2, 0x01})
+
+	data, err := b.read()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, data.Humidity > 0 && data.Humidity < 100, true)
+
+	// humidity is limited to 0-100%
+	_, tFine := b.compensateTemperature(519888)
+	gobot.Assert(t, b.compensateHumidity(0, tFine), 0.0)
+	gobot.Assert(t, b.compensateHumidity(0xFFFF, tFine), 100.0)
+}
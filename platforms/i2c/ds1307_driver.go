@@ -0,0 +1,189 @@
+package i2c
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*DS1307Driver)(nil)
+
+var (
+	// ErrRTCStopped is the error resulting when the time of a real-time
+	// clock is read while its oscillator is, or has been, stopped, so the
+	// time is not valid until it is set
+	ErrRTCStopped = errors.New("Clock is stopped, set its time")
+	// ErrInvalidRTCTime is the error resulting when a real-time clock is set
+	// to a time outside of the years it counts
+	ErrInvalidRTCTime = errors.New("Time is out of the range of the clock")
+	// ErrInvalidSquareWave is the error resulting when a real-time clock is
+	// set to a square wave rate it does not have
+	ErrInvalidSquareWave = errors.New("Invalid square wave rate")
+)
+
+const ds1307Address = 0x68
+
+// DS1307 registers and bits
+const (
+	rtcSeconds       = 0x00
+	ds1307Control    = 0x07
+	ds1307ClockHalt  = 0x80
+	ds1307SquareWave = 0x10
+)
+
+// rtcTime returns the time, in UTC, of the 7 time registers of a DS1307 or
+// DS3231. The hours may be in 12 hour mode, and the high bit of the month is
+// the century of a DS3231.
+func rtcTime(regs []byte) time.Time {
+	hours := bcdToInt(regs[2] & 0x3F)
+	if regs[2]&0x40 != 0 {
+		hours = bcdToInt(regs[2]&0x1F) % 12
+		if regs[2]&0x20 != 0 {
+			hours += 12
+		}
+	}
+	year := 2000 + bcdToInt(regs[6])
+	if regs[5]&0x80 != 0 {
+		year += 100
+	}
+	return time.Date(
+		year,
+		time.Month(bcdToInt(regs[5]&0x1F)),
+		bcdToInt(regs[4]&0x3F),
+		hours,
+		bcdToInt(regs[1]&0x7F),
+		bcdToInt(regs[0]&0x7F),
+		0,
+		time.UTC,
+	)
+}
+
+// rtcRegisters returns the 7 time registers of t in UTC, in 24 hour mode,
+// with the century in the high bit of the month
+func rtcRegisters(t time.Time) []byte {
+	t = t.UTC()
+	month := intToBcd(int(t.Month()))
+	if t.Year() >= 2100 {
+		month |= 0x80
+	}
+	return []byte{
+		intToBcd(t.Second()),
+		intToBcd(t.Minute()),
+		intToBcd(t.Hour()),
+		byte(t.Weekday()) + 1,
+		intToBcd(t.Day()),
+		month,
+		intToBcd(t.Year() % 100),
+	}
+}
+
+// bcdToInt returns the value of the binary coded decimal b
+func bcdToInt(b byte) int {
+	return int(b>>4)*10 + int(b&0x0F)
+}
+
+// intToBcd returns the binary coded decimal of n, from 0 to 99
+func intToBcd(n int) byte {
+	return byte(n/10<<4 | n%10)
+}
+
+// DS1307Driver represents a DS1307 real-time clock, which keeps the time,
+// from 2000 to 2099, while its battery lasts
+type DS1307Driver struct {
+	name       string
+	connection I2c
+	gobot.Commander
+}
+
+// NewDS1307Driver returns a new DS1307Driver given an I2c adaptor and name.
+//
+// Adds the following API Commands:
+//	"Now" - See DS1307Driver.Now
+//	"SetTime" - See DS1307Driver.SetTime, the time is given as "time" in RFC 3339 format
+//	"SetSquareWave" - See DS1307Driver.SetSquareWave, the rate is given as "rate"
+func NewDS1307Driver(a I2c, name string) *DS1307Driver {
+	d := &DS1307Driver{
+		name:       name,
+		connection: a,
+		Commander:  gobot.NewCommander(),
+	}
+
+	d.AddCommand("Now", func(params map[string]interface{}) interface{} {
+		now, err := d.Now()
+		return map[string]interface{}{"time": now, "err": err}
+	})
+	d.AddCommand("SetTime", func(params map[string]interface{}) interface{} {
+		t, err := time.Parse(time.RFC3339, params["time"].(string))
+		if err != nil {
+			return err
+		}
+		return d.SetTime(t)
+	})
+	d.AddCommand("SetSquareWave", func(params map[string]interface{}) interface{} {
+		return d.SetSquareWave(int(params["rate"].(float64)))
+	})
+
+	return d
+}
+
+// Name returns the DS1307Drivers name
+func (d *DS1307Driver) Name() string { return d.name }
+
+// Connection returns the DS1307Drivers connection
+func (d *DS1307Driver) Connection() gobot.Connection { return d.connection.(gobot.Connection) }
+
+// Start implements the Driver interface
+func (d *DS1307Driver) Start() (errs []error) {
+	if err := d.connection.I2cStart(ds1307Address); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Halt implements the Driver interface
+func (d *DS1307Driver) Halt() (errs []error) { return }
+
+// Now returns the time of the clock in UTC, or ErrRTCStopped if it has never
+// been set, or has been halted
+func (d *DS1307Driver) Now() (now time.Time, err error) {
+	if err = d.connection.I2cWrite(ds1307Address, []byte{rtcSeconds}); err != nil {
+		return
+	}
+	regs, err := d.connection.I2cRead(ds1307Address, 7)
+	if err != nil {
+		return
+	}
+	if len(regs) != 7 {
+		return now, ErrNotEnoughBytes
+	}
+	if regs[0]&ds1307ClockHalt != 0 {
+		return now, ErrRTCStopped
+	}
+	return rtcTime(regs), nil
+}
+
+// SetTime sets the clock to t, kept in UTC, and starts it if it was halted
+func (d *DS1307Driver) SetTime(t time.Time) error {
+	if t.UTC().Year() < 2000 || t.UTC().Year() > 2099 {
+		return ErrInvalidRTCTime
+	}
+	return d.connection.I2cWrite(ds1307Address, append([]byte{rtcSeconds}, rtcRegisters(t)...))
+}
+
+// SetSquareWave sets the rate of the square wave of the SQW/OUT pin, 1,
+// 4096, 8192 or 32768 Hz, or 0 to hold it low
+func (d *DS1307Driver) SetSquareWave(rate int) error {
+	rates := map[int]byte{
+		0:     0x00,
+		1:     ds1307SquareWave | 0x00,
+		4096:  ds1307SquareWave | 0x01,
+		8192:  ds1307SquareWave | 0x02,
+		32768: ds1307SquareWave | 0x03,
+	}
+	control, ok := rates[rate]
+	if !ok {
+		return ErrInvalidSquareWave
+	}
+	return d.connection.I2cWrite(ds1307Address, []byte{ds1307Control, control})
+}
@@ -0,0 +1,81 @@
+package i2c
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestDS1307Driver(t *testing.T) {
+	d := NewDS1307Driver(newI2cTestAdaptor("adaptor"), "bot")
+	gobot.Assert(t, d.Name(), "bot")
+	gobot.Assert(t, d.Connection().Name(), "adaptor")
+	gobot.Assert(t, len(d.Start()), 0)
+	gobot.Assert(t, len(d.Halt()), 0)
+}
+
+func TestDS1307DriverNow(t *testing.T) {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	d := NewDS1307Driver(adaptor, "bot")
+
+	adaptor.set(0, 0x56, 0x34, 0x12, 0x05, 0x28, 0x02, 0x24)
+	now, err := d.Now()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, now, time.Date(2024, time.February, 28, 12, 34, 56, 0, time.UTC))
+
+	// 12 hour mode, 11pm
+	adaptor.set(0, 0x00, 0x00, 0x71, 0x01, 0x01, 0x01, 0x99)
+	now, err = d.Now()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, now, time.Date(2099, time.January, 1, 23, 0, 0, 0, time.UTC))
+
+	// halted
+	adaptor.set(0, 0x80)
+	_, err = d.Now()
+	gobot.Assert(t, err, ErrRTCStopped)
+}
+
+func TestDS1307DriverSetTime(t *testing.T) {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	d := NewDS1307Driver(adaptor, "bot")
+
+	// a Sunday, written in UTC
+	zone := time.FixedZone("UTC+2", 2*60*60)
+	gobot.Assert(t, d.SetTime(time.Date(2016, time.March, 6, 9, 8, 7, 0, zone)), nil)
+	gobot.Assert(t, adaptor.writes[0], []byte{0x00, 0x07, 0x08, 0x07, 0x01, 0x06, 0x03, 0x16})
+
+	now, err := d.Now()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, now.Equal(time.Date(2016, time.March, 6, 9, 8, 7, 0, zone)), true)
+
+	gobot.Assert(t, d.SetTime(time.Date(1999, time.December, 31, 0, 0, 0, 0, time.UTC)), ErrInvalidRTCTime)
+	gobot.Assert(t, d.SetTime(time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)), ErrInvalidRTCTime)
+}
+
+func TestDS1307DriverSetSquareWave(t *testing.T) {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	d := NewDS1307Driver(adaptor, "bot")
+
+	gobot.Assert(t, d.SetSquareWave(1), nil)
+	gobot.Assert(t, d.SetSquareWave(32768), nil)
+	gobot.Assert(t, d.SetSquareWave(0), nil)
+	gobot.Assert(t, d.SetSquareWave(1024), ErrInvalidSquareWave)
+	gobot.Assert(t, adaptor.writes, [][]byte{{0x07, 0x10}, {0x07, 0x13}, {0x07, 0x00}})
+}
+
+func TestDS1307DriverCommands(t *testing.T) {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	d := NewDS1307Driver(adaptor, "bot")
+
+	result := d.Command("SetTime")(map[string]interface{}{"time": "2016-03-06T09:08:07Z"})
+	gobot.Assert(t, result, nil)
+	result = d.Command("Now")(map[string]interface{}{})
+	gobot.Assert(t, result.(map[string]interface{})["time"], time.Date(2016, time.March, 6, 9, 8, 7, 0, time.UTC))
+	result = d.Command("SetSquareWave")(map[string]interface{}{"rate": 4096.0})
+	gobot.Assert(t, result, nil)
+	gobot.Assert(t, adaptor.regs[0x07], byte(0x11))
+
+	result = d.Command("SetTime")(map[string]interface{}{"time": "yesterday"})
+	gobot.Refute(t, result, nil)
+}
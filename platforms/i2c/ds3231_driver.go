@@ -0,0 +1,164 @@
+package i2c
+
+import (
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*DS3231Driver)(nil)
+
+const ds3231Address = 0x68
+
+// DS3231 registers and bits
+const (
+	ds3231Control          = 0x0E
+	ds3231Status           = 0x0F
+	ds3231Temperature      = 0x11
+	ds3231InterruptControl = 0x04
+	ds3231RateSelect       = 0x18
+	ds3231OscillatorStop   = 0x80
+)
+
+// DS3231Driver represents a DS3231 temperature compensated real-time clock,
+// which keeps the time, from 2000 to 2199, while its battery lasts
+type DS3231Driver struct {
+	name       string
+	connection I2c
+	gobot.Commander
+}
+
+// NewDS3231Driver returns a new DS3231Driver given an I2c adaptor and name.
+//
+// Adds the following API Commands:
+//	"Now" - See DS3231Driver.Now
+//	"SetTime" - See DS3231Driver.SetTime, the time is given as "time" in RFC 3339 format
+//	"SetSquareWave" - See DS3231Driver.SetSquareWave, the rate is given as "rate"
+//	"Temperature" - See DS3231Driver.Temperature
+func NewDS3231Driver(a I2c, name string) *DS3231Driver {
+	d := &DS3231Driver{
+		name:       name,
+		connection: a,
+		Commander:  gobot.NewCommander(),
+	}
+
+	d.AddCommand("Now", func(params map[string]interface{}) interface{} {
+		now, err := d.Now()
+		return map[string]interface{}{"time": now, "err": err}
+	})
+	d.AddCommand("SetTime", func(params map[string]interface{}) interface{} {
+		t, err := time.Parse(time.RFC3339, params["time"].(string))
+		if err != nil {
+			return err
+		}
+		return d.SetTime(t)
+	})
+	d.AddCommand("SetSquareWave", func(params map[string]interface{}) interface{} {
+		return d.SetSquareWave(int(params["rate"].(float64)))
+	})
+	d.AddCommand("Temperature", func(params map[string]interface{}) interface{} {
+		temperature, err := d.Temperature()
+		return map[string]interface{}{"temperature": temperature, "err": err}
+	})
+
+	return d
+}
+
+// Name returns the DS3231Drivers name
+func (d *DS3231Driver) Name() string { return d.name }
+
+// Connection returns the DS3231Drivers connection
+func (d *DS3231Driver) Connection() gobot.Connection { return d.connection.(gobot.Connection) }
+
+// Start implements the Driver interface
+func (d *DS3231Driver) Start() (errs []error) {
+	if err := d.connection.I2cStart(ds3231Address); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Halt implements the Driver interface
+func (d *DS3231Driver) Halt() (errs []error) { return }
+
+// Now returns the time of the clock in UTC, or ErrRTCStopped if its
+// oscillator has stopped since the time was set, such as when it first had
+// power or its battery ran out
+func (d *DS3231Driver) Now() (now time.Time, err error) {
+	regs, err := d.read(rtcSeconds, 7)
+	if err != nil {
+		return
+	}
+	status, err := d.read(ds3231Status, 1)
+	if err != nil {
+		return
+	}
+	if status[0]&ds3231OscillatorStop != 0 {
+		return now, ErrRTCStopped
+	}
+	return rtcTime(regs), nil
+}
+
+// SetTime sets the clock to t, kept in UTC, and clears the flag of its
+// oscillator having stopped
+func (d *DS3231Driver) SetTime(t time.Time) (err error) {
+	if t.UTC().Year() < 2000 || t.UTC().Year() > 2199 {
+		return ErrInvalidRTCTime
+	}
+	if err = d.connection.I2cWrite(ds3231Address, append([]byte{rtcSeconds}, rtcRegisters(t)...)); err != nil {
+		return
+	}
+	status, err := d.read(ds3231Status, 1)
+	if err != nil {
+		return
+	}
+	return d.connection.I2cWrite(ds3231Address, []byte{ds3231Status, status[0] &^ ds3231OscillatorStop})
+}
+
+// SetSquareWave sets the rate of the square wave of the INT/SQW pin, 1, 1024,
+// 4096 or 8192 Hz, or 0 to use the pin for alarm interrupts
+func (d *DS3231Driver) SetSquareWave(rate int) (err error) {
+	rates := map[int]byte{
+		0:    ds3231InterruptControl,
+		1:    0x00,
+		1024: 0x08,
+		4096: 0x10,
+		8192: 0x18,
+	}
+	bits, ok := rates[rate]
+	if !ok {
+		return ErrInvalidSquareWave
+	}
+	control, err := d.read(ds3231Control, 1)
+	if err != nil {
+		return
+	}
+	return d.connection.I2cWrite(ds3231Address, []byte{
+		ds3231Control,
+		control[0]&^(ds3231InterruptControl|ds3231RateSelect) | bits,
+	})
+}
+
+// Temperature returns the temperature, which the DS3231 measures every 64
+// seconds to compensate its oscillator, in degrees Celsius to 0.25 degrees
+func (d *DS3231Driver) Temperature() (temperature float64, err error) {
+	ret, err := d.read(ds3231Temperature, 2)
+	if err != nil {
+		return
+	}
+	return float64(int8(ret[0])) + float64(ret[1]>>6)*0.25, nil
+}
+
+// read reads n registers from reg on
+func (d *DS3231Driver) read(reg byte, n int) (ret []byte, err error) {
+	if err = d.connection.I2cWrite(ds3231Address, []byte{reg}); err != nil {
+		return
+	}
+	if ret, err = d.connection.I2cRead(ds3231Address, n); err != nil {
+		return
+	}
+	if len(ret) != n {
+		return nil, ErrNotEnoughBytes
+	}
+	return
+}
@@ -0,0 +1,68 @@
+package i2c
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestDS3231Driver(t *testing.T) {
+	d := NewDS3231Driver(newI2cTestAdaptor("adaptor"), "bot")
+	gobot.Assert(t, d.Name(), "bot")
+	gobot.Assert(t, d.Connection().Name(), "adaptor")
+	gobot.Assert(t, len(d.Start()), 0)
+	gobot.Assert(t, len(d.Halt()), 0)
+}
+
+func TestDS3231DriverTime(t *testing.T) {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	d := NewDS3231Driver(adaptor, "bot")
+
+	// the oscillator has stopped
+	adaptor.set(0x0F, 0x88)
+	_, err := d.Now()
+	gobot.Assert(t, err, ErrRTCStopped)
+
+	// the next century, and the oscillator flag cleared
+	gobot.Assert(t, d.SetTime(time.Date(2101, time.October, 19, 18, 30, 0, 0, time.UTC)), nil)
+	gobot.Assert(t, adaptor.writes[2], []byte{0x00, 0x00, 0x30, 0x18, 0x04, 0x19, 0x90, 0x01})
+	gobot.Assert(t, adaptor.regs[0x0F], byte(0x08))
+
+	now, err := d.Now()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, now, time.Date(2101, time.October, 19, 18, 30, 0, 0, time.UTC))
+
+	gobot.Assert(t, d.SetTime(time.Date(2200, time.January, 1, 0, 0, 0, 0, time.UTC)), ErrInvalidRTCTime)
+}
+
+func TestDS3231DriverSetSquareWave(t *testing.T) {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	d := NewDS3231Driver(adaptor, "bot")
+
+	// the default with the alarm interrupts enabled
+	adaptor.set(0x0E, 0x1F)
+	gobot.Assert(t, d.SetSquareWave(1024), nil)
+	gobot.Assert(t, adaptor.regs[0x0E], byte(0x0B))
+	gobot.Assert(t, d.SetSquareWave(0), nil)
+	gobot.Assert(t, adaptor.regs[0x0E], byte(0x07))
+	gobot.Assert(t, d.SetSquareWave(32768), ErrInvalidSquareWave)
+}
+
+func TestDS3231DriverTemperature(t *testing.T) {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	d := NewDS3231Driver(adaptor, "bot")
+
+	adaptor.set(0x11, 0x19, 0x40)
+	temperature, err := d.Temperature()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, temperature, 25.25)
+
+	adaptor.set(0x11, 0xFE, 0x40)
+	temperature, err = d.Temperature()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, temperature, -1.75)
+
+	result := d.Command("Temperature")(map[string]interface{}{})
+	gobot.Assert(t, result.(map[string]interface{})["temperature"], -1.75)
+}
@@ -1,5 +1,7 @@
 package i2c
 
+import "sync"
+
 var rgb = map[string]interface{}{
 	"red":   1.0,
 	"green": 1.0,
@@ -48,3 +50,54 @@ func newI2cTestAdaptor(name string) *i2cTestAdaptor {
 		},
 	}
 }
+
+// i2cTestRegisters fakes a device with registers, width bytes each, from which
+// reads start at the register last written to. The register is the first
+// byte written, masked by mask.
+type i2cTestRegisters struct {
+	i2cTestAdaptor
+	mutex   sync.Mutex
+	regs    [256]byte
+	width   int
+	mask    byte
+	pointer int
+	address int
+	writes  [][]byte
+	// onWrite, when set, is called with each write, as the device responds
+	// to commands, and may change regs
+	onWrite func(buf []byte)
+}
+
+func newI2cTestRegisters(width int, mask byte) *i2cTestRegisters {
+	return &i2cTestRegisters{
+		i2cTestAdaptor: *newI2cTestAdaptor("adaptor"),
+		width:          width,
+		mask:           mask,
+	}
+}
+
+func (t *i2cTestRegisters) I2cWrite(address int, buf []byte) (err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.address = address
+	t.writes = append(t.writes, append([]byte{}, buf...))
+	t.pointer = int(buf[0]&t.mask) * t.width
+	copy(t.regs[t.pointer:], buf[1:])
+	if t.onWrite != nil {
+		t.onWrite(buf)
+	}
+	return t.i2cWriteImpl()
+}
+
+func (t *i2cTestRegisters) I2cRead(address int, n int) (data []byte, err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return append([]byte{}, t.regs[t.pointer:t.pointer+n]...), nil
+}
+
+// set sets the registers from reg on to data
+func (t *i2cTestRegisters) set(reg int, data ...byte) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	copy(t.regs[reg*t.width:], data)
+}
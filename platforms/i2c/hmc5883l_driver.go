@@ -0,0 +1,239 @@
+package i2c
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*HMC5883LDriver)(nil)
+
+// ErrMagneticOverflow is the error resulting when the field along an axis of
+// a magnetometer is beyond the range of its gain
+var ErrMagneticOverflow = errors.New("Magnetic field is out of range")
+
+const hmc5883lAddress = 0x1E
+
+// HMC5883L registers
+const (
+	hmc5883lConfigA = 0x00
+	hmc5883lConfigB = 0x01
+	hmc5883lMode    = 0x02
+	hmc5883lData    = 0x03
+)
+
+// hmc5883lOverflow is the value of an axis out of range
+const hmc5883lOverflow = -4096
+
+// The gains of an HMC5883L, by the largest field in gauss read at each
+const (
+	HMC5883LGain0_88 = iota
+	HMC5883LGain1_3
+	HMC5883LGain1_9
+	HMC5883LGain2_5
+	HMC5883LGain4_0
+	HMC5883LGain4_7
+	HMC5883LGain5_6
+	HMC5883LGain8_1
+)
+
+// hmc5883lScales are the raw values of 1 gauss at each gain
+var hmc5883lScales = []float64{1370, 1090, 820, 660, 440, 390, 330, 230}
+
+// HMC5883LData is a reading of an HMC5883L
+type HMC5883LData struct {
+	// Field is the magnetic field in gauss, less the hard iron offset
+	Field ThreeDVector
+	// Heading is the angle in degrees clockwise from north, from 0 to 360,
+	// of the X axis when the HMC5883L lies flat. It is the magnetic heading
+	// corrected by the Declination.
+	Heading float64
+}
+
+// HMC5883LDriver represents an HMC5883L 3-axis digital compass, which it
+// reads continuously
+type HMC5883LDriver struct {
+	name       string
+	connection I2c
+	mutex      sync.Mutex
+	offset     ThreeDData
+	// Gain is one of HMC5883LGain0_88 to HMC5883LGain8_1, set by Start,
+	// HMC5883LGain1_3 by default
+	Gain byte
+	// Declination is the angle in degrees from magnetic north to true north
+	// where the HMC5883L is, east being positive, added to the heading
+	Declination float64
+	gobot.Eventer
+	*Poller
+}
+
+// NewHMC5883LDriver returns a new HMC5883LDriver given an I2c adaptor and
+// name.
+//
+// Optionally accepts:
+//  time.Duration: interval at which the HMC5883L is read, 100ms by default
+//
+// Emits the Events:
+//	"data" - an HMC5883LData every interval
+//	"error" - an error reading the HMC5883L
+func NewHMC5883LDriver(a I2c, name string, v ...time.Duration) *HMC5883LDriver {
+	h := &HMC5883LDriver{
+		name:       name,
+		connection: a,
+		Gain:       HMC5883LGain1_3,
+		Eventer:    gobot.NewEventer(),
+	}
+
+	interval := 100 * time.Millisecond
+	if len(v) > 0 {
+		interval = v[0]
+	}
+
+	h.Poller = NewPoller(h, interval, func() (interface{}, error) {
+		return h.read()
+	})
+	return h
+}
+
+// Name returns the HMC5883LDrivers name
+func (h *HMC5883LDriver) Name() string { return h.name }
+
+// Connection returns the HMC5883LDrivers connection
+func (h *HMC5883LDriver) Connection() gobot.Connection { return h.connection.(gobot.Connection) }
+
+// Start sets the HMC5883L to measure continuously, averaging 8 samples 15
+// times a second at Gain, and starts reading it every interval
+func (h *HMC5883LDriver) Start() (errs []error) {
+	if err := h.initialize(); err != nil {
+		return []error{err}
+	}
+	h.StartPolling()
+	return
+}
+
+// Halt stops reading the HMC5883L, and puts it to sleep
+func (h *HMC5883LDriver) Halt() (errs []error) {
+	h.StopPolling()
+	if err := h.connection.I2cWrite(hmc5883lAddress, []byte{hmc5883lMode, 0x03}); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Reading returns the last reading of the HMC5883L, and the time it was made
+func (h *HMC5883LDriver) Reading() (data HMC5883LData, timestamp time.Time) {
+	value, timestamp := h.Latest()
+	data, _ = value.(HMC5883LData)
+	return
+}
+
+// Calibrate reads the HMC5883L samples times, once every interval, while it
+// is turned through every direction, and sets the hard iron offset, of
+// magnets and iron fixed near it, to the middle of the range along each
+// axis. It must be called after Start.
+func (h *HMC5883LDriver) Calibrate(samples int) (err error) {
+	min := [3]int{math.MaxInt16, math.MaxInt16, math.MaxInt16}
+	max := [3]int{math.MinInt16, math.MinInt16, math.MinInt16}
+	for i := 0; i < samples; i++ {
+		h.mutex.Lock()
+		raw, err := h.readRaw()
+		h.mutex.Unlock()
+		if err != nil {
+			return err
+		}
+		for j, val := range [3]int16{raw.X, raw.Y, raw.Z} {
+			if int(val) < min[j] {
+				min[j] = int(val)
+			}
+			if int(val) > max[j] {
+				max[j] = int(val)
+			}
+		}
+		<-time.After(h.Interval())
+	}
+
+	h.SetOffset(ThreeDData{
+		X: int16((min[0] + max[0]) / 2),
+		Y: int16((min[1] + max[1]) / 2),
+		Z: int16((min[2] + max[2]) / 2),
+	})
+	return
+}
+
+// Offset returns the hard iron offset removed from the raw readings, as set
+// by Calibrate, so it can be kept, such as in an AT24CDriver
+func (h *HMC5883LDriver) Offset() ThreeDData {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.offset
+}
+
+// SetOffset sets the hard iron offset removed from the raw readings, such as
+// that of an earlier Calibrate
+func (h *HMC5883LDriver) SetOffset(offset ThreeDData) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.offset = offset
+}
+
+// initialize sets the averaging, rate, gain and continuous mode
+func (h *HMC5883LDriver) initialize() (err error) {
+	if err = h.connection.I2cStart(hmc5883lAddress); err != nil {
+		return
+	}
+	// 8 samples averaged, 15Hz, normal measurement
+	if err = h.connection.I2cWrite(hmc5883lAddress, []byte{hmc5883lConfigA, 0x70}); err != nil {
+		return
+	}
+	if err = h.connection.I2cWrite(hmc5883lAddress, []byte{hmc5883lConfigB, (h.Gain & 0x07) << 5}); err != nil {
+		return
+	}
+	return h.connection.I2cWrite(hmc5883lAddress, []byte{hmc5883lMode, 0x00})
+}
+
+// read reads the HMC5883L and returns the reading in gauss and degrees
+func (h *HMC5883LDriver) read() (data HMC5883LData, err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	raw, err := h.readRaw()
+	if err != nil {
+		return
+	}
+	scale := hmc5883lScales[h.Gain&0x07]
+	data.Field = ThreeDVector{
+		X: float64(int(raw.X)-int(h.offset.X)) / scale,
+		Y: float64(int(raw.Y)-int(h.offset.Y)) / scale,
+		Z: float64(int(raw.Z)-int(h.offset.Z)) / scale,
+	}
+	heading := math.Atan2(data.Field.Y, data.Field.X)*180/math.Pi + h.Declination
+	data.Heading = math.Mod(heading+360, 360)
+	return
+}
+
+// readRaw reads the raw values of the axes
+func (h *HMC5883LDriver) readRaw() (raw ThreeDData, err error) {
+	if err = h.connection.I2cWrite(hmc5883lAddress, []byte{hmc5883lData}); err != nil {
+		return
+	}
+	ret, err := h.connection.I2cRead(hmc5883lAddress, 6)
+	if err != nil {
+		return
+	}
+	if len(ret) != 6 {
+		return raw, ErrNotEnoughBytes
+	}
+	// the axes are in the order X, Z, Y
+	var axes [3]int16
+	binary.Read(bytes.NewBuffer(ret), binary.BigEndian, &axes)
+	raw = ThreeDData{X: axes[0], Y: axes[2], Z: axes[1]}
+	if raw.X == hmc5883lOverflow || raw.Y == hmc5883lOverflow || raw.Z == hmc5883lOverflow {
+		return raw, ErrMagneticOverflow
+	}
+	return
+}
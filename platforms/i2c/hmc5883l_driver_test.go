@@ -0,0 +1,98 @@
+package i2c
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// hmc5883lReading returns the data registers of a raw reading
+func hmc5883lReading(x, y, z int16) []byte {
+	return []byte{byte(x >> 8), byte(x), byte(z >> 8), byte(z), byte(y >> 8), byte(y)}
+}
+
+func TestHMC5883LDriver(t *testing.T) {
+	h := NewHMC5883LDriver(newI2cTestAdaptor("adaptor"), "bot")
+	gobot.Assert(t, h.Name(), "bot")
+	gobot.Assert(t, h.Connection().Name(), "adaptor")
+	gobot.Assert(t, h.Interval(), 100*time.Millisecond)
+	gobot.Assert(t, h.Gain, byte(HMC5883LGain1_3))
+
+	h = NewHMC5883LDriver(newI2cTestAdaptor("adaptor"), "bot", time.Second)
+	gobot.Assert(t, h.Interval(), time.Second)
+}
+
+func TestHMC5883LDriverStart(t *testing.T) {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	adaptor.set(0x03, hmc5883lReading(0, 1090, 0)...)
+	h := NewHMC5883LDriver(adaptor, "bot")
+	h.Gain = HMC5883LGain4_0
+
+	data := make(chan interface{}, 1)
+	gobot.Once(h.Event(Data), func(d interface{}) {
+		data <- d
+	})
+
+	gobot.Assert(t, len(h.Start()), 0)
+	select {
+	case <-data:
+	case <-time.After(time.Second):
+		t.Errorf("data was not published")
+	}
+	gobot.Assert(t, len(h.Halt()), 0)
+	gobot.Assert(t, adaptor.writes[:3], [][]byte{{0x00, 0x70}, {0x01, 0x80}, {0x02, 0x00}})
+	gobot.Assert(t, adaptor.writes[len(adaptor.writes)-1], []byte{0x02, 0x03})
+}
+
+func TestHMC5883LDriverRead(t *testing.T) {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	h := NewHMC5883LDriver(adaptor, "bot")
+
+	// the X axis to the north
+	adaptor.set(0x03, hmc5883lReading(545, 0, -1090)...)
+	data, err := h.read()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, data.Field, ThreeDVector{X: 0.5, Y: 0, Z: -1})
+	gobot.Assert(t, data.Heading, 0.0)
+
+	// to the west, with a declination of 10 degrees east
+	h.Declination = 10
+	adaptor.set(0x03, hmc5883lReading(0, -545, 0)...)
+	data, err = h.read()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, data.Heading, 280.0)
+
+	adaptor.set(0x03, hmc5883lReading(0, -4096, 0)...)
+	_, err = h.read()
+	gobot.Assert(t, err, ErrMagneticOverflow)
+}
+
+func TestHMC5883LDriverCalibrate(t *testing.T) {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	h := NewHMC5883LDriver(adaptor, "bot")
+	h.SetInterval(time.Millisecond)
+
+	readings := [][]byte{
+		hmc5883lReading(100, -200, 50),
+		hmc5883lReading(-300, 400, 50),
+		hmc5883lReading(0, 0, 250),
+	}
+	reads := 0
+	adaptor.onWrite = func(buf []byte) {
+		if buf[0] == 0x03 {
+			copy(adaptor.regs[0x03:], readings[reads%len(readings)])
+			reads++
+		}
+	}
+
+	gobot.Assert(t, h.Calibrate(3), nil)
+	gobot.Assert(t, h.Offset(), ThreeDData{X: -100, Y: 100, Z: 150})
+
+	data, err := h.read()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, data.Field, ThreeDVector{X: 200.0 / 1090, Y: -300.0 / 1090, Z: -100.0 / 1090})
+
+	h.SetOffset(ThreeDData{})
+	gobot.Assert(t, h.Offset(), ThreeDData{})
+}
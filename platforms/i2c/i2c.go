@@ -7,6 +7,11 @@ import (
 )
 
 var (
+	// ErrEncryptedBytes was returned for the encrypted readings of a Wii
+	// extension, which are no longer made as extensions are initialized
+	// unencrypted.
+	//
+	// Deprecated: no driver returns ErrEncryptedBytes.
 	ErrEncryptedBytes  = errors.New("Encrypted bytes")
 	ErrNotEnoughBytes  = errors.New("Not enough bytes read")
 	ErrNotReady        = errors.New("Device is not ready")
@@ -15,6 +20,7 @@ var (
 
 const (
 	Error    = "error"
+	Data     = "data"
 	Joystick = "joystick"
 	C        = "c"
 	Z        = "z"
@@ -1,10 +1,10 @@
 package i2c
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
 )
 
 const (
@@ -49,7 +49,7 @@ var _ gobot.Driver = (*JHD1313M1Driver)(nil)
 // It's up to the developer to load the set up to 8 custom characters and
 // update the input text so the character is swapped by a byte reflecting
 // the position of the custom character to use.
-// See SetCustomChar
+// See gpio.HD44780.SetCustomChar
 var CustomLCDChars = map[string][8]byte{
 	"é":       [8]byte{130, 132, 142, 145, 159, 144, 142, 128},
 	"è":       [8]byte{136, 132, 142, 145, 159, 144, 142, 128},
@@ -72,21 +72,28 @@ var CustomLCDChars = map[string][8]byte{
 // one belongs to a controller and the other controls solely the backlight.
 // This module was tested with the Seed Grove LCD RGB Backlight v2.0 display which requires 5V to operate.
 // http://www.seeedstudio.com/wiki/Grove_-_LCD_RGB_Backlight
+//
+// The text is written by the embedded HD44780, which does not wrap lines by
+// default so long lines can be scrolled through.
 type JHD1313M1Driver struct {
 	name       string
 	connection I2c
 	lcdAddress int
 	rgbAddress int
+	*gpio.HD44780
 }
 
 // NewJHD1313M1Driver creates a new driver with specified name and i2c interface.
 func NewJHD1313M1Driver(a I2c, name string) *JHD1313M1Driver {
-	return &JHD1313M1Driver{
+	h := &JHD1313M1Driver{
 		name:       name,
 		connection: a,
 		lcdAddress: 0x3E,
 		rgbAddress: 0x62,
 	}
+	h.HD44780 = gpio.NewHD44780(&jhd1313m1Bus{h}, 16, 2)
+	h.Wrap = false
+	return h
 }
 
 // Name returns the name the JHD1313M1 Driver was given when created.
@@ -107,25 +114,7 @@ func (h *JHD1313M1Driver) Start() []error {
 		return []error{err}
 	}
 
-	<-time.After(50000 * time.Microsecond)
-	payload := []byte{LCD_CMD, LCD_FUNCTIONSET | LCD_2LINE}
-	if err := h.connection.I2cWrite(h.lcdAddress, payload); err != nil {
-		if err := h.connection.I2cWrite(h.lcdAddress, payload); err != nil {
-			return []error{err}
-		}
-	}
-
-	<-time.After(100 * time.Microsecond)
-	if err := h.connection.I2cWrite(h.lcdAddress, []byte{LCD_CMD, LCD_DISPLAYCONTROL | LCD_DISPLAYON}); err != nil {
-		return []error{err}
-	}
-
-	<-time.After(100 * time.Microsecond)
-	if err := h.Clear(); err != nil {
-		return []error{err}
-	}
-
-	if err := h.connection.I2cWrite(h.lcdAddress, []byte{LCD_CMD, LCD_ENTRYMODESET | LCD_ENTRYLEFT | LCD_ENTRYSHIFTDECREMENT}); err != nil {
+	if err := h.Init(); err != nil {
 		return []error{err}
 	}
 
@@ -157,38 +146,6 @@ func (h *JHD1313M1Driver) SetRGB(r, g, b int) error {
 	return h.setReg(REG_BLUE, b)
 }
 
-// Clear clears the text on the lCD display.
-func (h *JHD1313M1Driver) Clear() error {
-	err := h.command([]byte{LCD_CLEARDISPLAY})
-	return err
-}
-
-// Home sets the cursor to the origin position on the display.
-func (h *JHD1313M1Driver) Home() error {
-	err := h.command([]byte{LCD_RETURNHOME})
-	// This wait fixes a race condition when calling home and clear back to back.
-	<-time.After(2 * time.Millisecond)
-	return err
-}
-
-// Write displays the passed message on the screen.
-func (h *JHD1313M1Driver) Write(message string) error {
-	// This wait fixes an odd bug where the clear function doesn't always work properly.
-	<-time.After(1 * time.Millisecond)
-	for _, val := range message {
-		if val == '\n' {
-			if err := h.SetPosition(16); err != nil {
-				return err
-			}
-			continue
-		}
-		if err := h.connection.I2cWrite(h.lcdAddress, []byte{LCD_DATA, byte(val)}); err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
 // SetPosition sets the cursor and the data display to pos.
 // 0..15 are the positions in the first display line.
 // 16..32 are the positions in the second display line.
@@ -197,21 +154,7 @@ func (h *JHD1313M1Driver) SetPosition(pos int) (err error) {
 		err = ErrInvalidPosition
 		return
 	}
-	offset := byte(pos)
-	if pos >= 16 {
-		offset -= 16
-		offset |= LCD_2NDLINEOFFSET
-	}
-	err = h.command([]byte{LCD_SETDDRAMADDR | offset})
-	return
-}
-
-func (h *JHD1313M1Driver) Scroll(leftToRight bool) error {
-	if leftToRight {
-		return h.connection.I2cWrite(h.lcdAddress, []byte{LCD_CMD, LCD_CURSORSHIFT | LCD_DISPLAYMOVE | LCD_MOVELEFT})
-	}
-
-	return h.connection.I2cWrite(h.lcdAddress, []byte{LCD_CMD, LCD_CURSORSHIFT | LCD_DISPLAYMOVE | LCD_MOVERIGHT})
+	return h.HD44780.SetPosition(pos)
 }
 
 // Halt is a noop function.
@@ -224,26 +167,32 @@ func (h *JHD1313M1Driver) setReg(command int, data int) error {
 	return nil
 }
 
-func (h *JHD1313M1Driver) command(buf []byte) error {
-	return h.connection.I2cWrite(h.lcdAddress, append([]byte{LCD_CMD}, buf...))
+// jhd1313m1Bus writes to the LCD controller of a JHD1313M1Driver, which
+// takes a control byte before each instruction or data
+type jhd1313m1Bus struct {
+	driver *JHD1313M1Driver
 }
 
-// CustomChar sets one of the 8 CGRAM locations with a custom character.
-// The custom character can be used by writing a byte of value 0 to 7.
-// When you are using LCD as 5x8 dots in function set then you can define a total of 8 user defined patterns
-// (1 Byte for each row and 8 rows for each pattern).
-// Use http://www.8051projects.net/lcd-interfacing/lcd-custom-character.php to create your own
-// characters.
-// To use a custom character, write byte value of the custom character position as a string after
-// having setup the custom character.
-func (h *JHD1313M1Driver) SetCustomChar(pos int, charMap [8]byte) error {
-	if pos > 7 {
-		return fmt.Errorf("can't set a custom character at a position greater than 7")
-	}
-	location := uint8(pos)
-	if err := h.command([]byte{LCD_SETCGRAMADDR | (location << 3)}); err != nil {
-		return err
+// Begin waits for the controller to power up and wakes it, writing twice
+// as the first write can fail
+func (b *jhd1313m1Bus) Begin() error {
+	<-time.After(50000 * time.Microsecond)
+	payload := []byte{LCD_CMD, LCD_FUNCTIONSET | LCD_2LINE}
+	if err := b.driver.connection.I2cWrite(b.driver.lcdAddress, payload); err != nil {
+		if err := b.driver.connection.I2cWrite(b.driver.lcdAddress, payload); err != nil {
+			return err
+		}
 	}
+	<-time.After(100 * time.Microsecond)
+	return nil
+}
+
+// Command writes an instruction
+func (b *jhd1313m1Bus) Command(c byte) error {
+	return b.driver.connection.I2cWrite(b.driver.lcdAddress, []byte{LCD_CMD, c})
+}
 
-	return h.connection.I2cWrite(h.lcdAddress, append([]byte{LCD_DATA}, charMap[:]...))
+// Data writes bytes to the display or character RAM
+func (b *jhd1313m1Bus) Data(data ...byte) error {
+	return b.driver.connection.I2cWrite(b.driver.lcdAddress, append([]byte{LCD_DATA}, data...))
 }
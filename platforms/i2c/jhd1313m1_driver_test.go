@@ -0,0 +1,88 @@
+package i2c
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// jhd1313m1TestAdaptor records what is written to each address
+type jhd1313m1TestAdaptor struct {
+	i2cTestAdaptor
+	writes map[int][][]byte
+}
+
+func (t *jhd1313m1TestAdaptor) I2cWrite(address int, buf []byte) (err error) {
+	t.writes[address] = append(t.writes[address], buf)
+	return t.i2cWriteImpl()
+}
+
+func initTestJHD1313M1Driver() (*JHD1313M1Driver, *jhd1313m1TestAdaptor) {
+	adaptor := &jhd1313m1TestAdaptor{
+		i2cTestAdaptor: *newI2cTestAdaptor("adaptor"),
+		writes:         make(map[int][][]byte),
+	}
+	return NewJHD1313M1Driver(adaptor, "bot"), adaptor
+}
+
+func TestJHD1313M1Driver(t *testing.T) {
+	h, _ := initTestJHD1313M1Driver()
+	gobot.Assert(t, h.Name(), "bot")
+	gobot.Assert(t, h.Connection().Name(), "adaptor")
+	gobot.Assert(t, h.Columns(), 16)
+	gobot.Assert(t, h.Rows(), 2)
+	gobot.Assert(t, len(h.Halt()), 0)
+}
+
+func TestJHD1313M1DriverStart(t *testing.T) {
+	h, adaptor := initTestJHD1313M1Driver()
+	gobot.Assert(t, len(h.Start()), 0)
+	gobot.Assert(t, adaptor.writes[0x3E], [][]byte{
+		{LCD_CMD, 0x28},
+		{LCD_CMD, 0x28},
+		{LCD_CMD, 0x0C},
+		{LCD_CMD, 0x01},
+		{LCD_CMD, 0x06},
+	})
+	gobot.Assert(t, adaptor.writes[0x62], [][]byte{
+		{0, 0}, {1, 0}, {0x08, 0xAA},
+		{REG_RED, 255}, {REG_GREEN, 255}, {REG_BLUE, 255},
+	})
+
+	adaptor.i2cWriteImpl = func() error {
+		return errors.New("write error")
+	}
+	gobot.Assert(t, h.Start()[0], errors.New("write error"))
+
+	adaptor.i2cStartImpl = func() error {
+		return errors.New("start error")
+	}
+	gobot.Assert(t, h.Start()[0], errors.New("start error"))
+}
+
+func TestJHD1313M1DriverWrite(t *testing.T) {
+	h, adaptor := initTestJHD1313M1Driver()
+	// long lines are not wrapped, so they can be scrolled through
+	gobot.Assert(t, h.Write("ab\ncdefghijklmnopqrs"), nil)
+	writes := adaptor.writes[0x3E]
+	gobot.Assert(t, writes[0], []byte{LCD_DATA, 'a'})
+	gobot.Assert(t, writes[2], []byte{LCD_CMD, LCD_SETDDRAMADDR | LCD_2NDLINEOFFSET})
+	gobot.Assert(t, len(writes), 20)
+	gobot.Assert(t, h.Lines()[1], "cdefghijklmnopqr")
+
+	gobot.Assert(t, h.SetPosition(17), nil)
+	gobot.Assert(t, adaptor.writes[0x3E][20], []byte{LCD_CMD, 0xC1})
+	gobot.Assert(t, h.SetPosition(32), ErrInvalidPosition)
+}
+
+func TestJHD1313M1DriverSetCustomChar(t *testing.T) {
+	h, adaptor := initTestJHD1313M1Driver()
+	gobot.Assert(t, h.SetCustomChar(1, CustomLCDChars["heart"]), nil)
+	gobot.Assert(t, adaptor.writes[0x3E], [][]byte{
+		{LCD_CMD, LCD_SETCGRAMADDR | 1<<3},
+		append([]byte{LCD_DATA}, 0, 10, 31, 31, 31, 14, 4, 0),
+		{LCD_CMD, LCD_SETDDRAMADDR},
+	})
+	gobot.Refute(t, h.SetCustomChar(8, CustomLCDChars["heart"]), nil)
+}
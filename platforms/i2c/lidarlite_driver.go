@@ -1,78 +1,203 @@
 package i2c
 
 import (
-	"github.com/hybridgroup/gobot"
-
+	"sync"
 	"time"
+
+	"github.com/hybridgroup/gobot"
 )
 
 var _ gobot.Driver = (*LIDARLiteDriver)(nil)
 
 const lidarliteAddress = 0x62
 
+// LIDAR-Lite registers
+const (
+	lidarliteCommand      = 0x00
+	lidarliteConfig       = 0x04
+	lidarliteVelocity     = 0x09
+	lidarliteDistanceHigh = 0x0F
+	lidarliteDistanceLow  = 0x10
+	lidarliteLoopCount    = 0x11
+	lidarliteMeasureDelay = 0x45
+)
+
+// LIDARLiteData is a reading of a LIDAR-Lite
+type LIDARLiteData struct {
+	// Distance is the distance to the target in cm
+	Distance int
+	// Velocity is the change in Distance since the measurement before, in cm
+	Velocity int
+}
+
 type LIDARLiteDriver struct {
 	name       string
 	connection I2c
+	mutex      sync.Mutex
+	// Continuous, when true, has Start set a LIDAR-Lite v3 measuring by
+	// itself, every MeasureDelay, so each reading only has to read the last
+	// measurement. Otherwise each reading triggers a measurement.
+	Continuous bool
+	// MeasureDelay is the time between continuous measurements, in units of
+	// about 0.5ms, 0x14 by default for about 100 measurements a second
+	MeasureDelay byte
+	gobot.Eventer
+	*Poller
 }
 
-// NewLIDARLiteDriver creates a new driver with specified name and i2c interface
-func NewLIDARLiteDriver(a I2c, name string) *LIDARLiteDriver {
-	return &LIDARLiteDriver{
-		name:       name,
-		connection: a,
+// NewLIDARLiteDriver creates a new driver with specified name and i2c interface.
+// It is only read when asked, by Distance, unless StartPolling is called after
+// Start to have it read every interval.
+//
+// Optionally accepts:
+//  time.Duration: interval at which the LIDAR-Lite is read once polling, 50ms by default
+//
+// Emits the Events:
+//	"data" - a LIDARLiteData every interval while polling
+//	"error" - an error reading the LIDAR-Lite while polling
+func NewLIDARLiteDriver(a I2c, name string, v ...time.Duration) *LIDARLiteDriver {
+	h := &LIDARLiteDriver{
+		name:         name,
+		connection:   a,
+		MeasureDelay: 0x14,
+		Eventer:      gobot.NewEventer(),
 	}
+
+	interval := 50 * time.Millisecond
+	if len(v) > 0 {
+		interval = v[0]
+	}
+
+	h.Poller = NewPoller(h, interval, func() (interface{}, error) {
+		return h.read()
+	})
+	return h
 }
 
 func (h *LIDARLiteDriver) Name() string                 { return h.name }
 func (h *LIDARLiteDriver) Connection() gobot.Connection { return h.connection.(gobot.Connection) }
 
-// Start initialized the LIDAR
+// Start initializes the LIDAR, and starts it measuring when Continuous. It
+// does not start polling; call StartPolling for that.
 func (h *LIDARLiteDriver) Start() (errs []error) {
 	if err := h.connection.I2cStart(lidarliteAddress); err != nil {
 		return []error{err}
 	}
+	if h.Continuous {
+		if err := h.startContinuous(); err != nil {
+			return []error{err}
+		}
+	}
+	return
+}
+
+// Halt stops any polling of the LIDAR, and stops it measuring when Continuous
+func (h *LIDARLiteDriver) Halt() (errs []error) {
+	h.StopPolling()
+	if h.Continuous {
+		if err := h.connection.I2cWrite(lidarliteAddress, []byte{lidarliteLoopCount, 0x00}); err != nil {
+			return []error{err}
+		}
+	}
 	return
 }
 
-// Halt returns true if devices is halted successfully
-func (h *LIDARLiteDriver) Halt() (errs []error) { return }
+// Reading returns the last reading of the LIDAR, and the time it was made
+func (h *LIDARLiteDriver) Reading() (data LIDARLiteData, timestamp time.Time) {
+	value, timestamp := h.Latest()
+	data, _ = value.(LIDARLiteData)
+	return
+}
 
 // Distance returns the current distance in cm
 func (h *LIDARLiteDriver) Distance() (distance int, err error) {
-	if err = h.connection.I2cWrite(lidarliteAddress, []byte{0x00, 0x04}); err != nil {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if err = h.connection.I2cWrite(lidarliteAddress, []byte{lidarliteCommand, 0x04}); err != nil {
 		return
 	}
 	<-time.After(20 * time.Millisecond)
+	return h.distance()
+}
 
-	if err = h.connection.I2cWrite(lidarliteAddress, []byte{0x0F}); err != nil {
-		return
+// Velocity returns the change in distance, in cm, between the last two
+// measurements
+func (h *LIDARLiteDriver) Velocity() (velocity int, err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.velocity()
+}
+
+// startContinuous sets the LIDAR measuring over and over, every MeasureDelay
+func (h *LIDARLiteDriver) startContinuous() (err error) {
+	for _, reg := range [][]byte{
+		{lidarliteLoopCount, 0xFF},
+		{lidarliteMeasureDelay, h.MeasureDelay},
+		// the default configuration, using MeasureDelay between measurements
+		{lidarliteConfig, 0x08 | 0x20},
+		{lidarliteCommand, 0x04},
+	} {
+		if err = h.connection.I2cWrite(lidarliteAddress, reg); err != nil {
+			return
+		}
 	}
+	return
+}
 
-	upper, err := h.connection.I2cRead(lidarliteAddress, 1)
-	if err != nil {
+// read measures, unless the LIDAR is measuring continuously, and returns the
+// distance and velocity
+func (h *LIDARLiteDriver) read() (data LIDARLiteData, err error) {
+	if !h.Continuous {
+		if data.Distance, err = h.Distance(); err != nil {
+			return
+		}
+		data.Velocity, err = h.Velocity()
 		return
 	}
 
-	if len(upper) != 1 {
-		err = ErrNotEnoughBytes
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if data.Distance, err = h.distance(); err != nil {
 		return
 	}
+	data.Velocity, err = h.velocity()
+	return
+}
 
-	if err = h.connection.I2cWrite(lidarliteAddress, []byte{0x10}); err != nil {
+// distance reads the distance of the last measurement
+func (h *LIDARLiteDriver) distance() (distance int, err error) {
+	upper, err := h.readReg(lidarliteDistanceHigh)
+	if err != nil {
 		return
 	}
-
-	lower, err := h.connection.I2cRead(lidarliteAddress, 1)
+	lower, err := h.readReg(lidarliteDistanceLow)
 	if err != nil {
 		return
 	}
+	return int(upper)<<8 | int(lower), nil
+}
 
-	if len(lower) != 1 {
-		err = ErrNotEnoughBytes
+// velocity reads the velocity of the last measurement
+func (h *LIDARLiteDriver) velocity() (velocity int, err error) {
+	ret, err := h.readReg(lidarliteVelocity)
+	if err != nil {
 		return
 	}
+	return int(int8(ret)), nil
+}
 
-	distance = ((int(upper[0]) & 0xff) << 8) | (int(lower[0]) & 0xff)
-
-	return
+// readReg reads a register
+func (h *LIDARLiteDriver) readReg(reg byte) (val byte, err error) {
+	if err = h.connection.I2cWrite(lidarliteAddress, []byte{reg}); err != nil {
+		return
+	}
+	ret, err := h.connection.I2cRead(lidarliteAddress, 1)
+	if err != nil {
+		return
+	}
+	if len(ret) != 1 {
+		return 0, ErrNotEnoughBytes
+	}
+	return ret[0], nil
 }
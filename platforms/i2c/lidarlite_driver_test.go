@@ -3,6 +3,7 @@ package i2c
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/hybridgroup/gobot"
 )
@@ -104,3 +105,54 @@ func TestLIDARLiteDriverDistance(t *testing.T) {
 	gobot.Assert(t, distance, int(0))
 	gobot.Assert(t, err, errors.New("write error"))
 }
+
+func TestLIDARLiteDriverReading(t *testing.T) {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	adaptor.set(0x09, 0xFB)
+	adaptor.set(0x0F, 0x01, 0x02)
+	lidar := NewLIDARLiteDriver(adaptor, "bot")
+	gobot.Assert(t, lidar.Interval(), 50*time.Millisecond)
+
+	data := make(chan interface{}, 1)
+	gobot.Once(lidar.Event(Data), func(d interface{}) {
+		data <- d
+	})
+
+	gobot.Assert(t, len(lidar.Start()), 0)
+	gobot.Assert(t, lidar.Polling(), false)
+	lidar.StartPolling()
+	select {
+	case d := <-data:
+		gobot.Assert(t, d, LIDARLiteData{Distance: 258, Velocity: -5})
+	case <-time.After(time.Second):
+		t.Errorf("data was not published")
+	}
+	gobot.Assert(t, len(lidar.Halt()), 0)
+	// each reading measures
+	gobot.Assert(t, adaptor.writes[0], []byte{0x00, 0x04})
+
+	velocity, err := lidar.Velocity()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, velocity, -5)
+}
+
+func TestLIDARLiteDriverContinuous(t *testing.T) {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	adaptor.set(0x0F, 0x00, 0x64)
+	lidar := NewLIDARLiteDriver(adaptor, "bot", time.Hour)
+	lidar.Continuous = true
+	lidar.MeasureDelay = 0x28
+
+	gobot.Assert(t, len(lidar.Start()), 0)
+	lidar.StartPolling()
+	gobot.Assert(t, len(lidar.Halt()), 0)
+	gobot.Assert(t, lidar.Polling(), false)
+	gobot.Assert(t, adaptor.writes, [][]byte{
+		{0x11, 0xFF}, {0x45, 0x28}, {0x04, 0x28}, {0x00, 0x04},
+		{0x0F}, {0x10}, {0x09},
+		{0x11, 0x00},
+	})
+
+	reading, _ := lidar.Reading()
+	gobot.Assert(t, reading, LIDARLiteData{Distance: 100})
+}
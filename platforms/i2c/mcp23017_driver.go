@@ -17,21 +17,31 @@ limitations under the License.
 package i2c
 
 import (
-	"bytes"
-	"encoding/binary"
+	"errors"
 	"log"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
 )
 
 var (
 	Debug = true // Set this to true to see debugging information
 	// Register this Driver
 	_ gobot.Driver = (*MCP23017Driver)(nil)
+	// The pins of the MCP23017 can be used by gpio drivers
+	_ gpio.DigitalReader   = (*MCP23017Driver)(nil)
+	_ gpio.DigitalWriter   = (*MCP23017Driver)(nil)
+	_ gpio.DigitalNotifier = (*MCP23017Driver)(nil)
 )
 
+// ErrInvalidMCP23017Pin is the error resulting when a pin name is not one of
+// the MCP23017 pins A0-A7 and B0-B7
+var ErrInvalidMCP23017Pin = errors.New("MCP23017 pins are A0-A7 and B0-B7")
+
 // Port contains all the registers for the device.
 type port struct {
 	IODIR   byte // I/O direction register: 0=output / 1=input
@@ -73,9 +83,10 @@ type MCP23017Config struct {
 	Intpol uint8
 }
 
-// getBytes returns the mcp23017 configuration as a slice of bytes.
-func (conf *MCP23017Config) getBytes() []byte {
-	return []byte{conf.Bank, conf.Mirror, conf.Seqop, conf.Disslw, conf.Haen, conf.Odr, conf.Intpol}
+// getIOCON returns the mcp23017 configuration as the value of the IOCON register.
+func (conf *MCP23017Config) getIOCON() byte {
+	return conf.Bank<<7 | conf.Mirror<<6 | conf.Seqop<<5 | conf.Disslw<<4 |
+		conf.Haen<<3 | conf.Odr<<2 | conf.Intpol<<1
 }
 
 // MCP23107Driver contains the driver configuration parameters.
@@ -85,21 +96,43 @@ type MCP23017Driver struct {
 	conf            MCP23017Config
 	mcp23017Address int
 	interval        time.Duration
+	mutex           sync.Mutex
+	inputs          [2]byte
+	values          [2]byte
+	callbacks       [2][8][]func(val int)
+	host            gpio.DigitalReader
+	intPins         [2]string
+	wake            chan bool
+	halt            chan bool
 	gobot.Commander
 	gobot.Eventer
 }
 
 // NewMCP23017Driver creates a new driver with specified name and i2c interface.
+//
+// The MCP23017Driver is also a connection for gpio drivers, whose pins are
+// named "A0" to "A7" and "B0" to "B7".
+//
+// Optionally accepts:
+//  time.Duration: Interval at which pins are checked for DigitalNotify, 10ms by default
 func NewMCP23017Driver(a I2c, name string, conf MCP23017Config, deviceAddress int, v ...time.Duration) *MCP23017Driver {
 	m := &MCP23017Driver{
 		name:            name,
 		connection:      a,
 		conf:            conf,
 		mcp23017Address: deviceAddress,
+		interval:        10 * time.Millisecond,
+		wake:            make(chan bool, 1),
 		Commander:       gobot.NewCommander(),
 		Eventer:         gobot.NewEventer(),
 	}
 
+	if len(v) > 0 {
+		m.interval = v[0]
+	}
+
+	m.AddEvent(Error)
+
 	m.AddCommand("WriteGPIO", func(params map[string]interface{}) interface{} {
 		pin := params["pin"].(float64)
 		val := params["val"].(float64)
@@ -121,7 +154,23 @@ func (m *MCP23017Driver) Name() string { return m.name }
 
 func (m *MCP23017Driver) Connection() gobot.Connection { return m.connection.(gobot.Connection) }
 
-func (m *MCP23017Driver) Halt() (err []error) { return }
+// Halt stops checking pins for DigitalNotify
+func (m *MCP23017Driver) Halt() (err []error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.halt != nil {
+		close(m.halt)
+		m.halt = nil
+	}
+	return
+}
+
+// Connect implements the Adaptor interface, the MCP23017Driver is connected
+// once it has been started
+func (m *MCP23017Driver) Connect() (errs []error) { return }
+
+// Finalize implements the Adaptor interface
+func (m *MCP23017Driver) Finalize() (errs []error) { return }
 
 // Start writes initialization bytes and reads.
 func (m *MCP23017Driver) Start() (errs []error) {
@@ -130,13 +179,7 @@ func (m *MCP23017Driver) Start() (errs []error) {
 	}
 	// Set IOCON register with the given configuration.
 	selectedPort := m.getPort("A") // IOCON address is the same for Port A or B.
-	var ioval uint8
-	buf := bytes.NewReader(m.conf.getBytes())
-	err := binary.Read(buf, binary.LittleEndian, &ioval)
-	if err != nil {
-		return []error{err}
-	}
-	if err := m.connection.I2cWrite(m.mcp23017Address, []byte{selectedPort.IOCON, ioval}); err != nil {
+	if err := m.connection.I2cWrite(m.mcp23017Address, []byte{selectedPort.IOCON, m.conf.getIOCON()}); err != nil {
 		return []error{err}
 	}
 	return
@@ -190,6 +233,235 @@ func (m *MCP23017Driver) SetGPIOPolarity(pin uint8, val byte, portStr string) (e
 	return nil
 }
 
+// DigitalWrite makes pin, "A0" to "B7", an output and writes level to it
+func (m *MCP23017Driver) DigitalWrite(pin string, level byte) (err error) {
+	port, bit, err := parseMCP23017Pin(pin)
+	if err != nil {
+		return
+	}
+	if level != 0 {
+		level = 1
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.inputs[portIndex(port)] = clearBit(m.inputs[portIndex(port)], bit)
+	return m.WriteGPIO(float64(bit), float64(level), port)
+}
+
+// DigitalRead makes pin, "A0" to "B7", an input and reads it
+func (m *MCP23017Driver) DigitalRead(pin string) (val int, err error) {
+	port, bit, err := parseMCP23017Pin(pin)
+	if err != nil {
+		return
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if err = m.makeInput(port, bit); err != nil {
+		return
+	}
+	high, err := m.ReadGPIO(float64(bit), port)
+	if high {
+		val = 1
+	}
+	return
+}
+
+// SetInterruptPins tells the MCP23017Driver which pins of a, such as the
+// adaptor of the board, its INTA and INTB outputs are connected to. Ports
+// with an interrupt pin are then only read over i2c for DigitalNotify once
+// their interrupt pin is active. Either pin may be "" when not connected, and
+// with the Mirror configuration INTA alone covers both ports. It returns the
+// error of a which could not notify the changes of an interrupt pin, and the
+// ports are then read as if they had no interrupt pins.
+func (m *MCP23017Driver) SetInterruptPins(a gpio.DigitalReader, intA string, intB string) (err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	intPins := [2]string{intA, intB}
+
+	if n, ok := a.(gpio.DigitalNotifier); ok {
+		for _, pin := range intPins {
+			if pin == "" {
+				continue
+			}
+			err = n.DigitalNotify(pin, func(int) {
+				select {
+				case m.wake <- true:
+				default:
+				}
+			})
+			if err != nil {
+				return
+			}
+		}
+	}
+	m.host = a
+	m.intPins = intPins
+	return
+}
+
+// DigitalNotify makes pin, "A0" to "B7", an input and calls f with its value
+// every time it changes. The MCP23017 is set to interrupt on changes of pin,
+// see SetInterruptPins.
+func (m *MCP23017Driver) DigitalNotify(pin string, f func(val int)) (err error) {
+	port, bit, err := parseMCP23017Pin(pin)
+	if err != nil {
+		return
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if err = m.makeInput(port, bit); err != nil {
+		return
+	}
+	selectedPort := m.getPort(port)
+	// interrupt on any change from the previous value
+	if err = m.write(selectedPort.INTCON, bit, 0); err != nil {
+		return
+	}
+	if err = m.write(selectedPort.GPINTEN, bit, 1); err != nil {
+		return
+	}
+	if m.values[portIndex(port)], err = m.read(selectedPort.GPIO); err != nil {
+		return
+	}
+
+	m.callbacks[portIndex(port)][bit] = append(m.callbacks[portIndex(port)][bit], f)
+	if m.halt == nil {
+		m.halt = make(chan bool)
+		go m.watch(m.halt)
+	}
+	return
+}
+
+// DigitalUnnotify stops calling the functions given to DigitalNotify for pin,
+// and stops the MCP23017 interrupting on changes of pin
+func (m *MCP23017Driver) DigitalUnnotify(pin string) (err error) {
+	port, bit, err := parseMCP23017Pin(pin)
+	if err != nil {
+		return
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if len(m.callbacks[portIndex(port)][bit]) == 0 {
+		return
+	}
+	m.callbacks[portIndex(port)][bit] = nil
+	return m.write(m.getPort(port).GPINTEN, bit, 0)
+}
+
+// watch checks the pins with DigitalNotify callbacks every interval, or
+// when woken by an interrupt pin, until halt is closed
+func (m *MCP23017Driver) watch(halt chan bool) {
+	for {
+		select {
+		case <-halt:
+			return
+		case <-m.wake:
+		case <-time.After(m.interval):
+		}
+		m.mutex.Lock()
+		changes := m.changes()
+		m.mutex.Unlock()
+		for _, change := range changes {
+			change()
+		}
+	}
+}
+
+// changes reads the ports with DigitalNotify callbacks whose interrupt pin
+// is active, or which have none, and returns the calls of the callbacks of
+// the pins which have changed
+func (m *MCP23017Driver) changes() (calls []func()) {
+	for i, port := range []string{"A", "B"} {
+		if !m.notifying(i) || !m.interrupted(i) {
+			continue
+		}
+		values, err := m.read(m.getPort(port).GPIO)
+		if err != nil {
+			gobot.Publish(m.Event(Error), err)
+			continue
+		}
+		changed := values ^ m.values[i]
+		m.values[i] = values
+		for bit := uint8(0); bit < 8; bit++ {
+			if changed&(1<<bit) == 0 {
+				continue
+			}
+			val := int(values>>bit) & 1
+			for _, f := range m.callbacks[i][bit] {
+				f := f
+				calls = append(calls, func() { f(val) })
+			}
+		}
+	}
+	return
+}
+
+// notifying returns true when a pin of port i has DigitalNotify callbacks
+func (m *MCP23017Driver) notifying(i int) bool {
+	for _, callbacks := range m.callbacks[i] {
+		if len(callbacks) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// interrupted returns false when port i has an interrupt pin which is not
+// active, so the port has not changed
+func (m *MCP23017Driver) interrupted(i int) bool {
+	pin := m.intPins[i]
+	if pin == "" && i == 1 && m.conf.Mirror == 1 {
+		pin = m.intPins[0]
+	}
+	if pin == "" || m.host == nil {
+		return true
+	}
+	val, err := m.host.DigitalRead(pin)
+	if err != nil {
+		return true
+	}
+	return val == int(m.conf.Intpol)
+}
+
+// makeInput sets the IODIR register bit for the given pin to an input,
+// unless it already is one.
+func (m *MCP23017Driver) makeInput(port string, bit uint8) (err error) {
+	i := portIndex(port)
+	if m.inputs[i]&(1<<bit) != 0 {
+		return
+	}
+	if err = m.write(m.getPort(port).IODIR, bit, 1); err != nil {
+		return
+	}
+	m.inputs[i] = setBit(m.inputs[i], bit)
+	return
+}
+
+// parseMCP23017Pin returns the port and bit of a pin name such as "A3".
+func parseMCP23017Pin(pin string) (port string, bit uint8, err error) {
+	pin = strings.ToUpper(pin)
+	if len(pin) != 2 || (pin[0] != 'A' && pin[0] != 'B') {
+		return "", 0, ErrInvalidMCP23017Pin
+	}
+	n, err := strconv.Atoi(pin[1:])
+	if err != nil || n > 7 {
+		return "", 0, ErrInvalidMCP23017Pin
+	}
+	return pin[:1], uint8(n), nil
+}
+
+// portIndex returns 0 for port A and 1 for port B.
+func portIndex(port string) int {
+	if port == "B" {
+		return 1
+	}
+	return 0
+}
+
 // write gets the value of the passed in register, and then overwrites
 // the bit specified by the pin, with the given value.
 func (m *MCP23017Driver) write(reg byte, pin uint8, val byte) (err error) {
@@ -5,9 +5,12 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
 )
 
 func initTestMCP23017Driver(b uint8) (driver *MCP23017Driver) {
@@ -262,3 +265,238 @@ func TestClearBit(t *testing.T) {
 	actualVal := clearBit(128, 7)
 	gobot.Assert(t, expectedVal, actualVal)
 }
+
+// mcp23017TestAdaptor keeps the registers of an MCP23017 in bank 0, which
+// its driver reads from the start
+type mcp23017TestAdaptor struct {
+	i2cTestAdaptor
+	mutex sync.Mutex
+	regs  [0x16]byte
+}
+
+func (t *mcp23017TestAdaptor) I2cWrite(address int, buf []byte) (err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.regs[buf[0]] = buf[1]
+	return
+}
+
+func (t *mcp23017TestAdaptor) I2cRead(address int, n int) (data []byte, err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return append([]byte{}, t.regs[:n]...), nil
+}
+
+func (t *mcp23017TestAdaptor) reg(r byte) byte {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.regs[r]
+}
+
+func (t *mcp23017TestAdaptor) setReg(r byte, val byte) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.regs[r] = val
+}
+
+func initTestMCP23017DriverWithRegisters(v ...time.Duration) (*MCP23017Driver, *mcp23017TestAdaptor) {
+	adaptor := &mcp23017TestAdaptor{i2cTestAdaptor: *newI2cTestAdaptor("adaptor")}
+	return NewMCP23017Driver(adaptor, "bot", MCP23017Config{}, 0x20, v...), adaptor
+}
+
+func TestMCP23017DriverIOCON(t *testing.T) {
+	mcp, adaptor := initTestMCP23017DriverWithRegisters()
+	mcp.conf = MCP23017Config{Mirror: 1, Intpol: 1}
+	gobot.Assert(t, len(mcp.Start()), 0)
+	gobot.Assert(t, adaptor.reg(0x0A), uint8(0x42))
+}
+
+func TestParseMCP23017Pin(t *testing.T) {
+	port, bit, err := parseMCP23017Pin("a3")
+	gobot.Assert(t, port, "A")
+	gobot.Assert(t, bit, uint8(3))
+	gobot.Assert(t, err, nil)
+
+	port, bit, err = parseMCP23017Pin("B7")
+	gobot.Assert(t, port, "B")
+	gobot.Assert(t, bit, uint8(7))
+
+	for _, pin := range []string{"", "C1", "A8", "B", "A10", "Ax"} {
+		_, _, err = parseMCP23017Pin(pin)
+		gobot.Assert(t, err, ErrInvalidMCP23017Pin)
+	}
+}
+
+func TestMCP23017DriverDigitalWrite(t *testing.T) {
+	mcp, adaptor := initTestMCP23017DriverWithRegisters()
+	adaptor.setReg(0x00, 0xFF)
+	adaptor.setReg(0x01, 0xFF)
+
+	gobot.Assert(t, mcp.DigitalWrite("A1", 1), nil)
+	gobot.Assert(t, mcp.DigitalWrite("b7", 255), nil)
+	gobot.Assert(t, mcp.DigitalWrite("A1", 0), nil)
+	gobot.Assert(t, adaptor.reg(0x00), uint8(0xFD))
+	gobot.Assert(t, adaptor.reg(0x01), uint8(0x7F))
+	gobot.Assert(t, adaptor.reg(0x14), uint8(0x00))
+	gobot.Assert(t, adaptor.reg(0x15), uint8(0x80))
+
+	gobot.Assert(t, mcp.DigitalWrite("C1", 1), ErrInvalidMCP23017Pin)
+}
+
+func TestMCP23017DriverDigitalRead(t *testing.T) {
+	mcp, adaptor := initTestMCP23017DriverWithRegisters()
+	adaptor.setReg(0x13, 0x04)
+
+	val, err := mcp.DigitalRead("B2")
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, val, 1)
+	gobot.Assert(t, adaptor.reg(0x01), uint8(0x04))
+
+	val, _ = mcp.DigitalRead("B3")
+	gobot.Assert(t, val, 0)
+
+	_, err = mcp.DigitalRead("B9")
+	gobot.Assert(t, err, ErrInvalidMCP23017Pin)
+}
+
+func TestMCP23017DriverLedAndButton(t *testing.T) {
+	mcp, adaptor := initTestMCP23017DriverWithRegisters()
+
+	led := gpio.NewLedDriver(mcp, "led", "A0")
+	gobot.Assert(t, led.On(), nil)
+	gobot.Assert(t, adaptor.reg(0x14), uint8(0x01))
+
+	button := gpio.NewButtonDriver(mcp, "button", "B0", time.Millisecond)
+	gobot.Assert(t, len(button.Start()), 0)
+	defer button.Halt()
+	pushed := make(chan bool, 1)
+	gobot.Once(button.Event(gpio.Push), func(data interface{}) {
+		pushed <- true
+	})
+	adaptor.setReg(0x13, 0x01)
+	select {
+	case <-pushed:
+	case <-time.After(time.Second):
+		t.Errorf("push was not published")
+	}
+}
+
+func TestMCP23017DriverDigitalNotify(t *testing.T) {
+	mcp, adaptor := initTestMCP23017DriverWithRegisters(time.Millisecond)
+	defer mcp.Halt()
+	vals := make(chan int, 2)
+
+	gobot.Assert(t, mcp.DigitalNotify("A5", func(val int) { vals <- val }), nil)
+	gobot.Assert(t, adaptor.reg(0x00), uint8(0x20))
+	gobot.Assert(t, adaptor.reg(0x04), uint8(0x20))
+	gobot.Assert(t, adaptor.reg(0x08), uint8(0x00))
+
+	// other pins do not notify
+	adaptor.setReg(0x12, 0x01)
+	adaptor.setReg(0x12, 0x21)
+	select {
+	case val := <-vals:
+		gobot.Assert(t, val, 1)
+	case <-time.After(time.Second):
+		t.Errorf("change was not notified")
+	}
+	adaptor.setReg(0x12, 0x00)
+	select {
+	case val := <-vals:
+		gobot.Assert(t, val, 0)
+	case <-time.After(time.Second):
+		t.Errorf("change was not notified")
+	}
+
+	gobot.Assert(t, mcp.DigitalNotify("Z5", nil), ErrInvalidMCP23017Pin)
+
+	gobot.Assert(t, mcp.DigitalUnnotify("A5"), nil)
+	gobot.Assert(t, adaptor.reg(0x04), uint8(0x00))
+	adaptor.setReg(0x12, 0x20)
+	select {
+	case val := <-vals:
+		t.Errorf("change was notified after DigitalUnnotify: %v", val)
+	case <-time.After(20 * time.Millisecond):
+	}
+	gobot.Assert(t, mcp.DigitalUnnotify("Z5"), ErrInvalidMCP23017Pin)
+}
+
+func TestMCP23017DriverInterruptPins(t *testing.T) {
+	mcp, adaptor := initTestMCP23017DriverWithRegisters(time.Millisecond)
+	mcp.conf.Mirror = 1
+	defer mcp.Halt()
+	host := &mcp23017TestHost{vals: map[string]int{"7": 1}}
+	gobot.Assert(t, mcp.SetInterruptPins(host, "7", ""), nil)
+	vals := make(chan int, 2)
+
+	gobot.Assert(t, mcp.DigitalNotify("B1", func(val int) { vals <- val }), nil)
+
+	// INTA is active low, and port B is mirrored on it
+	adaptor.setReg(0x13, 0x02)
+	select {
+	case <-vals:
+		t.Errorf("change was notified before the interrupt")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	host.set("7", 0)
+	select {
+	case val := <-vals:
+		gobot.Assert(t, val, 1)
+	case <-time.After(time.Second):
+		t.Errorf("change was not notified")
+	}
+}
+
+func TestMCP23017DriverInterruptPinsError(t *testing.T) {
+	mcp, _ := initTestMCP23017DriverWithRegisters(time.Millisecond)
+	host := &mcp23017TestNotifyHost{
+		mcp23017TestHost: mcp23017TestHost{vals: map[string]int{"7": 1}},
+		err:              errors.New("notify error"),
+	}
+	gobot.Assert(t, mcp.SetInterruptPins(host, "7", ""), errors.New("notify error"))
+	gobot.Assert(t, mcp.interrupted(0), true)
+
+	host.err = nil
+	gobot.Assert(t, mcp.SetInterruptPins(host, "7", ""), nil)
+	gobot.Assert(t, host.notified, []string{"7"})
+	gobot.Assert(t, mcp.interrupted(0), false)
+}
+
+type mcp23017TestHost struct {
+	mutex sync.Mutex
+	vals  map[string]int
+}
+
+func (t *mcp23017TestHost) DigitalRead(pin string) (int, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.vals[pin], nil
+}
+
+func (t *mcp23017TestHost) set(pin string, val int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.vals[pin] = val
+}
+
+func (t *mcp23017TestHost) Name() string             { return "host" }
+func (t *mcp23017TestHost) Connect() (errs []error)  { return }
+func (t *mcp23017TestHost) Finalize() (errs []error) { return }
+
+// mcp23017TestNotifyHost is a mcp23017TestHost which notifies pin changes
+type mcp23017TestNotifyHost struct {
+	mcp23017TestHost
+	err      error
+	notified []string
+}
+
+func (t *mcp23017TestNotifyHost) DigitalNotify(pin string, f func(val int)) error {
+	if t.err != nil {
+		return t.err
+	}
+	t.notified = append(t.notified, pin)
+	return nil
+}
+
+func (t *mcp23017TestNotifyHost) DigitalUnnotify(pin string) error { return nil }
@@ -5,6 +5,7 @@ import (
 
 	"bytes"
 	"encoding/binary"
+	"sync"
 	"time"
 )
 
@@ -26,32 +27,54 @@ const MPL115A2_REGISTER_C12_COEFF_MSB = 0x0A
 const MPL115A2_REGISTER_C12_COEFF_LSB = 0x0B
 const MPL115A2_REGISTER_STARTCONVERSION = 0x12
 
+// MPL115A2Data is a reading of an MPL115A2
+type MPL115A2Data struct {
+	// Pressure is the pressure in kPa
+	Pressure float32
+	// Temperature is the temperature in degrees Celsius
+	Temperature float32
+}
+
 type MPL115A2Driver struct {
 	name       string
 	connection I2c
-	interval   time.Duration
+	mutex      sync.Mutex
 	gobot.Eventer
-	A0          float32
-	B1          float32
-	B2          float32
-	C12         float32
+	*Poller
+	A0  float32
+	B1  float32
+	B2  float32
+	C12 float32
+	// Pressure and Temperature are the last reading, which is written while
+	// the MPL115A2 is polled, see MPL115A2Driver.Reading for reading it
+	// safely
 	Pressure    float32
 	Temperature float32
 }
 
 // NewMPL115A2Driver creates a new driver with specified name and i2c interface
+//
+// Optionally accepts:
+//  time.Duration: interval at which the MPL115A2 is read, 10ms by default
+//
+// Emits the Events:
+//	"data" - an MPL115A2Data every interval
+//	"error" - an error reading the MPL115A2
 func NewMPL115A2Driver(a I2c, name string, v ...time.Duration) *MPL115A2Driver {
 	m := &MPL115A2Driver{
 		name:       name,
 		connection: a,
 		Eventer:    gobot.NewEventer(),
-		interval:   10 * time.Millisecond,
 	}
 
+	interval := 10 * time.Millisecond
 	if len(v) > 0 {
-		m.interval = v[0]
+		interval = v[0]
 	}
-	m.AddEvent(Error)
+
+	m.Poller = NewPoller(m, interval, func() (interface{}, error) {
+		return m.read()
+	})
 	return m
 }
 
@@ -61,53 +84,62 @@ func (h *MPL115A2Driver) Connection() gobot.Connection { return h.connection.(go
 // Start writes initialization bytes and reads from adaptor
 // using specified interval to accelerometer andtemperature data
 func (h *MPL115A2Driver) Start() (errs []error) {
-	var temperature uint16
-	var pressure uint16
-	var pressureComp float32
-
 	if err := h.initialization(); err != nil {
 		return []error{err}
 	}
+	h.StartPolling()
+	return
+}
 
-	go func() {
-		for {
-			if err := h.connection.I2cWrite(mpl115a2Address, []byte{MPL115A2_REGISTER_STARTCONVERSION, 0}); err != nil {
-				gobot.Publish(h.Event(Error), err)
-				continue
-
-			}
-			<-time.After(5 * time.Millisecond)
-
-			if err := h.connection.I2cWrite(mpl115a2Address, []byte{MPL115A2_REGISTER_PRESSURE_MSB}); err != nil {
-				gobot.Publish(h.Event(Error), err)
-				continue
-			}
-
-			ret, err := h.connection.I2cRead(mpl115a2Address, 4)
-			if err != nil {
-				gobot.Publish(h.Event(Error), err)
-				continue
-			}
-			if len(ret) == 4 {
-				buf := bytes.NewBuffer(ret)
-				binary.Read(buf, binary.BigEndian, &pressure)
-				binary.Read(buf, binary.BigEndian, &temperature)
-
-				temperature = temperature >> 6
-				pressure = pressure >> 6
-
-				pressureComp = float32(h.A0) + (float32(h.B1)+float32(h.C12)*float32(temperature))*float32(pressure) + float32(h.B2)*float32(temperature)
-				h.Pressure = (65.0/1023.0)*pressureComp + 50.0
-				h.Temperature = ((float32(temperature) - 498.0) / -5.35) + 25.0
-			}
-			<-time.After(h.interval)
-		}
-	}()
+// Halt stops reading the MPL115A2
+func (h *MPL115A2Driver) Halt() (err []error) {
+	h.StopPolling()
 	return
 }
 
-// Halt returns true if devices is halted successfully
-func (h *MPL115A2Driver) Halt() (err []error) { return }
+// Reading returns the last reading of the MPL115A2, and the time it was made
+func (h *MPL115A2Driver) Reading() (data MPL115A2Data, timestamp time.Time) {
+	value, timestamp := h.Latest()
+	data, _ = value.(MPL115A2Data)
+	return
+}
+
+// read starts a conversion and returns its result
+func (h *MPL115A2Driver) read() (data MPL115A2Data, err error) {
+	var temperature uint16
+	var pressure uint16
+	var pressureComp float32
+
+	if err = h.connection.I2cWrite(mpl115a2Address, []byte{MPL115A2_REGISTER_STARTCONVERSION, 0}); err != nil {
+		return
+	}
+	<-time.After(5 * time.Millisecond)
+
+	if err = h.connection.I2cWrite(mpl115a2Address, []byte{MPL115A2_REGISTER_PRESSURE_MSB}); err != nil {
+		return
+	}
+
+	ret, err := h.connection.I2cRead(mpl115a2Address, 4)
+	if err != nil {
+		return
+	}
+	if len(ret) != 4 {
+		return data, ErrNotEnoughBytes
+	}
+	buf := bytes.NewBuffer(ret)
+	binary.Read(buf, binary.BigEndian, &pressure)
+	binary.Read(buf, binary.BigEndian, &temperature)
+
+	temperature = temperature >> 6
+	pressure = pressure >> 6
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	pressureComp = float32(h.A0) + (float32(h.B1)+float32(h.C12)*float32(temperature))*float32(pressure) + float32(h.B2)*float32(temperature)
+	h.Pressure = (65.0/1023.0)*pressureComp + 50.0
+	h.Temperature = ((float32(temperature) - 498.0) / -5.35) + 25.0
+	return MPL115A2Data{Pressure: h.Pressure, Temperature: h.Temperature}, nil
+}
 
 func (h *MPL115A2Driver) initialization() (err error) {
 	var coA0 int16
@@ -134,6 +166,8 @@ func (h *MPL115A2Driver) initialization() (err error) {
 
 	coC12 = coC12 >> 2
 
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
 	h.A0 = float32(coA0) / 8.0
 	h.B1 = float32(coB1) / 8192.0
 	h.B2 = float32(coB2) / 16384.0
@@ -35,10 +35,10 @@ func TestMPL115A2Driver(t *testing.T) {
 
 	gobot.Assert(t, mpl.Name(), "bot")
 	gobot.Assert(t, mpl.Connection().Name(), "adaptor")
-	gobot.Assert(t, mpl.interval, 10*time.Millisecond)
+	gobot.Assert(t, mpl.Interval(), 10*time.Millisecond)
 
 	mpl = NewMPL115A2Driver(newI2cTestAdaptor("adaptor"), "bot", 100*time.Millisecond)
-	gobot.Assert(t, mpl.interval, 100*time.Millisecond)
+	gobot.Assert(t, mpl.Interval(), 100*time.Millisecond)
 }
 
 func TestMPL115A2DriverStart(t *testing.T) {
@@ -49,6 +49,7 @@ func TestMPL115A2DriverStart(t *testing.T) {
 	}
 	gobot.Assert(t, len(mpl.Start()), 0)
 	<-time.After(100 * time.Millisecond)
+	gobot.Assert(t, len(mpl.Halt()), 0)
 	gobot.Assert(t, mpl.Pressure, float32(50.007942))
 	gobot.Assert(t, mpl.Temperature, float32(116.58878))
 }
@@ -58,3 +59,34 @@ func TestMPL115A2DriverHalt(t *testing.T) {
 
 	gobot.Assert(t, len(mpl.Halt()), 0)
 }
+
+func TestMPL115A2DriverReading(t *testing.T) {
+	mpl, adaptor := initTestMPL115A2DriverWithStubbedAdaptor()
+	adaptor.i2cReadImpl = func() ([]byte, error) {
+		return []byte{0x00, 0x01, 0x02, 0x04}, nil
+	}
+	data := make(chan interface{}, 1)
+	gobot.Once(mpl.Event(Data), func(d interface{}) {
+		data <- d
+	})
+
+	gobot.Assert(t, len(mpl.Start()), 0)
+	select {
+	case d := <-data:
+		gobot.Assert(t, d, MPL115A2Data{Pressure: 50.007942, Temperature: 116.58878})
+	case <-time.After(time.Second):
+		t.Errorf("data was not published")
+	}
+	gobot.Assert(t, len(mpl.Halt()), 0)
+	gobot.Assert(t, mpl.Polling(), false)
+
+	reading, timestamp := mpl.Reading()
+	gobot.Assert(t, reading.Pressure, float32(50.007942))
+	gobot.Assert(t, timestamp.IsZero(), false)
+
+	adaptor.i2cReadImpl = func() ([]byte, error) {
+		return []byte{0x00}, nil
+	}
+	_, err := mpl.read()
+	gobot.Assert(t, err, ErrNotEnoughBytes)
+}
@@ -3,6 +3,7 @@ package i2c
 import (
 	"bytes"
 	"encoding/binary"
+	"sync"
 	"time"
 
 	"github.com/hybridgroup/gobot"
@@ -14,10 +15,14 @@ const mpu6050Address = 0x68
 
 const MPU6050_RA_ACCEL_XOUT_H = 0x3B
 const MPU6050_RA_PWR_MGMT_1 = 0x6B
+const MPU6050_RA_WHO_AM_I = 0x75
 const MPU6050_PWR1_CLKSEL_BIT = 2
 const MPU6050_PWR1_CLKSEL_LENGTH = 3
 const MPU6050_CLOCK_PLL_XGYRO = 0x01
 const MPU6050_GYRO_FS_250 = 0x00
+const MPU6050_GYRO_FS_500 = 0x01
+const MPU6050_GYRO_FS_1000 = 0x02
+const MPU6050_GYRO_FS_2000 = 0x03
 const MPU6050_RA_GYRO_CONFIG = 0x1B
 const MPU6050_GCONFIG_FS_SEL_LENGTH = 2
 const MPU6050_GCONFIG_FS_SEL_BIT = 4
@@ -25,6 +30,9 @@ const MPU6050_RA_ACCEL_CONFIG = 0x1C
 const MPU6050_ACONFIG_AFS_SEL_BIT = 4
 const MPU6050_ACONFIG_AFS_SEL_LENGTH = 2
 const MPU6050_ACCEL_FS_2 = 0x00
+const MPU6050_ACCEL_FS_4 = 0x01
+const MPU6050_ACCEL_FS_8 = 0x02
+const MPU6050_ACCEL_FS_16 = 0x03
 const MPU6050_PWR1_SLEEP_BIT = 6
 
 type ThreeDData struct {
@@ -33,30 +41,78 @@ type ThreeDData struct {
 	Z int16
 }
 
+// ThreeDVector is a reading along three axes in units
+type ThreeDVector struct {
+	X float64
+	Y float64
+	Z float64
+}
+
+// MPU6050Data is a reading of an MPU6050, with its offsets removed
+type MPU6050Data struct {
+	// Accelerometer is the acceleration in g
+	Accelerometer ThreeDVector
+	// Gyroscope is the rate of rotation in degrees per second
+	Gyroscope ThreeDVector
+	// Temperature is the temperature in degrees Celsius
+	Temperature float64
+	// Timestamp is the time the MPU6050 was read
+	Timestamp time.Time
+}
+
 type MPU6050Driver struct {
-	name          string
-	connection    I2c
-	interval      time.Duration
+	name       string
+	connection I2c
+	mutex      sync.Mutex
+	// Accelerometer, Gyroscope and Temperature are the last raw readings,
+	// which are written while the MPU6050 is polled, see
+	// MPU6050Driver.Reading for reading them safely
 	Accelerometer ThreeDData
 	Gyroscope     ThreeDData
 	Temperature   int16
+	// GyroRange and AccelRange are the full scale ranges set by Start, one
+	// of MPU6050_GYRO_FS_250-2000 and MPU6050_ACCEL_FS_2-16
+	GyroRange  byte
+	AccelRange byte
+	// AccelerometerOffset and GyroscopeOffset are removed from the raw
+	// readings, see MPU6050Driver.Calibrate
+	AccelerometerOffset ThreeDData
+	GyroscopeOffset     ThreeDData
+	// Address is the i2c address of the MPU6050, 0x68 by default, or 0x69
+	// when its AD0 pin is high
+	Address int
 	gobot.Eventer
+	*Poller
 }
 
-// NewMPU6050Driver creates a new driver with specified name and i2c interface
+// NewMPU6050Driver creates a new driver with specified name and i2c interface.
+// The ranges are 250 degrees per second and 2g until GyroRange and AccelRange
+// are set.
+//
+// Optionally accepts:
+//  time.Duration: interval at which the MPU6050 is read, 10ms by default
+//
+// Emits the Events:
+//	"data" - an MPU6050Data every interval
+//	"error" - an error reading the MPU6050
 func NewMPU6050Driver(a I2c, name string, v ...time.Duration) *MPU6050Driver {
 	m := &MPU6050Driver{
 		name:       name,
 		connection: a,
-		interval:   10 * time.Millisecond,
+		GyroRange:  MPU6050_GYRO_FS_250,
+		AccelRange: MPU6050_ACCEL_FS_2,
+		Address:    mpu6050Address,
 		Eventer:    gobot.NewEventer(),
 	}
 
+	interval := 10 * time.Millisecond
 	if len(v) > 0 {
-		m.interval = v[0]
+		interval = v[0]
 	}
 
-	m.AddEvent(Error)
+	m.Poller = NewPoller(m, interval, func() (interface{}, error) {
+		return m.read()
+	})
 	return m
 }
 
@@ -69,67 +125,159 @@ func (h *MPU6050Driver) Start() (errs []error) {
 	if err := h.initialize(); err != nil {
 		return []error{err}
 	}
+	h.StartPolling()
+	return
+}
+
+// Halt stops reading the MPU6050
+func (h *MPU6050Driver) Halt() (errs []error) {
+	h.StopPolling()
+	return
+}
+
+// Reading returns the last reading of the MPU6050 in units, and the time it
+// was made
+func (h *MPU6050Driver) Reading() (data MPU6050Data, timestamp time.Time) {
+	value, timestamp := h.Latest()
+	data, _ = value.(MPU6050Data)
+	return
+}
 
-	go func() {
-		for {
-			if err := h.connection.I2cWrite(mpu6050Address, []byte{MPU6050_RA_ACCEL_XOUT_H}); err != nil {
-				gobot.Publish(h.Event(Error), err)
-				continue
-			}
-
-			ret, err := h.connection.I2cRead(mpu6050Address, 14)
-			if err != nil {
-				gobot.Publish(h.Event(Error), err)
-				continue
-			}
-			buf := bytes.NewBuffer(ret)
-			binary.Read(buf, binary.BigEndian, &h.Accelerometer)
-			binary.Read(buf, binary.BigEndian, &h.Gyroscope)
-			binary.Read(buf, binary.BigEndian, &h.Temperature)
-			<-time.After(h.interval)
+// Calibrate reads the MPU6050 samples times, once every interval, while it
+// lies still with its Z axis pointing up, and sets the offsets so that it
+// then reads no rotation and an acceleration of 1g along Z. It must be
+// called after Start.
+func (h *MPU6050Driver) Calibrate(samples int) (err error) {
+	var accel, gyro [3]float64
+	for i := 0; i < samples; i++ {
+		h.mutex.Lock()
+		err = h.readRaw()
+		a, g := h.Accelerometer, h.Gyroscope
+		h.mutex.Unlock()
+		if err != nil {
+			return
 		}
-	}()
+		for j, val := range [3]int16{a.X, a.Y, a.Z} {
+			accel[j] += float64(val) / float64(samples)
+		}
+		for j, val := range [3]int16{g.X, g.Y, g.Z} {
+			gyro[j] += float64(val) / float64(samples)
+		}
+		<-time.After(h.Interval())
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	accel[2] -= h.accelScale()
+	h.AccelerometerOffset = ThreeDData{round16(accel[0]), round16(accel[1]), round16(accel[2])}
+	h.GyroscopeOffset = ThreeDData{round16(gyro[0]), round16(gyro[1]), round16(gyro[2])}
 	return
 }
 
-// Halt returns true if devices is halted successfully
-func (h *MPU6050Driver) Halt() (errs []error) { return }
+// Offsets returns the offsets removed from the raw readings, as set by
+// Calibrate, so they can be kept, such as in an AT24CDriver
+func (h *MPU6050Driver) Offsets() (accel ThreeDData, gyro ThreeDData) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.AccelerometerOffset, h.GyroscopeOffset
+}
+
+// SetOffsets sets the offsets removed from the raw readings, such as those
+// of an earlier Calibrate, while the MPU6050 may be polled
+func (h *MPU6050Driver) SetOffsets(accel ThreeDData, gyro ThreeDData) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.AccelerometerOffset, h.GyroscopeOffset = accel, gyro
+}
+
+// read reads the MPU6050 and returns the reading in units
+func (h *MPU6050Driver) read() (data MPU6050Data, err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if err = h.readRaw(); err != nil {
+		return
+	}
+	data = h.reading()
+	data.Timestamp = time.Now()
+	return
+}
+
+// readRaw reads the raw accelerometer, temperature and gyroscope values
+func (h *MPU6050Driver) readRaw() (err error) {
+	if err = h.connection.I2cWrite(h.Address, []byte{MPU6050_RA_ACCEL_XOUT_H}); err != nil {
+		return
+	}
+	ret, err := h.connection.I2cRead(h.Address, 14)
+	if err != nil {
+		return
+	}
+	if len(ret) < 14 {
+		return ErrNotEnoughBytes
+	}
+	buf := bytes.NewBuffer(ret)
+	binary.Read(buf, binary.BigEndian, &h.Accelerometer)
+	binary.Read(buf, binary.BigEndian, &h.Temperature)
+	binary.Read(buf, binary.BigEndian, &h.Gyroscope)
+	return
+}
+
+// reading converts the raw values, less their offsets, into units
+func (h *MPU6050Driver) reading() MPU6050Data {
+	vector := func(raw ThreeDData, offset ThreeDData, scale float64) ThreeDVector {
+		return ThreeDVector{
+			X: float64(int(raw.X)-int(offset.X)) / scale,
+			Y: float64(int(raw.Y)-int(offset.Y)) / scale,
+			Z: float64(int(raw.Z)-int(offset.Z)) / scale,
+		}
+	}
+	return MPU6050Data{
+		Accelerometer: vector(h.Accelerometer, h.AccelerometerOffset, h.accelScale()),
+		Gyroscope:     vector(h.Gyroscope, h.GyroscopeOffset, h.gyroScale()),
+		Temperature:   float64(h.Temperature)/340 + 36.53,
+	}
+}
+
+// accelScale returns the raw value of 1g at the accelerometer range
+func (h *MPU6050Driver) accelScale() float64 {
+	return 16384 / float64(int(1)<<(h.AccelRange&0x03))
+}
+
+// gyroScale returns the raw value of 1 degree per second at the gyroscope
+// range
+func (h *MPU6050Driver) gyroScale() float64 {
+	return 131 / float64(int(1)<<(h.GyroRange&0x03))
+}
 
 func (h *MPU6050Driver) initialize() (err error) {
-	if err = h.connection.I2cStart(mpu6050Address); err != nil {
+	if err = h.connection.I2cStart(h.Address); err != nil {
 		return
 	}
 
-	// setClockSource
-	if err = h.connection.I2cWrite(mpu6050Address, []byte{MPU6050_RA_PWR_MGMT_1,
-		MPU6050_PWR1_CLKSEL_BIT,
-		MPU6050_PWR1_CLKSEL_LENGTH,
+	// setClockSource, which also wakes the MPU6050 from sleep
+	if err = h.connection.I2cWrite(h.Address, []byte{MPU6050_RA_PWR_MGMT_1,
 		MPU6050_CLOCK_PLL_XGYRO}); err != nil {
 		return
 	}
 
 	// setFullScaleGyroRange
-	if err = h.connection.I2cWrite(mpu6050Address, []byte{MPU6050_GYRO_FS_250,
-		MPU6050_RA_GYRO_CONFIG,
-		MPU6050_GCONFIG_FS_SEL_LENGTH,
-		MPU6050_GCONFIG_FS_SEL_BIT}); err != nil {
+	if err = h.connection.I2cWrite(h.Address, []byte{MPU6050_RA_GYRO_CONFIG,
+		(h.GyroRange & 0x03) << 3}); err != nil {
 		return
 	}
 
 	// setFullScaleAccelRange
-	if err = h.connection.I2cWrite(mpu6050Address, []byte{MPU6050_RA_ACCEL_CONFIG,
-		MPU6050_ACONFIG_AFS_SEL_BIT,
-		MPU6050_ACONFIG_AFS_SEL_LENGTH,
-		MPU6050_ACCEL_FS_2}); err != nil {
-		return
-	}
-
-	// setSleepEnabled
-	if err = h.connection.I2cWrite(mpu6050Address, []byte{MPU6050_RA_PWR_MGMT_1,
-		MPU6050_PWR1_SLEEP_BIT,
-		0}); err != nil {
+	if err = h.connection.I2cWrite(h.Address, []byte{MPU6050_RA_ACCEL_CONFIG,
+		(h.AccelRange & 0x03) << 3}); err != nil {
 		return
 	}
 
 	return nil
 }
+
+// round16 rounds val to the nearest int16
+func round16(val float64) int16 {
+	if val < 0 {
+		return int16(val - 0.5)
+	}
+	return int16(val + 0.5)
+}
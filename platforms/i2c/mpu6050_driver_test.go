@@ -1,6 +1,9 @@
 package i2c
 
 import (
+	"bytes"
+	"encoding/binary"
+	"errors"
 	"testing"
 	"time"
 
@@ -33,10 +36,10 @@ func TestMPU6050Driver(t *testing.T) {
 	mpu := initTestMPU6050Driver()
 	gobot.Assert(t, mpu.Name(), "bot")
 	gobot.Assert(t, mpu.Connection().Name(), "adaptor")
-	gobot.Assert(t, mpu.interval, 10*time.Millisecond)
+	gobot.Assert(t, mpu.Interval(), 10*time.Millisecond)
 
 	mpu = NewMPU6050Driver(newI2cTestAdaptor("adaptor"), "bot", 100*time.Millisecond)
-	gobot.Assert(t, mpu.interval, 100*time.Millisecond)
+	gobot.Assert(t, mpu.Interval(), 100*time.Millisecond)
 }
 
 // Methods
@@ -44,6 +47,7 @@ func TestMPU6050DriverStart(t *testing.T) {
 	mpu := initTestMPU6050Driver()
 
 	gobot.Assert(t, len(mpu.Start()), 0)
+	gobot.Assert(t, len(mpu.Halt()), 0)
 }
 
 func TestMPU6050DriverHalt(t *testing.T) {
@@ -51,3 +55,112 @@ func TestMPU6050DriverHalt(t *testing.T) {
 
 	gobot.Assert(t, len(mpu.Halt()), 0)
 }
+
+// mpu6050Reading returns the 14 bytes read from an MPU6050
+func mpu6050Reading(accel ThreeDData, temp int16, gyro ThreeDData) []byte {
+	buf := new(bytes.Buffer)
+	binary.Write(buf, binary.BigEndian, accel)
+	binary.Write(buf, binary.BigEndian, temp)
+	binary.Write(buf, binary.BigEndian, gyro)
+	return buf.Bytes()
+}
+
+func TestMPU6050DriverInitialize(t *testing.T) {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	mpu := NewMPU6050Driver(adaptor, "bot")
+	mpu.GyroRange = MPU6050_GYRO_FS_1000
+	mpu.AccelRange = MPU6050_ACCEL_FS_8
+
+	gobot.Assert(t, mpu.initialize(), nil)
+	gobot.Assert(t, adaptor.writes, [][]byte{{0x6B, 0x01}, {0x1B, 0x10}, {0x1C, 0x10}})
+	gobot.Assert(t, adaptor.address, 0x68)
+
+	// with AD0 high
+	mpu.Address = 0x69
+	gobot.Assert(t, mpu.initialize(), nil)
+	gobot.Assert(t, adaptor.address, 0x69)
+
+	adaptor.i2cWriteImpl = func() error { return errors.New("write error") }
+	gobot.Assert(t, mpu.Start()[0], errors.New("write error"))
+	adaptor.i2cStartImpl = func() error { return errors.New("start error") }
+	gobot.Assert(t, mpu.Start()[0], errors.New("start error"))
+}
+
+func TestMPU6050DriverData(t *testing.T) {
+	mpu, adaptor := initTestMPU6050DriverWithStubbedAdaptor()
+	mpu.AccelRange = MPU6050_ACCEL_FS_4
+	mpu.GyroRange = MPU6050_GYRO_FS_500
+	adaptor.i2cReadImpl = func() ([]byte, error) {
+		return mpu6050Reading(ThreeDData{8192, -4096, 0}, 340, ThreeDData{655, 0, -131}), nil
+	}
+	data := make(chan interface{}, 1)
+	gobot.Once(mpu.Event(Data), func(d interface{}) {
+		data <- d
+	})
+
+	gobot.Assert(t, len(mpu.Start()), 0)
+	defer mpu.Halt()
+	select {
+	case d := <-data:
+		gobot.Assert(t, d.(MPU6050Data).Timestamp.IsZero(), false)
+		gobot.Assert(t, d, MPU6050Data{
+			Accelerometer: ThreeDVector{X: 1, Y: -0.5, Z: 0},
+			Gyroscope:     ThreeDVector{X: 10, Y: 0, Z: -2},
+			Temperature:   37.53,
+			Timestamp:     d.(MPU6050Data).Timestamp,
+		})
+	case <-time.After(time.Second):
+		t.Errorf("data was not published")
+	}
+	reading, timestamp := mpu.Reading()
+	gobot.Assert(t, reading.Accelerometer.X, 1.0)
+	gobot.Assert(t, timestamp.IsZero(), false)
+}
+
+func TestMPU6050DriverReadError(t *testing.T) {
+	mpu, adaptor := initTestMPU6050DriverWithStubbedAdaptor()
+	errs := make(chan interface{}, 1)
+	gobot.Once(mpu.Event(Error), func(err interface{}) {
+		errs <- err
+	})
+
+	gobot.Assert(t, len(mpu.Start()), 0)
+	defer mpu.Halt()
+	select {
+	case err := <-errs:
+		gobot.Assert(t, err, ErrNotEnoughBytes)
+	case <-time.After(time.Second):
+		t.Errorf("error was not published")
+	}
+
+	adaptor.i2cReadImpl = func() ([]byte, error) {
+		return nil, errors.New("read error")
+	}
+	gobot.Assert(t, mpu.Calibrate(1), errors.New("read error"))
+}
+
+func TestMPU6050DriverCalibrate(t *testing.T) {
+	mpu, adaptor := initTestMPU6050DriverWithStubbedAdaptor()
+	mpu.SetInterval(time.Millisecond)
+	reads := 0
+	adaptor.i2cReadImpl = func() ([]byte, error) {
+		reads++
+		return mpu6050Reading(ThreeDData{100, -50, 16384 + int16(reads)}, 0, ThreeDData{-20, 10, int16(reads)}), nil
+	}
+
+	gobot.Assert(t, mpu.Calibrate(4), nil)
+	gobot.Assert(t, reads, 4)
+	gobot.Assert(t, mpu.AccelerometerOffset, ThreeDData{100, -50, 3})
+	gobot.Assert(t, mpu.GyroscopeOffset, ThreeDData{-20, 10, 3})
+
+	data, _ := mpu.read()
+	gobot.Assert(t, data.Accelerometer, ThreeDVector{X: 0, Y: 0, Z: 1 + 2.0/16384})
+	gobot.Assert(t, data.Gyroscope, ThreeDVector{X: 0, Y: 0, Z: 2.0 / 131})
+
+	// offsets kept from an earlier calibration
+	accel, gyro := mpu.Offsets()
+	mpu = NewMPU6050Driver(adaptor, "bot")
+	mpu.SetOffsets(accel, gyro)
+	gobot.Assert(t, mpu.AccelerometerOffset, ThreeDData{100, -50, 3})
+	gobot.Assert(t, mpu.GyroscopeOffset, ThreeDData{-20, 10, 3})
+}
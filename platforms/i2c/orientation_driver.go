@@ -0,0 +1,154 @@
+package i2c
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*OrientationDriver)(nil)
+
+// OrientationDriver fuses the readings of an MPU6050Driver, and optionally
+// the heading of an HMC6352Driver, into the roll, pitch and yaw of a robot,
+// such as for balancing or self-levelling. The compass must be mounted level,
+// with its forward direction along the X axis of the MPU6050.
+type OrientationDriver struct {
+	name        string
+	mpu6050     *MPU6050Driver
+	compass     *HMC6352Driver
+	filter      OrientationFilter
+	mutex       sync.Mutex
+	subscribed  bool
+	running     bool
+	orientation Orientation
+	last        time.Time
+	lastHeading time.Time
+	// HeadingInterval is how often the compass is read, 100ms by default
+	HeadingInterval time.Duration
+	// HeadingGain is the fraction of the way the yaw is moved towards the
+	// heading of the compass each time it is read, 0.05 by default
+	HeadingGain float64
+	gobot.Eventer
+	gobot.Commander
+}
+
+// NewOrientationDriver returns a new OrientationDriver given an MPU6050Driver,
+// name and the OrientationFilter fusing its readings. The yaw is the rotation
+// since the driver started, unless a compass is given, when it is the
+// heading counter-clockwise from north.
+//
+// Optionally accepts:
+//  *HMC6352Driver: compass correcting the drift of the yaw
+//
+// Adds the following API Commands:
+//	"Orientation" - See OrientationDriver.Orientation
+//
+// Emits the Events:
+//	"data" - the Orientation after each reading of the MPU6050
+//	"error" - an error reading the compass
+func NewOrientationDriver(m *MPU6050Driver, name string, f OrientationFilter, v ...*HMC6352Driver) *OrientationDriver {
+	o := &OrientationDriver{
+		name:            name,
+		mpu6050:         m,
+		filter:          f,
+		HeadingInterval: 100 * time.Millisecond,
+		HeadingGain:     0.05,
+		Eventer:         gobot.NewEventer(),
+		Commander:       gobot.NewCommander(),
+	}
+
+	if len(v) > 0 {
+		o.compass = v[0]
+	}
+
+	o.AddEvent(Data)
+	o.AddEvent(Error)
+
+	o.AddCommand("Orientation", func(params map[string]interface{}) interface{} {
+		return o.Orientation()
+	})
+
+	return o
+}
+
+// Name returns the OrientationDrivers name
+func (o *OrientationDriver) Name() string { return o.name }
+
+// Connection returns the connection of the MPU6050Driver
+func (o *OrientationDriver) Connection() gobot.Connection { return o.mpu6050.Connection() }
+
+// Start updates the orientation on every reading of the MPU6050Driver, which
+// must also be started, until the driver is halted
+func (o *OrientationDriver) Start() (errs []error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if !o.subscribed {
+		// the handler can not be removed, so it is added once and ignores
+		// the readings while the driver is halted
+		if err := gobot.On(o.mpu6050.Event(Data), func(data interface{}) {
+			o.mutex.Lock()
+			running := o.running
+			o.mutex.Unlock()
+			if running {
+				o.update(data.(MPU6050Data))
+			}
+		}); err != nil {
+			return []error{err}
+		}
+		o.subscribed = true
+	}
+	o.running = true
+	return
+}
+
+// Halt stops updating the orientation. The time it was halted is not passed
+// to the filter once it is started again.
+func (o *OrientationDriver) Halt() (errs []error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.running = false
+	o.last = time.Time{}
+	return
+}
+
+// Orientation returns the last orientation
+func (o *OrientationDriver) Orientation() Orientation {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return o.orientation
+}
+
+// update passes a reading of the MPU6050 to the filter, over the time since
+// the reading before, and corrects the yaw with the compass when it is due to
+// be read. As the events of the readings may be handled out of order, a
+// reading no newer than the last one is skipped, the time it covers having
+// already been passed to the filter with the newer reading.
+func (o *OrientationDriver) update(data MPU6050Data) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	now := data.Timestamp
+	var dt time.Duration
+	if !o.last.IsZero() {
+		if !now.After(o.last) {
+			return
+		}
+		dt = now.Sub(o.last)
+	}
+	o.last = now
+	o.orientation = o.filter.Update(data.Accelerometer, data.Gyroscope, dt)
+
+	if o.compass != nil && now.Sub(o.lastHeading) >= o.HeadingInterval {
+		o.lastHeading = now
+		heading, err := o.compass.Heading()
+		if err != nil {
+			gobot.Publish(o.Event(Error), err)
+		} else {
+			// the heading of the compass is clockwise
+			o.orientation = o.filter.CorrectYaw(wrapDegrees(-float64(heading)), o.HeadingGain)
+		}
+	}
+
+	gobot.Publish(o.Event(Data), o.orientation)
+}
@@ -0,0 +1,131 @@
+package i2c
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func initTestOrientationDriver(v ...*HMC6352Driver) (*OrientationDriver, *MPU6050Driver) {
+	mpu := NewMPU6050Driver(newI2cTestAdaptor("adaptor"), "mpu")
+	return NewOrientationDriver(mpu, "bot", NewComplementaryFilter(0.98), v...), mpu
+}
+
+func TestOrientationDriver(t *testing.T) {
+	o, _ := initTestOrientationDriver()
+	gobot.Assert(t, o.Name(), "bot")
+	gobot.Assert(t, o.Connection().Name(), "adaptor")
+	gobot.Assert(t, len(o.Halt()), 0)
+	gobot.Assert(t, o.Command("Orientation")(nil), Orientation{})
+}
+
+func TestOrientationDriverStart(t *testing.T) {
+	o, mpu := initTestOrientationDriver()
+	data := make(chan interface{}, 1)
+	gobot.Once(o.Event(Data), func(d interface{}) {
+		data <- d
+	})
+
+	gobot.Assert(t, len(o.Start()), 0)
+	gobot.Publish(mpu.Event(Data), MPU6050Data{Accelerometer: ThreeDVector{X: 0, Y: 1, Z: 1}, Timestamp: time.Now()})
+	select {
+	case d := <-data:
+		gobot.Assert(t, near(d.(Orientation).Roll, 45), true)
+	case <-time.After(time.Second):
+		t.Errorf("data was not published")
+	}
+	gobot.Assert(t, near(o.Orientation().Roll, 45), true)
+}
+
+func TestOrientationDriverRestart(t *testing.T) {
+	o, mpu := initTestOrientationDriver()
+	data := make(chan interface{}, 4)
+	gobot.On(o.Event(Data), func(d interface{}) {
+		data <- d
+	})
+	now := time.Now()
+	reading := func(offset time.Duration) {
+		gobot.Publish(mpu.Event(Data), MPU6050Data{Accelerometer: level, Gyroscope: ThreeDVector{X: 0, Y: 0, Z: 10}, Timestamp: now.Add(offset)})
+		select {
+		case <-data:
+		case <-time.After(time.Second):
+			t.Errorf("data was not published")
+		}
+	}
+
+	gobot.Assert(t, len(o.Start()), 0)
+	gobot.Assert(t, len(o.Start()), 0)
+	reading(0)
+	reading(time.Second)
+	gobot.Assert(t, near(o.Orientation().Yaw, 10), true)
+
+	// readings are ignored while halted, and each is passed to the filter
+	// once after starting again
+	gobot.Assert(t, len(o.Halt()), 0)
+	gobot.Publish(mpu.Event(Data), MPU6050Data{Accelerometer: level, Gyroscope: ThreeDVector{X: 0, Y: 0, Z: 10}, Timestamp: now.Add(2 * time.Second)})
+	select {
+	case d := <-data:
+		t.Errorf("data was published while halted: %v", d)
+	case <-time.After(20 * time.Millisecond):
+	}
+	gobot.Assert(t, len(o.Start()), 0)
+	reading(5 * time.Second)
+	reading(6 * time.Second)
+	gobot.Assert(t, near(o.Orientation().Yaw, 20), true)
+	select {
+	case d := <-data:
+		t.Errorf("a reading was handled twice: %v", d)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestOrientationDriverUpdate(t *testing.T) {
+	o, _ := initTestOrientationDriver()
+	now := time.Now()
+
+	o.update(MPU6050Data{Accelerometer: level, Timestamp: now})
+	o.update(MPU6050Data{Accelerometer: level, Gyroscope: ThreeDVector{X: 0, Y: 0, Z: 10}, Timestamp: now.Add(time.Second)})
+	gobot.Assert(t, near(o.Orientation().Yaw, 10), true)
+
+	// a reading handled after a newer one is skipped, and readings are timed
+	// by when they were made rather than handled
+	o.update(MPU6050Data{Accelerometer: level, Gyroscope: ThreeDVector{X: 0, Y: 0, Z: 10}, Timestamp: now})
+	gobot.Assert(t, near(o.Orientation().Yaw, 10), true)
+	o.update(MPU6050Data{Accelerometer: level, Gyroscope: ThreeDVector{X: 0, Y: 0, Z: 10}, Timestamp: now.Add(3 * time.Second)})
+	gobot.Assert(t, near(o.Orientation().Yaw, 30), true)
+}
+
+func TestOrientationDriverCompass(t *testing.T) {
+	adaptor := newI2cTestAdaptor("adaptor")
+	// a heading of 90 degrees, clockwise from north
+	adaptor.i2cReadImpl = func() ([]byte, error) {
+		return []byte{0x03, 0x84}, nil
+	}
+	o, _ := initTestOrientationDriver(NewHMC6352Driver(adaptor, "compass"))
+	o.HeadingGain = 1
+	now := time.Now()
+
+	o.update(MPU6050Data{Accelerometer: level, Timestamp: now})
+	gobot.Assert(t, near(o.Orientation().Yaw, -90), true)
+
+	// the compass is only read every HeadingInterval
+	o.update(MPU6050Data{Accelerometer: level, Gyroscope: ThreeDVector{X: 0, Y: 0, Z: 10}, Timestamp: now.Add(time.Second / 20)})
+	gobot.Assert(t, near(o.Orientation().Yaw, -89.5), true)
+
+	errs := make(chan interface{}, 1)
+	gobot.Once(o.Event(Error), func(err interface{}) {
+		errs <- err
+	})
+	adaptor.i2cReadImpl = func() ([]byte, error) {
+		return nil, errors.New("read error")
+	}
+	o.update(MPU6050Data{Accelerometer: level, Timestamp: now.Add(time.Second)})
+	select {
+	case err := <-errs:
+		gobot.Assert(t, err, errors.New("read error"))
+	case <-time.After(time.Second):
+		t.Errorf("error was not published")
+	}
+}
@@ -0,0 +1,165 @@
+package i2c
+
+import (
+	"math"
+	"time"
+)
+
+// Orientation is the attitude of a sensor in degrees. Roll is the rotation
+// about its X axis, pitch about its Y axis and yaw about its Z axis,
+// counter-clockwise seen from above, all in -180 to 180.
+type Orientation struct {
+	Roll  float64
+	Pitch float64
+	Yaw   float64
+}
+
+// OrientationFilter fuses accelerometer and gyroscope readings into an
+// Orientation
+type OrientationFilter interface {
+	// Update takes a reading of the acceleration in g and of the rate of
+	// rotation in degrees per second, made dt after the previous reading,
+	// and returns the new orientation
+	Update(accel ThreeDVector, gyro ThreeDVector, dt time.Duration) Orientation
+	// CorrectYaw moves the yaw the fraction gain of the way towards yaw,
+	// measured by a compass, and returns the new orientation
+	CorrectYaw(yaw float64, gain float64) Orientation
+}
+
+// ComplementaryFilter integrates the gyroscope for the short term and leans
+// towards the roll and pitch given by gravity for the long term. It is cheap
+// and works well when the sensor is not accelerating for long.
+type ComplementaryFilter struct {
+	// Alpha is the weight of the gyroscope at each update, 0-1
+	Alpha       float64
+	orientation Orientation
+	started     bool
+}
+
+// NewComplementaryFilter returns a new ComplementaryFilter given the weight of
+// the gyroscope at each update, such as 0.98
+func NewComplementaryFilter(alpha float64) *ComplementaryFilter {
+	return &ComplementaryFilter{Alpha: alpha}
+}
+
+// Update implements the OrientationFilter interface
+func (f *ComplementaryFilter) Update(accel ThreeDVector, gyro ThreeDVector, dt time.Duration) Orientation {
+	roll, pitch := accelRollPitch(accel)
+	if !f.started {
+		f.orientation = Orientation{Roll: roll, Pitch: pitch}
+		f.started = true
+		return f.orientation
+	}
+
+	s := dt.Seconds()
+	f.orientation.Roll = wrapDegrees(f.orientation.Roll + gyro.X*s +
+		(1-f.Alpha)*wrapDegrees(roll-f.orientation.Roll-gyro.X*s))
+	f.orientation.Pitch = wrapDegrees(f.orientation.Pitch + gyro.Y*s +
+		(1-f.Alpha)*wrapDegrees(pitch-f.orientation.Pitch-gyro.Y*s))
+	f.orientation.Yaw = wrapDegrees(f.orientation.Yaw + gyro.Z*s)
+	return f.orientation
+}
+
+// CorrectYaw implements the OrientationFilter interface
+func (f *ComplementaryFilter) CorrectYaw(yaw float64, gain float64) Orientation {
+	f.orientation.Yaw = wrapDegrees(f.orientation.Yaw + gain*wrapDegrees(yaw-f.orientation.Yaw))
+	return f.orientation
+}
+
+// MadgwickFilter estimates the orientation as a quaternion, corrected
+// against gravity by gradient descent, as described by Sebastian Madgwick.
+// It handles any attitude, where the ComplementaryFilter is best kept within
+// 90 degrees of level.
+type MadgwickFilter struct {
+	// Beta is the gain of the correction, in radians per second, larger
+	// values converging faster but trusting the gyroscope less
+	Beta float64
+	q    [4]float64
+}
+
+// NewMadgwickFilter returns a new MadgwickFilter given its gain, such as 0.1
+func NewMadgwickFilter(beta float64) *MadgwickFilter {
+	return &MadgwickFilter{Beta: beta, q: [4]float64{1, 0, 0, 0}}
+}
+
+// Update implements the OrientationFilter interface
+func (f *MadgwickFilter) Update(accel ThreeDVector, gyro ThreeDVector, dt time.Duration) Orientation {
+	q0, q1, q2, q3 := f.q[0], f.q[1], f.q[2], f.q[3]
+	gx := gyro.X * math.Pi / 180
+	gy := gyro.Y * math.Pi / 180
+	gz := gyro.Z * math.Pi / 180
+
+	// rate of change of the quaternion from the gyroscope
+	qDot0 := 0.5 * (-q1*gx - q2*gy - q3*gz)
+	qDot1 := 0.5 * (q0*gx + q2*gz - q3*gy)
+	qDot2 := 0.5 * (q0*gy - q1*gz + q3*gx)
+	qDot3 := 0.5 * (q0*gz + q1*gy - q2*gx)
+
+	if norm := math.Sqrt(accel.X*accel.X + accel.Y*accel.Y + accel.Z*accel.Z); norm > 0 {
+		ax, ay, az := accel.X/norm, accel.Y/norm, accel.Z/norm
+
+		// gradient of the error between the measured and expected gravity
+		s0 := 4*q0*q2*q2 + 2*q2*ax + 4*q0*q1*q1 - 2*q1*ay
+		s1 := 4*q1*q3*q3 - 2*q3*ax + 4*q0*q0*q1 - 2*q0*ay - 4*q1 +
+			8*q1*q1*q1 + 8*q1*q2*q2 + 4*q1*az
+		s2 := 4*q0*q0*q2 + 2*q0*ax + 4*q2*q3*q3 - 2*q3*ay - 4*q2 +
+			8*q2*q1*q1 + 8*q2*q2*q2 + 4*q2*az
+		s3 := 4*q1*q1*q3 - 2*q1*ax + 4*q2*q2*q3 - 2*q2*ay
+		if norm := math.Sqrt(s0*s0 + s1*s1 + s2*s2 + s3*s3); norm > 0 {
+			qDot0 -= f.Beta * s0 / norm
+			qDot1 -= f.Beta * s1 / norm
+			qDot2 -= f.Beta * s2 / norm
+			qDot3 -= f.Beta * s3 / norm
+		}
+	}
+
+	s := dt.Seconds()
+	f.setQuaternion(q0+qDot0*s, q1+qDot1*s, q2+qDot2*s, q3+qDot3*s)
+	return f.orientation()
+}
+
+// CorrectYaw implements the OrientationFilter interface
+func (f *MadgwickFilter) CorrectYaw(yaw float64, gain float64) Orientation {
+	// rotate about the vertical by the correction
+	a := gain * wrapDegrees(yaw-f.orientation().Yaw) * math.Pi / 360
+	c, s := math.Cos(a), math.Sin(a)
+	q0, q1, q2, q3 := f.q[0], f.q[1], f.q[2], f.q[3]
+	f.setQuaternion(c*q0-s*q3, c*q1-s*q2, c*q2+s*q1, c*q3+s*q0)
+	return f.orientation()
+}
+
+// setQuaternion sets the quaternion, normalized
+func (f *MadgwickFilter) setQuaternion(q0, q1, q2, q3 float64) {
+	norm := math.Sqrt(q0*q0 + q1*q1 + q2*q2 + q3*q3)
+	if norm == 0 {
+		f.q = [4]float64{1, 0, 0, 0}
+		return
+	}
+	f.q = [4]float64{q0 / norm, q1 / norm, q2 / norm, q3 / norm}
+}
+
+// orientation returns the quaternion as roll, pitch and yaw
+func (f *MadgwickFilter) orientation() Orientation {
+	q0, q1, q2, q3 := f.q[0], f.q[1], f.q[2], f.q[3]
+	return Orientation{
+		Roll:  math.Atan2(2*(q0*q1+q2*q3), 1-2*(q1*q1+q2*q2)) * 180 / math.Pi,
+		Pitch: math.Asin(math.Max(-1, math.Min(1, 2*(q0*q2-q3*q1)))) * 180 / math.Pi,
+		Yaw:   math.Atan2(2*(q0*q3+q1*q2), 1-2*(q2*q2+q3*q3)) * 180 / math.Pi,
+	}
+}
+
+// accelRollPitch returns the roll and pitch at which gravity reads as accel
+func accelRollPitch(accel ThreeDVector) (roll float64, pitch float64) {
+	roll = math.Atan2(accel.Y, accel.Z) * 180 / math.Pi
+	pitch = math.Atan2(-accel.X, math.Sqrt(accel.Y*accel.Y+accel.Z*accel.Z)) * 180 / math.Pi
+	return
+}
+
+// wrapDegrees returns angle wrapped into -180 to 180
+func wrapDegrees(angle float64) float64 {
+	angle = math.Mod(angle+180, 360)
+	if angle < 0 {
+		angle += 360
+	}
+	return angle - 180
+}
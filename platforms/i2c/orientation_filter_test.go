@@ -0,0 +1,81 @@
+package i2c
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var level = ThreeDVector{X: 0, Y: 0, Z: 1}
+
+// near returns true when a and b differ by less than 0.5
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 0.5
+}
+
+func TestWrapDegrees(t *testing.T) {
+	gobot.Assert(t, wrapDegrees(0), 0.0)
+	gobot.Assert(t, wrapDegrees(190), -170.0)
+	gobot.Assert(t, wrapDegrees(-190), 170.0)
+	gobot.Assert(t, wrapDegrees(720+45), 45.0)
+}
+
+func TestAccelRollPitch(t *testing.T) {
+	roll, pitch := accelRollPitch(ThreeDVector{X: 0, Y: 1, Z: 1})
+	gobot.Assert(t, near(roll, 45), true)
+	gobot.Assert(t, near(pitch, 0), true)
+
+	roll, pitch = accelRollPitch(ThreeDVector{X: -1, Y: 0, Z: 0})
+	gobot.Assert(t, near(roll, 0), true)
+	gobot.Assert(t, near(pitch, 90), true)
+}
+
+func TestComplementaryFilter(t *testing.T) {
+	f := NewComplementaryFilter(0.98)
+
+	// the first reading sets the roll and pitch at once
+	o := f.Update(ThreeDVector{X: 0, Y: 1, Z: 1}, ThreeDVector{}, 0)
+	gobot.Assert(t, near(o.Roll, 45), true)
+
+	// they then drift towards gravity
+	for i := 0; i < 500; i++ {
+		o = f.Update(level, ThreeDVector{}, 10*time.Millisecond)
+	}
+	gobot.Assert(t, near(o.Roll, 0), true)
+
+	// while the yaw follows the gyroscope
+	for i := 0; i < 100; i++ {
+		o = f.Update(level, ThreeDVector{X: 0, Y: 0, Z: 90}, 10*time.Millisecond)
+	}
+	gobot.Assert(t, near(o.Yaw, 90), true)
+	gobot.Assert(t, near(o.Roll, 0), true)
+
+	o = f.CorrectYaw(-90, 0.5)
+	gobot.Assert(t, near(o.Yaw, 180) || near(o.Yaw, -180), true)
+}
+
+func TestMadgwickFilter(t *testing.T) {
+	f := NewMadgwickFilter(0.5)
+	gobot.Assert(t, f.Update(level, ThreeDVector{}, 0), Orientation{})
+
+	// converges towards gravity
+	var o Orientation
+	for i := 0; i < 1000; i++ {
+		o = f.Update(ThreeDVector{X: -1, Y: 0, Z: 1}, ThreeDVector{}, 10*time.Millisecond)
+	}
+	gobot.Assert(t, near(o.Pitch, 45), true)
+	gobot.Assert(t, near(o.Roll, 0), true)
+
+	f = NewMadgwickFilter(0.1)
+	for i := 0; i < 100; i++ {
+		o = f.Update(level, ThreeDVector{X: 0, Y: 0, Z: 90}, 10*time.Millisecond)
+	}
+	gobot.Assert(t, near(o.Yaw, 90), true)
+
+	o = f.CorrectYaw(0, 0.5)
+	gobot.Assert(t, near(o.Yaw, 45), true)
+	gobot.Assert(t, near(o.Roll, 0), true)
+	gobot.Assert(t, near(o.Pitch, 0), true)
+}
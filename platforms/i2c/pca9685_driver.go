@@ -0,0 +1,313 @@
+package i2c
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+)
+
+var _ gobot.Driver = (*PCA9685Driver)(nil)
+
+// The channels of the PCA9685 can be used by gpio drivers
+var (
+	_ gpio.DigitalWriter = (*PCA9685Driver)(nil)
+	_ gpio.PwmWriter     = (*PCA9685Driver)(nil)
+	_ gpio.ServoWriter   = (*PCA9685Driver)(nil)
+)
+
+// ErrInvalidPCA9685Channel is the error resulting when a pin name is not one
+// of the PCA9685 channels "0" to "15"
+var ErrInvalidPCA9685Channel = errors.New("PCA9685 channels are 0-15")
+
+const pca9685Address = 0x40
+
+// PCA9685 registers
+const (
+	pca9685Mode1     = 0x00
+	pca9685Mode2     = 0x01
+	pca9685Led0OnL   = 0x06
+	pca9685AllLedOnL = 0xFA
+	pca9685PreScale  = 0xFE
+)
+
+// PCA9685 MODE1 and MODE2 bits
+const (
+	pca9685Restart = 0x80
+	pca9685AI      = 0x20
+	pca9685Sleep   = 0x10
+	pca9685AllCall = 0x01
+	pca9685OutDrv  = 0x04
+)
+
+// pca9685Clock is the frequency of the internal oscillator of the PCA9685
+const pca9685Clock = 25000000.0
+
+// pca9685Full is the bit of the ON_H and OFF_H registers which holds a
+// channel fully on or off
+const pca9685Full = 0x1000
+
+// PCA9685Driver represents a PCA9685 16-channel, 12-bit PWM controller, as
+// found on many servo boards. It is also a DigitalWriter, PwmWriter and
+// ServoWriter whose pins "0" to "15" are its channels, so LedDriver,
+// MotorDriver and ServoDriver can run on them.
+type PCA9685Driver struct {
+	name       string
+	connection I2c
+	address    int
+	mutex      sync.Mutex
+	mode1      byte
+	prescale   byte
+	// ServoMinPulse and ServoMaxPulse are the pulse widths ServoWrite drives
+	// a channel with for 0 and 180 degrees
+	ServoMinPulse time.Duration
+	ServoMaxPulse time.Duration
+	gobot.Commander
+}
+
+// NewPCA9685Driver returns a new PCA9685Driver given an I2c adaptor and name.
+// Its channels run at 50Hz, the frequency servos expect, until SetFrequency
+// is called.
+//
+// Optionally accepts:
+//  int: i2c address of the PCA9685, 0x40 by default
+//
+// Adds the following API Commands:
+//	"SetFrequency" - See PCA9685Driver.SetFrequency, given "frequency" in Hz
+//	"SetPWM" - See PCA9685Driver.SetPWM, given "channel", "on" and "off"
+//	"SetDuty" - See PCA9685Driver.SetDuty, given "channel" and "duty"
+//	"AllOff" - See PCA9685Driver.AllOff
+//	"Sleep" - See PCA9685Driver.Sleep
+//	"Wake" - See PCA9685Driver.Wake
+func NewPCA9685Driver(a I2c, name string, v ...int) *PCA9685Driver {
+	p := &PCA9685Driver{
+		name:          name,
+		connection:    a,
+		address:       pca9685Address,
+		mode1:         pca9685AI | pca9685AllCall,
+		prescale:      pca9685Prescale(50),
+		ServoMinPulse: 1000 * time.Microsecond,
+		ServoMaxPulse: 2000 * time.Microsecond,
+		Commander:     gobot.NewCommander(),
+	}
+
+	if len(v) > 0 {
+		p.address = v[0]
+	}
+
+	p.AddCommand("SetFrequency", func(params map[string]interface{}) interface{} {
+		return p.SetFrequency(params["frequency"].(float64))
+	})
+	p.AddCommand("SetPWM", func(params map[string]interface{}) interface{} {
+		channel := int(params["channel"].(float64))
+		on := uint16(params["on"].(float64))
+		off := uint16(params["off"].(float64))
+		return p.SetPWM(channel, on, off)
+	})
+	p.AddCommand("SetDuty", func(params map[string]interface{}) interface{} {
+		channel := int(params["channel"].(float64))
+		duty := uint16(params["duty"].(float64))
+		return p.SetDuty(channel, duty)
+	})
+	p.AddCommand("AllOff", func(params map[string]interface{}) interface{} {
+		return p.AllOff()
+	})
+	p.AddCommand("Sleep", func(params map[string]interface{}) interface{} {
+		return p.Sleep()
+	})
+	p.AddCommand("Wake", func(params map[string]interface{}) interface{} {
+		return p.Wake()
+	})
+
+	return p
+}
+
+// Name returns the PCA9685Drivers name
+func (p *PCA9685Driver) Name() string { return p.name }
+
+// Connection returns the PCA9685Drivers connection
+func (p *PCA9685Driver) Connection() gobot.Connection { return p.connection.(gobot.Connection) }
+
+// Start sets the outputs to totem pole, turns every channel off and starts
+// the oscillator at the frequency
+func (p *PCA9685Driver) Start() (errs []error) {
+	if err := p.connection.I2cStart(p.address); err != nil {
+		return []error{err}
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if err := p.write(pca9685Mode2, pca9685OutDrv); err != nil {
+		return []error{err}
+	}
+	if err := p.write(pca9685AllLedOnL, 0, 0, 0, pca9685Full>>8); err != nil {
+		return []error{err}
+	}
+	if err := p.setPrescale(p.prescale); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Halt turns every channel off
+func (p *PCA9685Driver) Halt() (errs []error) {
+	if err := p.AllOff(); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Connect implements the Adaptor interface, the PCA9685Driver is connected
+// once its connection is
+func (p *PCA9685Driver) Connect() (errs []error) { return }
+
+// Finalize implements the Adaptor interface
+func (p *PCA9685Driver) Finalize() (errs []error) { return }
+
+// Frequency returns the frequency of the channels in Hz, as near to the
+// frequency set as the prescaler of the PCA9685 allows
+func (p *PCA9685Driver) Frequency() float64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return pca9685Clock / (4096 * (float64(p.prescale) + 1))
+}
+
+// SetFrequency sets the frequency of every channel, 24-1526Hz
+func (p *PCA9685Driver) SetFrequency(hz float64) (err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.setPrescale(pca9685Prescale(hz))
+}
+
+// SetPWM sets channel to go high at count on and low at count off of the
+// 4096 counts of each period. An on or off of 4096 holds the channel fully
+// on or off, off taking precedence.
+func (p *PCA9685Driver) SetPWM(channel int, on uint16, off uint16) (err error) {
+	if channel < 0 || channel > 15 {
+		return ErrInvalidPCA9685Channel
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.write(byte(pca9685Led0OnL+4*channel), byte(on), byte(on>>8), byte(off), byte(off>>8))
+}
+
+// SetDuty drives channel high for duty of the 4096 counts of each period, 0
+// being fully off and 4096 fully on
+func (p *PCA9685Driver) SetDuty(channel int, duty uint16) (err error) {
+	switch {
+	case duty == 0:
+		return p.SetPWM(channel, 0, pca9685Full)
+	case duty >= 4096:
+		return p.SetPWM(channel, pca9685Full, 0)
+	default:
+		return p.SetPWM(channel, 0, duty)
+	}
+}
+
+// AllOff turns every channel fully off
+func (p *PCA9685Driver) AllOff() (err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.write(pca9685AllLedOnL, 0, 0, 0, pca9685Full>>8)
+}
+
+// Sleep stops the oscillator, turning every channel off and saving power
+// until Wake is called
+func (p *PCA9685Driver) Sleep() (err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.mode1 |= pca9685Sleep
+	return p.write(pca9685Mode1, p.mode1)
+}
+
+// Wake restarts the oscillator, resuming every channel as it was before Sleep
+func (p *PCA9685Driver) Wake() (err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.mode1 &^= pca9685Sleep
+	return p.restart()
+}
+
+// DigitalWrite holds channel pin fully on or off
+func (p *PCA9685Driver) DigitalWrite(pin string, level byte) (err error) {
+	channel, err := pca9685Channel(pin)
+	if err != nil {
+		return
+	}
+	if level == 0 {
+		return p.SetDuty(channel, 0)
+	}
+	return p.SetDuty(channel, 4096)
+}
+
+// PwmWrite drives channel pin high for level of 255 of each period
+func (p *PCA9685Driver) PwmWrite(pin string, level byte) (err error) {
+	channel, err := pca9685Channel(pin)
+	if err != nil {
+		return
+	}
+	return p.SetDuty(channel, uint16(math.Floor(float64(level)*4096/255+0.5)))
+}
+
+// ServoWrite moves the servo on channel pin to angle, 0-180 degrees, by
+// driving it with a pulse between ServoMinPulse and ServoMaxPulse
+func (p *PCA9685Driver) ServoWrite(pin string, angle byte) (err error) {
+	channel, err := pca9685Channel(pin)
+	if err != nil {
+		return
+	}
+	if angle > 180 {
+		angle = 180
+	}
+	pulse := float64(p.ServoMinPulse) +
+		float64(p.ServoMaxPulse-p.ServoMinPulse)*float64(angle)/180
+	period := float64(time.Second) / p.Frequency()
+	return p.SetDuty(channel, uint16(math.Floor(4096*pulse/period+0.5)))
+}
+
+// setPrescale sets the prescaler, which can only be written while the
+// oscillator sleeps, and restarts the oscillator
+func (p *PCA9685Driver) setPrescale(prescale byte) (err error) {
+	if err = p.write(pca9685Mode1, p.mode1|pca9685Sleep); err != nil {
+		return
+	}
+	if err = p.write(pca9685PreScale, prescale); err != nil {
+		return
+	}
+	p.prescale = prescale
+	return p.restart()
+}
+
+// restart wakes the oscillator and, once it has settled, restarts the
+// channels
+func (p *PCA9685Driver) restart() (err error) {
+	if err = p.write(pca9685Mode1, p.mode1); err != nil {
+		return
+	}
+	<-time.After(500 * time.Microsecond)
+	return p.write(pca9685Mode1, p.mode1|pca9685Restart)
+}
+
+// write writes data to the registers from register on
+func (p *PCA9685Driver) write(register byte, data ...byte) (err error) {
+	return p.connection.I2cWrite(p.address, append([]byte{register}, data...))
+}
+
+// pca9685Prescale returns the prescaler value for frequency hz
+func pca9685Prescale(hz float64) byte {
+	prescale := math.Floor(pca9685Clock/(4096*hz)+0.5) - 1
+	return byte(math.Max(3, math.Min(255, prescale)))
+}
+
+// pca9685Channel parses a pin name into a PCA9685 channel
+func pca9685Channel(pin string) (channel int, err error) {
+	channel, err = strconv.Atoi(pin)
+	if err != nil || channel < 0 || channel > 15 {
+		return 0, ErrInvalidPCA9685Channel
+	}
+	return
+}
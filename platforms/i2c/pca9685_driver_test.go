@@ -0,0 +1,142 @@
+package i2c
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+)
+
+func initTestPCA9685Driver(v ...int) (*PCA9685Driver, *i2cTestRegisters) {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	return NewPCA9685Driver(adaptor, "bot", v...), adaptor
+}
+
+func TestPCA9685Driver(t *testing.T) {
+	p, _ := initTestPCA9685Driver()
+	gobot.Assert(t, p.Name(), "bot")
+	gobot.Assert(t, p.Connection().Name(), "adaptor")
+	gobot.Assert(t, len(p.Connect()), 0)
+	gobot.Assert(t, len(p.Finalize()), 0)
+	gobot.Assert(t, int(p.Frequency()), 50)
+}
+
+func TestPCA9685DriverStart(t *testing.T) {
+	p, adaptor := initTestPCA9685Driver(0x41)
+	gobot.Assert(t, len(p.Start()), 0)
+	gobot.Assert(t, adaptor.address, 0x41)
+	gobot.Assert(t, adaptor.writes, [][]byte{
+		{0x01, 0x04},
+		{0xFA, 0, 0, 0, 0x10},
+		{0x00, 0x31},
+		{0xFE, 121},
+		{0x00, 0x21},
+		{0x00, 0xA1},
+	})
+
+	adaptor.i2cWriteImpl = func() error {
+		return errors.New("write error")
+	}
+	gobot.Assert(t, p.Start()[0], errors.New("write error"))
+	gobot.Assert(t, p.Halt()[0], errors.New("write error"))
+
+	adaptor.i2cStartImpl = func() error {
+		return errors.New("start error")
+	}
+	gobot.Assert(t, p.Start()[0], errors.New("start error"))
+}
+
+func TestPCA9685DriverSetFrequency(t *testing.T) {
+	p, adaptor := initTestPCA9685Driver()
+	gobot.Assert(t, p.SetFrequency(1000), nil)
+	gobot.Assert(t, adaptor.writes[1], []byte{0xFE, 5})
+	gobot.Assert(t, p.Frequency() > 1000, true)
+
+	// the prescaler is limited to 3-255
+	p.SetFrequency(10000)
+	gobot.Assert(t, adaptor.writes[5], []byte{0xFE, 3})
+	p.SetFrequency(1)
+	gobot.Assert(t, adaptor.writes[9], []byte{0xFE, 255})
+}
+
+func TestPCA9685DriverSetDuty(t *testing.T) {
+	p, adaptor := initTestPCA9685Driver()
+	gobot.Assert(t, p.SetPWM(1, 0x0123, 0x0456), nil)
+	gobot.Assert(t, p.SetDuty(15, 0), nil)
+	gobot.Assert(t, p.SetDuty(0, 4096), nil)
+	gobot.Assert(t, p.SetDuty(0, 2048), nil)
+	gobot.Assert(t, adaptor.writes, [][]byte{
+		{0x0A, 0x23, 0x01, 0x56, 0x04},
+		{0x42, 0, 0, 0, 0x10},
+		{0x06, 0, 0x10, 0, 0},
+		{0x06, 0, 0, 0, 0x08},
+	})
+
+	gobot.Assert(t, p.SetPWM(16, 0, 0), ErrInvalidPCA9685Channel)
+	gobot.Assert(t, p.SetDuty(-1, 0), ErrInvalidPCA9685Channel)
+}
+
+func TestPCA9685DriverSleep(t *testing.T) {
+	p, adaptor := initTestPCA9685Driver()
+	gobot.Assert(t, p.Sleep(), nil)
+	gobot.Assert(t, p.Wake(), nil)
+	gobot.Assert(t, p.AllOff(), nil)
+	gobot.Assert(t, adaptor.writes, [][]byte{
+		{0x00, 0x31},
+		{0x00, 0x21},
+		{0x00, 0xA1},
+		{0xFA, 0, 0, 0, 0x10},
+	})
+}
+
+func TestPCA9685DriverWriters(t *testing.T) {
+	p, adaptor := initTestPCA9685Driver()
+	gobot.Assert(t, p.DigitalWrite("3", 1), nil)
+	gobot.Assert(t, p.DigitalWrite("3", 0), nil)
+	gobot.Assert(t, p.PwmWrite("4", 128), nil)
+	// 1.5ms of the 20ms period at 50Hz
+	gobot.Assert(t, p.ServoWrite("5", 90), nil)
+	gobot.Assert(t, adaptor.writes, [][]byte{
+		{0x12, 0, 0x10, 0, 0},
+		{0x12, 0, 0, 0, 0x10},
+		{0x16, 0, 0, 0x08, 0x08},
+		{0x1A, 0, 0, 0x33, 0x01},
+	})
+
+	gobot.Assert(t, p.DigitalWrite("16", 1), ErrInvalidPCA9685Channel)
+	gobot.Assert(t, p.PwmWrite("a", 1), ErrInvalidPCA9685Channel)
+	gobot.Assert(t, p.ServoWrite("", 1), ErrInvalidPCA9685Channel)
+}
+
+func TestPCA9685DriverGpioDrivers(t *testing.T) {
+	p, adaptor := initTestPCA9685Driver()
+	p.ServoMinPulse = 500 * time.Microsecond
+	p.ServoMaxPulse = 2500 * time.Microsecond
+
+	servo := gpio.NewServoDriver(p, "servo", "0")
+	gobot.Assert(t, servo.Max(), nil)
+	led := gpio.NewLedDriver(p, "led", "1")
+	gobot.Assert(t, led.Brightness(255), nil)
+	motor := gpio.NewMotorDriver(p, "motor", "2")
+	gobot.Assert(t, motor.Speed(0), nil)
+
+	gobot.Assert(t, adaptor.writes, [][]byte{
+		{0x06, 0, 0, 0x00, 0x02},
+		{0x0A, 0, 0x10, 0, 0},
+		{0x0E, 0, 0, 0, 0x10},
+	})
+}
+
+func TestPCA9685DriverCommands(t *testing.T) {
+	p, adaptor := initTestPCA9685Driver()
+	gobot.Assert(t, p.Command("SetFrequency")(map[string]interface{}{"frequency": 1000.0}), nil)
+	gobot.Assert(t, p.Command("SetPWM")(map[string]interface{}{"channel": 1.0, "on": 0.0, "off": 10.0}), nil)
+	gobot.Assert(t, p.Command("SetDuty")(map[string]interface{}{"channel": 2.0, "duty": 20.0}), nil)
+	gobot.Assert(t, p.Command("AllOff")(nil), nil)
+	gobot.Assert(t, p.Command("Sleep")(nil), nil)
+	gobot.Assert(t, p.Command("Wake")(nil), nil)
+	gobot.Assert(t, len(adaptor.writes), 4+3+3)
+	gobot.Assert(t, adaptor.writes[5], []byte{0x0E, 0, 0, 20, 0})
+}
@@ -0,0 +1,168 @@
+package i2c
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+)
+
+var _ gobot.Driver = (*PCF8574LcdDriver)(nil)
+
+const pcf8574LcdAddress = 0x27
+
+// The pins of a PCF8574 backpack wired to an HD44780, D4 to D7 being P4 to P7
+const (
+	pcf8574LcdRS        = 0x01
+	pcf8574LcdEnable    = 0x04
+	pcf8574LcdBacklight = 0x08
+)
+
+// PCF8574LcdDriver represents a character LCD with an HD44780 controller
+// driven by the common PCF8574 i2c backpack, which wires it 4 bits wide and
+// switches its backlight.
+type PCF8574LcdDriver struct {
+	name       string
+	connection I2c
+	mutex      sync.Mutex
+	backlight  byte
+	// Address is the i2c address of the backpack, 0x27 by default, or 0x3F
+	// for one with a PCF8574A
+	Address int
+	*gpio.HD44780
+	gobot.Commander
+}
+
+// NewPCF8574LcdDriver returns a new PCF8574LcdDriver given an I2c adaptor,
+// name and the number of columns and rows of the display, such as 16x2 or
+// 20x4.
+//
+// Adds the following API Commands:
+//	"Write" - See HD44780.Write, the text is given as "message"
+//	"Clear" - See HD44780.Clear
+//	"Home" - See HD44780.Home
+//	"SetCursor" - See HD44780.SetCursor
+//	"Backlight" - See PCF8574LcdDriver.Backlight
+func NewPCF8574LcdDriver(a I2c, name string, cols int, rows int) *PCF8574LcdDriver {
+	l := &PCF8574LcdDriver{
+		name:       name,
+		connection: a,
+		backlight:  pcf8574LcdBacklight,
+		Address:    pcf8574LcdAddress,
+		Commander:  gobot.NewCommander(),
+	}
+	l.HD44780 = gpio.NewHD44780(&pcf8574LcdBus{l}, cols, rows)
+
+	l.AddCommand("Write", func(params map[string]interface{}) interface{} {
+		return l.Write(params["message"].(string))
+	})
+	l.AddCommand("Clear", func(params map[string]interface{}) interface{} {
+		return l.Clear()
+	})
+	l.AddCommand("Home", func(params map[string]interface{}) interface{} {
+		return l.Home()
+	})
+	l.AddCommand("SetCursor", func(params map[string]interface{}) interface{} {
+		return l.SetCursor(int(params["col"].(float64)), int(params["row"].(float64)))
+	})
+	l.AddCommand("Backlight", func(params map[string]interface{}) interface{} {
+		return l.Backlight(params["on"].(bool))
+	})
+
+	return l
+}
+
+// Name returns the PCF8574LcdDrivers name
+func (l *PCF8574LcdDriver) Name() string { return l.name }
+
+// Connection returns the PCF8574LcdDrivers connection
+func (l *PCF8574LcdDriver) Connection() gobot.Connection { return l.connection.(gobot.Connection) }
+
+// Start sets the display up, clears it and turns the backlight on
+func (l *PCF8574LcdDriver) Start() (errs []error) {
+	if err := l.connection.I2cStart(l.Address); err != nil {
+		return []error{err}
+	}
+	if err := l.Init(); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Halt implements the Driver interface
+func (l *PCF8574LcdDriver) Halt() (errs []error) { return }
+
+// Backlight turns the backlight on or off
+func (l *PCF8574LcdDriver) Backlight(on bool) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.backlight = 0
+	if on {
+		l.backlight = pcf8574LcdBacklight
+	}
+	return l.connection.I2cWrite(l.Address, []byte{l.backlight})
+}
+
+// pins returns the state of the pins other than D4 to D7
+func (l *PCF8574LcdDriver) pins(rs byte) byte {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.backlight | rs
+}
+
+// pcf8574LcdBus writes to an HD44780 4 bits at a time through the outputs of
+// a PCF8574
+type pcf8574LcdBus struct {
+	driver *PCF8574LcdDriver
+}
+
+// Begin switches the controller to 4-bit instructions, whatever it was set
+// to, as in the datasheet
+func (b *pcf8574LcdBus) Begin() (err error) {
+	<-time.After(50 * time.Millisecond)
+	for _, wait := range []time.Duration{4500 * time.Microsecond, 150 * time.Microsecond, 150 * time.Microsecond} {
+		if err = b.write(b.nibble(0x30, 0)); err != nil {
+			return
+		}
+		<-time.After(wait)
+	}
+	return b.write(b.nibble(0x20, 0))
+}
+
+// Command writes an instruction
+func (b *pcf8574LcdBus) Command(c byte) (err error) {
+	err = b.write(b.bytes(0, c))
+	// most instructions take 37us
+	<-time.After(50 * time.Microsecond)
+	return
+}
+
+// Data writes bytes to the display or character RAM, all in one i2c write
+// since each takes longer than the controller needs
+func (b *pcf8574LcdBus) Data(data ...byte) error {
+	return b.write(b.bytes(pcf8574LcdRS, data...))
+}
+
+// bytes returns the outputs writing data a nibble at a time, high first, to
+// the register selected by rs
+func (b *pcf8574LcdBus) bytes(rs byte, data ...byte) (buf []byte) {
+	for _, d := range data {
+		buf = append(buf, b.nibble(d&0xF0, rs)...)
+		buf = append(buf, b.nibble(d<<4, rs)...)
+	}
+	return
+}
+
+// nibble returns the outputs setting D4 to D7 to the high bits of d and
+// pulsing E to latch them
+func (b *pcf8574LcdBus) nibble(d byte, rs byte) []byte {
+	out := d&0xF0 | b.driver.pins(rs)
+	return []byte{out | pcf8574LcdEnable, out}
+}
+
+// write writes the outputs in turn
+func (b *pcf8574LcdBus) write(buf []byte) error {
+	return b.driver.connection.I2cWrite(b.driver.Address, buf)
+}
@@ -0,0 +1,87 @@
+package i2c
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func initTestPCF8574LcdDriver() (*PCF8574LcdDriver, *i2cTestRegisters) {
+	adaptor := newI2cTestRegisters(1, 0)
+	return NewPCF8574LcdDriver(adaptor, "bot", 20, 4), adaptor
+}
+
+// pcf8574LcdDecode returns the nibbles latched by the falling edges of E in
+// writes, with RS and the backlight in the low bits
+func pcf8574LcdDecode(writes [][]byte) (nibbles []byte) {
+	for _, w := range writes {
+		for i := 1; i < len(w); i++ {
+			if w[i-1]&pcf8574LcdEnable != 0 && w[i]&pcf8574LcdEnable == 0 {
+				nibbles = append(nibbles, w[i])
+			}
+		}
+	}
+	return
+}
+
+func TestPCF8574LcdDriver(t *testing.T) {
+	l, _ := initTestPCF8574LcdDriver()
+	gobot.Assert(t, l.Name(), "bot")
+	gobot.Assert(t, l.Connection().Name(), "adaptor")
+	gobot.Assert(t, l.Address, 0x27)
+	gobot.Assert(t, l.Columns(), 20)
+	gobot.Assert(t, l.Rows(), 4)
+	gobot.Assert(t, len(l.Halt()), 0)
+	gobot.Refute(t, l.Command("Write"), nil)
+	gobot.Refute(t, l.Command("Clear"), nil)
+	gobot.Refute(t, l.Command("Home"), nil)
+	gobot.Refute(t, l.Command("SetCursor"), nil)
+	gobot.Refute(t, l.Command("Backlight"), nil)
+}
+
+func TestPCF8574LcdDriverStart(t *testing.T) {
+	l, adaptor := initTestPCF8574LcdDriver()
+	gobot.Assert(t, len(l.Start()), 0)
+	gobot.Assert(t, pcf8574LcdDecode(adaptor.writes), []byte{
+		// 4-bit mode
+		0x38, 0x38, 0x38, 0x28,
+		// function set, display on, clear and entry mode
+		0x28, 0x88, 0x08, 0xC8, 0x08, 0x18, 0x08, 0x68,
+	})
+
+	adaptor.i2cWriteImpl = func() error {
+		return errors.New("write error")
+	}
+	gobot.Assert(t, l.Start()[0], errors.New("write error"))
+
+	adaptor.i2cStartImpl = func() error {
+		return errors.New("start error")
+	}
+	gobot.Assert(t, l.Start()[0], errors.New("start error"))
+}
+
+func TestPCF8574LcdDriverWrite(t *testing.T) {
+	l, adaptor := initTestPCF8574LcdDriver()
+	gobot.Assert(t, l.Write("Hi"), nil)
+	gobot.Assert(t, adaptor.writes, [][]byte{{0x4D, 0x49, 0x8D, 0x89}, {0x6D, 0x69, 0x9D, 0x99}})
+	gobot.Assert(t, l.Lines()[0], "Hi                  ")
+
+	adaptor.writes = nil
+	l.Command("SetCursor")(map[string]interface{}{"col": 0.0, "row": 3.0})
+	gobot.Assert(t, pcf8574LcdDecode(adaptor.writes), []byte{0xD8, 0x48})
+}
+
+func TestPCF8574LcdDriverBacklight(t *testing.T) {
+	l, adaptor := initTestPCF8574LcdDriver()
+	gobot.Assert(t, l.Command("Backlight")(map[string]interface{}{"on": false}), nil)
+	gobot.Assert(t, adaptor.writes, [][]byte{{0x00}})
+
+	adaptor.writes = nil
+	l.Write("H")
+	gobot.Assert(t, adaptor.writes, [][]byte{{0x45, 0x41, 0x85, 0x81}})
+
+	adaptor.writes = nil
+	l.Backlight(true)
+	gobot.Assert(t, adaptor.writes, [][]byte{{0x08}})
+}
@@ -0,0 +1,160 @@
+package i2c
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// Poller reads a sensor every interval in the background. Each reading is
+// kept, with the time it was made, and published as the "data" event of the
+// sensor driver; each error is published as its "error" event. Sensor
+// drivers embed a Poller so they share the same start, halt and interval
+// behavior.
+type Poller struct {
+	eventer   gobot.Eventer
+	read      func() (interface{}, error)
+	mutex     sync.Mutex
+	interval  time.Duration
+	reset     chan bool
+	halt      chan bool
+	done      chan bool
+	value     interface{}
+	timestamp time.Time
+	failures  uint
+	// MaxBackoff limits how long the Poller waits between readings after
+	// errors, waiting twice as long after each error in a row, 1s by default
+	MaxBackoff time.Duration
+}
+
+// NewPoller returns a new Poller given the eventer of the sensor driver,
+// which is given the "data" and "error" events if it has not already, the
+// interval between readings and the function reading the sensor.
+func NewPoller(e gobot.Eventer, interval time.Duration, read func() (interface{}, error)) *Poller {
+	if e.Event(Data) == nil {
+		e.AddEvent(Data)
+	}
+	if e.Event(Error) == nil {
+		e.AddEvent(Error)
+	}
+	return &Poller{
+		eventer:    e,
+		read:       read,
+		interval:   interval,
+		reset:      make(chan bool, 1),
+		MaxBackoff: time.Second,
+	}
+}
+
+// StartPolling starts reading the sensor, stopping any earlier polling first
+func (p *Poller) StartPolling() {
+	p.StopPolling()
+
+	halt, done := make(chan bool), make(chan bool)
+	p.mutex.Lock()
+	p.halt, p.done = halt, done
+	p.mutex.Unlock()
+
+	go func() {
+		defer close(done)
+		for {
+			p.poll()
+			select {
+			case <-halt:
+				return
+			case <-p.reset:
+			case <-time.After(p.wait()):
+			}
+		}
+	}()
+}
+
+// StopPolling stops reading the sensor, waiting for a reading in progress to
+// finish
+func (p *Poller) StopPolling() {
+	p.mutex.Lock()
+	halt, done := p.halt, p.done
+	p.halt, p.done = nil, nil
+	p.mutex.Unlock()
+	if halt == nil {
+		return
+	}
+	close(halt)
+	<-done
+}
+
+// Polling returns true while the sensor is being read
+func (p *Poller) Polling() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.halt != nil
+}
+
+// Interval returns the interval between readings
+func (p *Poller) Interval() time.Duration {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.interval
+}
+
+// SetInterval sets the interval between readings, taking effect at once
+func (p *Poller) SetInterval(interval time.Duration) {
+	p.mutex.Lock()
+	p.interval = interval
+	p.mutex.Unlock()
+	select {
+	case p.reset <- true:
+	default:
+	}
+}
+
+// Latest returns the last reading and the time it was made, or nil and the
+// zero time before the first reading
+func (p *Poller) Latest() (value interface{}, timestamp time.Time) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.value, p.timestamp
+}
+
+// poll reads the sensor once and publishes the reading or error
+func (p *Poller) poll() {
+	value, err := p.read()
+	p.mutex.Lock()
+	if err != nil {
+		p.failures++
+	} else {
+		p.failures = 0
+		p.value, p.timestamp = value, time.Now()
+	}
+	p.mutex.Unlock()
+
+	if err != nil {
+		gobot.Publish(p.eventer.Event(Error), err)
+		return
+	}
+	gobot.Publish(p.eventer.Event(Data), value)
+}
+
+// wait returns how long to wait for the next reading, backing off after
+// errors
+func (p *Poller) wait() time.Duration {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	wait := p.interval
+	if p.failures > 0 {
+		if wait < time.Millisecond {
+			wait = time.Millisecond
+		}
+		for i := uint(0); i < p.failures && wait < p.MaxBackoff; i++ {
+			wait *= 2
+		}
+		if wait > p.MaxBackoff {
+			wait = p.MaxBackoff
+		}
+		if wait < p.interval {
+			wait = p.interval
+		}
+	}
+	return wait
+}
@@ -0,0 +1,122 @@
+package i2c
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// pollerTestSensor counts its readings and fails while err is set
+type pollerTestSensor struct {
+	mutex sync.Mutex
+	reads int
+	err   error
+}
+
+func (s *pollerTestSensor) read() (interface{}, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.reads++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.reads, nil
+}
+
+func (s *pollerTestSensor) count() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.reads
+}
+
+func TestPoller(t *testing.T) {
+	e := gobot.NewEventer()
+	s := &pollerTestSensor{}
+	p := NewPoller(e, time.Millisecond, s.read)
+	gobot.Refute(t, e.Event(Data), nil)
+	gobot.Refute(t, e.Event(Error), nil)
+	gobot.Assert(t, p.Polling(), false)
+
+	value, timestamp := p.Latest()
+	gobot.Assert(t, value, nil)
+	gobot.Assert(t, timestamp.IsZero(), true)
+
+	data := make(chan interface{}, 1)
+	gobot.Once(e.Event(Data), func(d interface{}) {
+		data <- d
+	})
+	p.StartPolling()
+	gobot.Assert(t, p.Polling(), true)
+	select {
+	case d := <-data:
+		gobot.Assert(t, d, 1)
+	case <-time.After(time.Second):
+		t.Errorf("data was not published")
+	}
+
+	p.StopPolling()
+	gobot.Assert(t, p.Polling(), false)
+	reads := s.count()
+	<-time.After(10 * time.Millisecond)
+	gobot.Assert(t, s.count(), reads)
+
+	value, timestamp = p.Latest()
+	gobot.Assert(t, value, reads)
+	gobot.Assert(t, timestamp.IsZero(), false)
+	p.StopPolling()
+}
+
+func TestPollerSetInterval(t *testing.T) {
+	s := &pollerTestSensor{}
+	p := NewPoller(gobot.NewEventer(), time.Hour, s.read)
+	p.StartPolling()
+	defer p.StopPolling()
+	<-time.After(10 * time.Millisecond)
+	gobot.Assert(t, s.count(), 1)
+
+	// the new interval takes effect without waiting out the hour
+	p.SetInterval(time.Millisecond)
+	gobot.Assert(t, p.Interval(), time.Millisecond)
+	<-time.After(20 * time.Millisecond)
+	gobot.Assert(t, s.count() > 2, true)
+}
+
+func TestPollerBackoff(t *testing.T) {
+	e := gobot.NewEventer()
+	s := &pollerTestSensor{err: errors.New("read error")}
+	p := NewPoller(e, 10*time.Millisecond, s.read)
+	p.MaxBackoff = 50 * time.Millisecond
+
+	gobot.Assert(t, p.wait(), 10*time.Millisecond)
+	p.failures = 1
+	gobot.Assert(t, p.wait(), 20*time.Millisecond)
+	p.failures = 2
+	gobot.Assert(t, p.wait(), 40*time.Millisecond)
+	p.failures = 100
+	gobot.Assert(t, p.wait(), 50*time.Millisecond)
+	p.failures = 0
+
+	errs := make(chan interface{}, 1)
+	gobot.Once(e.Event(Error), func(err interface{}) {
+		errs <- err
+	})
+	p.StartPolling()
+	select {
+	case err := <-errs:
+		gobot.Assert(t, err, errors.New("read error"))
+	case <-time.After(time.Second):
+		t.Errorf("error was not published")
+	}
+	<-time.After(100 * time.Millisecond)
+	p.StopPolling()
+	// 0, 20, 60 and 110ms rather than every 10ms
+	gobot.Assert(t, s.count() <= 5, true)
+
+	// an interval longer than the backoff is kept
+	p.SetInterval(time.Second)
+	p.failures = 3
+	gobot.Assert(t, p.wait(), time.Second)
+}
@@ -0,0 +1,301 @@
+package i2c
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// The range of addresses probed by a Scanner, leaving out those reserved by
+// the i2c specification
+const (
+	scanFirstAddress = 0x03
+	scanLastAddress  = 0x77
+)
+
+// ScannedDevice is a device found on the bus by a Scanner
+type ScannedDevice struct {
+	// Address is the i2c address of the device
+	Address int
+	// Name is the kind of the device, such as "mpu6050", or "" if it is not
+	// known
+	Name string
+}
+
+// String returns the address and name of the device
+func (d ScannedDevice) String() string {
+	name := d.Name
+	if name == "" {
+		name = "unknown"
+	}
+	return fmt.Sprintf("0x%02x %v", d.Address, name)
+}
+
+// scannerDevice is a kind of device a Scanner knows. It is identified at
+// one of its addresses by identify, given the addresses found, or by the
+// address alone if identify is nil. driver returns its driver at an
+// address, or nil if there is none for it there.
+type scannerDevice struct {
+	name      string
+	addresses []int
+	identify  func(s *Scanner, address int, found map[int]bool) bool
+	driver    func(a I2c, name string, address int) gobot.Device
+}
+
+// scannerDevices are the devices a Scanner knows, the more certainly
+// identified first where they share addresses
+var scannerDevices = []scannerDevice{
+	{
+		name:      "mpu6050",
+		addresses: []int{0x68, 0x69},
+		identify: func(s *Scanner, address int, found map[int]bool) bool {
+			// WHO_AM_I reads the default address whatever AD0 is
+			return s.registerIs(address, MPU6050_RA_WHO_AM_I, 0x68)
+		},
+		driver: func(a I2c, name string, address int) gobot.Device {
+			m := NewMPU6050Driver(a, name)
+			m.Address = address
+			return m
+		},
+	},
+	{
+		name:      "bmp180",
+		addresses: []int{bmp180Address},
+		identify: func(s *Scanner, address int, found map[int]bool) bool {
+			return s.registerIs(address, bmp280ChipID, 0x55)
+		},
+		driver: func(a I2c, name string, address int) gobot.Device {
+			return NewBMP180Driver(a, name)
+		},
+	},
+	{
+		name:      "bmp280",
+		addresses: []int{0x76, bmp280Address},
+		identify: func(s *Scanner, address int, found map[int]bool) bool {
+			return s.registerIs(address, bmp280ChipID, 0x58)
+		},
+		driver: func(a I2c, name string, address int) gobot.Device {
+			b := NewBMP280Driver(a, name)
+			b.Address = address
+			return b
+		},
+	},
+	{
+		name:      "bme280",
+		addresses: []int{0x76, bmp280Address},
+		identify: func(s *Scanner, address int, found map[int]bool) bool {
+			return s.registerIs(address, bmp280ChipID, bme280ID)
+		},
+		driver: func(a I2c, name string, address int) gobot.Device {
+			b := NewBMP280Driver(a, name)
+			b.Address = address
+			return b
+		},
+	},
+	{
+		name:      "hmc6352",
+		addresses: []int{hmc6352Address},
+		identify: func(s *Scanner, address int, found map[int]bool) bool {
+			// the first byte of its EEPROM is its 8-bit write address
+			if err := s.connection.I2cWrite(address, []byte{'r', 0x00}); err != nil {
+				return false
+			}
+			<-time.After(time.Millisecond)
+			ret, err := s.read(address, 1)
+			return err == nil && len(ret) == 1 && ret[0] == hmc6352Address<<1
+		},
+		driver: func(a I2c, name string, address int) gobot.Device {
+			return NewHMC6352Driver(a, name)
+		},
+	},
+	{
+		name:      "mcp23017",
+		addresses: []int{0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27},
+		identify: func(s *Scanner, address int, found map[int]bool) bool {
+			// IOCON is at both 0x0A and 0x0B, and its lowest bit reads 0
+			ret, err := s.readRegisters(address, 0x0A, 2)
+			return err == nil && ret[0] == ret[1] && ret[0]&0x01 == 0
+		},
+		driver: func(a I2c, name string, address int) gobot.Device {
+			return NewMCP23017Driver(a, name, MCP23017Config{}, address)
+		},
+	},
+	{
+		name:      "blinkm",
+		addresses: []int{blinkmAddress},
+		identify: func(s *Scanner, address int, found map[int]bool) bool {
+			// the firmware version is 2 letters
+			if err := s.connection.I2cWrite(address, []byte("Z")); err != nil {
+				return false
+			}
+			ret, err := s.read(address, 2)
+			return err == nil && len(ret) == 2 && ret[0] >= 'a' && ret[0] <= 'z'
+		},
+		driver: func(a I2c, name string, address int) gobot.Device {
+			return NewBlinkMDriver(a, name)
+		},
+	},
+	{
+		name:      "hmc5883l",
+		addresses: []int{hmc5883lAddress},
+		identify: func(s *Scanner, address int, found map[int]bool) bool {
+			ret, err := s.readRegisters(address, 0x0A, 3)
+			return err == nil && string(ret) == "H43"
+		},
+		driver: func(a I2c, name string, address int) gobot.Device {
+			return NewHMC5883LDriver(a, name)
+		},
+	},
+	{
+		name:      "vl53l0x",
+		addresses: []int{vl53l0xAddress},
+		identify: func(s *Scanner, address int, found map[int]bool) bool {
+			return s.registerIs(address, vl53l0xModelID, 0xEE)
+		},
+		driver: func(a I2c, name string, address int) gobot.Device {
+			return NewVL53L0XDriver(a, name)
+		},
+	},
+	{
+		// the LCD controller and the backlight of the Grove LCD
+		name:      "jhd1313m1",
+		addresses: []int{0x3E, 0x62},
+		identify: func(s *Scanner, address int, found map[int]bool) bool {
+			return found[0x3E] && found[0x62]
+		},
+		driver: func(a I2c, name string, address int) gobot.Device {
+			if address != 0x3E {
+				return nil
+			}
+			return NewJHD1313M1Driver(a, name)
+		},
+	},
+	{
+		name:      "lidarlite",
+		addresses: []int{lidarliteAddress},
+		driver: func(a I2c, name string, address int) gobot.Device {
+			return NewLIDARLiteDriver(a, name)
+		},
+	},
+}
+
+// Scanner finds the devices on an i2c bus, and identifies the MPU6050,
+// BMP180, BMP280, BME280, HMC6352, MCP23017, BlinkM, HMC5883L, VL53L0X,
+// JHD1313M1 and LIDAR-Lite by their addresses and ID registers.
+type Scanner struct {
+	connection I2c
+	// Timeout is how long a device has to answer a probe, 100ms by default.
+	// Adaptors such as firmata never answer for an address with no device.
+	Timeout time.Duration
+}
+
+// NewScanner returns a new Scanner given an I2c adaptor, which should be
+// connected before scanning
+func NewScanner(a I2c) *Scanner {
+	return &Scanner{
+		connection: a,
+		Timeout:    100 * time.Millisecond,
+	}
+}
+
+// Scan probes the addresses from 0x03 to 0x77 by reading a byte from each,
+// and returns the devices which answer, identified where possible
+func (s *Scanner) Scan() (devices []ScannedDevice, err error) {
+	found := map[int]bool{}
+	for address := scanFirstAddress; address <= scanLastAddress; address++ {
+		if err = s.connection.I2cStart(address); err != nil {
+			return nil, err
+		}
+		if s.Probe(address) {
+			found[address] = true
+			devices = append(devices, ScannedDevice{Address: address})
+		}
+	}
+
+	for i := range devices {
+		devices[i].Name = s.identify(devices[i].Address, found)
+	}
+	return
+}
+
+// Probe returns true if a device answers at address
+func (s *Scanner) Probe(address int) bool {
+	ret, err := s.read(address, 1)
+	return err == nil && len(ret) == 1
+}
+
+// Drivers returns drivers for the known devices, named after the device
+// and its address, such as "mcp23017_0x20". Unknown devices are skipped, as
+// are those for which there is no driver at their address.
+func (s *Scanner) Drivers(devices []ScannedDevice) (drivers []gobot.Device) {
+	for _, d := range devices {
+		for _, known := range scannerDevices {
+			if known.name != d.Name {
+				continue
+			}
+			if driver := known.driver(s.connection, fmt.Sprintf("%v_0x%02x", d.Name, d.Address), d.Address); driver != nil {
+				drivers = append(drivers, driver)
+			}
+			break
+		}
+	}
+	return
+}
+
+// identify returns the name of the device at address, or "" if it is not
+// known
+func (s *Scanner) identify(address int, found map[int]bool) string {
+	for _, known := range scannerDevices {
+		for _, a := range known.addresses {
+			if a != address {
+				continue
+			}
+			if known.identify == nil || known.identify(s, address, found) {
+				return known.name
+			}
+		}
+	}
+	return ""
+}
+
+// registerIs returns true if the register reg of the device at address
+// reads val
+func (s *Scanner) registerIs(address int, reg byte, val byte) bool {
+	ret, err := s.readRegisters(address, reg, 1)
+	return err == nil && ret[0] == val
+}
+
+// readRegisters reads n registers from reg on of the device at address
+func (s *Scanner) readRegisters(address int, reg byte, n int) (ret []byte, err error) {
+	if err = s.connection.I2cWrite(address, []byte{reg}); err != nil {
+		return
+	}
+	if ret, err = s.read(address, n); err != nil {
+		return
+	}
+	if len(ret) != n {
+		return nil, ErrNotEnoughBytes
+	}
+	return
+}
+
+// read reads n bytes from the device at address, giving up after Timeout
+func (s *Scanner) read(address int, n int) (data []byte, err error) {
+	type result struct {
+		data []byte
+		err  error
+	}
+	ret := make(chan result, 1)
+	go func() {
+		data, err := s.connection.I2cRead(address, n)
+		ret <- result{data, err}
+	}()
+
+	select {
+	case r := <-ret:
+		return r.data, r.err
+	case <-time.After(s.Timeout):
+		return nil, ErrNotReady
+	}
+}
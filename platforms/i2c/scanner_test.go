@@ -0,0 +1,148 @@
+package i2c
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// scannerTestAdaptor has a bus of devices, each a set of registers whose
+// pointer is set by the first byte written to it
+type scannerTestAdaptor struct {
+	i2cTestAdaptor
+	mutex    sync.Mutex
+	devices  map[int]*[256]byte
+	pointers map[int]byte
+	// hang makes reads of missing devices block, as on firmata
+	hang bool
+}
+
+func (t *scannerTestAdaptor) I2cWrite(address int, buf []byte) (err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.devices[address] == nil {
+		return errors.New("no device")
+	}
+	t.pointers[address] = buf[0]
+	return
+}
+
+func (t *scannerTestAdaptor) I2cRead(address int, n int) (data []byte, err error) {
+	t.mutex.Lock()
+	regs := t.devices[address]
+	if regs == nil {
+		t.mutex.Unlock()
+		if t.hang {
+			select {}
+		}
+		return nil, errors.New("no device")
+	}
+	defer t.mutex.Unlock()
+	p := int(t.pointers[address])
+	return append([]byte{}, regs[p:p+n]...), nil
+}
+
+// add adds a device at address with the registers from reg on set to data
+func (t *scannerTestAdaptor) add(address int, reg byte, data ...byte) {
+	t.devices[address] = &[256]byte{}
+	copy(t.devices[address][reg:], data)
+}
+
+func initTestScanner() (*Scanner, *scannerTestAdaptor) {
+	adaptor := &scannerTestAdaptor{
+		i2cTestAdaptor: *newI2cTestAdaptor("adaptor"),
+		devices:        make(map[int]*[256]byte),
+		pointers:       make(map[int]byte),
+	}
+	return NewScanner(adaptor), adaptor
+}
+
+func TestScanner(t *testing.T) {
+	s, _ := initTestScanner()
+	gobot.Assert(t, s.Timeout, 100*time.Millisecond)
+	gobot.Assert(t, ScannedDevice{Address: 0x09, Name: "blinkm"}.String(), "0x09 blinkm")
+	gobot.Assert(t, ScannedDevice{Address: 0x50}.String(), "0x50 unknown")
+}
+
+func TestScannerScan(t *testing.T) {
+	s, adaptor := initTestScanner()
+	adaptor.add(0x09, 'Z', 'a', 'd')
+	adaptor.add(0x20, 0x0A, 0x04, 0x04)
+	adaptor.add(0x21, 'r', 0x42)
+	adaptor.add(0x1E, 0x0A, 'H', '4', '3')
+	adaptor.add(0x29, vl53l0xModelID, 0xEE)
+	adaptor.add(0x50, 0x00)
+	adaptor.add(0x62, 0x00)
+	adaptor.add(0x68, MPU6050_RA_WHO_AM_I, 0x68)
+	adaptor.add(0x69, MPU6050_RA_WHO_AM_I, 0x68)
+	adaptor.add(0x76, bmp280ChipID, bme280ID)
+	adaptor.add(0x77, bmp280ChipID, 0x55)
+	// out of the range scanned
+	adaptor.add(0x78, 0x00)
+
+	devices, err := s.Scan()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, devices, []ScannedDevice{
+		{0x09, "blinkm"},
+		{0x1E, "hmc5883l"},
+		{0x20, "mcp23017"},
+		{0x21, "hmc6352"},
+		{0x29, "vl53l0x"},
+		{0x50, ""},
+		{0x62, "lidarlite"},
+		{0x68, "mpu6050"},
+		{0x69, "mpu6050"},
+		{0x76, "bme280"},
+		{0x77, "bmp180"},
+	})
+
+	// unknown devices have no driver
+	names := []string{}
+	for _, d := range s.Drivers(devices) {
+		names = append(names, d.Name())
+	}
+	gobot.Assert(t, names, []string{
+		"blinkm_0x09", "hmc5883l_0x1e", "mcp23017_0x20", "hmc6352_0x21",
+		"vl53l0x_0x29", "lidarlite_0x62",
+		"mpu6050_0x68", "mpu6050_0x69", "bme280_0x76", "bmp180_0x77",
+	})
+	gobot.Assert(t, s.Drivers(devices)[7].(*MPU6050Driver).Address, 0x69)
+	gobot.Assert(t, s.Drivers(devices)[8].(*BMP280Driver).Address, 0x76)
+
+	adaptor.i2cStartImpl = func() error {
+		return errors.New("start error")
+	}
+	_, err = s.Scan()
+	gobot.Assert(t, err, errors.New("start error"))
+}
+
+func TestScannerScanJHD1313M1(t *testing.T) {
+	s, adaptor := initTestScanner()
+	adaptor.add(0x3E, 0x00)
+	adaptor.add(0x62, 0x00)
+	// an MCP23017 with HAEN set, at the address of the HMC6352
+	adaptor.add(0x21, 0x0A, 0x08, 0x08)
+
+	devices, _ := s.Scan()
+	gobot.Assert(t, devices, []ScannedDevice{
+		{0x21, "mcp23017"},
+		{0x3E, "jhd1313m1"},
+		{0x62, "jhd1313m1"},
+	})
+	drivers := s.Drivers(devices)
+	gobot.Assert(t, len(drivers), 2)
+	gobot.Assert(t, drivers[1].Name(), "jhd1313m1_0x3e")
+}
+
+func TestScannerTimeout(t *testing.T) {
+	s, adaptor := initTestScanner()
+	adaptor.hang = true
+	adaptor.add(0x40, 0x00)
+	s.Timeout = time.Millisecond
+
+	gobot.Assert(t, s.Probe(0x41), false)
+	gobot.Assert(t, s.Probe(0x40), true)
+}
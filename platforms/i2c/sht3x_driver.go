@@ -0,0 +1,144 @@
+package i2c
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*SHT3xDriver)(nil)
+
+// ErrInvalidCRC is the error resulting when the checksum of data read from a
+// device does not match the data
+var ErrInvalidCRC = errors.New("Invalid CRC")
+
+const sht3xAddress = 0x44
+
+// sht3xMeasure is the command for a single measurement with high
+// repeatability and without clock stretching
+var sht3xMeasure = []byte{0x24, 0x00}
+
+// SHT3xData is a reading of an SHT3x
+type SHT3xData struct {
+	// Temperature is the temperature in degrees Celsius
+	Temperature float64
+	// Humidity is the relative humidity in percent
+	Humidity float64
+}
+
+// SHT3xDriver represents an SHT30, SHT31 or SHT35 humidity and temperature
+// sensor
+type SHT3xDriver struct {
+	name       string
+	connection I2c
+	mutex      sync.Mutex
+	// Address is the i2c address of the sensor, 0x44 by default, or 0x45
+	// when its ADDR pin is high
+	Address int
+	gobot.Eventer
+	*Poller
+}
+
+// NewSHT3xDriver returns a new SHT3xDriver given an I2c adaptor and name.
+//
+// Optionally accepts:
+//  time.Duration: interval at which the sensor is read, 1s by default
+//
+// Emits the Events:
+//	"data" - an SHT3xData every interval
+//	"error" - an error reading the sensor
+func NewSHT3xDriver(a I2c, name string, v ...time.Duration) *SHT3xDriver {
+	s := &SHT3xDriver{
+		name:       name,
+		connection: a,
+		Address:    sht3xAddress,
+		Eventer:    gobot.NewEventer(),
+	}
+
+	interval := time.Second
+	if len(v) > 0 {
+		interval = v[0]
+	}
+
+	s.Poller = NewPoller(s, interval, func() (interface{}, error) {
+		return s.read()
+	})
+	return s
+}
+
+// Name returns the SHT3xDrivers name
+func (s *SHT3xDriver) Name() string { return s.name }
+
+// Connection returns the SHT3xDrivers connection
+func (s *SHT3xDriver) Connection() gobot.Connection { return s.connection.(gobot.Connection) }
+
+// Start starts reading the sensor every interval
+func (s *SHT3xDriver) Start() (errs []error) {
+	if err := s.connection.I2cStart(s.Address); err != nil {
+		return []error{err}
+	}
+	s.StartPolling()
+	return
+}
+
+// Halt stops reading the sensor
+func (s *SHT3xDriver) Halt() (errs []error) {
+	s.StopPolling()
+	return
+}
+
+// Reading returns the last reading of the sensor, and the time it was made
+func (s *SHT3xDriver) Reading() (data SHT3xData, timestamp time.Time) {
+	value, timestamp := s.Latest()
+	data, _ = value.(SHT3xData)
+	return
+}
+
+// read makes a measurement and checks its checksums
+func (s *SHT3xDriver) read() (data SHT3xData, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err = s.connection.I2cWrite(s.Address, sht3xMeasure); err != nil {
+		return
+	}
+	// the longest a measurement with high repeatability takes
+	<-time.After(16 * time.Millisecond)
+
+	ret, err := s.connection.I2cRead(s.Address, 6)
+	if err != nil {
+		return
+	}
+	if len(ret) != 6 {
+		return data, ErrNotEnoughBytes
+	}
+	if crc8(ret[0:2]) != ret[2] || crc8(ret[3:5]) != ret[5] {
+		return data, ErrInvalidCRC
+	}
+
+	temperature := float64(uint16(ret[0])<<8 | uint16(ret[1]))
+	humidity := float64(uint16(ret[3])<<8 | uint16(ret[4]))
+	return SHT3xData{
+		Temperature: -45 + 175*temperature/65535,
+		Humidity:    100 * humidity / 65535,
+	}, nil
+}
+
+// crc8 returns the checksum of data used by Sensirion sensors, with the
+// polynomial 0x31 starting from 0xFF
+func crc8(data []byte) byte {
+	crc := byte(0xFF)
+	for _, b := range data {
+		crc ^= b
+		for i := 0; i < 8; i++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x31
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
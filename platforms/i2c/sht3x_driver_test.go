@@ -0,0 +1,71 @@
+package i2c
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func initTestSHT3xDriver() (*SHT3xDriver, *i2cTestRegisters) {
+	adaptor := newI2cTestRegisters(1, 0)
+	return NewSHT3xDriver(adaptor, "bot"), adaptor
+}
+
+func TestSHT3xDriver(t *testing.T) {
+	s, _ := initTestSHT3xDriver()
+	gobot.Assert(t, s.Name(), "bot")
+	gobot.Assert(t, s.Connection().Name(), "adaptor")
+	gobot.Assert(t, s.Address, 0x44)
+	gobot.Assert(t, s.Interval(), time.Second)
+
+	s = NewSHT3xDriver(newI2cTestAdaptor("adaptor"), "bot", time.Minute)
+	gobot.Assert(t, s.Interval(), time.Minute)
+}
+
+func TestCRC8(t *testing.T) {
+	// the example in the datasheet
+	gobot.Assert(t, crc8([]byte{0xBE, 0xEF}), byte(0x92))
+}
+
+func TestSHT3xDriverStart(t *testing.T) {
+	s, adaptor := initTestSHT3xDriver()
+	gobot.Assert(t, len(s.Start()), 0)
+	gobot.Assert(t, len(s.Halt()), 0)
+
+	adaptor.i2cStartImpl = func() error {
+		return errors.New("start error")
+	}
+	gobot.Assert(t, s.Start()[0], errors.New("start error"))
+}
+
+func TestSHT3xDriverRead(t *testing.T) {
+	s, adaptor := initTestSHT3xDriver()
+	// the middle of the range of each
+	measurement := []byte{0x80, 0x00, crc8([]byte{0x80, 0x00}), 0x80, 0x00, crc8([]byte{0x80, 0x00})}
+	adaptor.onWrite = func(buf []byte) {
+		copy(adaptor.regs[:], measurement)
+	}
+
+	data, err := s.read()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, adaptor.writes, [][]byte{{0x24, 0x00}})
+	gobot.Assert(t, math.Abs(data.Temperature-42.5) < 0.01, true)
+	gobot.Assert(t, math.Abs(data.Humidity-50) < 0.01, true)
+
+	measurement[5] = 0x00
+	_, err = s.read()
+	gobot.Assert(t, err, ErrInvalidCRC)
+
+	adaptor.i2cWriteImpl = func() error {
+		return errors.New("write error")
+	}
+	_, err = s.read()
+	gobot.Assert(t, err, errors.New("write error"))
+
+	s = NewSHT3xDriver(newI2cTestAdaptor("adaptor"), "bot")
+	_, err = s.read()
+	gobot.Assert(t, err, ErrNotEnoughBytes)
+}
@@ -0,0 +1,107 @@
+package i2c
+
+import (
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+)
+
+var _ gobot.Driver = (*SSD1306Driver)(nil)
+
+const ssd1306Address = 0x3C
+
+// The control bytes of SSD1306 i2c writes, and the most data written at once
+// so the writes fit in the buffers of boards such as the Arduino
+const (
+	ssd1306Command = 0x00
+	ssd1306Data    = 0x40
+	ssd1306Chunk   = 16
+)
+
+// SSD1306Driver represents a monochrome OLED display with an SSD1306
+// controller wired to i2c. The embedded SSD1306 is a draw.Image, so text,
+// shapes and images can be drawn on it before they are written by Flush.
+type SSD1306Driver struct {
+	name       string
+	connection I2c
+	// Address is the i2c address of the display, 0x3C by default, or 0x3D
+	// depending on its SA0 pin
+	Address int
+	*gpio.SSD1306
+	gobot.Commander
+}
+
+// NewSSD1306Driver returns a new SSD1306Driver given an I2c adaptor, name
+// and the width and height of the display in pixels, such as 128x64 or
+// 128x32.
+//
+// Adds the following API Commands:
+//	"Clear" - See SSD1306.Clear
+//	"Flush" - See SSD1306.Flush
+//	"DrawText" - See SSD1306.DrawText, given "x", "y" and "text"
+//	"SetContrast" - See SSD1306.SetContrast, given "contrast"
+func NewSSD1306Driver(a I2c, name string, width int, height int) *SSD1306Driver {
+	s := &SSD1306Driver{
+		name:       name,
+		connection: a,
+		Address:    ssd1306Address,
+		Commander:  gobot.NewCommander(),
+	}
+	s.SSD1306 = gpio.NewSSD1306(&ssd1306I2cBus{s}, width, height)
+	s.SSD1306.AddCommands(s)
+
+	return s
+}
+
+// Name returns the SSD1306Drivers name
+func (s *SSD1306Driver) Name() string { return s.name }
+
+// Connection returns the SSD1306Drivers connection
+func (s *SSD1306Driver) Connection() gobot.Connection { return s.connection.(gobot.Connection) }
+
+// Start sets the display up and writes the framebuffer to it
+func (s *SSD1306Driver) Start() (errs []error) {
+	if err := s.connection.I2cStart(s.Address); err != nil {
+		return []error{err}
+	}
+	if err := s.Init(); err != nil {
+		return []error{err}
+	}
+	if err := s.Flush(); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Halt turns the display off
+func (s *SSD1306Driver) Halt() (errs []error) {
+	if err := s.Display(false); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// ssd1306I2cBus writes to the SSD1306 of an SSD1306Driver, with a control
+// byte before the commands or data of each write
+type ssd1306I2cBus struct {
+	driver *SSD1306Driver
+}
+
+// Command writes commands and their arguments
+func (b *ssd1306I2cBus) Command(c ...byte) error {
+	return b.driver.connection.I2cWrite(b.driver.Address, append([]byte{ssd1306Command}, c...))
+}
+
+// Data writes bytes to the display RAM, ssd1306Chunk at a time
+func (b *ssd1306I2cBus) Data(d []byte) (err error) {
+	for len(d) > 0 {
+		n := ssd1306Chunk
+		if n > len(d) {
+			n = len(d)
+		}
+		if err = b.driver.connection.I2cWrite(b.driver.Address, append([]byte{ssd1306Data}, d[:n]...)); err != nil {
+			return
+		}
+		d = d[n:]
+	}
+	return
+}
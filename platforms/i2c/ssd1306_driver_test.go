@@ -0,0 +1,89 @@
+package i2c
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func initTestSSD1306Driver() (*SSD1306Driver, *i2cTestRegisters) {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	return NewSSD1306Driver(adaptor, "bot", 128, 32), adaptor
+}
+
+func TestSSD1306Driver(t *testing.T) {
+	s, _ := initTestSSD1306Driver()
+	gobot.Assert(t, s.Name(), "bot")
+	gobot.Assert(t, s.Connection().Name(), "adaptor")
+	gobot.Assert(t, s.Address, 0x3C)
+	gobot.Assert(t, s.Bounds(), image.Rect(0, 0, 128, 32))
+	gobot.Refute(t, s.Command("Clear"), nil)
+	gobot.Refute(t, s.Command("Flush"), nil)
+	gobot.Refute(t, s.Command("DrawText"), nil)
+	gobot.Refute(t, s.Command("SetContrast"), nil)
+}
+
+func TestSSD1306DriverStart(t *testing.T) {
+	s, adaptor := initTestSSD1306Driver()
+	gobot.Assert(t, len(s.Start()), 0)
+	gobot.Assert(t, adaptor.address, 0x3C)
+	gobot.Assert(t, adaptor.writes[0], []byte{
+		0x00,
+		0xAE, 0xD5, 0x80, 0xA8, 31, 0xD3, 0x00, 0x40, 0x8D, 0x14, 0x20, 0x00,
+		0xA1, 0xC8, 0xDA, 0x02, 0x81, 0xCF, 0xD9, 0xF1, 0xDB, 0x40, 0xA4,
+		0xA6, 0xAF,
+	})
+	gobot.Assert(t, adaptor.writes[1], []byte{0x00, 0x21, 0, 127, 0x22, 0, 3})
+	// 512 bytes of framebuffer, 16 at a time
+	gobot.Assert(t, len(adaptor.writes), 2+32)
+	for _, w := range adaptor.writes[2:] {
+		gobot.Assert(t, w, append([]byte{0x40}, make([]byte, 16)...))
+	}
+
+	adaptor.i2cWriteImpl = func() error {
+		return errors.New("write error")
+	}
+	gobot.Assert(t, s.Start()[0], errors.New("write error"))
+	gobot.Assert(t, s.Halt()[0], errors.New("write error"))
+
+	adaptor.i2cStartImpl = func() error {
+		return errors.New("start error")
+	}
+	gobot.Assert(t, s.Start()[0], errors.New("start error"))
+}
+
+func TestSSD1306DriverFlush(t *testing.T) {
+	s, adaptor := initTestSSD1306Driver()
+	s.Start()
+	adaptor.writes = nil
+
+	draw.Draw(s, image.Rect(8, 0, 28, 2), image.NewUniform(color.White), image.ZP, draw.Src)
+	gobot.Assert(t, s.Flush(), nil)
+	gobot.Assert(t, adaptor.writes, [][]byte{
+		{0x00, 0x21, 8, 27, 0x22, 0, 0},
+		{0x40, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3},
+		{0x40, 3, 3, 3, 3},
+	})
+
+	adaptor.writes = nil
+	s.Command("DrawText")(map[string]interface{}{"x": 0.0, "y": 24.0, "text": "-"})
+	s.Command("Flush")(nil)
+	gobot.Assert(t, adaptor.writes, [][]byte{
+		{0x00, 0x21, 0, 4, 0x22, 3, 3},
+		{0x40, 0x08, 0x08, 0x08, 0x08, 0x08},
+	})
+}
+
+func TestSSD1306DriverHalt(t *testing.T) {
+	s, adaptor := initTestSSD1306Driver()
+	gobot.Assert(t, len(s.Halt()), 0)
+	gobot.Assert(t, adaptor.writes, [][]byte{{0x00, 0xAE}})
+
+	adaptor.writes = nil
+	s.Command("SetContrast")(map[string]interface{}{"contrast": 128.0})
+	gobot.Assert(t, adaptor.writes, [][]byte{{0x00, 0x81, 0x80}})
+}
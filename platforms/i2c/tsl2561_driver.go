@@ -0,0 +1,228 @@
+package i2c
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*TSL2561Driver)(nil)
+
+// ErrSaturated is the error resulting when a sensor reads the most it can,
+// so the real value is unknown
+var ErrSaturated = errors.New("Sensor is saturated")
+
+const tsl2561Address = 0x39
+
+// TSL2561 registers, addressed through the command register
+const (
+	tsl2561Command = 0x80
+	tsl2561Word    = 0x20
+	tsl2561Control = 0x00
+	tsl2561Timing  = 0x01
+	tsl2561Data0   = 0x0C
+	tsl2561Data1   = 0x0E
+)
+
+// TSL2561 integration times
+const (
+	// TSL2561Integration13ms integrates for 13.7ms
+	TSL2561Integration13ms = 0x00
+	// TSL2561Integration101ms integrates for 101ms
+	TSL2561Integration101ms = 0x01
+	// TSL2561Integration402ms integrates for 402ms
+	TSL2561Integration402ms = 0x02
+)
+
+// tsl2561Integration is the duration of an integration time, the most a
+// channel can count in it and the scale of that count to 402ms
+type tsl2561Integration struct {
+	duration time.Duration
+	max      uint16
+	scale    float64
+}
+
+// tsl2561Integrations are the integration times of the TSL2561
+var tsl2561Integrations = []tsl2561Integration{
+	{14 * time.Millisecond, 5047, 322.0 / 11},
+	{101 * time.Millisecond, 37177, 322.0 / 81},
+	{402 * time.Millisecond, 65535, 1},
+}
+
+// TSL2561Data is a reading of a TSL2561
+type TSL2561Data struct {
+	// Lux is the illuminance
+	Lux float64
+	// Broadband and Infrared are the raw counts of the channels sensing
+	// visible and infrared light, and infrared light only
+	Broadband uint16
+	Infrared  uint16
+}
+
+// TSL2561Driver represents a TSL2561 light sensor
+type TSL2561Driver struct {
+	name       string
+	connection I2c
+	mutex      sync.Mutex
+	// Address is the i2c address of the sensor, 0x39 by default, or 0x29 or
+	// 0x49 depending on its ADDR SEL pin
+	Address int
+	// HighGain multiplies the sensitivity by 16, for dim light
+	HighGain bool
+	// Integration is the time the sensor counts light for, one of
+	// TSL2561Integration13ms, 101ms and 402ms, 402ms by default
+	Integration byte
+	gobot.Eventer
+	*Poller
+}
+
+// NewTSL2561Driver returns a new TSL2561Driver given an I2c adaptor and name.
+//
+// Optionally accepts:
+//  time.Duration: interval at which the sensor is read, 1s by default
+//
+// Emits the Events:
+//	"data" - a TSL2561Data every interval
+//	"error" - an error reading the sensor, ErrSaturated when the light is
+//	too bright to measure
+func NewTSL2561Driver(a I2c, name string, v ...time.Duration) *TSL2561Driver {
+	l := &TSL2561Driver{
+		name:        name,
+		connection:  a,
+		Address:     tsl2561Address,
+		Integration: TSL2561Integration402ms,
+		Eventer:     gobot.NewEventer(),
+	}
+
+	interval := time.Second
+	if len(v) > 0 {
+		interval = v[0]
+	}
+
+	l.Poller = NewPoller(l, interval, func() (interface{}, error) {
+		return l.read()
+	})
+	return l
+}
+
+// Name returns the TSL2561Drivers name
+func (l *TSL2561Driver) Name() string { return l.name }
+
+// Connection returns the TSL2561Drivers connection
+func (l *TSL2561Driver) Connection() gobot.Connection { return l.connection.(gobot.Connection) }
+
+// Start powers the sensor up with its gain and integration time, and starts
+// reading it every interval once it has integrated
+func (l *TSL2561Driver) Start() (errs []error) {
+	if err := l.connection.I2cStart(l.Address); err != nil {
+		return []error{err}
+	}
+
+	l.mutex.Lock()
+	timing := l.Integration & 0x03
+	if timing > TSL2561Integration402ms {
+		timing = TSL2561Integration402ms
+	}
+	if l.HighGain {
+		timing |= 0x10
+	}
+	err := l.connection.I2cWrite(l.Address, []byte{tsl2561Command | tsl2561Control, 0x03})
+	if err == nil {
+		err = l.connection.I2cWrite(l.Address, []byte{tsl2561Command | tsl2561Timing, timing})
+	}
+	l.mutex.Unlock()
+	if err != nil {
+		return []error{err}
+	}
+
+	<-time.After(l.integration().duration)
+	l.StartPolling()
+	return
+}
+
+// Halt stops reading the sensor and powers it down
+func (l *TSL2561Driver) Halt() (errs []error) {
+	l.StopPolling()
+	if err := l.connection.I2cWrite(l.Address, []byte{tsl2561Command | tsl2561Control, 0x00}); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Reading returns the last reading of the sensor, and the time it was made
+func (l *TSL2561Driver) Reading() (data TSL2561Data, timestamp time.Time) {
+	value, timestamp := l.Latest()
+	data, _ = value.(TSL2561Data)
+	return
+}
+
+// read reads both channels and works out the illuminance
+func (l *TSL2561Driver) read() (data TSL2561Data, err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if data.Broadband, err = l.readChannel(tsl2561Data0); err != nil {
+		return
+	}
+	if data.Infrared, err = l.readChannel(tsl2561Data1); err != nil {
+		return
+	}
+	integration := l.integration()
+	if data.Broadband >= integration.max || data.Infrared >= integration.max {
+		return data, ErrSaturated
+	}
+
+	scale := integration.scale
+	if !l.HighGain {
+		scale *= 16
+	}
+	data.Lux = tsl2561Lux(float64(data.Broadband)*scale, float64(data.Infrared)*scale)
+	return
+}
+
+// readChannel reads the count of the channel with its low byte at reg
+func (l *TSL2561Driver) readChannel(reg byte) (count uint16, err error) {
+	if err = l.connection.I2cWrite(l.Address, []byte{tsl2561Command | tsl2561Word | reg}); err != nil {
+		return
+	}
+	ret, err := l.connection.I2cRead(l.Address, 2)
+	if err != nil {
+		return
+	}
+	if len(ret) != 2 {
+		return 0, ErrNotEnoughBytes
+	}
+	return uint16(ret[1])<<8 | uint16(ret[0]), nil
+}
+
+// integration returns the integration time the sensor is set to
+func (l *TSL2561Driver) integration() tsl2561Integration {
+	i := int(l.Integration)
+	if i >= len(tsl2561Integrations) {
+		i = len(tsl2561Integrations) - 1
+	}
+	return tsl2561Integrations[i]
+}
+
+// tsl2561Lux returns the illuminance given the counts of both channels, at
+// high gain over 402ms, as in the datasheet for the T, FN and CL packages
+func tsl2561Lux(ch0 float64, ch1 float64) float64 {
+	if ch0 == 0 {
+		return 0
+	}
+	ratio := ch1 / ch0
+	switch {
+	case ratio <= 0.50:
+		return 0.0304*ch0 - 0.062*ch0*math.Pow(ratio, 1.4)
+	case ratio <= 0.61:
+		return 0.0224*ch0 - 0.031*ch1
+	case ratio <= 0.80:
+		return 0.0128*ch0 - 0.0153*ch1
+	case ratio <= 1.30:
+		return 0.00146*ch0 - 0.00112*ch1
+	}
+	return 0
+}
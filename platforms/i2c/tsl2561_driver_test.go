@@ -0,0 +1,84 @@
+package i2c
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func initTestTSL2561Driver() (*TSL2561Driver, *i2cTestRegisters) {
+	adaptor := newI2cTestRegisters(1, 0x0F)
+	return NewTSL2561Driver(adaptor, "bot"), adaptor
+}
+
+func TestTSL2561Driver(t *testing.T) {
+	l, _ := initTestTSL2561Driver()
+	gobot.Assert(t, l.Name(), "bot")
+	gobot.Assert(t, l.Connection().Name(), "adaptor")
+	gobot.Assert(t, l.Address, 0x39)
+	gobot.Assert(t, l.Integration, byte(TSL2561Integration402ms))
+	gobot.Assert(t, l.Interval(), time.Second)
+
+	l = NewTSL2561Driver(newI2cTestAdaptor("adaptor"), "bot", time.Minute)
+	gobot.Assert(t, l.Interval(), time.Minute)
+}
+
+func TestTSL2561DriverStart(t *testing.T) {
+	l, adaptor := initTestTSL2561Driver()
+	l.Integration = TSL2561Integration13ms
+	l.HighGain = true
+	gobot.Assert(t, len(l.Start()), 0)
+	gobot.Assert(t, len(l.Halt()), 0)
+	gobot.Assert(t, adaptor.writes[:2], [][]byte{{0x80, 0x03}, {0x81, 0x10}})
+	gobot.Assert(t, adaptor.writes[len(adaptor.writes)-1], []byte{0x80, 0x00})
+
+	adaptor.i2cWriteImpl = func() error {
+		return errors.New("write error")
+	}
+	gobot.Assert(t, l.Start()[0], errors.New("write error"))
+	gobot.Assert(t, l.Halt()[0], errors.New("write error"))
+	adaptor.i2cStartImpl = func() error {
+		return errors.New("start error")
+	}
+	gobot.Assert(t, l.Start()[0], errors.New("start error"))
+}
+
+func TestTSL2561Lux(t *testing.T) {
+	gobot.Assert(t, tsl2561Lux(0, 0), 0.0)
+	gobot.Assert(t, math.Abs(tsl2561Lux(1000, 200)-23.886) < 0.001, true)
+	gobot.Assert(t, math.Abs(tsl2561Lux(1000, 550)-5.35) < 0.001, true)
+	gobot.Assert(t, math.Abs(tsl2561Lux(1000, 700)-2.09) < 0.001, true)
+	gobot.Assert(t, math.Abs(tsl2561Lux(1000, 1000)-0.34) < 0.001, true)
+	gobot.Assert(t, tsl2561Lux(1000, 2000), 0.0)
+}
+
+func TestTSL2561DriverRead(t *testing.T) {
+	l, adaptor := initTestTSL2561Driver()
+	l.HighGain = true
+	adaptor.set(tsl2561Data0, 0xE8, 0x03)
+	adaptor.set(tsl2561Data1, 0xC8, 0x00)
+
+	data, err := l.read()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, adaptor.writes, [][]byte{{0xAC}, {0xAE}})
+	gobot.Assert(t, data.Broadband, uint16(1000))
+	gobot.Assert(t, data.Infrared, uint16(200))
+	gobot.Assert(t, math.Abs(data.Lux-23.886) < 0.001, true)
+
+	// low gain over 13.7ms is scaled up
+	l.HighGain = false
+	l.Integration = TSL2561Integration13ms
+	data, _ = l.read()
+	gobot.Assert(t, math.Abs(data.Lux-23.886*16*322/11) < 0.1, true)
+
+	adaptor.set(tsl2561Data0, 0xFF, 0xFF)
+	_, err = l.read()
+	gobot.Assert(t, err, ErrSaturated)
+
+	l = NewTSL2561Driver(newI2cTestAdaptor("adaptor"), "bot")
+	_, err = l.read()
+	gobot.Assert(t, err, ErrNotEnoughBytes)
+}
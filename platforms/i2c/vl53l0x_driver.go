@@ -0,0 +1,405 @@
+package i2c
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*VL53L0XDriver)(nil)
+
+var (
+	// ErrOutOfRange is the error resulting when a distance sensor finds
+	// nothing in range
+	ErrOutOfRange = errors.New("Nothing is in range")
+	// ErrInvalidVL53L0X is the error resulting when the device started by a
+	// VL53L0XDriver is not a VL53L0X
+	ErrInvalidVL53L0X = errors.New("Device is not a VL53L0X")
+)
+
+const vl53l0xAddress = 0x29
+
+// VL53L0X registers
+const (
+	vl53l0xSysrangeStart          = 0x00
+	vl53l0xSequenceConfig         = 0x01
+	vl53l0xInterruptConfig        = 0x0A
+	vl53l0xInterruptClear         = 0x0B
+	vl53l0xInterruptStatus        = 0x13
+	vl53l0xRange                  = 0x1E
+	vl53l0xMinCountRateLimit      = 0x44
+	vl53l0xMsrcConfig             = 0x60
+	vl53l0xGpioActiveHigh         = 0x84
+	vl53l0xPadConfig              = 0x89
+	vl53l0xStopVariable           = 0x91
+	vl53l0xSpadEnables            = 0xB0
+	vl53l0xRefEnStartSelect       = 0xB6
+	vl53l0xModelID                = 0xC0
+	vl53l0xDynamicSpadRequested   = 0x4E
+	vl53l0xDynamicSpadStartOffset = 0x4F
+)
+
+// vl53l0xOutOfRange is the least range read when nothing is in range
+const vl53l0xOutOfRange = 8190
+
+// vl53l0xTuning are the register writes of the default tuning settings of
+// the VL53L0X API
+var vl53l0xTuning = [][2]byte{
+	{0xFF, 0x01}, {0x00, 0x00}, {0xFF, 0x00}, {0x09, 0x00}, {0x10, 0x00},
+	{0x11, 0x00}, {0x24, 0x01}, {0x25, 0xFF}, {0x75, 0x00}, {0xFF, 0x01},
+	{0x4E, 0x2C}, {0x48, 0x00}, {0x30, 0x20}, {0xFF, 0x00}, {0x30, 0x09},
+	{0x54, 0x00}, {0x31, 0x04}, {0x32, 0x03}, {0x40, 0x83}, {0x46, 0x25},
+	{0x60, 0x00}, {0x27, 0x00}, {0x50, 0x06}, {0x51, 0x00}, {0x52, 0x96},
+	{0x56, 0x08}, {0x57, 0x30}, {0x61, 0x00}, {0x62, 0x00}, {0x64, 0x00},
+	{0x65, 0x00}, {0x66, 0xA0}, {0xFF, 0x01}, {0x22, 0x32}, {0x47, 0x14},
+	{0x49, 0xFF}, {0x4A, 0x00}, {0xFF, 0x00}, {0x7A, 0x0A}, {0x7B, 0x00},
+	{0x78, 0x21}, {0xFF, 0x01}, {0x23, 0x34}, {0x42, 0x00}, {0x44, 0xFF},
+	{0x45, 0x26}, {0x46, 0x05}, {0x40, 0x40}, {0x0E, 0x06}, {0x20, 0x1A},
+	{0x43, 0x40}, {0xFF, 0x00}, {0x34, 0x03}, {0x35, 0x44}, {0xFF, 0x01},
+	{0x31, 0x04}, {0x4B, 0x09}, {0x4C, 0x05}, {0x4D, 0x04}, {0xFF, 0x00},
+	{0x44, 0x00}, {0x45, 0x20}, {0x47, 0x08}, {0x48, 0x28}, {0x67, 0x00},
+	{0x70, 0x04}, {0x71, 0x01}, {0x72, 0xFE}, {0x76, 0x00}, {0x77, 0x00},
+	{0xFF, 0x01}, {0x0D, 0x01}, {0xFF, 0x00}, {0x80, 0x01}, {0x01, 0xF8},
+	{0xFF, 0x01}, {0x8E, 0x01}, {0x00, 0x01}, {0xFF, 0x00}, {0x80, 0x00},
+}
+
+// VL53L0XData is a reading of a VL53L0X
+type VL53L0XData struct {
+	// Distance is the distance to the target in mm
+	Distance int
+}
+
+// VL53L0XDriver represents a VL53L0X time-of-flight distance sensor, which
+// measures up to about 2m continuously, back to back
+type VL53L0XDriver struct {
+	name         string
+	connection   I2c
+	mutex        sync.Mutex
+	stopVariable byte
+	// Timeout is how long a measurement may take, 500ms by default
+	Timeout time.Duration
+	gobot.Eventer
+	*Poller
+}
+
+// NewVL53L0XDriver returns a new VL53L0XDriver given an I2c adaptor and name.
+//
+// Optionally accepts:
+//  time.Duration: interval at which the VL53L0X is read, 50ms by default
+//
+// Emits the Events:
+//	"data" - a VL53L0XData every interval
+//	"error" - an error reading the VL53L0X, ErrOutOfRange when nothing is in range
+func NewVL53L0XDriver(a I2c, name string, v ...time.Duration) *VL53L0XDriver {
+	d := &VL53L0XDriver{
+		name:       name,
+		connection: a,
+		Timeout:    500 * time.Millisecond,
+		Eventer:    gobot.NewEventer(),
+	}
+
+	interval := 50 * time.Millisecond
+	if len(v) > 0 {
+		interval = v[0]
+	}
+
+	d.Poller = NewPoller(d, interval, func() (interface{}, error) {
+		return d.read()
+	})
+	return d
+}
+
+// Name returns the VL53L0XDrivers name
+func (d *VL53L0XDriver) Name() string { return d.name }
+
+// Connection returns the VL53L0XDrivers connection
+func (d *VL53L0XDriver) Connection() gobot.Connection { return d.connection.(gobot.Connection) }
+
+// Start sets the VL53L0X up and calibrates it, as in its API, starts it
+// measuring continuously and starts reading it every interval
+func (d *VL53L0XDriver) Start() (errs []error) {
+	if err := d.connection.I2cStart(vl53l0xAddress); err != nil {
+		return []error{err}
+	}
+	d.mutex.Lock()
+	err := d.initialize()
+	if err == nil {
+		err = d.startContinuous()
+	}
+	d.mutex.Unlock()
+	if err != nil {
+		return []error{err}
+	}
+	d.StartPolling()
+	return
+}
+
+// Halt stops reading the VL53L0X, and stops it measuring
+func (d *VL53L0XDriver) Halt() (errs []error) {
+	d.StopPolling()
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if err := d.writeRegs([][2]byte{
+		{vl53l0xSysrangeStart, 0x01},
+		{0xFF, 0x01}, {0x00, 0x00}, {vl53l0xStopVariable, 0x00}, {0x00, 0x01}, {0xFF, 0x00},
+	}); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Reading returns the last reading of the VL53L0X, and the time it was made
+func (d *VL53L0XDriver) Reading() (data VL53L0XData, timestamp time.Time) {
+	value, timestamp := d.Latest()
+	data, _ = value.(VL53L0XData)
+	return
+}
+
+// initialize follows the data, static and reference calibration steps of
+// the initialisation in the VL53L0X API, with its default tuning settings
+func (d *VL53L0XDriver) initialize() (err error) {
+	id, err := d.readReg(vl53l0xModelID)
+	if err != nil {
+		return
+	}
+	if id != 0xEE {
+		return ErrInvalidVL53L0X
+	}
+
+	// 2.8V I/O, as on most breakout boards
+	pad, err := d.readReg(vl53l0xPadConfig)
+	if err != nil {
+		return
+	}
+	if err = d.writeRegs([][2]byte{{vl53l0xPadConfig, pad | 0x01}, {0x88, 0x00}}); err != nil {
+		return
+	}
+
+	if err = d.writeRegs([][2]byte{{0x80, 0x01}, {0xFF, 0x01}, {0x00, 0x00}}); err != nil {
+		return
+	}
+	if d.stopVariable, err = d.readReg(vl53l0xStopVariable); err != nil {
+		return
+	}
+	if err = d.writeRegs([][2]byte{{0x00, 0x01}, {0xFF, 0x00}, {0x80, 0x00}}); err != nil {
+		return
+	}
+
+	// disable the signal rate limit checks of MSRC and pre-range, and limit
+	// the final range signal rate to 0.25 million counts per second
+	msrc, err := d.readReg(vl53l0xMsrcConfig)
+	if err != nil {
+		return
+	}
+	if err = d.writeRegs([][2]byte{{vl53l0xMsrcConfig, msrc | 0x12}}); err != nil {
+		return
+	}
+	if err = d.connection.I2cWrite(vl53l0xAddress, []byte{vl53l0xMinCountRateLimit, 0x00, 0x20}); err != nil {
+		return
+	}
+	if err = d.writeRegs([][2]byte{{vl53l0xSequenceConfig, 0xFF}}); err != nil {
+		return
+	}
+
+	if err = d.setReferenceSpads(); err != nil {
+		return
+	}
+	if err = d.writeRegs(vl53l0xTuning); err != nil {
+		return
+	}
+
+	// interrupt on new samples, active low
+	active, err := d.readReg(vl53l0xGpioActiveHigh)
+	if err != nil {
+		return
+	}
+	if err = d.writeRegs([][2]byte{
+		{vl53l0xInterruptConfig, 0x04},
+		{vl53l0xGpioActiveHigh, active &^ 0x10},
+		{vl53l0xInterruptClear, 0x01},
+		// disable MSRC and TCC
+		{vl53l0xSequenceConfig, 0xE8},
+	}); err != nil {
+		return
+	}
+
+	// the VHV and phase reference calibrations
+	if err = d.writeRegs([][2]byte{{vl53l0xSequenceConfig, 0x01}}); err != nil {
+		return
+	}
+	if err = d.calibrate(0x40); err != nil {
+		return
+	}
+	if err = d.writeRegs([][2]byte{{vl53l0xSequenceConfig, 0x02}}); err != nil {
+		return
+	}
+	if err = d.calibrate(0x00); err != nil {
+		return
+	}
+	return d.writeRegs([][2]byte{{vl53l0xSequenceConfig, 0xE8}})
+}
+
+// setReferenceSpads enables the reference SPADs, the number and kind of
+// which are in the VL53L0X's NVM
+func (d *VL53L0XDriver) setReferenceSpads() (err error) {
+	if err = d.writeRegs([][2]byte{{0x80, 0x01}, {0xFF, 0x01}, {0x00, 0x00}, {0xFF, 0x06}}); err != nil {
+		return
+	}
+	reg, err := d.readReg(0x83)
+	if err != nil {
+		return
+	}
+	if err = d.writeRegs([][2]byte{
+		{0x83, reg | 0x04}, {0xFF, 0x07}, {0x81, 0x01}, {0x80, 0x01}, {0x94, 0x6B}, {0x83, 0x00},
+	}); err != nil {
+		return
+	}
+	if err = d.waitFor(0x83, 0xFF); err != nil {
+		return
+	}
+	if err = d.writeRegs([][2]byte{{0x83, 0x01}}); err != nil {
+		return
+	}
+	info, err := d.readReg(0x92)
+	if err != nil {
+		return
+	}
+	if err = d.writeRegs([][2]byte{{0x81, 0x00}, {0xFF, 0x06}}); err != nil {
+		return
+	}
+	if reg, err = d.readReg(0x83); err != nil {
+		return
+	}
+	if err = d.writeRegs([][2]byte{
+		{0x83, reg &^ 0x04}, {0xFF, 0x01}, {0x00, 0x01}, {0xFF, 0x00}, {0x80, 0x00},
+	}); err != nil {
+		return
+	}
+
+	count, aperture := int(info&0x7F), info&0x80 != 0
+	spads, err := d.readRegs(vl53l0xSpadEnables, 6)
+	if err != nil {
+		return
+	}
+	if err = d.writeRegs([][2]byte{
+		{0xFF, 0x01},
+		{vl53l0xDynamicSpadStartOffset, 0x00},
+		{vl53l0xDynamicSpadRequested, 0x2C},
+		{0xFF, 0x00},
+		{vl53l0xRefEnStartSelect, 0xB4},
+	}); err != nil {
+		return
+	}
+	// the first 12 SPADs are not apertures
+	first := 0
+	if aperture {
+		first = 12
+	}
+	enabled := 0
+	for i := 0; i < 48; i++ {
+		if i < first || enabled == count {
+			spads[i/8] &^= 1 << uint(i%8)
+		} else if spads[i/8]>>uint(i%8)&0x01 == 1 {
+			enabled++
+		}
+	}
+	return d.connection.I2cWrite(vl53l0xAddress, append([]byte{vl53l0xSpadEnables}, spads...))
+}
+
+// calibrate makes a single reference calibration
+func (d *VL53L0XDriver) calibrate(vhv byte) (err error) {
+	if err = d.writeRegs([][2]byte{{vl53l0xSysrangeStart, 0x01 | vhv}}); err != nil {
+		return
+	}
+	if err = d.waitFor(vl53l0xInterruptStatus, 0x07); err != nil {
+		return
+	}
+	return d.writeRegs([][2]byte{{vl53l0xInterruptClear, 0x01}, {vl53l0xSysrangeStart, 0x00}})
+}
+
+// startContinuous starts measuring back to back
+func (d *VL53L0XDriver) startContinuous() error {
+	return d.writeRegs([][2]byte{
+		{0x80, 0x01}, {0xFF, 0x01}, {0x00, 0x00},
+		{vl53l0xStopVariable, d.stopVariable},
+		{0x00, 0x01}, {0xFF, 0x00}, {0x80, 0x00},
+		{vl53l0xSysrangeStart, 0x02},
+	})
+}
+
+// read waits for a measurement and returns it
+func (d *VL53L0XDriver) read() (data VL53L0XData, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err = d.waitFor(vl53l0xInterruptStatus, 0x07); err != nil {
+		return
+	}
+	ret, err := d.readRegs(vl53l0xRange, 2)
+	if err != nil {
+		return
+	}
+	if err = d.writeRegs([][2]byte{{vl53l0xInterruptClear, 0x01}}); err != nil {
+		return
+	}
+	distance := int(ret[0])<<8 | int(ret[1])
+	if distance >= vl53l0xOutOfRange {
+		return data, ErrOutOfRange
+	}
+	return VL53L0XData{Distance: distance}, nil
+}
+
+// waitFor waits until any of the bits of mask of reg are set, giving up with
+// ErrNotReady after Timeout
+func (d *VL53L0XDriver) waitFor(reg byte, mask byte) error {
+	timeout := time.After(d.Timeout)
+	for {
+		val, err := d.readReg(reg)
+		if err != nil {
+			return err
+		}
+		if val&mask != 0 {
+			return nil
+		}
+		select {
+		case <-timeout:
+			return ErrNotReady
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+// writeRegs writes each register in turn
+func (d *VL53L0XDriver) writeRegs(regs [][2]byte) (err error) {
+	for _, reg := range regs {
+		if err = d.connection.I2cWrite(vl53l0xAddress, reg[:]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// readReg reads a register
+func (d *VL53L0XDriver) readReg(reg byte) (val byte, err error) {
+	ret, err := d.readRegs(reg, 1)
+	if err != nil {
+		return
+	}
+	return ret[0], nil
+}
+
+// readRegs reads n registers from reg on
+func (d *VL53L0XDriver) readRegs(reg byte, n int) (ret []byte, err error) {
+	if err = d.connection.I2cWrite(vl53l0xAddress, []byte{reg}); err != nil {
+		return
+	}
+	if ret, err = d.connection.I2cRead(vl53l0xAddress, n); err != nil {
+		return
+	}
+	if len(ret) != n {
+		return nil, ErrNotEnoughBytes
+	}
+	return
+}
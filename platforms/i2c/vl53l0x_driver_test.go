@@ -0,0 +1,93 @@
+package i2c
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// newVL53L0XTestRegisters returns registers faking a VL53L0X whose
+// measurements are always ready
+func newVL53L0XTestRegisters() *i2cTestRegisters {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	adaptor.set(0xC0, 0xEE)
+	adaptor.set(0x91, 0x3C)
+	adaptor.set(0x13, 0x07)
+	// 3 aperture SPADs, of which the first 12 are not
+	adaptor.set(0x92, 0x83)
+	adaptor.set(0xB0, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF)
+	adaptor.onWrite = func(buf []byte) {
+		if len(buf) == 2 && buf[0] == 0x83 && buf[1] == 0x00 {
+			adaptor.regs[0x83] = 0x10
+		}
+		if len(buf) == 2 && buf[0] == 0x13 {
+			adaptor.regs[0x13] = 0x07
+		}
+	}
+	return adaptor
+}
+
+func TestVL53L0XDriver(t *testing.T) {
+	d := NewVL53L0XDriver(newI2cTestAdaptor("adaptor"), "bot")
+	gobot.Assert(t, d.Name(), "bot")
+	gobot.Assert(t, d.Connection().Name(), "adaptor")
+	gobot.Assert(t, d.Interval(), 50*time.Millisecond)
+	gobot.Assert(t, d.Timeout, 500*time.Millisecond)
+}
+
+func TestVL53L0XDriverStart(t *testing.T) {
+	adaptor := newVL53L0XTestRegisters()
+	adaptor.set(0x1E, 0x01, 0x2C)
+	d := NewVL53L0XDriver(adaptor, "bot")
+
+	data := make(chan interface{}, 1)
+	gobot.Once(d.Event(Data), func(v interface{}) {
+		data <- v
+	})
+
+	gobot.Assert(t, len(d.Start()), 0)
+	select {
+	case v := <-data:
+		gobot.Assert(t, v, VL53L0XData{Distance: 300})
+	case <-time.After(time.Second):
+		t.Errorf("data was not published")
+	}
+	gobot.Assert(t, len(d.Halt()), 0)
+
+	adaptor.mutex.Lock()
+	defer adaptor.mutex.Unlock()
+	// the SPADs past the 3 from 12 on disabled
+	gobot.Assert(t, adaptor.regs[0xB0:0xB6], []byte{0x00, 0x70, 0x00, 0x00, 0x00, 0x00})
+	// started back to back with the stop variable
+	contains := func(w []byte) bool {
+		for _, write := range adaptor.writes {
+			if string(write) == string(w) {
+				return true
+			}
+		}
+		return false
+	}
+	gobot.Assert(t, contains([]byte{0x91, 0x3C}), true)
+	gobot.Assert(t, contains([]byte{0x00, 0x02}), true)
+}
+
+func TestVL53L0XDriverRead(t *testing.T) {
+	adaptor := newVL53L0XTestRegisters()
+	d := NewVL53L0XDriver(adaptor, "bot")
+
+	adaptor.set(0x1E, 0x1F, 0xFE)
+	_, err := d.read()
+	gobot.Assert(t, err, ErrOutOfRange)
+
+	// no measurement ready
+	d.Timeout = 5 * time.Millisecond
+	adaptor.onWrite = nil
+	adaptor.set(0x13, 0x00)
+	_, err = d.read()
+	gobot.Assert(t, err, ErrNotReady)
+
+	// not a VL53L0X
+	adaptor.set(0xC0, 0x00)
+	gobot.Assert(t, d.Start()[0], ErrInvalidVL53L0X)
+}
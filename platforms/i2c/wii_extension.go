@@ -0,0 +1,125 @@
+package i2c
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// The addresses of a Wii Remote extension, and of a Motion Plus until it is
+// activated
+const (
+	wiiExtensionAddress  = 0x52
+	wiiMotionPlusAddress = 0x53
+)
+
+// wiiExtension is the base of the drivers of the Wii Remote extensions, the
+// Nunchuck, Classic Controller and Motion Plus. They all answer at 0x52 once
+// initialised, without encryption, and are read 6 bytes at a time.
+type wiiExtension struct {
+	name       string
+	connection I2c
+	mutex      sync.Mutex
+	buttons    map[string]bool
+	// initialize sets the extension up to be read, by default writing the
+	// unencrypted initialisation sequence
+	initialize func() error
+	gobot.Eventer
+	*Poller
+}
+
+// newWiiExtension returns a new wiiExtension with the "[button]_press" and
+// "[button]_release" events of buttons, polled every interval, by default
+// 10ms, with read
+func newWiiExtension(a I2c, name string, buttons []string, v []time.Duration, read func() (interface{}, error)) *wiiExtension {
+	w := &wiiExtension{
+		name:       name,
+		connection: a,
+		buttons:    map[string]bool{},
+		Eventer:    gobot.NewEventer(),
+	}
+	w.initialize = w.initUnencrypted
+
+	for _, button := range buttons {
+		w.AddEvent(fmt.Sprintf("%s_press", button))
+		w.AddEvent(fmt.Sprintf("%s_release", button))
+	}
+
+	interval := 10 * time.Millisecond
+	if len(v) > 0 {
+		interval = v[0]
+	}
+	w.Poller = NewPoller(w, interval, read)
+	return w
+}
+
+// Name returns the name of the driver
+func (w *wiiExtension) Name() string { return w.name }
+
+// Connection returns the connection of the driver
+func (w *wiiExtension) Connection() gobot.Connection { return w.connection.(gobot.Connection) }
+
+// Start initialises the extension and starts reading it every interval
+func (w *wiiExtension) Start() (errs []error) {
+	if err := w.connection.I2cStart(wiiExtensionAddress); err != nil {
+		return []error{err}
+	}
+	if err := w.initialize(); err != nil {
+		return []error{err}
+	}
+	w.StartPolling()
+	return
+}
+
+// Halt stops reading the extension
+func (w *wiiExtension) Halt() (errs []error) {
+	w.StopPolling()
+	return
+}
+
+// initUnencrypted initialises the extension so its data is not encrypted,
+// which works for all extensions, including those not made by Nintendo
+func (w *wiiExtension) initUnencrypted() (err error) {
+	if err = w.connection.I2cWrite(wiiExtensionAddress, []byte{0xF0, 0x55}); err != nil {
+		return
+	}
+	return w.connection.I2cWrite(wiiExtensionAddress, []byte{0xFB, 0x00})
+}
+
+// readData reads the 6 bytes of data of the extension
+func (w *wiiExtension) readData() (data []byte, err error) {
+	if err = w.connection.I2cWrite(wiiExtensionAddress, []byte{0x00}); err != nil {
+		return
+	}
+	if data, err = w.connection.I2cRead(wiiExtensionAddress, 6); err != nil {
+		return
+	}
+	if len(data) != 6 {
+		return nil, ErrNotEnoughBytes
+	}
+	// an extension which is unplugged or not initialised reads all 0xFF
+	for _, b := range data {
+		if b != 0xFF {
+			return
+		}
+	}
+	return nil, ErrNotReady
+}
+
+// updateButtons publishes the "[button]_press" and "[button]_release"
+// events of the buttons of pressed which have changed since the last update
+func (w *wiiExtension) updateButtons(pressed map[string]bool) {
+	for button, down := range pressed {
+		if w.buttons[button] == down {
+			continue
+		}
+		w.buttons[button] = down
+		if down {
+			gobot.Publish(w.Event(fmt.Sprintf("%s_press", button)), nil)
+		} else {
+			gobot.Publish(w.Event(fmt.Sprintf("%s_release", button)), nil)
+		}
+	}
+}
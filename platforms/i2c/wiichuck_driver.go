@@ -8,29 +8,42 @@ import (
 
 var _ gobot.Driver = (*WiichuckDriver)(nil)
 
-const wiichuckAddress = 0x52
+// WiichuckData is a reading of a Wii Nunchuck
+type WiichuckData struct {
+	// X and Y are the position of the joystick from where it first was
+	X float64
+	Y float64
+	// C and Z are true while the buttons are pressed
+	C bool
+	Z bool
+	// AccelX, AccelY and AccelZ are the accelerations along each axis, from 0
+	// to 1023, about 512 at rest and some 200 more for each g
+	AccelX int
+	AccelY int
+	AccelZ int
+}
 
+// WiichuckDriver represents a Wii Nunchuck
 type WiichuckDriver struct {
-	name       string
-	connection I2c
-	interval   time.Duration
-	gobot.Eventer
+	*wiiExtension
 	joystick map[string]float64
 	data     map[string]float64
 }
 
 // NewWiichuckDriver creates a WiichuckDriver with specified i2c interface and name.
 //
+// Optionally accepts:
+//  time.Duration: interval at which the Nunchuck is read, 10ms by default
+//
 // It adds the following events:
 //	"z"- Get's triggered every interval amount of time if the z button is pressed
 //	"c" - Get's triggered every interval amount of time if the c button is pressed
+//	"c_press", "c_release", "z_press", "z_release" - the buttons are pressed or released
 //	"joystick" - Get's triggered every "interval" amount of time if a joystick event occured, you can access values x, y
+//	"data" - a WiichuckData every interval
+//	"error" - an error reading the Nunchuck
 func NewWiichuckDriver(a I2c, name string, v ...time.Duration) *WiichuckDriver {
 	w := &WiichuckDriver{
-		name:       name,
-		connection: a,
-		interval:   10 * time.Millisecond,
-		Eventer:    gobot.NewEventer(),
 		joystick: map[string]float64{
 			"sy_origin": -1,
 			"sx_origin": -1,
@@ -40,70 +53,56 @@ func NewWiichuckDriver(a I2c, name string, v ...time.Duration) *WiichuckDriver {
 			"sy": 0,
 			"z":  0,
 			"c":  0,
+			"ax": 0,
+			"ay": 0,
+			"az": 0,
 		},
 	}
-
-	if len(v) > 0 {
-		w.interval = v[0]
-	}
+	w.wiiExtension = newWiiExtension(a, name, []string{C, Z}, v, func() (interface{}, error) {
+		return w.read()
+	})
 
 	w.AddEvent(Z)
 	w.AddEvent(C)
 	w.AddEvent(Joystick)
 	return w
 }
-func (w *WiichuckDriver) Name() string                 { return w.name }
-func (w *WiichuckDriver) Connection() gobot.Connection { return w.connection.(gobot.Connection) }
-
-// Start initilizes i2c and reads from adaptor
-// using specified interval to update with new value
-func (w *WiichuckDriver) Start() (errs []error) {
-	if err := w.connection.I2cStart(wiichuckAddress); err != nil {
-		return []error{err}
-	}
 
-	go func() {
-		for {
-			if err := w.connection.I2cWrite(wiichuckAddress, []byte{0x40, 0x00}); err != nil {
-				gobot.Publish(w.Event(Error), err)
-				continue
-			}
-			if err := w.connection.I2cWrite(wiichuckAddress, []byte{0x00}); err != nil {
-				gobot.Publish(w.Event(Error), err)
-				continue
-			}
-			newValue, err := w.connection.I2cRead(wiichuckAddress, 6)
-			if err != nil {
-				gobot.Publish(w.Event(Error), err)
-				continue
-			}
-			if len(newValue) == 6 {
-				if err = w.update(newValue); err != nil {
-					gobot.Publish(w.Event(Error), err)
-					continue
-				}
-			}
-			<-time.After(w.interval)
-		}
-	}()
+// Reading returns the last reading of the Nunchuck, and the time it was made
+func (w *WiichuckDriver) Reading() (data WiichuckData, timestamp time.Time) {
+	value, timestamp := w.Latest()
+	data, _ = value.(WiichuckData)
 	return
 }
 
-// Halt returns true if driver is halted successfully
-func (w *WiichuckDriver) Halt() (errs []error) { return }
-
-// update parses value to update buttons and joystick.
-// If value is encrypted, warning message is printed
-func (w *WiichuckDriver) update(value []byte) (err error) {
-	if w.isEncrypted(value) {
-		return ErrEncryptedBytes
-	} else {
-		w.parse(value)
-		w.adjustOrigins()
-		w.updateButtons()
-		w.updateJoystick()
+// read reads the Nunchuck, publishing its button and joystick events, and
+// returns the reading
+func (w *WiichuckDriver) read() (data WiichuckData, err error) {
+	newValue, err := w.readData()
+	if err != nil {
+		return
 	}
-	return
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.update(newValue)
+	return WiichuckData{
+		X:      w.calculateJoystickValue(w.data["sx"], w.joystick["sx_origin"]),
+		Y:      w.calculateJoystickValue(w.data["sy"], w.joystick["sy_origin"]),
+		C:      w.data["c"] == 0,
+		Z:      w.data["z"] == 0,
+		AccelX: int(w.data["ax"]),
+		AccelY: int(w.data["ay"]),
+		AccelZ: int(w.data["az"]),
+	}, nil
+}
+
+// update parses value to update buttons and joystick
+func (w *WiichuckDriver) update(value []byte) {
+	w.parse(value)
+	w.adjustOrigins()
+	w.updateButtons()
+	w.updateJoystick()
 }
 
 // setJoystickDefaultValue sets default value if value is -1
@@ -118,26 +117,14 @@ func (w *WiichuckDriver) calculateJoystickValue(axis float64, origin float64) fl
 	return float64(axis - origin)
 }
 
-// isEncrypted returns true if value is encrypted
-func (w *WiichuckDriver) isEncrypted(value []byte) bool {
-	if value[0] == value[1] && value[2] == value[3] && value[4] == value[5] {
-		return true
-	}
-	return false
-}
-
-// decode removes encoding from `x` byte
-func (w *WiichuckDriver) decode(x byte) float64 {
-	return float64((x ^ 0x17) + 0x17)
-}
-
 // adjustOrigins sets sy_origin and sx_origin with values from data
 func (w *WiichuckDriver) adjustOrigins() {
 	w.setJoystickDefaultValue("sy_origin", w.data["sy"])
 	w.setJoystickDefaultValue("sx_origin", w.data["sx"])
 }
 
-// updateButtons publishes "c" and "x" events if present in data
+// updateButtons publishes "c" and "z" events if present in data, and the
+// press and release events of the buttons which have changed
 func (w *WiichuckDriver) updateButtons() {
 	if w.data["c"] == 0 {
 		gobot.Publish(w.Event(C), true)
@@ -145,6 +132,10 @@ func (w *WiichuckDriver) updateButtons() {
 	if w.data["z"] == 0 {
 		gobot.Publish(w.Event(Z), true)
 	}
+	w.wiiExtension.updateButtons(map[string]bool{
+		C: w.data["c"] == 0,
+		Z: w.data["z"] == 0,
+	})
 }
 
 // updateJoystick publishes event with current x and y values for joystick
@@ -155,10 +146,14 @@ func (w *WiichuckDriver) updateJoystick() {
 	})
 }
 
-// parse sets driver values based on parsed value
+// parse sets driver values based on parsed value. The last byte holds the
+// buttons, which read 0 when pressed, and the low bits of the accelerations.
 func (w *WiichuckDriver) parse(value []byte) {
-	w.data["sx"] = w.decode(value[0])
-	w.data["sy"] = w.decode(value[1])
-	w.data["z"] = float64(uint8(w.decode(value[5])) & 0x01)
-	w.data["c"] = float64(uint8(w.decode(value[5])) & 0x02)
+	w.data["sx"] = float64(value[0])
+	w.data["sy"] = float64(value[1])
+	w.data["ax"] = float64(int(value[2])<<2 | int(value[5]>>2&0x03))
+	w.data["ay"] = float64(int(value[3])<<2 | int(value[5]>>4&0x03))
+	w.data["az"] = float64(int(value[4])<<2 | int(value[5]>>6&0x03))
+	w.data["z"] = float64(value[5] & 0x01)
+	w.data["c"] = float64(value[5] & 0x02)
 }
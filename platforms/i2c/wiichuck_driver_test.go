@@ -33,10 +33,10 @@ func TestWiichuckDriver(t *testing.T) {
 	wii := initTestWiichuckDriver()
 	gobot.Assert(t, wii.Name(), "bot")
 	gobot.Assert(t, wii.Connection().Name(), "adaptor")
-	gobot.Assert(t, wii.interval, 10*time.Millisecond)
+	gobot.Assert(t, wii.Interval(), 10*time.Millisecond)
 
 	wii = NewWiichuckDriver(newI2cTestAdaptor("adaptor"), "bot", 100*time.Millisecond)
-	gobot.Assert(t, wii.interval, 100*time.Millisecond)
+	gobot.Assert(t, wii.Interval(), 100*time.Millisecond)
 }
 func TestWiichuckDriverStart(t *testing.T) {
 	sem := make(chan bool)
@@ -48,14 +48,17 @@ func TestWiichuckDriverStart(t *testing.T) {
 
 	numberOfCyclesForEvery := 3
 
-	wii.interval = 1 * time.Millisecond
+	wii.SetInterval(1 * time.Millisecond)
 	gobot.Assert(t, len(wii.Start()), 0)
 
 	go func() {
 		for {
 			<-time.After(time.Duration(numberOfCyclesForEvery) * time.Millisecond)
-			if (wii.joystick["sy_origin"] == float64(44)) &&
-				(wii.joystick["sx_origin"] == float64(45)) {
+			wii.mutex.Lock()
+			ok := (wii.joystick["sy_origin"] == float64(2)) &&
+				(wii.joystick["sx_origin"] == float64(1))
+			wii.mutex.Unlock()
+			if ok {
 				sem <- true
 			}
 		}
@@ -78,19 +81,18 @@ func TestWiichuckDriverHalt(t *testing.T) {
 func TestWiichuckDriverUpdate(t *testing.T) {
 	wii := initTestWiichuckDriver()
 
-	// ------ When value is not encrypted
-	decryptedValue := []byte{1, 2, 3, 4, 5, 6}
+	decryptedValue := []byte{1, 2, 3, 4, 5, 0xFC}
 	wii.update(decryptedValue)
 
 	// - This should be done by WiichuckDriver.parse
-	gobot.Assert(t, wii.data["sx"], float64(45))
-	gobot.Assert(t, wii.data["sy"], float64(44))
+	gobot.Assert(t, wii.data["sx"], float64(1))
+	gobot.Assert(t, wii.data["sy"], float64(2))
 	gobot.Assert(t, wii.data["z"], float64(0))
 	gobot.Assert(t, wii.data["c"], float64(0))
 
 	// - This should be done by WiichuckDriver.adjustOrigins
-	gobot.Assert(t, wii.joystick["sx_origin"], float64(45))
-	gobot.Assert(t, wii.joystick["sy_origin"], float64(44))
+	gobot.Assert(t, wii.joystick["sx_origin"], float64(1))
+	gobot.Assert(t, wii.joystick["sy_origin"], float64(2))
 
 	// - This should be done by WiichuckDriver.updateButtons
 	chann := make(chan bool)
@@ -139,20 +141,6 @@ func TestWiichuckDriverUpdate(t *testing.T) {
 	case <-time.After(10 * time.Second):
 		t.Errorf("Did not recieve 'Joystick' event")
 	}
-
-	// ------ When value is encrypted
-	wii = initTestWiichuckDriver()
-	encryptedValue := []byte{1, 1, 2, 2, 3, 3}
-
-	wii.update(encryptedValue)
-
-	gobot.Assert(t, wii.data["sx"], float64(0))
-	gobot.Assert(t, wii.data["sy"], float64(0))
-	gobot.Assert(t, wii.data["z"], float64(0))
-	gobot.Assert(t, wii.data["c"], float64(0))
-
-	gobot.Assert(t, wii.joystick["sx_origin"], float64(-1))
-	gobot.Assert(t, wii.joystick["sy_origin"], float64(-1))
 }
 
 func TestWiichuckDriverSetJoystickDefaultValue(t *testing.T) {
@@ -181,34 +169,6 @@ func TestWiichuckDriverCalculateJoystickValue(t *testing.T) {
 	gobot.Assert(t, wii.calculateJoystickValue(float64(5), float64(10)), float64(-5))
 }
 
-func TestWiichuckDriverIsEncrypted(t *testing.T) {
-	wii := initTestWiichuckDriver()
-
-	encryptedValue := []byte{1, 1, 2, 2, 3, 3}
-	gobot.Assert(t, wii.isEncrypted(encryptedValue), true)
-
-	encryptedValue = []byte{42, 42, 24, 24, 30, 30}
-	gobot.Assert(t, wii.isEncrypted(encryptedValue), true)
-
-	decryptedValue := []byte{1, 2, 3, 4, 5, 6}
-	gobot.Assert(t, wii.isEncrypted(decryptedValue), false)
-
-	decryptedValue = []byte{1, 1, 2, 2, 5, 6}
-	gobot.Assert(t, wii.isEncrypted(decryptedValue), false)
-
-	decryptedValue = []byte{1, 1, 2, 3, 3, 3}
-	gobot.Assert(t, wii.isEncrypted(decryptedValue), false)
-}
-
-func TestWiichuckDriverDecode(t *testing.T) {
-	wii := initTestWiichuckDriver()
-
-	gobot.Assert(t, wii.decode(byte(0)), float64(46))
-	gobot.Assert(t, wii.decode(byte(100)), float64(138))
-	gobot.Assert(t, wii.decode(byte(200)), float64(246))
-	gobot.Assert(t, wii.decode(byte(254)), float64(0))
-}
-
 func TestWiichuckDriverParse(t *testing.T) {
 	wii := initTestWiichuckDriver()
 
@@ -218,18 +178,24 @@ func TestWiichuckDriverParse(t *testing.T) {
 	gobot.Assert(t, wii.data["c"], float64(0))
 
 	// First pass
-	wii.parse([]byte{12, 23, 34, 45, 56, 67})
+	wii.parse([]byte{12, 23, 34, 45, 56, 0x1F})
 
-	gobot.Assert(t, wii.data["sx"], float64(50))
+	gobot.Assert(t, wii.data["sx"], float64(12))
 	gobot.Assert(t, wii.data["sy"], float64(23))
+	gobot.Assert(t, wii.data["ax"], float64(34<<2|3))
+	gobot.Assert(t, wii.data["ay"], float64(45<<2|1))
+	gobot.Assert(t, wii.data["az"], float64(56<<2|0))
 	gobot.Assert(t, wii.data["z"], float64(1))
 	gobot.Assert(t, wii.data["c"], float64(2))
 
 	// Second pass
-	wii.parse([]byte{70, 81, 92, 103, 204, 205})
+	wii.parse([]byte{70, 81, 128, 130, 180, 0xE1})
 
-	gobot.Assert(t, wii.data["sx"], float64(104))
-	gobot.Assert(t, wii.data["sy"], float64(93))
+	gobot.Assert(t, wii.data["sx"], float64(70))
+	gobot.Assert(t, wii.data["sy"], float64(81))
+	gobot.Assert(t, wii.data["ax"], float64(128<<2|0))
+	gobot.Assert(t, wii.data["ay"], float64(130<<2|2))
+	gobot.Assert(t, wii.data["az"], float64(180<<2|3))
 	gobot.Assert(t, wii.data["z"], float64(1))
 	gobot.Assert(t, wii.data["c"], float64(0))
 }
@@ -244,8 +210,8 @@ func TestWiichuckDriverAdjustOrigins(t *testing.T) {
 	wii.parse([]byte{1, 2, 3, 4, 5, 6})
 	wii.adjustOrigins()
 
-	gobot.Assert(t, wii.joystick["sy_origin"], float64(44))
-	gobot.Assert(t, wii.joystick["sx_origin"], float64(45))
+	gobot.Assert(t, wii.joystick["sy_origin"], float64(2))
+	gobot.Assert(t, wii.joystick["sx_origin"], float64(1))
 
 	// Second pass
 	wii = initTestWiichuckDriver()
@@ -253,8 +219,8 @@ func TestWiichuckDriverAdjustOrigins(t *testing.T) {
 	wii.parse([]byte{61, 72, 83, 94, 105, 206})
 	wii.adjustOrigins()
 
-	gobot.Assert(t, wii.joystick["sy_origin"], float64(118))
-	gobot.Assert(t, wii.joystick["sx_origin"], float64(65))
+	gobot.Assert(t, wii.joystick["sy_origin"], float64(72))
+	gobot.Assert(t, wii.joystick["sx_origin"], float64(61))
 }
 
 func TestWiichuckDriverUpdateButtons(t *testing.T) {
@@ -350,3 +316,83 @@ func TestWiichuckDriverUpdateJoystick(t *testing.T) {
 		t.Errorf("Did not recieve 'Joystick' event")
 	}
 }
+
+func TestWiichuckDriverReading(t *testing.T) {
+	wii, adaptor := initTestWiichuckDriverWithStubbedAdaptor()
+	adaptor.i2cReadImpl = func() ([]byte, error) {
+		return []byte{1, 2, 128, 128, 180, 0x04}, nil
+	}
+	data := make(chan interface{}, 1)
+	gobot.Once(wii.Event(Data), func(d interface{}) {
+		data <- d
+	})
+
+	gobot.Assert(t, len(wii.Start()), 0)
+	select {
+	case d := <-data:
+		gobot.Assert(t, d, WiichuckData{X: 0, Y: 0, C: true, Z: true, AccelX: 513, AccelY: 512, AccelZ: 720})
+	case <-time.After(time.Second):
+		t.Errorf("data was not published")
+	}
+	gobot.Assert(t, len(wii.Halt()), 0)
+
+	reading, timestamp := wii.Reading()
+	gobot.Assert(t, reading.Z, true)
+	gobot.Assert(t, timestamp.IsZero(), false)
+
+	// not initialised
+	adaptor.i2cReadImpl = func() ([]byte, error) {
+		return []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, nil
+	}
+	_, err := wii.read()
+	gobot.Assert(t, err, ErrNotReady)
+
+	adaptor.i2cReadImpl = func() ([]byte, error) {
+		return []byte{1, 2}, nil
+	}
+	_, err = wii.read()
+	gobot.Assert(t, err, ErrNotEnoughBytes)
+}
+
+func TestWiichuckDriverInit(t *testing.T) {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	wii := NewWiichuckDriver(adaptor, "bot", time.Hour)
+
+	gobot.Assert(t, len(wii.Start()), 0)
+	gobot.Assert(t, len(wii.Halt()), 0)
+	gobot.Assert(t, adaptor.writes[:3], [][]byte{{0xF0, 0x55}, {0xFB, 0x00}, {0x00}})
+}
+
+func TestWiichuckDriverPressRelease(t *testing.T) {
+	wii := initTestWiichuckDriver()
+	events := make(chan string, 4)
+	for _, e := range []string{"c_press", "c_release", "z_press", "z_release"} {
+		e := e
+		gobot.On(wii.Event(e), func(data interface{}) {
+			events <- e
+		})
+	}
+
+	wii.update([]byte{1, 2, 3, 4, 5, 0x01})
+	select {
+	case e := <-events:
+		gobot.Assert(t, e, "c_press")
+	case <-time.After(time.Second):
+		t.Errorf("Did not recieve 'c_press' event")
+	}
+
+	// holding c down publishes nothing more
+	wii.update([]byte{1, 2, 3, 4, 5, 0x01})
+	wii.update([]byte{1, 2, 3, 4, 5, 0x03})
+	select {
+	case e := <-events:
+		gobot.Assert(t, e, "c_release")
+	case <-time.After(time.Second):
+		t.Errorf("Did not recieve 'c_release' event")
+	}
+	select {
+	case e := <-events:
+		t.Errorf("Unexpected %v event", e)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
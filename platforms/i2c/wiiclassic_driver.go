@@ -0,0 +1,143 @@
+package i2c
+
+import (
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*WiiClassicDriver)(nil)
+
+// The buttons of a Wii Classic Controller, and the bits of the last 2 bytes
+// of its data which read 0 while they are pressed
+var wiiClassicButtons = []struct {
+	name string
+	byte int
+	bit  uint
+}{
+	{"right", 4, 7},
+	{"down", 4, 6},
+	{"l", 4, 5},
+	{"minus", 4, 4},
+	{"home", 4, 3},
+	{"plus", 4, 2},
+	{"r", 4, 1},
+	{"zl", 5, 7},
+	{"b", 5, 6},
+	{"y", 5, 5},
+	{"a", 5, 4},
+	{"x", 5, 3},
+	{"zr", 5, 2},
+	{"left", 5, 1},
+	{"up", 5, 0},
+}
+
+// WiiClassicData is a reading of a Wii Classic Controller
+type WiiClassicData struct {
+	// LeftX and LeftY are the position of the left stick, from -32 to 31,
+	// and RightX and RightY that of the right stick, from -16 to 15, from
+	// where they first were
+	LeftX  float64
+	LeftY  float64
+	RightX float64
+	RightY float64
+	// LeftTrigger and RightTrigger are how far the triggers are pulled, from
+	// 0 to 31, the "l" and "r" buttons being pressed at the end
+	LeftTrigger  int
+	RightTrigger int
+	// Buttons are true while pressed, by the names of their events, "a",
+	// "b", "x", "y", "l", "r", "zl", "zr", "plus", "minus", "home" and the
+	// d-pad's "up", "down", "left" and "right"
+	Buttons map[string]bool
+}
+
+// WiiClassicDriver represents a Wii Classic Controller, or a Classic
+// Controller Pro
+type WiiClassicDriver struct {
+	*wiiExtension
+	origin []float64
+}
+
+// NewWiiClassicDriver creates a WiiClassicDriver with specified i2c interface
+// and name.
+//
+// Optionally accepts:
+//  time.Duration: interval at which the controller is read, 10ms by default
+//
+// It adds the following events:
+//	"[button]_press", "[button]_release" - a button, named as in
+//	WiiClassicData, is pressed or released
+//	"left_joystick", "right_joystick" - the position of a stick every interval, you can access values x, y
+//	"data" - a WiiClassicData every interval
+//	"error" - an error reading the controller
+func NewWiiClassicDriver(a I2c, name string, v ...time.Duration) *WiiClassicDriver {
+	w := &WiiClassicDriver{}
+	buttons := []string{}
+	for _, b := range wiiClassicButtons {
+		buttons = append(buttons, b.name)
+	}
+	w.wiiExtension = newWiiExtension(a, name, buttons, v, func() (interface{}, error) {
+		return w.read()
+	})
+
+	w.AddEvent("left_joystick")
+	w.AddEvent("right_joystick")
+	return w
+}
+
+// Reading returns the last reading of the controller, and the time it was
+// made
+func (w *WiiClassicDriver) Reading() (data WiiClassicData, timestamp time.Time) {
+	value, timestamp := w.Latest()
+	data, _ = value.(WiiClassicData)
+	return
+}
+
+// read reads the controller, publishing its button and joystick events, and
+// returns the reading
+func (w *WiiClassicDriver) read() (data WiiClassicData, err error) {
+	value, err := w.readData()
+	if err != nil {
+		return
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	data = w.parse(value)
+	w.updateButtons(data.Buttons)
+	gobot.Publish(w.Event("left_joystick"), map[string]float64{
+		"x": data.LeftX,
+		"y": data.LeftY,
+	})
+	gobot.Publish(w.Event("right_joystick"), map[string]float64{
+		"x": data.RightX,
+		"y": data.RightY,
+	})
+	return
+}
+
+// parse returns the reading of value, taking the positions of the sticks
+// from those of the first reading
+func (w *WiiClassicDriver) parse(value []byte) (data WiiClassicData) {
+	sticks := []float64{
+		float64(value[0] & 0x3F),
+		float64(value[1] & 0x3F),
+		float64(value[0]>>6<<3 | value[1]>>6<<1 | value[2]>>7),
+		float64(value[2] & 0x1F),
+	}
+	if w.origin == nil {
+		w.origin = sticks
+	}
+
+	data.LeftX = sticks[0] - w.origin[0]
+	data.LeftY = sticks[1] - w.origin[1]
+	data.RightX = sticks[2] - w.origin[2]
+	data.RightY = sticks[3] - w.origin[3]
+	data.LeftTrigger = int(value[2]>>5&0x03<<3 | value[3]>>5)
+	data.RightTrigger = int(value[3] & 0x1F)
+	data.Buttons = map[string]bool{}
+	for _, b := range wiiClassicButtons {
+		data.Buttons[b.name] = value[b.byte]>>b.bit&0x01 == 0
+	}
+	return
+}
@@ -0,0 +1,81 @@
+package i2c
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestWiiClassicDriver(t *testing.T) {
+	wii := NewWiiClassicDriver(newI2cTestAdaptor("adaptor"), "bot")
+	gobot.Assert(t, wii.Name(), "bot")
+	gobot.Assert(t, wii.Connection().Name(), "adaptor")
+	gobot.Assert(t, wii.Interval(), 10*time.Millisecond)
+	gobot.Refute(t, wii.Event("a_press"), nil)
+	gobot.Refute(t, wii.Event("home_release"), nil)
+	gobot.Refute(t, wii.Event("left_joystick"), nil)
+
+	wii = NewWiiClassicDriver(newI2cTestAdaptor("adaptor"), "bot", 100*time.Millisecond)
+	gobot.Assert(t, wii.Interval(), 100*time.Millisecond)
+}
+
+func TestWiiClassicDriverParse(t *testing.T) {
+	wii := NewWiiClassicDriver(newI2cTestAdaptor("adaptor"), "bot")
+
+	// the sticks centered, the triggers released and no buttons pressed
+	data := wii.parse([]byte{0x60, 0x20, 0x10, 0x00, 0xFF, 0xFF})
+	gobot.Assert(t, data.LeftX, 0.0)
+	gobot.Assert(t, data.RightY, 0.0)
+	gobot.Assert(t, data.LeftTrigger, 0)
+	gobot.Assert(t, data.Buttons["a"], false)
+
+	// left stick right, right stick down, left trigger half way, right
+	// trigger and "r" all the way, "a", "home" and "up" pressed
+	data = wii.parse([]byte{0x7F, 0x20, 0x40, 0x1F, 0xF5, 0xEE})
+	gobot.Assert(t, data.LeftX, 31.0)
+	gobot.Assert(t, data.LeftY, 0.0)
+	gobot.Assert(t, data.RightX, 0.0)
+	gobot.Assert(t, data.RightY, -16.0)
+	gobot.Assert(t, data.LeftTrigger, 16)
+	gobot.Assert(t, data.RightTrigger, 31)
+	gobot.Assert(t, data.Buttons, map[string]bool{
+		"right": false, "down": false, "l": false, "minus": false,
+		"home": true, "plus": false, "r": true,
+		"zl": false, "b": false, "y": false, "a": true, "x": false,
+		"zr": false, "left": false, "up": true,
+	})
+}
+
+func TestWiiClassicDriverReading(t *testing.T) {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	adaptor.set(0, 0x60, 0x20, 0x10, 0x00, 0xFF, 0xEF)
+	wii := NewWiiClassicDriver(adaptor, "bot")
+
+	press := make(chan bool, 1)
+	gobot.Once(wii.Event("a_press"), func(data interface{}) {
+		press <- true
+	})
+	data := make(chan interface{}, 1)
+	gobot.Once(wii.Event(Data), func(d interface{}) {
+		data <- d
+	})
+
+	gobot.Assert(t, len(wii.Start()), 0)
+	select {
+	case <-press:
+	case <-time.After(time.Second):
+		t.Errorf("a_press was not published")
+	}
+	select {
+	case d := <-data:
+		gobot.Assert(t, d.(WiiClassicData).Buttons["a"], true)
+	case <-time.After(time.Second):
+		t.Errorf("data was not published")
+	}
+	gobot.Assert(t, len(wii.Halt()), 0)
+
+	reading, timestamp := wii.Reading()
+	gobot.Assert(t, reading.Buttons["a"], true)
+	gobot.Assert(t, timestamp.IsZero(), false)
+}
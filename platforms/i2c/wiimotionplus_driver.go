@@ -0,0 +1,123 @@
+package i2c
+
+import (
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*WiiMotionPlusDriver)(nil)
+
+// The units of the gyroscope in each degree per second, in slow mode, up to
+// about 440 degrees per second, and in fast mode, up to about 2000
+const (
+	wiiMotionPlusSlowScale = 20.0
+	wiiMotionPlusFastScale = wiiMotionPlusSlowScale * 440 / 2000
+)
+
+// WiiMotionPlusData is a reading of a Wii Motion Plus
+type WiiMotionPlusData struct {
+	// Yaw, Roll and Pitch are the rates of turn about each axis in degrees
+	// per second, from those of the first reading
+	Yaw   float64
+	Roll  float64
+	Pitch float64
+	// YawFast, RollFast and PitchFast are true when the rate about the axis
+	// is measured in fast mode, which is less precise
+	YawFast   bool
+	RollFast  bool
+	PitchFast bool
+	// Extension is true when another extension is plugged into the Motion
+	// Plus
+	Extension bool
+}
+
+// WiiMotionPlusDriver represents a Wii Motion Plus gyroscope, used on its own.
+// An extension plugged into it is detected but not read.
+type WiiMotionPlusDriver struct {
+	*wiiExtension
+	origin []int
+}
+
+// NewWiiMotionPlusDriver creates a WiiMotionPlusDriver with specified i2c
+// interface and name.
+//
+// Optionally accepts:
+//  time.Duration: interval at which the Motion Plus is read, 10ms by default
+//
+// It adds the following events:
+//	"data" - a WiiMotionPlusData every interval
+//	"error" - an error reading the Motion Plus
+func NewWiiMotionPlusDriver(a I2c, name string, v ...time.Duration) *WiiMotionPlusDriver {
+	w := &WiiMotionPlusDriver{}
+	w.wiiExtension = newWiiExtension(a, name, nil, v, func() (interface{}, error) {
+		return w.read()
+	})
+	w.initialize = w.activate
+	return w
+}
+
+// Reading returns the last reading of the Motion Plus, and the time it was
+// made
+func (w *WiiMotionPlusDriver) Reading() (data WiiMotionPlusData, timestamp time.Time) {
+	value, timestamp := w.Latest()
+	data, _ = value.(WiiMotionPlusData)
+	return
+}
+
+// activate initialises the Motion Plus at its own address, then activates it,
+// after which it answers at the address of other extensions
+func (w *WiiMotionPlusDriver) activate() (err error) {
+	if err = w.connection.I2cWrite(wiiMotionPlusAddress, []byte{0xF0, 0x55}); err != nil {
+		return
+	}
+	if err = w.connection.I2cWrite(wiiMotionPlusAddress, []byte{0xFE, 0x04}); err != nil {
+		return
+	}
+	<-time.After(100 * time.Millisecond)
+	return
+}
+
+// read reads the Motion Plus and returns the reading
+func (w *WiiMotionPlusDriver) read() (data WiiMotionPlusData, err error) {
+	value, err := w.readData()
+	if err != nil {
+		return
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.parse(value), nil
+}
+
+// parse returns the reading of value. The 14 bit rates are split between
+// the first 3 bytes and the high bits of the last 3, whose low bits are the
+// modes of the axes.
+func (w *WiiMotionPlusDriver) parse(value []byte) (data WiiMotionPlusData) {
+	rates := []int{
+		int(value[3]>>2)<<8 | int(value[0]),
+		int(value[4]>>2)<<8 | int(value[1]),
+		int(value[5]>>2)<<8 | int(value[2]),
+	}
+	if w.origin == nil {
+		w.origin = rates
+	}
+
+	data.YawFast = value[3]&0x02 == 0
+	data.RollFast = value[4]&0x02 == 0
+	data.PitchFast = value[3]&0x01 == 0
+	data.Extension = value[4]&0x01 == 1
+	data.Yaw = wiiMotionPlusRate(rates[0]-w.origin[0], data.YawFast)
+	data.Roll = wiiMotionPlusRate(rates[1]-w.origin[1], data.RollFast)
+	data.Pitch = wiiMotionPlusRate(rates[2]-w.origin[2], data.PitchFast)
+	return
+}
+
+// wiiMotionPlusRate returns the rate of turn in degrees per second of a
+// gyroscope reading in fast or slow mode
+func wiiMotionPlusRate(rate int, fast bool) float64 {
+	if fast {
+		return float64(rate) / wiiMotionPlusFastScale
+	}
+	return float64(rate) / wiiMotionPlusSlowScale
+}
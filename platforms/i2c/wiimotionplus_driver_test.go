@@ -0,0 +1,54 @@
+package i2c
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestWiiMotionPlusDriver(t *testing.T) {
+	wii := NewWiiMotionPlusDriver(newI2cTestAdaptor("adaptor"), "bot")
+	gobot.Assert(t, wii.Name(), "bot")
+	gobot.Assert(t, wii.Connection().Name(), "adaptor")
+	gobot.Assert(t, wii.Interval(), 10*time.Millisecond)
+}
+
+func TestWiiMotionPlusDriverParse(t *testing.T) {
+	wii := NewWiiMotionPlusDriver(newI2cTestAdaptor("adaptor"), "bot")
+
+	// about 8192 on each axis at rest, all in slow mode
+	data := wii.parse([]byte{0x00, 0x00, 0x00, 0x83, 0x82, 0x82})
+	gobot.Assert(t, data, WiiMotionPlusData{})
+
+	// yaw 200 units faster in slow mode, roll 44 slower in fast mode, and an
+	// extension plugged in
+	data = wii.parse([]byte{0xC8, 0xD4, 0x00, 0x83, 0x7D, 0x82})
+	gobot.Assert(t, data, WiiMotionPlusData{
+		Yaw:       10,
+		Roll:      -10,
+		RollFast:  true,
+		Extension: true,
+	})
+}
+
+func TestWiiMotionPlusDriverStart(t *testing.T) {
+	adaptor := newI2cTestRegisters(1, 0xFF)
+	adaptor.set(0, 0x00, 0x00, 0x00, 0x83, 0x82, 0x82)
+	wii := NewWiiMotionPlusDriver(adaptor, "bot")
+
+	data := make(chan interface{}, 1)
+	gobot.Once(wii.Event(Data), func(d interface{}) {
+		data <- d
+	})
+
+	gobot.Assert(t, len(wii.Start()), 0)
+	select {
+	case d := <-data:
+		gobot.Assert(t, d, WiiMotionPlusData{})
+	case <-time.After(time.Second):
+		t.Errorf("data was not published")
+	}
+	gobot.Assert(t, len(wii.Halt()), 0)
+	gobot.Assert(t, adaptor.writes[:3], [][]byte{{0xF0, 0x55}, {0xFE, 0x04}, {0x00}})
+}
@@ -2,12 +2,15 @@ package edison
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/hybridgroup/gobot"
 	"github.com/hybridgroup/gobot/platforms/gpio"
 	"github.com/hybridgroup/gobot/platforms/i2c"
+	"github.com/hybridgroup/gobot/platforms/spi"
 	"github.com/hybridgroup/gobot/sysfs"
 )
 
@@ -15,11 +18,16 @@ var _ gobot.Adaptor = (*EdisonAdaptor)(nil)
 
 var _ gpio.DigitalReader = (*EdisonAdaptor)(nil)
 var _ gpio.DigitalWriter = (*EdisonAdaptor)(nil)
+var _ gpio.PulseReader = (*EdisonAdaptor)(nil)
+var _ gpio.TriggeredPulseReader = (*EdisonAdaptor)(nil)
 var _ gpio.AnalogReader = (*EdisonAdaptor)(nil)
 var _ gpio.PwmWriter = (*EdisonAdaptor)(nil)
+var _ gpio.PwmPeriodWriter = (*EdisonAdaptor)(nil)
 
 var _ i2c.I2c = (*EdisonAdaptor)(nil)
 
+var _ spi.SpiTransferer = (*EdisonAdaptor)(nil)
+
 func writeFile(path string, data []byte) (i int, err error) {
 	file, err := sysfs.OpenFile(path, os.O_WRONLY, 0644)
 	defer file.Close()
@@ -65,6 +73,7 @@ type EdisonAdaptor struct {
 	digitalPins map[int]sysfs.DigitalPin
 	pwmPins     map[int]*pwmPin
 	i2cDevice   sysfs.I2cDevice
+	spiDevices  map[string]sysfs.SpiDevice
 	connect     func(e *EdisonAdaptor) (err error)
 }
 
@@ -265,6 +274,7 @@ func (e *EdisonAdaptor) Name() string { return e.name }
 func (e *EdisonAdaptor) Connect() (errs []error) {
 	e.digitalPins = make(map[int]sysfs.DigitalPin)
 	e.pwmPins = make(map[int]*pwmPin)
+	e.spiDevices = make(map[string]sysfs.SpiDevice)
 	if err := e.connect(e); err != nil {
 		return []error{err}
 	}
@@ -298,6 +308,11 @@ func (e *EdisonAdaptor) Finalize() (errs []error) {
 			errs = append(errs, err)
 		}
 	}
+	for _, device := range e.spiDevices {
+		if err := device.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	return errs
 }
 
@@ -399,37 +414,111 @@ func (e *EdisonAdaptor) DigitalWrite(pin string, val byte) (err error) {
 	return sysfsPin.Write(int(val))
 }
 
+// PulseRead waits for pin to go to level and returns how long it stays there,
+// timing the edges through the interrupts of the pin. If trigger is not 0, pin
+// is driven to level for trigger once its interrupts are ready, and is then
+// returned to an input.
+func (e *EdisonAdaptor) PulseRead(pin string, level int, trigger time.Duration, timeout time.Duration) (time.Duration, error) {
+	if trigger > 0 {
+		return e.TriggeredPulseRead(pin, pin, level, trigger, timeout)
+	}
+	return e.pulse(pin, level, nil, timeout)
+}
+
+// TriggeredPulseRead drives triggerPin to level for trigger once the
+// interrupts of pin are ready, then returns how long pin stays at level.
+// triggerPin may be pin itself.
+func (e *EdisonAdaptor) TriggeredPulseRead(triggerPin string, pin string, level int, trigger time.Duration, timeout time.Duration) (time.Duration, error) {
+	return e.pulse(pin, level, func() (err error) {
+		if err = e.trigger(triggerPin, level, trigger); err != nil || triggerPin != pin {
+			return
+		}
+		_, err = e.digitalPin(pin, "in")
+		return
+	}, timeout)
+}
+
+// pulse times a pulse at level on pin, started by trigger when not nil
+func (e *EdisonAdaptor) pulse(pin string, level int, trigger func() error, timeout time.Duration) (time.Duration, error) {
+	sysfsPin, err := e.digitalPin(pin, "in")
+	if err != nil {
+		return 0, err
+	}
+	pulsePin, ok := sysfsPin.(sysfs.PulsePin)
+	if !ok {
+		return 0, sysfs.ErrPulseUnsupported
+	}
+	return pulsePin.Pulse(level, trigger, timeout)
+}
+
+// trigger drives pin to level for duration, through DigitalWrite as the level
+// shifter of the pin has to change direction too
+func (e *EdisonAdaptor) trigger(pin string, level int, duration time.Duration) (err error) {
+	if err = e.DigitalWrite(pin, byte(level)); err != nil {
+		return
+	}
+	time.Sleep(duration)
+	return e.DigitalWrite(pin, byte(level^1))
+}
+
 // PwmWrite writes the 0-254 value to the specified pin
 func (e *EdisonAdaptor) PwmWrite(pin string, val byte) (err error) {
+	pwm, err := e.pwmPin(pin)
+	if err != nil {
+		return
+	}
+	p, err := pwm.period()
+	if err != nil {
+		return err
+	}
+	period, err := strconv.Atoi(p)
+	if err != nil {
+		return err
+	}
+	duty := gobot.FromScale(float64(val), 0, 255.0)
+	return pwm.writeDuty(strconv.Itoa(int(float64(period) * duty)))
+}
+
+// PwmPeriodWrite drives the specified pin with a signal of period, high for the
+// 0-255 fraction duty of each period
+func (e *EdisonAdaptor) PwmPeriodWrite(pin string, period time.Duration, duty byte) (err error) {
+	pwm, err := e.pwmPin(pin)
+	if err != nil {
+		return
+	}
+	// the duty cycle may not exceed the period, so clear it first
+	if err = pwm.writeDuty("0"); err != nil {
+		return
+	}
+	if err = pwm.writePeriod(strconv.Itoa(int(period))); err != nil {
+		return
+	}
+	high := gobot.FromScale(float64(duty), 0, 255.0)
+	return pwm.writeDuty(strconv.Itoa(int(float64(period) * high)))
+}
+
+// pwmPin returns the exported and enabled pwmPin of pin
+func (e *EdisonAdaptor) pwmPin(pin string) (p *pwmPin, err error) {
 	sysPin := sysfsPinMap[pin]
-	if sysPin.pwmPin != -1 {
-		if e.pwmPins[sysPin.pwmPin] == nil {
-			if err = e.DigitalWrite(pin, 1); err != nil {
-				return
-			}
-			if err = changePinMode(strconv.Itoa(int(sysPin.pin)), "1"); err != nil {
-				return
-			}
-			e.pwmPins[sysPin.pwmPin] = newPwmPin(sysPin.pwmPin)
-			if err = e.pwmPins[sysPin.pwmPin].export(); err != nil {
-				return
-			}
-			if err = e.pwmPins[sysPin.pwmPin].enable("1"); err != nil {
-				return
-			}
+	if sysPin.pwmPin == -1 {
+		return nil, errors.New("Not a PWM pin")
+	}
+	if e.pwmPins[sysPin.pwmPin] == nil {
+		if err = e.DigitalWrite(pin, 1); err != nil {
+			return
 		}
-		p, err := e.pwmPins[sysPin.pwmPin].period()
-		if err != nil {
-			return err
+		if err = changePinMode(strconv.Itoa(int(sysPin.pin)), "1"); err != nil {
+			return
+		}
+		e.pwmPins[sysPin.pwmPin] = newPwmPin(sysPin.pwmPin)
+		if err = e.pwmPins[sysPin.pwmPin].export(); err != nil {
+			return
 		}
-		period, err := strconv.Atoi(p)
-		if err != nil {
-			return err
+		if err = e.pwmPins[sysPin.pwmPin].enable("1"); err != nil {
+			return
 		}
-		duty := gobot.FromScale(float64(val), 0, 255.0)
-		return e.pwmPins[sysPin.pwmPin].writeDuty(strconv.Itoa(int(float64(period) * duty)))
 	}
-	return errors.New("Not a PWM pin")
+	return e.pwmPins[sysPin.pwmPin], nil
 }
 
 // AnalogRead returns value from analog reading of specified pin
@@ -517,3 +606,27 @@ func (e *EdisonAdaptor) I2cRead(address int, size int) (data []byte, err error)
 	_, err = e.i2cDevice.Read(data)
 	return
 }
+
+// SpiStart opens the spidev device for the given bus and chip select. The
+// Arduino breakout board exposes bus 5 chip 1, /dev/spidev5.1, on pins 10-13.
+func (e *EdisonAdaptor) SpiStart(bus int, chip int, mode int, bits int, maxSpeed int) (err error) {
+	location := fmt.Sprintf("/dev/spidev%v.%v", bus, chip)
+	if e.spiDevices[location] != nil {
+		return
+	}
+	device, err := sysfs.NewSpiDevice(location, byte(mode), byte(bits), uint32(maxSpeed))
+	if err != nil {
+		return
+	}
+	e.spiDevices[location] = device
+	return
+}
+
+// SpiTransfer writes tx to the spi device and returns the bytes read back
+func (e *EdisonAdaptor) SpiTransfer(bus int, chip int, tx []byte) (rx []byte, err error) {
+	device, ok := e.spiDevices[fmt.Sprintf("/dev/spidev%v.%v", bus, chip)]
+	if !ok {
+		return nil, spi.ErrNotStarted
+	}
+	return device.Transfer(tx)
+}
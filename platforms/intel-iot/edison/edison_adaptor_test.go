@@ -3,8 +3,10 @@ package edison
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/spi"
 	"github.com/hybridgroup/gobot/sysfs"
 )
 
@@ -174,6 +176,18 @@ func TestEdisonAdaptorPwm(t *testing.T) {
 	gobot.Assert(t, err, errors.New("Not a PWM pin"))
 }
 
+func TestEdisonAdaptorPwmPeriod(t *testing.T) {
+	a, fs := initTestEdisonAdaptor()
+
+	err := a.PwmPeriodWrite("5", 2*time.Millisecond, 128)
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, fs.Files["/sys/class/pwm/pwmchip0/pwm1/period"].Contents, "2000000")
+	gobot.Assert(t, fs.Files["/sys/class/pwm/pwmchip0/pwm1/duty_cycle"].Contents, "1003921")
+
+	err = a.PwmPeriodWrite("7", 2*time.Millisecond, 128)
+	gobot.Assert(t, err, errors.New("Not a PWM pin"))
+}
+
 func TestEdisonAdaptorAnalog(t *testing.T) {
 	a, fs := initTestEdisonAdaptor()
 
@@ -181,3 +195,24 @@ func TestEdisonAdaptorAnalog(t *testing.T) {
 	i, _ := a.AnalogRead("0")
 	gobot.Assert(t, i, 250)
 }
+
+func TestEdisonAdaptorSpi(t *testing.T) {
+	a, fs := initTestEdisonAdaptor()
+	fs.Add("/dev/spidev5.1")
+	sysfs.SetSyscall(&sysfs.MockSyscall{})
+
+	_, err := a.SpiTransfer(5, 1, []byte{0x01})
+	gobot.Assert(t, err, spi.ErrNotStarted)
+
+	gobot.Assert(t, a.SpiStart(5, 1, spi.Mode0, 8, 500000), nil)
+
+	device := sysfs.NewMockSpiDevice()
+	device.Respond = func(tx []byte) []byte {
+		return []byte{0xAA, 0x55}
+	}
+	a.spiDevices["/dev/spidev5.1"] = device
+
+	data, err := a.SpiTransfer(5, 1, []byte{0x01, 0x02})
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, data, []byte{0xAA, 0x55})
+}
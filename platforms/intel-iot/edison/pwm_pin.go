@@ -56,6 +56,12 @@ func (p *pwmPin) period() (period string, err error) {
 	return string(buf[0 : len(buf)-1]), nil
 }
 
+// writePeriod writes value to pwm period path
+func (p *pwmPin) writePeriod(period string) (err error) {
+	_, err = writeFile(pwmPeriodPath(p.pin), []byte(period))
+	return
+}
+
 // writeDuty writes value to pwm duty cycle path
 func (p *pwmPin) writeDuty(duty string) (err error) {
 	_, err = writeFile(pwmDutyCyclePath(p.pin), []byte(duty))
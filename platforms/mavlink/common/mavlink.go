@@ -9,7 +9,8 @@ import (
 	"bytes"
 	"encoding/binary"
 	"io"
-	"time"
+
+	"github.com/hybridgroup/gobot/serial"
 )
 
 const (
@@ -51,30 +52,27 @@ type MAVLinkPacket struct {
 	Checksum    uint16
 }
 
+// packetFramer splits a stream into MAVLink 1.0 packets, whose length byte
+// counts the payload but not the 4 header bytes after it nor the 2 byte CRC
+var packetFramer = &serial.LengthPrefixed{
+	Sync:  []byte{MAVLINK_STX},
+	Extra: 6,
+}
+
 // ReadMAVLinkPacket reads an io.Reader for a new packet and returns a new MAVLink packet
 // or returns the error received by the io.Reader
 func ReadMAVLinkPacket(r io.Reader) (*MAVLinkPacket, error) {
 	for {
-		header, err := read(r, 1)
+		data, err := packetFramer.ReadFrame(r)
 		if err != nil {
 			return nil, err
 		}
-		if header[0] == 254 {
-			length, err := read(r, 1)
-			if err != nil {
-				return nil, err
-			} else if length[0] > 250 {
-				continue
-			}
-			m := &MAVLinkPacket{}
-			data, err := read(r, int(length[0])+7)
-			if err != nil {
-				return nil, err
-			}
-			data = append([]byte{header[0], length[0]}, data...)
-			m.Decode(data)
-			return m, nil
+		if data[1] > 250 {
+			continue
 		}
+		m := &MAVLinkPacket{}
+		m.Decode(data)
+		return m, nil
 	}
 }
 
@@ -135,30 +133,10 @@ func (m *MAVLinkPacket) Decode(buf []byte) {
 	m.ComponentID = buf[4]
 	m.MessageID = buf[5]
 	m.Data = buf[6 : 6+int(m.Length)]
-	checksum := buf[7+int(m.Length):]
+	checksum := buf[6+int(m.Length):]
 	m.Checksum = uint16(checksum[1])<<8 | uint16(checksum[0])
 }
 
-func read(r io.Reader, length int) ([]byte, error) {
-	buf := []byte{}
-	for length > 0 {
-		tmp := make([]byte, length)
-		i, err := r.Read(tmp[:])
-		if err != nil {
-			return nil, err
-		} else {
-			length -= i
-			buf = append(buf, tmp...)
-			if length != i {
-				<-time.After(1 * time.Millisecond)
-			} else {
-				break
-			}
-		}
-	}
-	return buf, nil
-}
-
 //
 // Accumulate the X.25 CRC by adding one char at a time.
 //
@@ -4,6 +4,7 @@ import (
 	"bytes"
 
 	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/serial"
 )
 
 var _ gobot.Driver = (*NeuroskyDriver)(nil)
@@ -31,6 +32,14 @@ const CodeWave byte = 0x80
 // ASIC EEG POWER 8 3-byte big-endian integers
 const CodeAsicEEG byte = 0x83
 
+// packetFramer reads ThinkGear packets: two BTSync bytes, the payload length,
+// the payload and a checksum over the payload
+var packetFramer = &serial.LengthPrefixed{
+	Sync:           []byte{BTSync, BTSync},
+	Checksum:       serial.SumComplement,
+	ChecksumOffset: 3,
+}
+
 type NeuroskyDriver struct {
 	name       string
 	connection gobot.Connection
@@ -89,12 +98,13 @@ func (n *NeuroskyDriver) adaptor() *NeuroskyAdaptor {
 func (n *NeuroskyDriver) Start() (errs []error) {
 	go func() {
 		for {
-			buff := make([]byte, 1024)
-			_, err := n.adaptor().sp.Read(buff[:])
-			if err != nil {
+			packet, err := packetFramer.ReadFrame(n.adaptor().sp)
+			if err == serial.ErrChecksum {
+				continue
+			} else if err != nil {
 				gobot.Publish(n.Event("error"), err)
 			} else {
-				n.parse(bytes.NewBuffer(buff))
+				n.parsePacket(bytes.NewBuffer(packet[3 : len(packet)-1]))
 			}
 		}
 	}()
@@ -107,16 +117,13 @@ func (n *NeuroskyDriver) Halt() (errs []error) { return }
 // parse converts bytes buffer into packets until no more data is present
 func (n *NeuroskyDriver) parse(buf *bytes.Buffer) {
 	for buf.Len() > 2 {
-		b1, _ := buf.ReadByte()
-		b2, _ := buf.ReadByte()
-		if b1 == BTSync && b2 == BTSync {
-			length, _ := buf.ReadByte()
-			payload := make([]byte, length)
-			buf.Read(payload)
-			//checksum, _ := buf.ReadByte()
-			buf.Next(1)
-			n.parsePacket(bytes.NewBuffer(payload))
+		packet, err := packetFramer.ReadFrame(buf)
+		if err == serial.ErrChecksum {
+			continue
+		} else if err != nil {
+			return
 		}
+		n.parsePacket(bytes.NewBuffer(packet[3 : len(packet)-1]))
 	}
 }
 
@@ -59,7 +59,7 @@ func TestNeuroskyDriverParse(t *testing.T) {
 	// CodeEx
 	go func() {
 		<-time.After(5 * time.Millisecond)
-		d.parse(bytes.NewBuffer([]byte{0xAA, 0xAA, 1, 0x55, 0x00}))
+		d.parse(bytes.NewBuffer([]byte{0xAA, 0xAA, 1, 0x55, 0xAA}))
 	}()
 
 	gobot.On(d.Event("extended"), func(data interface{}) {
@@ -75,7 +75,7 @@ func TestNeuroskyDriverParse(t *testing.T) {
 	// CodeSignalQuality
 	go func() {
 		<-time.After(5 * time.Millisecond)
-		d.parse(bytes.NewBuffer([]byte{0xAA, 0xAA, 2, 0x02, 100, 0x00}))
+		d.parse(bytes.NewBuffer([]byte{0xAA, 0xAA, 2, 0x02, 100, 0x99}))
 	}()
 
 	gobot.On(d.Event("signal"), func(data interface{}) {
@@ -88,7 +88,7 @@ func TestNeuroskyDriverParse(t *testing.T) {
 	// CodeAttention
 	go func() {
 		<-time.After(5 * time.Millisecond)
-		d.parse(bytes.NewBuffer([]byte{0xAA, 0xAA, 2, 0x04, 40, 0x00}))
+		d.parse(bytes.NewBuffer([]byte{0xAA, 0xAA, 2, 0x04, 40, 0xD3}))
 	}()
 
 	gobot.On(d.Event("attention"), func(data interface{}) {
@@ -101,7 +101,7 @@ func TestNeuroskyDriverParse(t *testing.T) {
 	// CodeMeditation
 	go func() {
 		<-time.After(5 * time.Millisecond)
-		d.parse(bytes.NewBuffer([]byte{0xAA, 0xAA, 2, 0x05, 60, 0x00}))
+		d.parse(bytes.NewBuffer([]byte{0xAA, 0xAA, 2, 0x05, 60, 0xBE}))
 	}()
 
 	gobot.On(d.Event("meditation"), func(data interface{}) {
@@ -114,7 +114,7 @@ func TestNeuroskyDriverParse(t *testing.T) {
 	// CodeBlink
 	go func() {
 		<-time.After(5 * time.Millisecond)
-		d.parse(bytes.NewBuffer([]byte{0xAA, 0xAA, 2, 0x16, 150, 0x00}))
+		d.parse(bytes.NewBuffer([]byte{0xAA, 0xAA, 2, 0x16, 150, 0x53}))
 	}()
 
 	gobot.On(d.Event("blink"), func(data interface{}) {
@@ -127,7 +127,7 @@ func TestNeuroskyDriverParse(t *testing.T) {
 	// CodeWave
 	go func() {
 		<-time.After(5 * time.Millisecond)
-		d.parse(bytes.NewBuffer([]byte{0xAA, 0xAA, 4, 0x80, 0x00, 0x40, 0x11, 0x00}))
+		d.parse(bytes.NewBuffer([]byte{0xAA, 0xAA, 4, 0x80, 0x00, 0x40, 0x11, 0x2E}))
 	}()
 
 	gobot.On(d.Event("wave"), func(data interface{}) {
@@ -140,9 +140,9 @@ func TestNeuroskyDriverParse(t *testing.T) {
 	// CodeAsicEEG
 	go func() {
 		<-time.After(5 * time.Millisecond)
-		d.parse(bytes.NewBuffer([]byte{0xAA, 0xAA, 30, 0x83, 24, 1, 121, 89, 0,
+		d.parse(bytes.NewBuffer([]byte{0xAA, 0xAA, 26, 0x83, 24, 1, 121, 89, 0,
 			97, 26, 0, 30, 189, 0, 57, 1, 0, 62, 160, 0, 31, 127, 0, 18, 207, 0, 13,
-			108, 0x00}))
+			108, 0x2B}))
 	}()
 
 	gobot.On(d.Event("eeg"), func(data interface{}) {
@@ -162,3 +162,23 @@ func TestNeuroskyDriverParse(t *testing.T) {
 	})
 	<-sem
 }
+
+func TestNeuroskyDriverParseChecksum(t *testing.T) {
+	sem := make(chan byte, 2)
+	d := initTestNeuroskyDriver()
+
+	gobot.On(d.Event("blink"), func(data interface{}) {
+		sem <- data.(byte)
+	})
+
+	// the first packet fails its checksum and is dropped
+	d.parse(bytes.NewBuffer([]byte{0xAA, 0xAA, 2, 0x16, 100, 0x00,
+		0xAA, 0xAA, 2, 0x16, 150, 0x53}))
+
+	select {
+	case data := <-sem:
+		gobot.Assert(t, data, uint8(150))
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("Event \"blink\" was not published")
+	}
+}
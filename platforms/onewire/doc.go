@@ -0,0 +1,11 @@
+/*
+Package onewire provides Gobot drivers for 1-Wire devices.
+
+Installing:
+
+	go get github.com/hybridgroup/gobot/platforms/onewire
+
+For further information refer to onewire README:
+https://github.com/hybridgroup/gobot/blob/master/platforms/onewire/README.md
+*/
+package onewire
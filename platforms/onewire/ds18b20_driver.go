@@ -0,0 +1,184 @@
+package onewire
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*DS18B20Driver)(nil)
+
+const ds18b20Family = "28"
+
+// ds18b20PowerOnReset is the raw temperature register of a DS18B20 from power
+// on, 85.0 celsius, which it also returns when a conversion fails because the
+// probe browned out
+const ds18b20PowerOnReset = 0x0550
+
+// DS18B20Driver represents one or more DS18B20 temperature probes sharing a 1-Wire bus
+type DS18B20Driver struct {
+	name       string
+	ids        []string
+	halt       chan bool
+	interval   time.Duration
+	connection OneWire
+	gobot.Eventer
+	gobot.Commander
+}
+
+// NewDS18B20Driver returns a new DS18B20Driver with a polling interval of
+// 1 Second given a OneWire adaptor, name and the ROM IDs of the probes to read.
+// If no ROM IDs are given, every DS18B20 found on the bus is read.
+//
+// Optionally accepts:
+// 	time.Duration: Interval at which the probes are polled for new information
+//
+// Adds the following API Commands:
+// 	"Temperature" - See DS18B20Driver.Temperature
+// 	"IDs" - See DS18B20Driver.IDs
+func NewDS18B20Driver(a OneWire, name string, ids []string, v ...time.Duration) *DS18B20Driver {
+	d := &DS18B20Driver{
+		name:       name,
+		ids:        ids,
+		connection: a,
+		Eventer:    gobot.NewEventer(),
+		Commander:  gobot.NewCommander(),
+		interval:   1 * time.Second,
+		halt:       make(chan bool),
+	}
+
+	if len(v) > 0 {
+		d.interval = v[0]
+	}
+
+	d.AddEvent(Data)
+	d.AddEvent(Error)
+
+	d.AddCommand("Temperature", func(params map[string]interface{}) interface{} {
+		id := params["id"].(string)
+		val, err := d.Temperature(id)
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	d.AddCommand("IDs", func(params map[string]interface{}) interface{} {
+		return d.IDs()
+	})
+
+	return d
+}
+
+// Name returns the DS18B20Drivers name
+func (d *DS18B20Driver) Name() string { return d.name }
+
+// Connection returns the DS18B20Drivers Connection
+func (d *DS18B20Driver) Connection() gobot.Connection { return d.connection.(gobot.Connection) }
+
+// IDs returns the ROM IDs of the probes read by the DS18B20Driver
+func (d *DS18B20Driver) IDs() []string { return d.ids }
+
+// Start looks up the probes on the bus if none were given, then reads them at
+// the given interval.
+//
+// Emits the Events:
+//	Data map[string]float64 - Event is emitted when a reading changes and holds
+//	the latest temperature in celsius of every probe, keyed by ROM ID.
+//	Error error - Event is emitted on error reading from a probe, including CRC
+//	mismatches. The error message starts with the probe's ROM ID.
+func (d *DS18B20Driver) Start() (errs []error) {
+	if len(d.ids) == 0 {
+		ids, err := d.connection.OneWireDevices()
+		if err != nil {
+			return []error{err}
+		}
+		for _, id := range ids {
+			if strings.HasPrefix(id, ds18b20Family+"-") {
+				d.ids = append(d.ids, id)
+			}
+		}
+		if len(d.ids) == 0 {
+			return []error{ErrNoDevices}
+		}
+	}
+
+	values := make(map[string]float64)
+	go func() {
+		for {
+			changed := false
+			for _, id := range d.ids {
+				val, err := d.Temperature(id)
+				if err != nil {
+					gobot.Publish(d.Event(Error), fmt.Errorf("%v: %v", id, err))
+					continue
+				}
+				if last, ok := values[id]; !ok || last != val {
+					values[id] = val
+					changed = true
+				}
+			}
+			if changed {
+				data := make(map[string]float64)
+				for id, val := range values {
+					data[id] = val
+				}
+				gobot.Publish(d.Event(Data), data)
+			}
+			select {
+			case <-time.After(d.interval):
+			case <-d.halt:
+				return
+			}
+		}
+	}()
+	return
+}
+
+// Halt stops polling the probes for new information
+func (d *DS18B20Driver) Halt() (errs []error) {
+	d.halt <- true
+	return
+}
+
+// Temperature returns the current temperature in celsius of the probe with the
+// given ROM ID. The reading is rejected with ErrCrcMismatch if its CRC is invalid,
+// and with ErrPowerOnReset if it is the 85.0 the probe holds before converting,
+// so a probe really at 85.0 celsius cannot be read.
+func (d *DS18B20Driver) Temperature(id string) (val float64, err error) {
+	data, err := d.connection.OneWireRead(id)
+	if err != nil {
+		return
+	}
+
+	// the first line of w1_slave holds the 9 byte scratchpad in hex, eg.
+	// "72 01 4b 46 7f ff 0e 10 57 : crc=57 YES"
+	fields := strings.Fields(strings.SplitN(string(data), "\n", 2)[0])
+	if len(fields) < 9 {
+		err = ErrInvalidReading
+		return
+	}
+
+	scratchpad := make([]byte, 9)
+	for i := range scratchpad {
+		b, perr := strconv.ParseUint(fields[i], 16, 8)
+		if perr != nil {
+			err = ErrInvalidReading
+			return
+		}
+		scratchpad[i] = byte(b)
+	}
+
+	if crc8(scratchpad[:8]) != scratchpad[8] {
+		err = ErrCrcMismatch
+		return
+	}
+
+	raw := int16(uint16(scratchpad[1])<<8 | uint16(scratchpad[0]))
+	if raw == ds18b20PowerOnReset {
+		err = ErrPowerOnReset
+		return
+	}
+
+	val = float64(raw) / 16.0
+	return
+}
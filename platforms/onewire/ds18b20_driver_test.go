@@ -0,0 +1,126 @@
+package onewire
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/sysfs"
+)
+
+func initTestDS18B20Driver() *DS18B20Driver {
+	return NewDS18B20Driver(newOnewireTestAdaptor("adaptor"), "bot", []string{})
+}
+
+func TestDS18B20Driver(t *testing.T) {
+	d := initTestDS18B20Driver()
+	gobot.Assert(t, d.Name(), "bot")
+	gobot.Assert(t, d.Connection().Name(), "adaptor")
+	gobot.Assert(t, d.interval, 1*time.Second)
+
+	d = NewDS18B20Driver(newOnewireTestAdaptor("adaptor"), "bot", []string{"28-000005e2fdc3"}, 30*time.Second)
+	gobot.Assert(t, d.interval, 30*time.Second)
+	gobot.Assert(t, d.IDs(), []string{"28-000005e2fdc3"})
+}
+
+func TestDS18B20DriverTemperature(t *testing.T) {
+	fs := initTestOnewireFilesystem()
+	d := initTestDS18B20Driver()
+
+	val, err := d.Temperature("28-000005e2fdc3")
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, val, 23.125)
+
+	val, err = d.Temperature("28-0000063f4b1a")
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, val, -10.125)
+
+	ret := d.Command("Temperature")(map[string]interface{}{"id": "28-000005e2fdc3"}).(map[string]interface{})
+	gobot.Assert(t, ret["val"].(float64), 23.125)
+	gobot.Assert(t, ret["err"], nil)
+
+	fs.Files["/sys/bus/w1/devices/28-000005e2fdc3/w1_slave"].Contents =
+		"72 01 4b 46 7f ff 0e 10 58 : crc=58 NO\n"
+	_, err = d.Temperature("28-000005e2fdc3")
+	gobot.Assert(t, err, ErrCrcMismatch)
+
+	fs.Files["/sys/bus/w1/devices/28-000005e2fdc3/w1_slave"].Contents = "72 01 4b\n"
+	_, err = d.Temperature("28-000005e2fdc3")
+	gobot.Assert(t, err, ErrInvalidReading)
+
+	fs.Files["/sys/bus/w1/devices/28-000005e2fdc3/w1_slave"].Contents = "72 01 4b 46 7f ff 0e 10 zz\n"
+	_, err = d.Temperature("28-000005e2fdc3")
+	gobot.Assert(t, err, ErrInvalidReading)
+
+	fs.Files["/sys/bus/w1/devices/28-000005e2fdc3/w1_slave"].Contents =
+		"50 05 4b 46 7f ff 0c 10 1c : crc=1c YES\n50 05 4b 46 7f ff 0c 10 1c t=85000\n"
+	_, err = d.Temperature("28-000005e2fdc3")
+	gobot.Assert(t, err, ErrPowerOnReset)
+
+	_, err = d.Temperature("28-000000000000")
+	gobot.Refute(t, err, nil)
+}
+
+func TestDS18B20DriverStart(t *testing.T) {
+	sem := make(chan bool, 1)
+	fs := initTestOnewireFilesystem()
+	a := newOnewireTestAdaptor("adaptor")
+	d := NewDS18B20Driver(a, "bot", []string{}, 10*time.Millisecond)
+
+	gobot.Once(d.Event(Data), func(data interface{}) {
+		gobot.Assert(t, data.(map[string]float64), map[string]float64{
+			"28-000005e2fdc3": 23.125,
+			"28-0000063f4b1a": -10.125,
+		})
+		sem <- true
+	})
+
+	gobot.Assert(t, len(d.Start()), 0)
+	gobot.Assert(t, d.IDs(), []string{"28-000005e2fdc3", "28-0000063f4b1a"})
+
+	select {
+	case <-sem:
+	case <-time.After(1 * time.Second):
+		t.Errorf("DS18B20 Event \"Data\" was not published")
+	}
+
+	gobot.Once(d.Event(Error), func(data interface{}) {
+		gobot.Assert(t, data.(error).Error(), "28-000005e2fdc3: CRC mismatch")
+		sem <- true
+	})
+
+	a.setContents(fs.Files["/sys/bus/w1/devices/28-000005e2fdc3/w1_slave"],
+		"72 01 4b 46 7f ff 0e 10 58 : crc=58 NO\n")
+
+	select {
+	case <-sem:
+	case <-time.After(1 * time.Second):
+		t.Errorf("DS18B20 Event \"Error\" was not published")
+	}
+
+	d.halt <- true
+}
+
+func TestDS18B20DriverStartNoDevices(t *testing.T) {
+	fs := initTestOnewireFilesystem()
+	fs.Files["/sys/bus/w1/devices/w1_bus_master1/w1_master_slaves"].Contents = "not found.\n"
+	d := initTestDS18B20Driver()
+	gobot.Assert(t, d.Start()[0], ErrNoDevices)
+
+	sysfs.SetFilesystem(sysfs.NewMockFilesystem([]string{}))
+	d = initTestDS18B20Driver()
+	gobot.Refute(t, len(d.Start()), 0)
+}
+
+func TestDS18B20DriverHalt(t *testing.T) {
+	d := initTestDS18B20Driver()
+	go func() {
+		<-d.halt
+	}()
+	gobot.Assert(t, len(d.Halt()), 0)
+}
+
+func TestCrc8(t *testing.T) {
+	gobot.Assert(t, crc8([]byte{0x72, 0x01, 0x4b, 0x46, 0x7f, 0xff, 0x0e, 0x10}), byte(0x57))
+	gobot.Assert(t, crc8([]byte{}), byte(0x00))
+}
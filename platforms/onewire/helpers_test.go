@@ -0,0 +1,55 @@
+package onewire
+
+import (
+	"sync"
+
+	"github.com/hybridgroup/gobot/sysfs"
+)
+
+type onewireTestAdaptor struct {
+	name  string
+	mutex sync.Mutex
+}
+
+func (t *onewireTestAdaptor) OneWireDevices() (ids []string, err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return sysfs.OneWireDevices()
+}
+func (t *onewireTestAdaptor) OneWireRead(id string) (data []byte, err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return sysfs.NewOneWireDevice(id).Read()
+}
+func (t *onewireTestAdaptor) Name() string             { return t.name }
+func (t *onewireTestAdaptor) Connect() (errs []error)  { return }
+func (t *onewireTestAdaptor) Finalize() (errs []error) { return }
+
+// setContents sets the contents of a mock file while a driver may be reading it
+func (t *onewireTestAdaptor) setContents(f *sysfs.MockFile, contents string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	f.Contents = contents
+}
+
+func newOnewireTestAdaptor(name string) *onewireTestAdaptor {
+	return &onewireTestAdaptor{
+		name: name,
+	}
+}
+
+func initTestOnewireFilesystem() *sysfs.MockFilesystem {
+	fs := sysfs.NewMockFilesystem([]string{
+		"/sys/bus/w1/devices/w1_bus_master1/w1_master_slaves",
+		"/sys/bus/w1/devices/28-000005e2fdc3/w1_slave",
+		"/sys/bus/w1/devices/28-0000063f4b1a/w1_slave",
+	})
+	fs.Files["/sys/bus/w1/devices/w1_bus_master1/w1_master_slaves"].Contents =
+		"28-000005e2fdc3\n10-000802b4d38b\n28-0000063f4b1a\n"
+	fs.Files["/sys/bus/w1/devices/28-000005e2fdc3/w1_slave"].Contents =
+		"72 01 4b 46 7f ff 0e 10 57 : crc=57 YES\n72 01 4b 46 7f ff 0e 10 57 t=23125\n"
+	fs.Files["/sys/bus/w1/devices/28-0000063f4b1a/w1_slave"].Contents =
+		"5e ff 4b 46 7f ff 02 10 b6 : crc=b6 YES\n5e ff 4b 46 7f ff 02 10 b6 t=-10125\n"
+	sysfs.SetFilesystem(fs)
+	return fs
+}
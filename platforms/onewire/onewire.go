@@ -0,0 +1,51 @@
+package onewire
+
+import (
+	"errors"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var (
+	// ErrCrcMismatch is the error resulting when the CRC of a reading does not
+	// match the data it was sent with
+	ErrCrcMismatch = errors.New("CRC mismatch")
+	// ErrInvalidReading is the error resulting when a device returns data which
+	// cannot be parsed
+	ErrInvalidReading = errors.New("Invalid reading")
+	// ErrNoDevices is the error resulting when a driver finds none of its
+	// devices on the bus
+	ErrNoDevices = errors.New("No devices found")
+	// ErrPowerOnReset is the error resulting when a temperature probe returns
+	// the value it holds from power on, before it has made a conversion
+	ErrPowerOnReset = errors.New("Power-on reset value")
+)
+
+const (
+	// Error event
+	Error = "error"
+	// Data event
+	Data = "data"
+)
+
+// OneWire interface represents an Adaptor which has 1-Wire bus capabilities
+type OneWire interface {
+	gobot.Adaptor
+	OneWireDevices() (ids []string, err error)
+	OneWireRead(id string) (data []byte, err error)
+}
+
+// crc8 returns the Dallas/Maxim 1-Wire CRC of data
+func crc8(data []byte) (crc byte) {
+	for _, b := range data {
+		for i := 0; i < 8; i++ {
+			mix := (crc ^ b) & 0x01
+			crc >>= 1
+			if mix != 0 {
+				crc ^= 0x8C
+			}
+			b >>= 1
+		}
+	}
+	return
+}
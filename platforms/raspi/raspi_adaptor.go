@@ -7,10 +7,13 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hybridgroup/gobot"
 	"github.com/hybridgroup/gobot/platforms/gpio"
 	"github.com/hybridgroup/gobot/platforms/i2c"
+	"github.com/hybridgroup/gobot/platforms/onewire"
+	"github.com/hybridgroup/gobot/platforms/spi"
 	"github.com/hybridgroup/gobot/sysfs"
 )
 
@@ -18,9 +21,15 @@ var _ gobot.Adaptor = (*RaspiAdaptor)(nil)
 
 var _ gpio.DigitalReader = (*RaspiAdaptor)(nil)
 var _ gpio.DigitalWriter = (*RaspiAdaptor)(nil)
+var _ gpio.PulseReader = (*RaspiAdaptor)(nil)
+var _ gpio.TriggeredPulseReader = (*RaspiAdaptor)(nil)
 
 var _ i2c.I2c = (*RaspiAdaptor)(nil)
 
+var _ spi.SpiTransferer = (*RaspiAdaptor)(nil)
+
+var _ onewire.OneWire = (*RaspiAdaptor)(nil)
+
 var readFile = func() ([]byte, error) {
 	return ioutil.ReadFile("/proc/cpuinfo")
 }
@@ -32,6 +41,7 @@ type RaspiAdaptor struct {
 	digitalPins map[int]sysfs.DigitalPin
 	pwmPins     []int
 	i2cDevice   sysfs.I2cDevice
+	spiDevices  map[string]sysfs.SpiDevice
 }
 
 var pins = map[string]map[string]int{
@@ -127,6 +137,7 @@ func NewRaspiAdaptor(name string) *RaspiAdaptor {
 		name:        name,
 		digitalPins: make(map[int]sysfs.DigitalPin),
 		pwmPins:     []int{},
+		spiDevices:  make(map[string]sysfs.SpiDevice),
 	}
 	content, _ := readFile()
 	for _, v := range strings.Split(string(content), "\n") {
@@ -174,6 +185,11 @@ func (r *RaspiAdaptor) Finalize() (errs []error) {
 			errs = append(errs, err)
 		}
 	}
+	for _, device := range r.spiDevices {
+		if err := device.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	return errs
 }
 
@@ -250,6 +266,52 @@ func (r *RaspiAdaptor) DigitalWrite(pin string, val byte) (err error) {
 	return sysfsPin.Write(int(val))
 }
 
+// PulseRead waits for pin to go to level and returns how long it stays there,
+// timing the edges through the interrupts of the pin. If trigger is not 0, pin
+// is driven to level for trigger once its interrupts are ready, and is then
+// returned to an input.
+func (r *RaspiAdaptor) PulseRead(pin string, level int, trigger time.Duration, timeout time.Duration) (time.Duration, error) {
+	if trigger > 0 {
+		return r.TriggeredPulseRead(pin, pin, level, trigger, timeout)
+	}
+	return r.pulse(pin, level, nil, timeout)
+}
+
+// TriggeredPulseRead drives triggerPin to level for trigger once the
+// interrupts of pin are ready, then returns how long pin stays at level.
+// triggerPin may be pin itself.
+func (r *RaspiAdaptor) TriggeredPulseRead(triggerPin string, pin string, level int, trigger time.Duration, timeout time.Duration) (time.Duration, error) {
+	return r.pulse(pin, level, func() (err error) {
+		if err = r.trigger(triggerPin, level, trigger); err != nil || triggerPin != pin {
+			return
+		}
+		_, err = r.digitalPin(pin, sysfs.IN)
+		return
+	}, timeout)
+}
+
+// pulse times a pulse at level on pin, started by trigger when not nil
+func (r *RaspiAdaptor) pulse(pin string, level int, trigger func() error, timeout time.Duration) (time.Duration, error) {
+	sysfsPin, err := r.digitalPin(pin, sysfs.IN)
+	if err != nil {
+		return 0, err
+	}
+	pulsePin, ok := sysfsPin.(sysfs.PulsePin)
+	if !ok {
+		return 0, sysfs.ErrPulseUnsupported
+	}
+	return pulsePin.Pulse(level, trigger, timeout)
+}
+
+// trigger drives pin to level for duration
+func (r *RaspiAdaptor) trigger(pin string, level int, duration time.Duration) (err error) {
+	if err = r.DigitalWrite(pin, byte(level)); err != nil {
+		return
+	}
+	time.Sleep(duration)
+	return r.DigitalWrite(pin, byte(level^1))
+}
+
 // I2cStart starts a i2c device in specified address
 func (r *RaspiAdaptor) I2cStart(address int) (err error) {
 	if r.i2cDevice == nil {
@@ -277,6 +339,40 @@ func (r *RaspiAdaptor) I2cRead(address int, size int) (data []byte, err error) {
 	return
 }
 
+// SpiStart opens the spidev device for the given bus and chip select, eg. bus 0
+// chip 1 is /dev/spidev0.1
+func (r *RaspiAdaptor) SpiStart(bus int, chip int, mode int, bits int, maxSpeed int) (err error) {
+	location := fmt.Sprintf("/dev/spidev%v.%v", bus, chip)
+	if r.spiDevices[location] != nil {
+		return
+	}
+	device, err := sysfs.NewSpiDevice(location, byte(mode), byte(bits), uint32(maxSpeed))
+	if err != nil {
+		return
+	}
+	r.spiDevices[location] = device
+	return
+}
+
+// SpiTransfer writes tx to the spi device and returns the bytes read back
+func (r *RaspiAdaptor) SpiTransfer(bus int, chip int, tx []byte) (rx []byte, err error) {
+	device, ok := r.spiDevices[fmt.Sprintf("/dev/spidev%v.%v", bus, chip)]
+	if !ok {
+		return nil, spi.ErrNotStarted
+	}
+	return device.Transfer(tx)
+}
+
+// OneWireDevices returns the ROM IDs of the devices on the w1-gpio bus
+func (r *RaspiAdaptor) OneWireDevices() (ids []string, err error) {
+	return sysfs.OneWireDevices()
+}
+
+// OneWireRead returns the latest reading of the 1-Wire device with the given ROM ID
+func (r *RaspiAdaptor) OneWireRead(id string) (data []byte, err error) {
+	return sysfs.NewOneWireDevice(id).Read()
+}
+
 func (r *RaspiAdaptor) PwmWrite(pin string, val byte) (err error) {
 	sysfsPin, err := r.pwmPin(pin)
 	if err != nil {
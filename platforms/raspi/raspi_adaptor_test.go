@@ -3,8 +3,10 @@ package raspi
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/spi"
 	"github.com/hybridgroup/gobot/sysfs"
 )
 
@@ -144,6 +146,57 @@ func TestRaspiAdaptorDigitalIO(t *testing.T) {
 	gobot.Assert(t, i, 1)
 }
 
+func TestRaspiAdaptorPulseRead(t *testing.T) {
+	a := initTestRaspiAdaptor()
+	fs := sysfs.NewMockFilesystem([]string{
+		"/sys/class/gpio/export",
+		"/sys/class/gpio/gpio27/value",
+		"/sys/class/gpio/gpio27/direction",
+		"/sys/class/gpio/gpio27/edge",
+	})
+
+	sysfs.SetFilesystem(fs)
+
+	a.PulseRead("13", 1, 0, time.Millisecond)
+	gobot.Assert(t, fs.Files["/sys/class/gpio/gpio27/direction"].Contents, "in")
+	gobot.Assert(t, fs.Files["/sys/class/gpio/gpio27/edge"].Contents, "both")
+
+	// triggering on the pin drives it as an output once it is armed, then
+	// arms it again
+	fs.Files["/sys/class/gpio/gpio27/value"].Contents = "0"
+	_, err := a.PulseRead("13", 1, 10*time.Microsecond, time.Millisecond)
+	gobot.Assert(t, err, sysfs.ErrPulseTimeout)
+	gobot.Assert(t, fs.Files["/sys/class/gpio/gpio27/value"].Contents, "0")
+	gobot.Assert(t, fs.Files["/sys/class/gpio/gpio27/direction"].Contents, "in")
+	gobot.Assert(t, fs.Files["/sys/class/gpio/gpio27/edge"].Contents, "both")
+
+	_, err = a.PulseRead("99", 1, 0, time.Millisecond)
+	gobot.Refute(t, err, nil)
+
+	a.digitalPins[27] = struct{ sysfs.DigitalPin }{sysfs.NewDigitalPin(27)}
+	_, err = a.PulseRead("13", 1, 0, time.Millisecond)
+	gobot.Assert(t, err, sysfs.ErrPulseUnsupported)
+}
+
+func TestRaspiAdaptorTriggeredPulseRead(t *testing.T) {
+	a := initTestRaspiAdaptor()
+	fs := sysfs.NewMockFilesystem([]string{
+		"/sys/class/gpio/export",
+		"/sys/class/gpio/gpio27/value",
+		"/sys/class/gpio/gpio27/direction",
+		"/sys/class/gpio/gpio27/edge",
+	})
+
+	sysfs.SetFilesystem(fs)
+
+	a.TriggeredPulseRead("11", "13", 1, 10*time.Microsecond, time.Millisecond)
+	gobot.Assert(t, fs.Files["/sys/class/gpio/gpio27/direction"].Contents, "in")
+	gobot.Assert(t, fs.Files["/sys/class/gpio/gpio27/edge"].Contents, "both")
+
+	_, err := a.TriggeredPulseRead("11", "99", 1, 10*time.Microsecond, time.Millisecond)
+	gobot.Refute(t, err, nil)
+}
+
 func TestRaspiAdaptorI2c(t *testing.T) {
 	a := initTestRaspiAdaptor()
 	fs := sysfs.NewMockFilesystem([]string{
@@ -158,3 +211,51 @@ func TestRaspiAdaptorI2c(t *testing.T) {
 	data, _ := a.I2cRead(0xff, 2)
 	gobot.Assert(t, data, []byte{0x00, 0x01})
 }
+
+func TestRaspiAdaptorSpi(t *testing.T) {
+	a := initTestRaspiAdaptor()
+	fs := sysfs.NewMockFilesystem([]string{
+		"/dev/spidev0.1",
+	})
+	sysfs.SetFilesystem(fs)
+	sysfs.SetSyscall(&sysfs.MockSyscall{})
+
+	_, err := a.SpiTransfer(0, 1, []byte{0x01})
+	gobot.Assert(t, err, spi.ErrNotStarted)
+
+	gobot.Refute(t, a.SpiStart(0, 0, spi.Mode0, 8, 500000), nil)
+	gobot.Assert(t, a.SpiStart(0, 1, spi.Mode0, 8, 500000), nil)
+
+	device := sysfs.NewMockSpiDevice()
+	device.Respond = func(tx []byte) []byte {
+		return []byte{0x00, 0x03, 0xFF}
+	}
+	a.spiDevices["/dev/spidev0.1"] = device
+
+	data, err := a.SpiTransfer(0, 1, []byte{0x01, 0x80, 0x00})
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, data, []byte{0x00, 0x03, 0xFF})
+	gobot.Assert(t, device.Tx[0], []byte{0x01, 0x80, 0x00})
+
+	gobot.Assert(t, len(a.Finalize()), 0)
+	gobot.Assert(t, device.Closed, true)
+}
+
+func TestRaspiAdaptorOneWire(t *testing.T) {
+	a := initTestRaspiAdaptor()
+	fs := sysfs.NewMockFilesystem([]string{
+		"/sys/bus/w1/devices/w1_bus_master1/w1_master_slaves",
+		"/sys/bus/w1/devices/28-000005e2fdc3/w1_slave",
+	})
+	sysfs.SetFilesystem(fs)
+	fs.Files["/sys/bus/w1/devices/w1_bus_master1/w1_master_slaves"].Contents = "28-000005e2fdc3\n"
+	fs.Files["/sys/bus/w1/devices/28-000005e2fdc3/w1_slave"].Contents = "72 01 4b 46 7f ff 0e 10 57 : crc=57 YES\n"
+
+	ids, err := a.OneWireDevices()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, ids, []string{"28-000005e2fdc3"})
+
+	data, err := a.OneWireRead("28-000005e2fdc3")
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, string(data), "72 01 4b 46 7f ff 0e 10 57 : crc=57 YES\n")
+}
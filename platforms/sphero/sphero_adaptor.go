@@ -4,7 +4,7 @@ import (
 	"io"
 
 	"github.com/hybridgroup/gobot"
-	"github.com/tarm/goserial"
+	"github.com/hybridgroup/gobot/serial"
 )
 
 var _ gobot.Adaptor = (*SpheroAdaptor)(nil)
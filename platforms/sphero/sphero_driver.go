@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/serial"
 )
 
 var _ gobot.Driver = (*SpheroDriver)(nil)
@@ -17,6 +18,18 @@ const (
 	Error      = "error"
 )
 
+// responseFramer reads the responses and asynchronous messages sent by the
+// Sphero, which start with 0xFF 0xFF or 0xFF 0xFE respectively. The length byte
+// follows 3 header bytes and counts the checksum, which covers everything after
+// the first 2 bytes.
+var responseFramer = &serial.LengthPrefixed{
+	Sync:                   []byte{0xFF},
+	Offset:                 3,
+	Checksum:               serial.SumComplement,
+	ChecksumOffset:         2,
+	LengthIncludesChecksum: true,
+}
+
 type packet struct {
 	header   []uint8
 	body     []uint8
@@ -173,20 +186,16 @@ func (s *SpheroDriver) Start() (errs []error) {
 
 	go func() {
 		for {
-			header := s.readHeader()
-			if header != nil && len(header) != 0 {
-				body := s.readBody(header[4])
-				data := append(header, body...)
-				checksum := data[len(data)-1]
-				if checksum != calculateChecksum(data[2:len(data)-1]) {
-					continue
-				}
-				switch header[1] {
-				case 0xFE:
-					s.asyncResponse = append(s.asyncResponse, data)
-				case 0xFF:
-					s.responseChannel <- data
-				}
+			data, err := responseFramer.ReadFrame(s.adaptor().sp)
+			if err != nil {
+				time.Sleep(1 * time.Millisecond)
+				continue
+			}
+			switch data[1] {
+			case 0xFE:
+				s.asyncResponse = append(s.asyncResponse, data)
+			case 0xFF:
+				s.responseChannel <- data
 			}
 		}
 	}()
@@ -375,32 +384,5 @@ func (s *SpheroDriver) calculateChecksum(packet *packet) uint8 {
 }
 
 func calculateChecksum(buf []byte) byte {
-	var calculatedChecksum uint16
-	for i := range buf {
-		calculatedChecksum += uint16(buf[i])
-	}
-	return uint8(^(calculatedChecksum % 256))
-}
-
-func (s *SpheroDriver) readHeader() []uint8 {
-	return s.readNextChunk(5)
-}
-
-func (s *SpheroDriver) readBody(length uint8) []uint8 {
-	return s.readNextChunk(int(length))
-}
-
-func (s *SpheroDriver) readNextChunk(length int) []uint8 {
-	read := make([]uint8, length)
-	bytesRead := 0
-
-	for bytesRead < length {
-		time.Sleep(1 * time.Millisecond)
-		n, err := s.adaptor().sp.Read(read[bytesRead:])
-		if err != nil {
-			return nil
-		}
-		bytesRead += n
-	}
-	return read
+	return serial.SumComplement(buf)
 }
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"encoding/binary"
 	"testing"
+	"time"
 
 	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/serial"
 )
 
 func initTestSpheroDriver() *SpheroDriver {
@@ -76,6 +78,36 @@ func TestSpheroDriverStart(t *testing.T) {
 	gobot.Assert(t, len(d.Start()), 0)
 }
 
+func TestSpheroDriverCollision(t *testing.T) {
+	sem := make(chan CollisionPacket)
+	sp, sphero := serial.NewPipe()
+	d := initTestSpheroDriver()
+	d.adaptor().sp = sp
+
+	gobot.Once(d.Event(Collision), func(data interface{}) {
+		sem <- data.(CollisionPacket)
+	})
+	gobot.Assert(t, len(d.Start()), 0)
+
+	collision := CollisionPacket{X: 1, Y: -2, Z: 3, Axis: 1, Speed: 100}
+	buf := bytes.NewBuffer([]byte{0xFE, 0x07, 0x00})
+	binary.Write(buf, binary.BigEndian, collision)
+	frame, _ := responseFramer.Frame(buf.Bytes())
+
+	// a corrupted message is dropped
+	bad := append([]byte{}, frame...)
+	bad[len(bad)-1]++
+	sphero.Write(bad)
+	sphero.Write(frame)
+
+	select {
+	case data := <-sem:
+		gobot.Assert(t, data, collision)
+	case <-time.After(500 * time.Millisecond):
+		t.Errorf("Collision event was not published")
+	}
+}
+
 func TestSpheroDriverHalt(t *testing.T) {
 	d := initTestSpheroDriver()
 	d.adaptor().connected = true
@@ -0,0 +1,91 @@
+package spi
+
+import (
+	"image/color"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+)
+
+var _ gobot.Driver = (*APA102Driver)(nil)
+
+var _ gpio.Pixels = (*APA102Driver)(nil)
+
+const apa102Speed = 500000
+
+// APA102Driver represents a strip of APA102 (DotStar) addressable RGB LEDs.
+// The alpha of each color is used as the LED's global brightness.
+type APA102Driver struct {
+	name       string
+	bus        int
+	chip       int
+	connection SpiTransferer
+	gobot.Eventer
+	gobot.Commander
+	*gpio.PixelBuffer
+}
+
+// NewAPA102Driver returns a new APA102Driver given a SpiTransferer, name,
+// spi bus, chip select and the number of LEDs on the strip.
+//
+// Adds the following API Commands:
+// 	"SetRGBA" - See gpio.PixelBuffer.SetRGBA
+// 	"SetHex" - See gpio.PixelBuffer.SetHex
+// 	"Fill" - See gpio.PixelBuffer.Fill, the color is given as "hex"
+// 	"Draw" - See gpio.PixelBuffer.Draw
+// 	"Clear" - See gpio.PixelBuffer.Clear
+// 	"Animate" - See gpio.LedAnimator.AnimateEffect
+// 	"StopAnimation" - See gpio.LedAnimator.StopAnimation
+//
+// Emits the Events:
+// 	"finished" - the name of an effect which has finished
+// 	"error" - an error writing a frame of an animation
+func NewAPA102Driver(a SpiTransferer, name string, bus int, chip int, count int) *APA102Driver {
+	d := &APA102Driver{
+		name:       name,
+		bus:        bus,
+		chip:       chip,
+		connection: a,
+		Eventer:    gobot.NewEventer(),
+		Commander:  gobot.NewCommander(),
+	}
+	d.PixelBuffer = gpio.NewPixelBuffer(count, d.write, d.Eventer, d.Commander)
+
+	return d
+}
+
+// Name returns the APA102Drivers name
+func (d *APA102Driver) Name() string { return d.name }
+
+// Connection returns the APA102Drivers Connection
+func (d *APA102Driver) Connection() gobot.Connection { return d.connection.(gobot.Connection) }
+
+// Start opens the spi device in mode 0
+func (d *APA102Driver) Start() (errs []error) {
+	if err := d.connection.SpiStart(d.bus, d.chip, Mode0, 8, apa102Speed); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Halt stops any running animation
+func (d *APA102Driver) Halt() (errs []error) {
+	d.StopAnimation()
+	return
+}
+
+// write writes colors to the strip
+func (d *APA102Driver) write(colors []color.RGBA) (err error) {
+	// start frame, one frame per LED, then enough clock pulses for the
+	// data to propagate to the end of the strip
+	tx := make([]byte, 4, 4+4*len(colors)+len(colors)/16+1)
+	for _, c := range colors {
+		tx = append(tx, 0xE0|(c.A>>3), c.B, c.G, c.R)
+	}
+	for i := 0; i < len(colors)/16+1; i++ {
+		tx = append(tx, 0xFF)
+	}
+
+	_, err = d.connection.SpiTransfer(d.bus, d.chip, tx)
+	return
+}
@@ -0,0 +1,103 @@
+package spi
+
+import (
+	"errors"
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+)
+
+func initTestAPA102DriverWithStubbedAdaptor() (*APA102Driver, *spiTestAdaptor) {
+	adaptor := newSpiTestAdaptor("adaptor")
+	return NewAPA102Driver(adaptor, "bot", 0, 0, 2), adaptor
+}
+
+func TestAPA102Driver(t *testing.T) {
+	d, _ := initTestAPA102DriverWithStubbedAdaptor()
+	gobot.Assert(t, d.Name(), "bot")
+	gobot.Assert(t, d.Connection().Name(), "adaptor")
+	gobot.Assert(t, d.Len(), 2)
+}
+
+func TestAPA102DriverStart(t *testing.T) {
+	d, adaptor := initTestAPA102DriverWithStubbedAdaptor()
+	gobot.Assert(t, len(d.Start()), 0)
+	gobot.Assert(t, adaptor.device.Speed, uint32(apa102Speed))
+
+	adaptor.spiStartImpl = func() error {
+		return errors.New("start error")
+	}
+	gobot.Assert(t, d.Start()[0], errors.New("start error"))
+}
+
+func TestAPA102DriverHalt(t *testing.T) {
+	d, _ := initTestAPA102DriverWithStubbedAdaptor()
+	gobot.Assert(t, len(d.Halt()), 0)
+}
+
+func TestAPA102DriverDraw(t *testing.T) {
+	d, adaptor := initTestAPA102DriverWithStubbedAdaptor()
+	d.SetRGBA(0, color.RGBA{R: 1, G: 2, B: 3, A: 255})
+	d.SetRGBA(1, color.RGBA{R: 4, G: 5, B: 6, A: 8})
+	d.SetRGBA(2, color.RGBA{R: 7, G: 8, B: 9, A: 255})
+
+	gobot.Assert(t, d.Draw(), nil)
+	gobot.Assert(t, adaptor.device.Tx[0], []byte{
+		0x00, 0x00, 0x00, 0x00,
+		0xFF, 0x03, 0x02, 0x01,
+		0xE1, 0x06, 0x05, 0x04,
+		0xFF,
+	})
+
+	gobot.Assert(t, d.Clear(), nil)
+	gobot.Assert(t, adaptor.device.Tx[1], []byte{
+		0x00, 0x00, 0x00, 0x00,
+		0xE0, 0x00, 0x00, 0x00,
+		0xE0, 0x00, 0x00, 0x00,
+		0xFF,
+	})
+}
+
+func TestAPA102DriverCommands(t *testing.T) {
+	d, adaptor := initTestAPA102DriverWithStubbedAdaptor()
+	d.Command("SetRGBA")(map[string]interface{}{
+		"index": 1.0,
+		"red":   10.0,
+		"green": 20.0,
+		"blue":  30.0,
+		"alpha": 255.0,
+	})
+	gobot.Assert(t, d.Command("Draw")(nil), nil)
+	gobot.Assert(t, adaptor.device.Tx[0][8:12], []byte{0xFF, 30, 20, 10})
+	gobot.Assert(t, d.Command("Clear")(nil), nil)
+}
+
+func TestAPA102DriverAnimate(t *testing.T) {
+	d, adaptor := initTestAPA102DriverWithStubbedAdaptor()
+	d.Gamma = 2
+	d.Interval = time.Millisecond
+	finished := make(chan bool)
+	gobot.Once(d.Event("finished"), func(data interface{}) {
+		finished <- true
+	})
+	gobot.Assert(t, d.Command("Animate")(map[string]interface{}{
+		"effect":   "fade",
+		"from":     "#808080",
+		"to":       "#808080",
+		"duration": 1.0,
+	}), nil)
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Errorf("finished was not published")
+	}
+	gobot.Assert(t, d.Animating(), false)
+	gobot.Assert(t, adaptor.device.Tx[0][4:8], []byte{0xFF, 0x40, 0x40, 0x40})
+
+	d.Animate(gpio.RainbowEffect(time.Second))
+	gobot.Assert(t, len(d.Halt()), 0)
+	gobot.Assert(t, d.Animating(), false)
+}
@@ -0,0 +1,35 @@
+package spi
+
+import "github.com/hybridgroup/gobot/sysfs"
+
+type spiTestAdaptor struct {
+	name         string
+	device       *sysfs.MockSpiDevice
+	spiStartImpl func() error
+}
+
+func (t *spiTestAdaptor) SpiStart(bus int, chip int, mode int, bits int, maxSpeed int) (err error) {
+	if err = t.spiStartImpl(); err != nil {
+		return
+	}
+	t.device.SetMode(byte(mode))
+	t.device.SetBitsPerWord(byte(bits))
+	t.device.SetSpeed(uint32(maxSpeed))
+	return
+}
+func (t *spiTestAdaptor) SpiTransfer(bus int, chip int, tx []byte) (rx []byte, err error) {
+	return t.device.Transfer(tx)
+}
+func (t *spiTestAdaptor) Name() string             { return t.name }
+func (t *spiTestAdaptor) Connect() (errs []error)  { return }
+func (t *spiTestAdaptor) Finalize() (errs []error) { return }
+
+func newSpiTestAdaptor(name string) *spiTestAdaptor {
+	return &spiTestAdaptor{
+		name:   name,
+		device: sysfs.NewMockSpiDevice(),
+		spiStartImpl: func() error {
+			return nil
+		},
+	}
+}
@@ -0,0 +1,104 @@
+package spi
+
+import (
+	"strconv"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+)
+
+var _ gobot.Driver = (*MCP3008Driver)(nil)
+var _ gpio.AnalogReader = (*MCP3008Driver)(nil)
+
+const mcp3008Speed = 1350000
+
+// MCP3008Driver represents a MCP3008 8 channel 10-bit analog to digital converter.
+// It implements gpio.AnalogReader, so analog drivers such as AnalogSensorDriver
+// can use its channels "0" to "7" as pins.
+type MCP3008Driver struct {
+	name       string
+	bus        int
+	chip       int
+	connection SpiTransferer
+	gobot.Commander
+}
+
+// NewMCP3008Driver returns a new MCP3008Driver given a SpiTransferer, name,
+// spi bus and chip select.
+//
+// Adds the following API Commands:
+// 	"Read" - See MCP3008Driver.Read
+func NewMCP3008Driver(a SpiTransferer, name string, bus int, chip int) *MCP3008Driver {
+	m := &MCP3008Driver{
+		name:       name,
+		bus:        bus,
+		chip:       chip,
+		connection: a,
+		Commander:  gobot.NewCommander(),
+	}
+
+	m.AddCommand("Read", func(params map[string]interface{}) interface{} {
+		channel := int(params["channel"].(float64))
+		val, err := m.Read(channel)
+		return map[string]interface{}{"val": val, "err": err}
+	})
+
+	return m
+}
+
+// Name returns the MCP3008Drivers name
+func (m *MCP3008Driver) Name() string { return m.name }
+
+// Connection returns the MCP3008Drivers Connection
+func (m *MCP3008Driver) Connection() gobot.Connection { return m.connection.(gobot.Connection) }
+
+// Start opens the spi device in mode 0
+func (m *MCP3008Driver) Start() (errs []error) {
+	if err := m.connection.SpiStart(m.bus, m.chip, Mode0, 8, mcp3008Speed); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Halt implements the Driver interface
+func (m *MCP3008Driver) Halt() (errs []error) { return }
+
+// Connect implements the Adaptor interface so the MCP3008Driver can be used as
+// a connection by analog drivers
+func (m *MCP3008Driver) Connect() (errs []error) { return }
+
+// Finalize implements the Adaptor interface
+func (m *MCP3008Driver) Finalize() (errs []error) { return }
+
+// Read returns the 0-1023 single-ended reading of channel 0-7
+func (m *MCP3008Driver) Read(channel int) (val int, err error) {
+	if channel < 0 || channel > 7 {
+		err = ErrInvalidChannel
+		return
+	}
+
+	rx, err := m.connection.SpiTransfer(m.bus, m.chip, []byte{
+		0x01,
+		byte(0x08|channel) << 4,
+		0x00,
+	})
+	if err != nil {
+		return
+	}
+	if len(rx) != 3 {
+		err = ErrNotEnoughBytes
+		return
+	}
+
+	val = (int(rx[1])&0x03)<<8 | int(rx[2])
+	return
+}
+
+// AnalogRead returns the reading of the channel named by pin, "0" to "7"
+func (m *MCP3008Driver) AnalogRead(pin string) (val int, err error) {
+	channel, err := strconv.Atoi(pin)
+	if err != nil {
+		return
+	}
+	return m.Read(channel)
+}
@@ -0,0 +1,78 @@
+package spi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+)
+
+func initTestMCP3008DriverWithStubbedAdaptor() (*MCP3008Driver, *spiTestAdaptor) {
+	adaptor := newSpiTestAdaptor("adaptor")
+	return NewMCP3008Driver(adaptor, "bot", 0, 0), adaptor
+}
+
+func TestMCP3008Driver(t *testing.T) {
+	d, _ := initTestMCP3008DriverWithStubbedAdaptor()
+	gobot.Assert(t, d.Name(), "bot")
+	gobot.Assert(t, d.Connection().Name(), "adaptor")
+	gobot.Assert(t, len(d.Connect()), 0)
+	gobot.Assert(t, len(d.Finalize()), 0)
+}
+
+func TestMCP3008DriverStart(t *testing.T) {
+	d, adaptor := initTestMCP3008DriverWithStubbedAdaptor()
+	gobot.Assert(t, len(d.Start()), 0)
+	gobot.Assert(t, adaptor.device.Mode, byte(Mode0))
+	gobot.Assert(t, adaptor.device.Speed, uint32(mcp3008Speed))
+
+	adaptor.spiStartImpl = func() error {
+		return errors.New("start error")
+	}
+	gobot.Assert(t, d.Start()[0], errors.New("start error"))
+}
+
+func TestMCP3008DriverHalt(t *testing.T) {
+	d, _ := initTestMCP3008DriverWithStubbedAdaptor()
+	gobot.Assert(t, len(d.Halt()), 0)
+}
+
+func TestMCP3008DriverRead(t *testing.T) {
+	d, adaptor := initTestMCP3008DriverWithStubbedAdaptor()
+	adaptor.device.Respond = func(tx []byte) []byte {
+		return []byte{0x00, 0xFE, 0x34}
+	}
+
+	val, err := d.Read(5)
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, val, 0x234)
+	gobot.Assert(t, adaptor.device.Tx[0], []byte{0x01, 0xD0, 0x00})
+
+	_, err = d.Read(8)
+	gobot.Assert(t, err, ErrInvalidChannel)
+
+	val, err = d.AnalogRead("5")
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, val, 0x234)
+
+	_, err = d.AnalogRead("A5")
+	gobot.Refute(t, err, nil)
+
+	ret := d.Command("Read")(map[string]interface{}{"channel": 0.0}).(map[string]interface{})
+	gobot.Assert(t, ret["val"].(int), 0x234)
+	gobot.Assert(t, ret["err"], nil)
+}
+
+func TestMCP3008DriverAnalogSensor(t *testing.T) {
+	d, adaptor := initTestMCP3008DriverWithStubbedAdaptor()
+	adaptor.device.Respond = func(tx []byte) []byte {
+		return []byte{0x00, 0x01, 0x00}
+	}
+
+	sensor := gpio.NewAnalogSensorDriver(d, "sensor", "3")
+	val, err := sensor.Read()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, val, 256)
+	gobot.Assert(t, sensor.Connection().Name(), "bot")
+}
@@ -0,0 +1,43 @@
+package spi
+
+import (
+	"errors"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var (
+	// ErrNotStarted is the error resulting when a transfer is attempted on a
+	// bus and chip select which has not been started with SpiStart
+	ErrNotStarted = errors.New("SPI device has not been started")
+	// ErrNotEnoughBytes is the error resulting when a transfer returns fewer
+	// bytes than were written
+	ErrNotEnoughBytes = errors.New("Not enough bytes read")
+	// ErrInvalidChannel is the error resulting when a driver is asked to read
+	// a channel the device does not have
+	ErrInvalidChannel = errors.New("Invalid channel")
+)
+
+const (
+	// Mode0 samples on the rising edge with the clock idle low
+	Mode0 = 0x00
+	// Mode1 samples on the falling edge with the clock idle low
+	Mode1 = 0x01
+	// Mode2 samples on the falling edge with the clock idle high
+	Mode2 = 0x02
+	// Mode3 samples on the rising edge with the clock idle high
+	Mode3 = 0x03
+)
+
+// SpiStarter interface represents an Adaptor which can open a spi device
+// given its bus, chip select, mode, bits per word and maximum speed in Hz
+type SpiStarter interface {
+	SpiStart(bus int, chip int, mode int, bits int, maxSpeed int) (err error)
+}
+
+// SpiTransferer interface represents an Adaptor which has full-duplex spi capabilities
+type SpiTransferer interface {
+	gobot.Adaptor
+	SpiStarter
+	SpiTransfer(bus int, chip int, tx []byte) (rx []byte, err error)
+}
@@ -0,0 +1,127 @@
+package spi
+
+import (
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+)
+
+var _ gobot.Driver = (*SSD1306Driver)(nil)
+
+const ssd1306Speed = 8000000
+
+// SSD1306Driver represents a monochrome OLED display with an SSD1306
+// controller wired to spi, and to a pin selecting whether it is sent
+// commands or data. The embedded SSD1306 is a draw.Image, so text, shapes
+// and images can be drawn on it before they are written by Flush.
+type SSD1306Driver struct {
+	name       string
+	bus        int
+	chip       int
+	connection SpiTransferer
+	dc         gpio.DigitalWriter
+	dcPin      string
+	// ResetPin is the pin of the DigitalWriter wired to the RES input of
+	// the display, if it is not wired to the reset of the board. It is
+	// pulsed low by Start.
+	ResetPin string
+	*gpio.SSD1306
+	gobot.Commander
+}
+
+// NewSSD1306Driver returns a new SSD1306Driver given a SpiTransferer, name,
+// spi bus and chip select, the DigitalWriter and pin wired to the D/C input
+// of the display, and its width and height in pixels, such as 128x64 or
+// 128x32.
+//
+// Adds the following API Commands:
+// 	"Clear" - See SSD1306.Clear
+// 	"Flush" - See SSD1306.Flush
+// 	"DrawText" - See SSD1306.DrawText, given "x", "y" and "text"
+// 	"SetContrast" - See SSD1306.SetContrast, given "contrast"
+func NewSSD1306Driver(a SpiTransferer, name string, bus int, chip int, dc gpio.DigitalWriter, dcPin string, width int, height int) *SSD1306Driver {
+	s := &SSD1306Driver{
+		name:       name,
+		bus:        bus,
+		chip:       chip,
+		connection: a,
+		dc:         dc,
+		dcPin:      dcPin,
+		Commander:  gobot.NewCommander(),
+	}
+	s.SSD1306 = gpio.NewSSD1306(&ssd1306SpiBus{s}, width, height)
+	s.SSD1306.AddCommands(s)
+
+	return s
+}
+
+// Name returns the SSD1306Drivers name
+func (s *SSD1306Driver) Name() string { return s.name }
+
+// Connection returns the SSD1306Drivers connection
+func (s *SSD1306Driver) Connection() gobot.Connection { return s.connection.(gobot.Connection) }
+
+// Start opens the spi device in mode 0, resets the display if it has a
+// ResetPin, sets it up and writes the framebuffer to it
+func (s *SSD1306Driver) Start() (errs []error) {
+	if err := s.connection.SpiStart(s.bus, s.chip, Mode0, 8, ssd1306Speed); err != nil {
+		return []error{err}
+	}
+	if s.ResetPin != "" {
+		if err := s.reset(); err != nil {
+			return []error{err}
+		}
+	}
+	if err := s.Init(); err != nil {
+		return []error{err}
+	}
+	if err := s.Flush(); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Halt turns the display off
+func (s *SSD1306Driver) Halt() (errs []error) {
+	if err := s.Display(false); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// reset pulses ResetPin low
+func (s *SSD1306Driver) reset() (err error) {
+	for _, level := range []byte{1, 0, 1} {
+		if err = s.dc.DigitalWrite(s.ResetPin, level); err != nil {
+			return
+		}
+		<-time.After(time.Millisecond)
+	}
+	return
+}
+
+// ssd1306SpiBus writes to the SSD1306 of an SSD1306Driver, selecting
+// commands or data with its D/C pin
+type ssd1306SpiBus struct {
+	driver *SSD1306Driver
+}
+
+// Command writes commands and their arguments
+func (b *ssd1306SpiBus) Command(c ...byte) error {
+	return b.write(0, c)
+}
+
+// Data writes bytes to the display RAM
+func (b *ssd1306SpiBus) Data(d []byte) error {
+	return b.write(1, d)
+}
+
+// write sets the D/C pin to dc and transfers buf
+func (b *ssd1306SpiBus) write(dc byte, buf []byte) (err error) {
+	if err = b.driver.dc.DigitalWrite(b.driver.dcPin, dc); err != nil {
+		return
+	}
+	_, err = b.driver.connection.SpiTransfer(b.driver.bus, b.driver.chip, buf)
+	return
+}
@@ -0,0 +1,86 @@
+package spi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// ssd1306TestPins records the levels written to each pin
+type ssd1306TestPins struct {
+	writes   map[string][]byte
+	writeErr error
+}
+
+func (t *ssd1306TestPins) DigitalWrite(pin string, level byte) (err error) {
+	t.writes[pin] = append(t.writes[pin], level)
+	return t.writeErr
+}
+func (t *ssd1306TestPins) Name() string             { return "pins" }
+func (t *ssd1306TestPins) Connect() (errs []error)  { return }
+func (t *ssd1306TestPins) Finalize() (errs []error) { return }
+
+func initTestSSD1306Driver() (*SSD1306Driver, *spiTestAdaptor, *ssd1306TestPins) {
+	adaptor := newSpiTestAdaptor("adaptor")
+	pins := &ssd1306TestPins{writes: make(map[string][]byte)}
+	return NewSSD1306Driver(adaptor, "bot", 0, 1, pins, "dc", 128, 64), adaptor, pins
+}
+
+func TestSSD1306Driver(t *testing.T) {
+	s, _, _ := initTestSSD1306Driver()
+	gobot.Assert(t, s.Name(), "bot")
+	gobot.Assert(t, s.Connection().Name(), "adaptor")
+	gobot.Refute(t, s.Command("Clear"), nil)
+	gobot.Refute(t, s.Command("Flush"), nil)
+	gobot.Refute(t, s.Command("DrawText"), nil)
+	gobot.Refute(t, s.Command("SetContrast"), nil)
+}
+
+func TestSSD1306DriverStart(t *testing.T) {
+	s, adaptor, pins := initTestSSD1306Driver()
+	s.ResetPin = "rst"
+	gobot.Assert(t, len(s.Start()), 0)
+	gobot.Assert(t, adaptor.device.Mode, byte(Mode0))
+	gobot.Assert(t, adaptor.device.Speed, uint32(ssd1306Speed))
+	gobot.Assert(t, pins.writes["rst"], []byte{1, 0, 1})
+	// commands, then the framebuffer with its address, then its data
+	gobot.Assert(t, pins.writes["dc"], []byte{0, 0, 1})
+	gobot.Assert(t, adaptor.device.Tx[0], []byte{
+		0xAE, 0xD5, 0x80, 0xA8, 63, 0xD3, 0x00, 0x40, 0x8D, 0x14, 0x20, 0x00,
+		0xA1, 0xC8, 0xDA, 0x12, 0x81, 0xCF, 0xD9, 0xF1, 0xDB, 0x40, 0xA4,
+		0xA6, 0xAF,
+	})
+	gobot.Assert(t, adaptor.device.Tx[1], []byte{0x21, 0, 127, 0x22, 0, 7})
+	gobot.Assert(t, adaptor.device.Tx[2], make([]byte, 1024))
+
+	pins.writeErr = errors.New("write error")
+	gobot.Assert(t, s.Start()[0], errors.New("write error"))
+	s.ResetPin = ""
+	gobot.Assert(t, s.Start()[0], errors.New("write error"))
+
+	adaptor.spiStartImpl = func() error {
+		return errors.New("start error")
+	}
+	gobot.Assert(t, s.Start()[0], errors.New("start error"))
+}
+
+func TestSSD1306DriverHalt(t *testing.T) {
+	s, adaptor, pins := initTestSSD1306Driver()
+	gobot.Assert(t, len(s.Halt()), 0)
+	gobot.Assert(t, pins.writes["dc"], []byte{0})
+	gobot.Assert(t, adaptor.device.Tx, [][]byte{{0xAE}})
+
+	pins.writeErr = errors.New("write error")
+	gobot.Assert(t, s.Halt()[0], errors.New("write error"))
+}
+
+func TestSSD1306DriverFlush(t *testing.T) {
+	s, adaptor, _ := initTestSSD1306Driver()
+	s.Start()
+	adaptor.device.Tx = nil
+
+	s.SetPixel(127, 63, true)
+	gobot.Assert(t, s.Flush(), nil)
+	gobot.Assert(t, adaptor.device.Tx, [][]byte{{0x21, 127, 127, 0x22, 7, 7}, {0x80}})
+}
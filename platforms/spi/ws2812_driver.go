@@ -0,0 +1,112 @@
+package spi
+
+import (
+	"image/color"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+)
+
+var _ gobot.Driver = (*WS2812Driver)(nil)
+
+var _ gpio.Pixels = (*WS2812Driver)(nil)
+
+const (
+	// at 2.4MHz every 3 spi bits take 1.25us, the length of a WS2812 bit
+	ws2812Speed = 2400000
+	// holding the line low for 280us latches the colors, long enough for
+	// the newer WS2812B revisions
+	ws2812ResetBytes = 84
+)
+
+// WS2812Driver represents a strip of WS2812 (NeoPixel) addressable RGB LEDs
+// with their data line on the MOSI pin of an spi bus. Each bit of the
+// colors is sent as 3 spi bits, 110 for a 1 and 100 for a 0, which match
+// the pulses the LEDs expect. The alpha of the colors is ignored.
+type WS2812Driver struct {
+	name       string
+	bus        int
+	chip       int
+	connection SpiTransferer
+	gobot.Eventer
+	gobot.Commander
+	*gpio.PixelBuffer
+}
+
+// NewWS2812Driver returns a new WS2812Driver given a SpiTransferer, name,
+// spi bus, chip select and the number of LEDs on the strip.
+//
+// Adds the following API Commands:
+// 	"SetRGBA" - See gpio.PixelBuffer.SetRGBA
+// 	"SetHex" - See gpio.PixelBuffer.SetHex
+// 	"Fill" - See gpio.PixelBuffer.Fill, the color is given as "hex"
+// 	"Draw" - See gpio.PixelBuffer.Draw
+// 	"Clear" - See gpio.PixelBuffer.Clear
+// 	"Animate" - See gpio.LedAnimator.AnimateEffect
+// 	"StopAnimation" - See gpio.LedAnimator.StopAnimation
+//
+// Emits the Events:
+// 	"finished" - the name of an effect which has finished
+// 	"error" - an error writing a frame of an animation
+func NewWS2812Driver(a SpiTransferer, name string, bus int, chip int, count int) *WS2812Driver {
+	d := &WS2812Driver{
+		name:       name,
+		bus:        bus,
+		chip:       chip,
+		connection: a,
+		Eventer:    gobot.NewEventer(),
+		Commander:  gobot.NewCommander(),
+	}
+	d.PixelBuffer = gpio.NewPixelBuffer(count, d.write, d.Eventer, d.Commander)
+
+	return d
+}
+
+// Name returns the WS2812Drivers name
+func (d *WS2812Driver) Name() string { return d.name }
+
+// Connection returns the WS2812Drivers Connection
+func (d *WS2812Driver) Connection() gobot.Connection { return d.connection.(gobot.Connection) }
+
+// Start opens the spi device in mode 0
+func (d *WS2812Driver) Start() (errs []error) {
+	if err := d.connection.SpiStart(d.bus, d.chip, Mode0, 8, ws2812Speed); err != nil {
+		return []error{err}
+	}
+	return
+}
+
+// Halt stops any running animation
+func (d *WS2812Driver) Halt() (errs []error) {
+	d.StopAnimation()
+	return
+}
+
+// write writes colors to the strip
+func (d *WS2812Driver) write(colors []color.RGBA) (err error) {
+	tx := make([]byte, 0, 9*len(colors)+ws2812ResetBytes)
+	for _, c := range colors {
+		// the LEDs take green first
+		for _, v := range []byte{c.G, c.R, c.B} {
+			tx = append(tx, ws2812Encode(v)...)
+		}
+	}
+	tx = append(tx, make([]byte, ws2812ResetBytes)...)
+
+	_, err = d.connection.SpiTransfer(d.bus, d.chip, tx)
+	return
+}
+
+// ws2812Encode returns the 3 spi bytes which send the bits of v
+func ws2812Encode(v byte) []byte {
+	var bits uint32
+	for i := 7; i >= 0; i-- {
+		bits <<= 3
+		if v&(1<<uint(i)) != 0 {
+			bits |= 6
+		} else {
+			bits |= 4
+		}
+	}
+	return []byte{byte(bits >> 16), byte(bits >> 8), byte(bits)}
+}
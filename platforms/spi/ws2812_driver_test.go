@@ -0,0 +1,82 @@
+package spi
+
+import (
+	"errors"
+	"image/color"
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func initTestWS2812DriverWithStubbedAdaptor() (*WS2812Driver, *spiTestAdaptor) {
+	adaptor := newSpiTestAdaptor("adaptor")
+	return NewWS2812Driver(adaptor, "bot", 0, 0, 2), adaptor
+}
+
+func TestWS2812Driver(t *testing.T) {
+	d, _ := initTestWS2812DriverWithStubbedAdaptor()
+	gobot.Assert(t, d.Name(), "bot")
+	gobot.Assert(t, d.Connection().Name(), "adaptor")
+	gobot.Assert(t, d.Len(), 2)
+}
+
+func TestWS2812DriverStart(t *testing.T) {
+	d, adaptor := initTestWS2812DriverWithStubbedAdaptor()
+	gobot.Assert(t, len(d.Start()), 0)
+	gobot.Assert(t, adaptor.device.Speed, uint32(ws2812Speed))
+
+	adaptor.spiStartImpl = func() error {
+		return errors.New("start error")
+	}
+	gobot.Assert(t, d.Start()[0], errors.New("start error"))
+}
+
+func TestWS2812DriverHalt(t *testing.T) {
+	d, _ := initTestWS2812DriverWithStubbedAdaptor()
+	gobot.Assert(t, len(d.Halt()), 0)
+}
+
+func TestWS2812Encode(t *testing.T) {
+	gobot.Assert(t, ws2812Encode(0x00), []byte{0x92, 0x49, 0x24})
+	gobot.Assert(t, ws2812Encode(0xFF), []byte{0xDB, 0x6D, 0xB6})
+	gobot.Assert(t, ws2812Encode(0x80), []byte{0xD2, 0x49, 0x24})
+}
+
+func TestWS2812DriverDraw(t *testing.T) {
+	d, adaptor := initTestWS2812DriverWithStubbedAdaptor()
+	d.SetRGBA(0, color.RGBA{R: 0xFF, G: 0x80, B: 0x00, A: 255})
+	d.SetRGBA(2, color.RGBA{R: 0xFF, A: 255})
+
+	gobot.Assert(t, d.Draw(), nil)
+	tx := adaptor.device.Tx[0]
+	gobot.Assert(t, len(tx), 18+ws2812ResetBytes)
+	gobot.Assert(t, tx[:9], []byte{
+		0xD2, 0x49, 0x24,
+		0xDB, 0x6D, 0xB6,
+		0x92, 0x49, 0x24,
+	})
+	gobot.Assert(t, tx[18:], make([]byte, ws2812ResetBytes))
+
+	d.Gamma = 2
+	gobot.Assert(t, d.Draw(), nil)
+	gobot.Assert(t, adaptor.device.Tx[1][:3], ws2812Encode(0x40))
+
+	gobot.Assert(t, d.Clear(), nil)
+	gobot.Assert(t, adaptor.device.Tx[2][:3], ws2812Encode(0))
+}
+
+func TestWS2812DriverCommands(t *testing.T) {
+	d, adaptor := initTestWS2812DriverWithStubbedAdaptor()
+	d.Command("SetRGBA")(map[string]interface{}{
+		"index": 1.0,
+		"red":   0.0,
+		"green": 0.0,
+		"blue":  255.0,
+	})
+	gobot.Assert(t, d.Command("Draw")(nil), nil)
+	gobot.Assert(t, adaptor.device.Tx[0][15:18], ws2812Encode(255))
+	gobot.Assert(t, d.Command("Clear")(nil), nil)
+	gobot.Assert(t, d.Command("Animate")(map[string]interface{}{"effect": "rainbow"}), nil)
+	gobot.Assert(t, d.Animating(), true)
+	gobot.Assert(t, d.Command("StopAnimation")(nil), nil)
+}
@@ -0,0 +1,12 @@
+/*
+Package serial provides generic access to serial ports (UARTs) for adaptors
+that talk to their devices over a serial connection.
+
+It provides a Config describing the port settings (baud rate, data bits, parity,
+stop bits and read timeout), an in-memory Pipe for testing adaptors and drivers
+without hardware, and Framers which split a serial byte stream into frames.
+
+It is intended to be used while implementing support for serial devices such as
+the Sphero, Neurosky MindWave, MAVLink flight controllers or Firmata boards.
+*/
+package serial
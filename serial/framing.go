@@ -0,0 +1,240 @@
+package serial
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+var (
+	// ErrChecksum is the error returned by ReadFrame when a frame fails its checksum
+	ErrChecksum = errors.New("serial: checksum mismatch")
+	// ErrFrameTooLong is the error returned by ReadFrame when a frame exceeds its maximum length
+	ErrFrameTooLong = errors.New("serial: frame too long")
+	// ErrPayloadTooLong is the error returned by Frame when a payload does not fit in a frame
+	ErrPayloadTooLong = errors.New("serial: payload too long")
+)
+
+// DefaultMaxLength is the maximum length of a Delimited frame unless set otherwise
+const DefaultMaxLength = 1024
+
+// Framer splits a serial byte stream into frames
+type Framer interface {
+	// Frame returns the frame which carries payload
+	Frame(payload []byte) ([]byte, error)
+	// ReadFrame reads from r until it has read a complete frame and returns it,
+	// skipping any bytes in front of it which do not start a frame. A frame
+	// which fails its checksum is consumed and ErrChecksum returned.
+	ReadFrame(r io.Reader) ([]byte, error)
+}
+
+// Checksum computes the checksum byte of b
+type Checksum func(b []byte) byte
+
+// Sum returns the sum of b modulo 256
+func Sum(b []byte) byte {
+	var sum byte
+	for _, v := range b {
+		sum += v
+	}
+	return sum
+}
+
+// SumComplement returns the one's complement of the sum of b modulo 256,
+// as used by the Sphero and Neurosky protocols
+func SumComplement(b []byte) byte {
+	return ^Sum(b)
+}
+
+// XOR returns the exclusive or of every byte of b
+func XOR(b []byte) byte {
+	var x byte
+	for _, v := range b {
+		x ^= v
+	}
+	return x
+}
+
+// LengthPrefixed frames start with the Sync bytes, followed by Offset header
+// bytes, a length byte, the data and an optional checksum byte:
+//
+//	[Sync...][header...][length][data...][checksum]
+//
+// The length byte counts all of the data but the last Extra bytes.
+type LengthPrefixed struct {
+	// Sync holds the bytes which start every frame, eg. []byte{0xAA, 0xAA}
+	Sync []byte
+	// Offset is the number of header bytes between Sync and the length byte
+	Offset int
+	// Checksum computes the trailing checksum byte, there is none if nil
+	Checksum Checksum
+	// ChecksumOffset is the index of the first frame byte covered by the
+	// checksum, which covers every byte up to the checksum itself
+	ChecksumOffset int
+	// LengthIncludesChecksum is true if the length byte counts the checksum
+	// byte as well as the data
+	LengthIncludesChecksum bool
+	// Extra is the number of data bytes the length byte does not count, such
+	// as the header and 2 byte CRC of a MAVLink packet
+	Extra int
+}
+
+// Frame returns the frame which carries payload. The first Offset bytes of
+// payload are the header bytes, the rest is the data.
+func (l *LengthPrefixed) Frame(payload []byte) ([]byte, error) {
+	if len(payload) < l.Offset+l.Extra {
+		return nil, io.ErrShortBuffer
+	}
+
+	length := len(payload) - l.Offset - l.Extra
+	if l.Checksum != nil && l.LengthIncludesChecksum {
+		length++
+	}
+	if length > 0xFF {
+		return nil, ErrPayloadTooLong
+	}
+
+	frame := append([]byte{}, l.Sync...)
+	frame = append(frame, payload[:l.Offset]...)
+	frame = append(frame, byte(length))
+	frame = append(frame, payload[l.Offset:]...)
+	if l.Checksum != nil {
+		frame = append(frame, l.Checksum(frame[l.ChecksumOffset:]))
+	}
+	return frame, nil
+}
+
+// ReadFrame reads the next complete frame from r
+func (l *LengthPrefixed) ReadFrame(r io.Reader) ([]byte, error) {
+	frame, err := readSync(r, l.Sync)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, l.Offset+1)
+	if _, err = io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	frame = append(frame, header...)
+
+	length := int(header[l.Offset]) + l.Extra
+	if l.Checksum != nil && !l.LengthIncludesChecksum {
+		length++
+	}
+	body := make([]byte, length)
+	if _, err = io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	frame = append(frame, body...)
+
+	if l.Checksum != nil {
+		if len(frame)-1 < l.ChecksumOffset ||
+			l.Checksum(frame[l.ChecksumOffset:len(frame)-1]) != frame[len(frame)-1] {
+			return nil, ErrChecksum
+		}
+	}
+	return frame, nil
+}
+
+// Delimited frames start with the Start bytes, if any, and end with the End
+// bytes, with an optional checksum byte in front of End:
+//
+//	[Start...][data...][checksum][End...]
+//
+// The checksum covers the data only.
+type Delimited struct {
+	// Start holds the bytes which start every frame, there are none if empty
+	Start []byte
+	// End holds the bytes which end every frame, eg. []byte("\r\n"). It must not be empty
+	End []byte
+	// Checksum computes the checksum byte, there is none if nil
+	Checksum Checksum
+	// MaxLength is the maximum length of a frame, DefaultMaxLength if zero
+	MaxLength int
+}
+
+// Frame returns the frame which carries payload. The payload must not contain
+// the End bytes.
+func (d *Delimited) Frame(payload []byte) ([]byte, error) {
+	frame := append([]byte{}, d.Start...)
+	frame = append(frame, payload...)
+	if d.Checksum != nil {
+		frame = append(frame, d.Checksum(payload))
+	}
+	frame = append(frame, d.End...)
+	if len(frame) > d.maxLength() {
+		return nil, ErrPayloadTooLong
+	}
+	return frame, nil
+}
+
+// ReadFrame reads the next complete frame from r
+func (d *Delimited) ReadFrame(r io.Reader) ([]byte, error) {
+	if len(d.End) == 0 {
+		return nil, ErrUnsupportedConfig
+	}
+
+	frame, err := readSync(r, d.Start)
+	if err != nil {
+		return nil, err
+	}
+
+	for !bytes.HasSuffix(frame[len(d.Start):], d.End) {
+		if len(frame) >= d.maxLength() {
+			return nil, ErrFrameTooLong
+		}
+		b, err := readByte(r)
+		if err != nil {
+			return nil, err
+		}
+		frame = append(frame, b)
+	}
+
+	if d.Checksum != nil {
+		data := frame[len(d.Start) : len(frame)-len(d.End)]
+		if len(data) == 0 || d.Checksum(data[:len(data)-1]) != data[len(data)-1] {
+			return nil, ErrChecksum
+		}
+	}
+	return frame, nil
+}
+
+func (d *Delimited) maxLength() int {
+	if d.MaxLength == 0 {
+		return DefaultMaxLength
+	}
+	return d.MaxLength
+}
+
+// readSync reads from r until it has read the sync bytes and returns them
+func readSync(r io.Reader, sync []byte) ([]byte, error) {
+	matched := 0
+	for matched < len(sync) {
+		b, err := readByte(r)
+		if err != nil {
+			return nil, err
+		}
+		if b == sync[matched] {
+			matched++
+		} else if b == sync[0] {
+			matched = 1
+		} else {
+			matched = 0
+		}
+	}
+	return append([]byte{}, sync...), nil
+}
+
+// readByte reads a single byte from r, returning any error r reports with it
+func readByte(r io.Reader) (byte, error) {
+	b := make([]byte, 1)
+	for {
+		n, err := r.Read(b)
+		if err != nil {
+			return 0, err
+		}
+		if n == 1 {
+			return b[0], nil
+		}
+	}
+}
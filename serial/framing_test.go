@@ -0,0 +1,144 @@
+package serial
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestChecksums(t *testing.T) {
+	gobot.Assert(t, Sum([]byte{0xFF, 0x02}), byte(0x01))
+	gobot.Assert(t, SumComplement([]byte{0x55}), byte(0xAA))
+	gobot.Assert(t, SumComplement([]byte{0x02, 0x01, 0x00}), byte(0xFC))
+	gobot.Assert(t, XOR([]byte{0x0F, 0xF0, 0x01}), byte(0xFE))
+}
+
+func TestLengthPrefixed(t *testing.T) {
+	var _ Framer = (*LengthPrefixed)(nil)
+	l := &LengthPrefixed{
+		Sync:           []byte{0xAA, 0xAA},
+		Checksum:       SumComplement,
+		ChecksumOffset: 3,
+	}
+
+	frame, err := l.Frame([]byte{0x02, 0x64})
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, frame, []byte{0xAA, 0xAA, 0x02, 0x02, 0x64, 0x99})
+
+	// leading garbage and a partial sync are skipped
+	r := bytes.NewBuffer(append([]byte{0x00, 0xAA, 0x01}, frame...))
+	read, err := l.ReadFrame(r)
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, read, frame)
+
+	_, err = l.ReadFrame(r)
+	gobot.Assert(t, err, io.EOF)
+
+	_, err = l.ReadFrame(bytes.NewBuffer([]byte{0xAA, 0xAA, 0x01, 0x55, 0x00}))
+	gobot.Assert(t, err, ErrChecksum)
+
+	_, err = l.ReadFrame(bytes.NewBuffer([]byte{0xAA, 0xAA, 0x03, 0x01}))
+	gobot.Assert(t, err, io.ErrUnexpectedEOF)
+
+	_, err = l.Frame(make([]byte, 256))
+	gobot.Assert(t, err, ErrPayloadTooLong)
+}
+
+func TestLengthPrefixedHeader(t *testing.T) {
+	// sphero style response: the length counts the checksum, which covers
+	// everything after the sync bytes
+	l := &LengthPrefixed{
+		Sync:                   []byte{0xFF, 0xFF},
+		Offset:                 2,
+		Checksum:               SumComplement,
+		ChecksumOffset:         2,
+		LengthIncludesChecksum: true,
+	}
+
+	frame, err := l.Frame([]byte{0x00, 0x01, 0x05})
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, frame, []byte{0xFF, 0xFF, 0x00, 0x01, 0x02, 0x05, 0xF7})
+
+	read, err := l.ReadFrame(bytes.NewBuffer(frame))
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, read, frame)
+
+	_, err = l.Frame([]byte{0x00})
+	gobot.Assert(t, err, io.ErrShortBuffer)
+
+	l.Checksum = nil
+	frame, _ = l.Frame([]byte{0x00, 0x01})
+	gobot.Assert(t, frame, []byte{0xFF, 0xFF, 0x00, 0x01, 0x00})
+	read, _ = l.ReadFrame(bytes.NewBuffer(frame))
+	gobot.Assert(t, read, frame)
+}
+
+func TestLengthPrefixedExtra(t *testing.T) {
+	// mavlink style packet: the length counts the payload only, not the 4
+	// header bytes after it nor the 2 byte CRC
+	l := &LengthPrefixed{
+		Sync:  []byte{0xFE},
+		Extra: 6,
+	}
+
+	frame, err := l.Frame([]byte{0x01, 0x02, 0x03, 0x00, 0xAB, 0xCD, 0xEF})
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, frame, []byte{0xFE, 0x01, 0x01, 0x02, 0x03, 0x00, 0xAB, 0xCD, 0xEF})
+
+	read, err := l.ReadFrame(bytes.NewBuffer(append(frame, 0xFE)))
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, read, frame)
+
+	_, err = l.Frame([]byte{0x01, 0x02, 0x03})
+	gobot.Assert(t, err, io.ErrShortBuffer)
+}
+
+func TestDelimited(t *testing.T) {
+	var _ Framer = (*Delimited)(nil)
+	d := &Delimited{End: []byte("\r\n")}
+
+	frame, err := d.Frame([]byte("hello"))
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, frame, []byte("hello\r\n"))
+
+	r := bytes.NewBufferString("one\r\ntwo\r\nthr")
+	read, _ := d.ReadFrame(r)
+	gobot.Assert(t, read, []byte("one\r\n"))
+	read, _ = d.ReadFrame(r)
+	gobot.Assert(t, read, []byte("two\r\n"))
+	_, err = d.ReadFrame(r)
+	gobot.Assert(t, err, io.EOF)
+
+	d.MaxLength = 4
+	_, err = d.ReadFrame(bytes.NewBufferString("toolong\r\n"))
+	gobot.Assert(t, err, ErrFrameTooLong)
+	_, err = d.Frame([]byte("toolong"))
+	gobot.Assert(t, err, ErrPayloadTooLong)
+
+	_, err = (&Delimited{}).ReadFrame(r)
+	gobot.Assert(t, err, ErrUnsupportedConfig)
+}
+
+func TestDelimitedStartChecksum(t *testing.T) {
+	d := &Delimited{
+		Start:    []byte{0xF0},
+		End:      []byte{0xF7},
+		Checksum: XOR,
+	}
+
+	frame, err := d.Frame([]byte{0x01, 0x02})
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, frame, []byte{0xF0, 0x01, 0x02, 0x03, 0xF7})
+
+	read, err := d.ReadFrame(bytes.NewBuffer(append([]byte{0x7F, 0xF7}, frame...)))
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, read, frame)
+
+	_, err = d.ReadFrame(bytes.NewBuffer([]byte{0xF0, 0x01, 0x02, 0x00, 0xF7}))
+	gobot.Assert(t, err, ErrChecksum)
+
+	_, err = d.ReadFrame(bytes.NewBuffer([]byte{0xF0, 0xF7}))
+	gobot.Assert(t, err, ErrChecksum)
+}
@@ -0,0 +1,101 @@
+package serial
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"time"
+)
+
+// Pipe is one end of an in-memory serial connection. Whatever is written to
+// one end can be read from the other, which makes it a drop in replacement for
+// a serial port when testing adaptors and drivers.
+//
+// Unlike io.Pipe, writes are buffered and never block.
+type Pipe struct {
+	rx      *pipeBuffer
+	tx      *pipeBuffer
+	timeout time.Duration
+}
+
+// NewPipe returns both ends of a new in-memory serial connection
+func NewPipe() (*Pipe, *Pipe) {
+	a, b := newPipeBuffer(), newPipeBuffer()
+	return &Pipe{rx: a, tx: b}, &Pipe{rx: b, tx: a}
+}
+
+// SetReadTimeout sets how long Read waits for data before returning ErrTimeout.
+// A zero timeout blocks until data arrives or the other end is closed.
+func (p *Pipe) SetReadTimeout(d time.Duration) { p.timeout = d }
+
+// Read reads data written to the other end of the pipe. It returns io.EOF
+// once the other end is closed and all its data has been read.
+func (p *Pipe) Read(b []byte) (n int, err error) {
+	var timeout <-chan time.Time
+	if p.timeout > 0 {
+		timeout = time.After(p.timeout)
+	}
+
+	for {
+		p.rx.mutex.Lock()
+		if p.rx.buf.Len() > 0 {
+			n, err = p.rx.buf.Read(b)
+			p.rx.mutex.Unlock()
+			return
+		}
+		closed := p.rx.closed
+		p.rx.mutex.Unlock()
+
+		if closed {
+			return 0, io.EOF
+		}
+
+		select {
+		case <-p.rx.notify:
+		case <-timeout:
+			return 0, ErrTimeout
+		}
+	}
+}
+
+// Write writes data to be read from the other end of the pipe
+func (p *Pipe) Write(b []byte) (n int, err error) {
+	p.tx.mutex.Lock()
+	defer p.tx.mutex.Unlock()
+
+	if p.tx.closed {
+		return 0, io.ErrClosedPipe
+	}
+	n, err = p.tx.buf.Write(b)
+	p.tx.signal()
+	return
+}
+
+// Close closes this end of the pipe
+func (p *Pipe) Close() error {
+	p.tx.mutex.Lock()
+	p.tx.closed = true
+	p.tx.signal()
+	p.tx.mutex.Unlock()
+	return nil
+}
+
+// pipeBuffer holds the data travelling in one direction of a Pipe
+type pipeBuffer struct {
+	mutex  sync.Mutex
+	buf    bytes.Buffer
+	closed bool
+	notify chan struct{}
+}
+
+func newPipeBuffer() *pipeBuffer {
+	return &pipeBuffer{notify: make(chan struct{}, 1)}
+}
+
+// signal wakes up a Read waiting for data
+func (b *pipeBuffer) signal() {
+	select {
+	case b.notify <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,70 @@
+package serial
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestPipe(t *testing.T) {
+	a, b := NewPipe()
+	var _ io.ReadWriteCloser = a
+
+	n, err := a.Write([]byte{0x01, 0x02, 0x03})
+	gobot.Assert(t, n, 3)
+	gobot.Assert(t, err, nil)
+
+	buf := make([]byte, 2)
+	n, err = b.Read(buf)
+	gobot.Assert(t, n, 2)
+	gobot.Assert(t, buf, []byte{0x01, 0x02})
+	n, err = b.Read(buf)
+	gobot.Assert(t, n, 1)
+	gobot.Assert(t, buf[:n], []byte{0x03})
+
+	b.Write([]byte{0x04})
+	n, err = a.Read(buf)
+	gobot.Assert(t, buf[:n], []byte{0x04})
+}
+
+func TestPipeBlockingRead(t *testing.T) {
+	a, b := NewPipe()
+
+	go func() {
+		<-time.After(10 * time.Millisecond)
+		a.Write([]byte{0x05})
+	}()
+
+	buf := make([]byte, 1)
+	n, err := b.Read(buf)
+	gobot.Assert(t, n, 1)
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, buf[0], byte(0x05))
+}
+
+func TestPipeReadTimeout(t *testing.T) {
+	_, b := NewPipe()
+	b.SetReadTimeout(10 * time.Millisecond)
+
+	n, err := b.Read(make([]byte, 1))
+	gobot.Assert(t, n, 0)
+	gobot.Assert(t, err, ErrTimeout)
+}
+
+func TestPipeClose(t *testing.T) {
+	a, b := NewPipe()
+	a.Write([]byte{0x06})
+	gobot.Assert(t, a.Close(), nil)
+
+	_, err := a.Write([]byte{0x07})
+	gobot.Assert(t, err, io.ErrClosedPipe)
+
+	buf := make([]byte, 1)
+	n, err := b.Read(buf)
+	gobot.Assert(t, n, 1)
+	gobot.Assert(t, err, nil)
+	_, err = b.Read(buf)
+	gobot.Assert(t, err, io.EOF)
+}
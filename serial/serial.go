@@ -0,0 +1,77 @@
+package serial
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	goserial "github.com/tarm/goserial"
+)
+
+var (
+	// ErrTimeout is the error returned by Read when no data arrives within the read timeout
+	ErrTimeout = errors.New("serial: read timeout")
+	// ErrUnsupportedConfig is the error returned by OpenPort when the port can not be configured as requested
+	ErrUnsupportedConfig = errors.New("serial: unsupported config")
+	// ErrInvalidBaud is the error returned by OpenPort when the baud rate is not supported
+	ErrInvalidBaud = errors.New("serial: invalid baud rate")
+)
+
+// Parity is the parity checking mode of a serial port
+type Parity byte
+
+const (
+	// ParityNone disables parity checking
+	ParityNone Parity = iota
+	// ParityOdd enables odd parity checking
+	ParityOdd
+	// ParityEven enables even parity checking
+	ParityEven
+)
+
+// StopBits is the number of stop bits of a serial port
+type StopBits byte
+
+const (
+	// StopBits1 sends one stop bit
+	StopBits1 StopBits = iota
+	// StopBits2 sends two stop bits
+	StopBits2
+)
+
+// Config represents the settings of a serial port.
+// The zero values of Size, Parity and StopBits select 8N1.
+//
+// A port set to 8N1 without a read timeout is opened with goserial on every
+// platform. Other settings are only supported on linux, and not on mips.
+type Config struct {
+	// Name is the name of the serial port, eg. "/dev/ttyACM0" or "COM3"
+	Name string
+	// Baud is the baud rate of the serial port, eg. 57600
+	Baud int
+	// Size is the number of data bits, 5 to 8. Defaults to 8
+	Size byte
+	// Parity is the parity checking mode. Defaults to ParityNone
+	Parity Parity
+	// StopBits is the number of stop bits. Defaults to StopBits1
+	StopBits StopBits
+	// ReadTimeout is how long a Read waits for data before returning ErrTimeout.
+	// A zero ReadTimeout blocks until data arrives.
+	ReadTimeout time.Duration
+}
+
+// OpenPort opens the serial port described by c
+func OpenPort(c *Config) (io.ReadWriteCloser, error) {
+	if c.size() == 8 && c.Parity == ParityNone && c.StopBits == StopBits1 && c.ReadTimeout == 0 {
+		return goserial.OpenPort(&goserial.Config{Name: c.Name, Baud: c.Baud})
+	}
+	return openPort(c)
+}
+
+// size returns the number of data bits of c
+func (c *Config) size() byte {
+	if c.Size == 0 {
+		return 8
+	}
+	return c.Size
+}
@@ -0,0 +1,11 @@
+// +build !linux mips mipsle mips64 mips64le
+
+package serial
+
+import "io"
+
+// openPort is only called for settings other than 8N1 without a read
+// timeout, which goserial can not set here.
+func openPort(c *Config) (io.ReadWriteCloser, error) {
+	return nil, ErrUnsupportedConfig
+}
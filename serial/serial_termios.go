@@ -0,0 +1,160 @@
+// +build linux,!mips,!mipsle,!mips64,!mips64le
+
+package serial
+
+import (
+	"io"
+	"os"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+var bauds = map[int]uint32{
+	50:      syscall.B50,
+	75:      syscall.B75,
+	110:     syscall.B110,
+	134:     syscall.B134,
+	150:     syscall.B150,
+	200:     syscall.B200,
+	300:     syscall.B300,
+	600:     syscall.B600,
+	1200:    syscall.B1200,
+	1800:    syscall.B1800,
+	2400:    syscall.B2400,
+	4800:    syscall.B4800,
+	9600:    syscall.B9600,
+	19200:   syscall.B19200,
+	38400:   syscall.B38400,
+	57600:   syscall.B57600,
+	115200:  syscall.B115200,
+	230400:  syscall.B230400,
+	460800:  syscall.B460800,
+	500000:  syscall.B500000,
+	576000:  syscall.B576000,
+	921600:  syscall.B921600,
+	1000000: syscall.B1000000,
+	1152000: syscall.B1152000,
+	1500000: syscall.B1500000,
+	2000000: syscall.B2000000,
+	2500000: syscall.B2500000,
+	3000000: syscall.B3000000,
+	3500000: syscall.B3500000,
+	4000000: syscall.B4000000,
+}
+
+var sizes = map[byte]uint32{
+	5: syscall.CS5,
+	6: syscall.CS6,
+	7: syscall.CS7,
+	8: syscall.CS8,
+}
+
+// port is a serial port opened on linux with settings goserial does not support
+type port struct {
+	f       *os.File
+	timeout time.Duration
+}
+
+func openPort(c *Config) (io.ReadWriteCloser, error) {
+	t, err := termios(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// open non-blocking so the open does not wait for carrier detect
+	fd, err := syscall.Open(c.Name, syscall.O_RDWR|syscall.O_NOCTTY|syscall.O_NONBLOCK, 0666)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: c.Name, Err: err}
+	}
+	f := os.NewFile(uintptr(fd), c.Name)
+
+	if _, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		uintptr(fd),
+		uintptr(syscall.TCSETS),
+		uintptr(unsafe.Pointer(t)),
+	); errno != 0 {
+		f.Close()
+		return nil, errno
+	}
+
+	if err = syscall.SetNonblock(fd, false); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &port{f: f, timeout: c.ReadTimeout}, nil
+}
+
+// termios returns the raw mode terminal settings described by c
+func termios(c *Config) (*syscall.Termios, error) {
+	baud, ok := bauds[c.Baud]
+	if !ok {
+		return nil, ErrInvalidBaud
+	}
+	size, ok := sizes[c.size()]
+	if !ok {
+		return nil, ErrUnsupportedConfig
+	}
+
+	t := &syscall.Termios{
+		Cflag:  syscall.CREAD | syscall.CLOCAL | baud | size,
+		Ispeed: baud,
+		Ospeed: baud,
+	}
+
+	switch c.Parity {
+	case ParityNone:
+	case ParityOdd:
+		t.Cflag |= syscall.PARENB | syscall.PARODD
+		t.Iflag |= syscall.INPCK
+	case ParityEven:
+		t.Cflag |= syscall.PARENB
+		t.Iflag |= syscall.INPCK
+	default:
+		return nil, ErrUnsupportedConfig
+	}
+
+	switch c.StopBits {
+	case StopBits1:
+	case StopBits2:
+		t.Cflag |= syscall.CSTOPB
+	default:
+		return nil, ErrUnsupportedConfig
+	}
+
+	// VTIME is in tenths of a second and at most 25.5 seconds
+	if c.ReadTimeout > 0 {
+		vtime := c.ReadTimeout / (100 * time.Millisecond)
+		if vtime < 1 {
+			vtime = 1
+		} else if vtime > 255 {
+			vtime = 255
+		}
+		t.Cc[syscall.VMIN] = 0
+		t.Cc[syscall.VTIME] = uint8(vtime)
+	} else {
+		t.Cc[syscall.VMIN] = 1
+		t.Cc[syscall.VTIME] = 0
+	}
+
+	return t, nil
+}
+
+// Read reads from the serial port. It returns ErrTimeout if a read timeout is
+// set and no data arrived in time.
+func (p *port) Read(b []byte) (n int, err error) {
+	n, err = p.f.Read(b)
+	// a read which times out returns no data, which os.File reports as EOF
+	if err == io.EOF && p.timeout > 0 {
+		err = ErrTimeout
+	}
+	return
+}
+
+// Write writes to the serial port
+func (p *port) Write(b []byte) (int, error) { return p.f.Write(b) }
+
+// Close closes the serial port
+func (p *port) Close() error { return p.f.Close() }
@@ -0,0 +1,59 @@
+// +build linux,!mips,!mipsle,!mips64,!mips64le
+
+package serial
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestTermios(t *testing.T) {
+	tio, err := termios(&Config{Baud: 57600})
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, tio.Cflag, uint32(syscall.CREAD|syscall.CLOCAL|syscall.B57600|syscall.CS8))
+	gobot.Assert(t, tio.Ispeed, uint32(syscall.B57600))
+	gobot.Assert(t, tio.Cc[syscall.VMIN], uint8(1))
+	gobot.Assert(t, tio.Cc[syscall.VTIME], uint8(0))
+
+	tio, err = termios(&Config{
+		Baud:        9600,
+		Size:        7,
+		Parity:      ParityEven,
+		StopBits:    StopBits2,
+		ReadTimeout: 500 * time.Millisecond,
+	})
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, tio.Cflag, uint32(syscall.CREAD|syscall.CLOCAL|syscall.B9600|
+		syscall.CS7|syscall.PARENB|syscall.CSTOPB))
+	gobot.Assert(t, tio.Iflag, uint32(syscall.INPCK))
+	gobot.Assert(t, tio.Cc[syscall.VMIN], uint8(0))
+	gobot.Assert(t, tio.Cc[syscall.VTIME], uint8(5))
+
+	tio, _ = termios(&Config{Baud: 115200, Parity: ParityOdd, ReadTimeout: time.Millisecond})
+	gobot.Assert(t, tio.Cflag&syscall.PARODD, uint32(syscall.PARODD))
+	gobot.Assert(t, tio.Cc[syscall.VTIME], uint8(1))
+
+	tio, _ = termios(&Config{Baud: 115200, ReadTimeout: time.Minute})
+	gobot.Assert(t, tio.Cc[syscall.VTIME], uint8(255))
+
+	_, err = termios(&Config{Baud: 12345})
+	gobot.Assert(t, err, ErrInvalidBaud)
+	_, err = termios(&Config{Baud: 9600, Size: 9})
+	gobot.Assert(t, err, ErrUnsupportedConfig)
+	_, err = termios(&Config{Baud: 9600, Parity: Parity(5)})
+	gobot.Assert(t, err, ErrUnsupportedConfig)
+}
+
+func TestOpenPort(t *testing.T) {
+	_, err := OpenPort(&Config{Name: "/dev/does/not/exist", Baud: 9600, StopBits: StopBits2})
+	gobot.Refute(t, err, nil)
+	_, ok := err.(*os.PathError)
+	gobot.Assert(t, ok, true)
+
+	_, err = OpenPort(&Config{Name: "/dev/does/not/exist", Baud: 1, Parity: ParityEven})
+	gobot.Assert(t, err, ErrInvalidBaud)
+}
@@ -1,12 +1,22 @@
 package sysfs
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
 	"syscall"
+	"time"
 )
 
+// ErrPulseTimeout is returned by PulsePin.Pulse when no pulse is read before
+// the timeout
+var ErrPulseTimeout = errors.New("timed out waiting for pulse")
+
+// ErrPulseUnsupported is the error resulting when timing a pulse on a
+// DigitalPin which is not a PulsePin
+var ErrPulseUnsupported = errors.New("pin can not time pulses")
+
 const (
 	// IN gpio direction
 	IN = "in"
@@ -18,6 +28,10 @@ const (
 	LOW = 0
 	// GPIOPATH default linux gpio path
 	GPIOPATH = "/sys/class/gpio"
+	// BOTH gpio edge, interrupting on rising and falling edges
+	BOTH = "both"
+	// NONE gpio edge, not interrupting
+	NONE = "none"
 )
 
 // DigitalPin is the interface for sysfs gpio interactions
@@ -34,9 +48,27 @@ type DigitalPin interface {
 	Write(int) error
 }
 
+// PulsePin is the interface of DigitalPins which can time pulses, as those of
+// NewDigitalPin do
+type PulsePin interface {
+	// Pulse waits for the pin to go to level and returns how long it stays
+	// there. If trigger is not nil, it is called to start the pulse once the
+	// pin is ready to time it. The trigger may drive the pin itself, as long
+	// as it returns it to an input.
+	Pulse(level int, trigger func() error, timeout time.Duration) (time.Duration, error)
+}
+
+var _ PulsePin = (*digitalPin)(nil)
+
 type digitalPin struct {
 	pin   string
 	label string
+	// the value file kept open by Pulse for the edge interrupts of the pin,
+	// until it is unexported
+	value File
+	// edge is true while the edge interrupts of the pin are enabled, which
+	// only an input can have
+	edge bool
 }
 
 // NewDigitalPin returns a DigitalPin given the pin number and an optional sysfs pin label.
@@ -54,6 +86,11 @@ func NewDigitalPin(pin int, v ...string) DigitalPin {
 }
 
 func (d *digitalPin) Direction(dir string) error {
+	if dir != IN {
+		if err := d.setEdge(false); err != nil {
+			return err
+		}
+	}
 	_, err := writeFile(fmt.Sprintf("%v/%v/direction", GPIOPATH, d.label), []byte(dir))
 	return err
 }
@@ -71,6 +108,106 @@ func (d *digitalPin) Read() (n int, err error) {
 	return strconv.Atoi(string(buf[0]))
 }
 
+// Pulse waits for the pin to go to level and returns how long it stays there,
+// timing the edges through the edge interrupts of the pin, which Pulse
+// enables. If trigger is not nil, it is called to start the pulse once the
+// interrupts are enabled and the pin is not at level. A trigger driving the
+// pin itself turns the interrupts off, so they are enabled again after it,
+// keeping the value file open so the pulse is not missed. It returns
+// ErrPulseTimeout if no pulse ends within timeout.
+func (d *digitalPin) Pulse(level int, trigger func() error, timeout time.Duration) (time.Duration, error) {
+	if err := d.arm(); err != nil {
+		return 0, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	// a pulse which already started can not be measured
+	if _, err := waitForLevel(d.value, level^1, deadline); err != nil {
+		return 0, err
+	}
+	if trigger != nil {
+		if err := trigger(); err != nil {
+			return 0, err
+		}
+		if err := d.arm(); err != nil {
+			return 0, err
+		}
+	}
+	start, err := waitForLevel(d.value, level, deadline)
+	if err != nil {
+		return 0, err
+	}
+	end, err := waitForLevel(d.value, level^1, deadline)
+	if err != nil {
+		return 0, err
+	}
+	return end.Sub(start), nil
+}
+
+// arm enables the edge interrupts of the pin and opens its value file, unless
+// already done
+func (d *digitalPin) arm() error {
+	if err := d.setEdge(true); err != nil {
+		return err
+	}
+	if d.value == nil {
+		file, err := OpenFile(fmt.Sprintf("%v/%v/value", GPIOPATH, d.label), os.O_RDONLY, 0644)
+		if err != nil {
+			return err
+		}
+		d.value = file
+	}
+	return nil
+}
+
+// disarm disables the edge interrupts of the pin and closes the value file
+// kept by Pulse
+func (d *digitalPin) disarm() error {
+	if d.value != nil {
+		d.value.Close()
+		d.value = nil
+	}
+	return d.setEdge(false)
+}
+
+// setEdge enables or disables the edge interrupts of the pin, when they are
+// not already
+func (d *digitalPin) setEdge(enabled bool) error {
+	if d.edge == enabled {
+		return nil
+	}
+	edge := NONE
+	if enabled {
+		edge = BOTH
+	}
+	if _, err := writeFile(fmt.Sprintf("%v/%v/edge", GPIOPATH, d.label), []byte(edge)); err != nil {
+		return err
+	}
+	d.edge = enabled
+	return nil
+}
+
+// waitForLevel waits until the value file f reads level, and returns the time
+// it did
+func waitForLevel(f File, level int, deadline time.Time) (time.Time, error) {
+	buf := make([]byte, 1)
+	for {
+		if _, err := f.ReadAt(buf, 0); err != nil {
+			return time.Time{}, err
+		}
+		now := time.Now()
+		if int(buf[0]-'0') == level {
+			return now, nil
+		}
+		if !now.Before(deadline) {
+			return now, ErrPulseTimeout
+		}
+		if err := pollEdge(f, deadline.Sub(now)); err != nil {
+			return now, err
+		}
+	}
+}
+
 func (d *digitalPin) Export() error {
 	if _, err := writeFile(GPIOPATH+"/export", []byte(d.pin)); err != nil {
 		// If EBUSY then the pin has already been exported
@@ -82,6 +219,9 @@ func (d *digitalPin) Export() error {
 }
 
 func (d *digitalPin) Unexport() error {
+	if err := d.disarm(); err != nil {
+		return err
+	}
 	if _, err := writeFile(GPIOPATH+"/unexport", []byte(d.pin)); err != nil {
 		// If EINVAL then the pin is reserved in the system and can't be unexported
 		if err.(*os.PathError).Err != syscall.EINVAL {
@@ -0,0 +1,29 @@
+package sysfs
+
+import (
+	"syscall"
+	"time"
+)
+
+// pollEdge waits up to timeout for an edge interrupt on the value file f
+var pollEdge = func(f File, timeout time.Duration) (err error) {
+	epfd, err := syscall.EpollCreate1(0)
+	if err != nil {
+		return
+	}
+	defer syscall.Close(epfd)
+
+	fd := int(f.Fd())
+	event := syscall.EpollEvent{Events: syscall.EPOLLPRI | syscall.EPOLLERR, Fd: int32(fd)}
+	if err = syscall.EpollCtl(epfd, syscall.EPOLL_CTL_ADD, fd, &event); err != nil {
+		return
+	}
+
+	events := make([]syscall.EpollEvent, 1)
+	// round up, so that a timeout below a millisecond does not return at once
+	msec := int((timeout + time.Millisecond - 1) / time.Millisecond)
+	if _, err = syscall.EpollWait(epfd, events, msec); err == syscall.EINTR {
+		err = nil
+	}
+	return
+}
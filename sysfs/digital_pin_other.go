@@ -0,0 +1,13 @@
+// +build !linux
+
+package sysfs
+
+import (
+	"errors"
+	"time"
+)
+
+// pollEdge waits up to timeout for an edge interrupt on the value file f
+var pollEdge = func(f File, timeout time.Duration) error {
+	return errors.New("edge interrupts are not supported on this platform")
+}
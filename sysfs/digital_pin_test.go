@@ -5,11 +5,14 @@ import (
 	"os"
 	"syscall"
 	"testing"
+	"time"
 
 	"github.com/hybridgroup/gobot"
 )
 
 func TestDigitalPin(t *testing.T) {
+	defer func(w func(string, []byte) (int, error)) { writeFile = w }(writeFile)
+
 	fs := NewMockFilesystem([]string{
 		"/sys/class/gpio/export",
 		"/sys/class/gpio/unexport",
@@ -77,3 +80,83 @@ func TestDigitalPin(t *testing.T) {
 	err = pin.Export()
 	gobot.Assert(t, err.(*os.PathError).Err, errors.New("write error"))
 }
+
+func TestDigitalPinPulse(t *testing.T) {
+	fs := NewMockFilesystem([]string{
+		"/sys/class/gpio/gpio10/value",
+		"/sys/class/gpio/gpio10/direction",
+		"/sys/class/gpio/gpio10/edge",
+		"/sys/class/gpio/unexport",
+	})
+
+	SetFilesystem(fs)
+
+	value := fs.Files["/sys/class/gpio/gpio10/value"]
+	levels := []string{}
+	defer func(p func(File, time.Duration) error) { pollEdge = p }(pollEdge)
+	pollEdge = func(f File, timeout time.Duration) error {
+		if len(levels) == 0 {
+			time.Sleep(timeout)
+			return nil
+		}
+		time.Sleep(2 * time.Millisecond)
+		value.Contents, levels = levels[0], levels[1:]
+		return nil
+	}
+
+	pin := NewDigitalPin(10).(PulsePin)
+
+	// the pulse which is underway when starting is skipped
+	value.Contents = "1"
+	levels = []string{"0", "1", "1", "0"}
+	d, err := pin.Pulse(HIGH, nil, time.Second)
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, d >= 4*time.Millisecond, true)
+	gobot.Assert(t, len(levels), 0)
+	gobot.Assert(t, fs.Files["/sys/class/gpio/gpio10/edge"].Contents, "both")
+
+	// the trigger starts the pulse once the pin is ready
+	value.Contents = "1"
+	triggered := false
+	d, err = pin.Pulse(LOW, func() error {
+		triggered = true
+		levels = []string{"0", "1"}
+		return nil
+	}, time.Second)
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, triggered, true)
+	gobot.Assert(t, d >= 2*time.Millisecond, true)
+
+	value.Contents = "0"
+	_, err = pin.Pulse(HIGH, func() error {
+		return errors.New("trigger error")
+	}, time.Second)
+	gobot.Assert(t, err, errors.New("trigger error"))
+
+	_, err = pin.Pulse(HIGH, nil, 10*time.Millisecond)
+	gobot.Assert(t, err, ErrPulseTimeout)
+
+	// the trigger may drive the pin itself, turning its interrupts off
+	value.Contents = "0"
+	d, err = pin.Pulse(HIGH, func() error {
+		if err := pin.(DigitalPin).Direction(OUT); err != nil {
+			return err
+		}
+		gobot.Assert(t, fs.Files["/sys/class/gpio/gpio10/edge"].Contents, "none")
+		levels = []string{"1", "0"}
+		return pin.(DigitalPin).Direction(IN)
+	}, time.Second)
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, d >= 2*time.Millisecond, true)
+	gobot.Assert(t, fs.Files["/sys/class/gpio/gpio10/edge"].Contents, "both")
+	gobot.Assert(t, value.Closed, false)
+
+	// an output can not interrupt
+	gobot.Assert(t, pin.(DigitalPin).Direction(OUT), nil)
+	gobot.Assert(t, fs.Files["/sys/class/gpio/gpio10/edge"].Contents, "none")
+	gobot.Assert(t, pin.(DigitalPin).Unexport(), nil)
+	gobot.Assert(t, value.Closed, true)
+
+	_, err = NewDigitalPin(30).(PulsePin).Pulse(HIGH, nil, time.Second)
+	gobot.Refute(t, err, nil)
+}
@@ -1,5 +1,5 @@
 /*
-Package sysfs provides generic access to linux gpio.
+Package sysfs provides generic access to linux gpio, i2c and spi devices.
 
 It is intended to be used while implementing support for a single board linux computer
 */
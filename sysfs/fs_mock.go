@@ -3,6 +3,7 @@ package sysfs
 import (
 	"errors"
 	"os"
+	"sync"
 	"time"
 )
 
@@ -13,6 +14,9 @@ var _ Filesystem = (*MockFilesystem)(nil)
 type MockFilesystem struct {
 	Seq   int // Increases with each write or read.
 	Files map[string]*MockFile
+	// mutex guards Opened and Closed, as files are opened and closed from
+	// the goroutines of the adaptors
+	mutex sync.Mutex
 }
 
 // A MockFile represents a mock file that contains a single string.  Any write
@@ -68,6 +72,11 @@ func (f *MockFile) Fd() uintptr {
 
 // Close implements the File interface Close function
 func (f *MockFile) Close() error {
+	if f != nil {
+		f.fs.mutex.Lock()
+		defer f.fs.mutex.Unlock()
+		f.Closed = true
+	}
 	return nil
 }
 
@@ -86,6 +95,8 @@ func NewMockFilesystem(files []string) *MockFilesystem {
 
 // OpenFile opens file name from fs.Files, if the file does not exist it returns an os.PathError
 func (fs *MockFilesystem) OpenFile(name string, flag int, perm os.FileMode) (file File, err error) {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
 	f, ok := fs.Files[name]
 	if ok {
 		f.Opened = true
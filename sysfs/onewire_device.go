@@ -0,0 +1,74 @@
+package sysfs
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// W1PATH default linux 1-Wire bus path
+	W1PATH = "/sys/bus/w1/devices"
+	// W1MASTER default linux 1-Wire bus master
+	W1MASTER = "w1_bus_master1"
+)
+
+// OneWireDevice is the interface for sysfs 1-Wire device interactions
+type OneWireDevice interface {
+	// ID returns the ROM ID of the device, eg. "28-000005e2fdc3"
+	ID() string
+	// Family returns the family code of the device, eg. "28" for a DS18B20
+	Family() string
+	// Read reads the contents of the device's w1_slave file
+	Read() ([]byte, error)
+}
+
+type oneWireDevice struct {
+	id string
+}
+
+// NewOneWireDevice returns a OneWireDevice given its ROM ID
+func NewOneWireDevice(id string) OneWireDevice {
+	return &oneWireDevice{id: id}
+}
+
+// OneWireDevices returns the ROM IDs of every device found by the 1-Wire bus master
+func OneWireDevices() (ids []string, err error) {
+	buf, err := readAll(fmt.Sprintf("%v/%v/w1_master_slaves", W1PATH, W1MASTER))
+	if err != nil {
+		return
+	}
+
+	ids = []string{}
+	for _, line := range strings.Split(string(buf), "\n") {
+		line = strings.TrimSpace(line)
+		// the bus master reports "not found." when no slaves are attached
+		if line == "" || line == "not found." {
+			continue
+		}
+		ids = append(ids, line)
+	}
+	return
+}
+
+func (d *oneWireDevice) ID() string { return d.id }
+
+func (d *oneWireDevice) Family() string {
+	return strings.SplitN(d.id, "-", 2)[0]
+}
+
+func (d *oneWireDevice) Read() ([]byte, error) {
+	return readAll(fmt.Sprintf("%v/%v/w1_slave", W1PATH, d.id))
+}
+
+var readAll = func(path string) ([]byte, error) {
+	file, err := OpenFile(path, os.O_RDONLY, 0644)
+	defer file.Close()
+	if err != nil {
+		return make([]byte, 0), err
+	}
+
+	buf := make([]byte, 1024)
+	n, err := file.Read(buf)
+	return buf[:n], err
+}
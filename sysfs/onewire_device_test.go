@@ -0,0 +1,45 @@
+package sysfs
+
+import (
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestOneWireDevices(t *testing.T) {
+	fs := NewMockFilesystem([]string{})
+	SetFilesystem(fs)
+
+	_, err := OneWireDevices()
+	gobot.Refute(t, err, nil)
+
+	fs.Add("/sys/bus/w1/devices/w1_bus_master1/w1_master_slaves").Contents = "not found.\n"
+	ids, err := OneWireDevices()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, ids, []string{})
+
+	fs.Files["/sys/bus/w1/devices/w1_bus_master1/w1_master_slaves"].Contents =
+		"28-000005e2fdc3\n28-0000063f4b1a\n"
+	ids, err = OneWireDevices()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, ids, []string{"28-000005e2fdc3", "28-0000063f4b1a"})
+}
+
+func TestOneWireDevice(t *testing.T) {
+	fs := NewMockFilesystem([]string{
+		"/sys/bus/w1/devices/28-000005e2fdc3/w1_slave",
+	})
+	SetFilesystem(fs)
+
+	d := NewOneWireDevice("28-000005e2fdc3")
+	gobot.Assert(t, d.ID(), "28-000005e2fdc3")
+	gobot.Assert(t, d.Family(), "28")
+
+	fs.Files["/sys/bus/w1/devices/28-000005e2fdc3/w1_slave"].Contents = "72 01 4b 46 7f ff 0e 10 57 : crc=57 YES\n"
+	data, err := d.Read()
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, string(data), "72 01 4b 46 7f ff 0e 10 57 : crc=57 YES\n")
+
+	_, err = NewOneWireDevice("28-0000063f4b1a").Read()
+	gobot.Refute(t, err, nil)
+}
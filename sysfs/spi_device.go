@@ -0,0 +1,137 @@
+package sysfs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	SPI_IOC_WR_MODE          = 0x40016b01
+	SPI_IOC_WR_BITS_PER_WORD = 0x40016b03
+	SPI_IOC_WR_MAX_SPEED_HZ  = 0x40046b04
+	SPI_IOC_MESSAGE_1        = 0x40206b00
+)
+
+// spiIocTransfer mirrors the kernel's struct spi_ioc_transfer
+type spiIocTransfer struct {
+	txBuf       uint64
+	rxBuf       uint64
+	length      uint32
+	speedHz     uint32
+	delayUsecs  uint16
+	bitsPerWord uint8
+	csChange    uint8
+	txNbits     uint8
+	rxNbits     uint8
+	pad         uint16
+}
+
+// SpiDevice is the interface for full-duplex transfers on a linux spidev device
+type SpiDevice interface {
+	io.Closer
+	// SetMode sets the SPI clock polarity and phase, 0-3
+	SetMode(mode byte) error
+	// SetBitsPerWord sets the word size of each transfer
+	SetBitsPerWord(bits byte) error
+	// SetSpeed sets the maximum clock speed in Hz
+	SetSpeed(speed uint32) error
+	// Transfer writes tx to the device and returns the bytes clocked in at the same time
+	Transfer(tx []byte) (rx []byte, err error)
+}
+
+type spiDevice struct {
+	file  File
+	bits  byte
+	speed uint32
+}
+
+// NewSpiDevice returns a SpiDevice given a spidev location such as /dev/spidev0.0,
+// the SPI mode, bits per word and maximum clock speed in Hz
+func NewSpiDevice(location string, mode byte, bits byte, speed uint32) (*spiDevice, error) {
+	d := &spiDevice{}
+
+	file, err := OpenFile(location, os.O_RDWR, os.ModeExclusive)
+	if err != nil {
+		return nil, err
+	}
+	d.file = file
+
+	if err = d.configure(mode, bits, speed); err != nil {
+		d.file.Close()
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// configure sets the mode, bits per word and speed of a newly opened device
+func (d *spiDevice) configure(mode byte, bits byte, speed uint32) (err error) {
+	if err = d.SetMode(mode); err != nil {
+		return
+	}
+	if err = d.SetBitsPerWord(bits); err != nil {
+		return
+	}
+	return d.SetSpeed(speed)
+}
+
+func (d *spiDevice) SetMode(mode byte) (err error) {
+	return d.ioctl(SPI_IOC_WR_MODE, uintptr(unsafe.Pointer(&mode)))
+}
+
+func (d *spiDevice) SetBitsPerWord(bits byte) (err error) {
+	if err = d.ioctl(SPI_IOC_WR_BITS_PER_WORD, uintptr(unsafe.Pointer(&bits))); err != nil {
+		return
+	}
+	d.bits = bits
+	return
+}
+
+func (d *spiDevice) SetSpeed(speed uint32) (err error) {
+	if err = d.ioctl(SPI_IOC_WR_MAX_SPEED_HZ, uintptr(unsafe.Pointer(&speed))); err != nil {
+		return
+	}
+	d.speed = speed
+	return
+}
+
+func (d *spiDevice) Close() (err error) {
+	return d.file.Close()
+}
+
+func (d *spiDevice) Transfer(tx []byte) (rx []byte, err error) {
+	rx = make([]byte, len(tx))
+	if len(tx) == 0 {
+		return
+	}
+
+	transfer := &spiIocTransfer{
+		txBuf:       uint64(uintptr(unsafe.Pointer(&tx[0]))),
+		rxBuf:       uint64(uintptr(unsafe.Pointer(&rx[0]))),
+		length:      uint32(len(tx)),
+		speedHz:     d.speed,
+		bitsPerWord: d.bits,
+	}
+
+	err = d.ioctl(SPI_IOC_MESSAGE_1, uintptr(unsafe.Pointer(transfer)))
+
+	return
+}
+
+func (d *spiDevice) ioctl(request uintptr, arg uintptr) (err error) {
+	_, _, errno := Syscall(
+		syscall.SYS_IOCTL,
+		d.file.Fd(),
+		request,
+		arg,
+	)
+
+	if errno != 0 {
+		err = fmt.Errorf("Failed with syscall.Errno %v", errno)
+	}
+
+	return
+}
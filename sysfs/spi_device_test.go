@@ -0,0 +1,87 @@
+package sysfs
+
+import (
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestNewSpiDevice(t *testing.T) {
+	fs := NewMockFilesystem([]string{})
+	SetFilesystem(fs)
+
+	_, err := NewSpiDevice(os.DevNull, 0, 8, 500000)
+	gobot.Refute(t, err, nil)
+
+	fs = NewMockFilesystem([]string{
+		"/dev/spidev0.0",
+	})
+	SetFilesystem(fs)
+	SetSyscall(&MockSyscall{})
+
+	d, err := NewSpiDevice("/dev/spidev0.0", 0, 8, 500000)
+	var _ SpiDevice = d
+
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, d.bits, byte(8))
+	gobot.Assert(t, d.speed, uint32(500000))
+
+	gobot.Assert(t, d.SetMode(3), nil)
+	gobot.Assert(t, d.SetSpeed(1000000), nil)
+	gobot.Assert(t, d.speed, uint32(1000000))
+
+	rx, err := d.Transfer([]byte{0x01, 0x80, 0x00})
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, len(rx), 3)
+
+	rx, err = d.Transfer([]byte{})
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, len(rx), 0)
+
+	gobot.Assert(t, d.Close(), nil)
+}
+
+type spiFailingSyscall struct{}
+
+func (sys *spiFailingSyscall) Syscall(trap, a1, a2, a3 uintptr) (r1, r2 uintptr, err syscall.Errno) {
+	return 0, 0, syscall.EINVAL
+}
+
+func TestNewSpiDeviceError(t *testing.T) {
+	fs := NewMockFilesystem([]string{
+		"/dev/spidev0.0",
+	})
+	SetFilesystem(fs)
+	SetSyscall(&spiFailingSyscall{})
+	defer SetSyscall(&MockSyscall{})
+
+	d, err := NewSpiDevice("/dev/spidev0.0", 0, 8, 500000)
+	gobot.Refute(t, err, nil)
+	gobot.Assert(t, d == nil, true)
+	gobot.Assert(t, fs.Files["/dev/spidev0.0"].Closed, true)
+}
+
+func TestMockSpiDevice(t *testing.T) {
+	d := NewMockSpiDevice()
+	d.SetMode(1)
+	d.SetBitsPerWord(16)
+	d.SetSpeed(2000)
+	gobot.Assert(t, d.Mode, byte(1))
+	gobot.Assert(t, d.Bits, byte(16))
+	gobot.Assert(t, d.Speed, uint32(2000))
+
+	rx, _ := d.Transfer([]byte{0x01, 0x02})
+	gobot.Assert(t, rx, []byte{0x00, 0x00})
+
+	d.Respond = func(tx []byte) []byte {
+		return []byte{tx[1], tx[0]}
+	}
+	rx, _ = d.Transfer([]byte{0x03, 0x04})
+	gobot.Assert(t, rx, []byte{0x04, 0x03})
+	gobot.Assert(t, d.Tx, [][]byte{[]byte{0x01, 0x02}, []byte{0x03, 0x04}})
+
+	d.Close()
+	gobot.Assert(t, d.Closed, true)
+}
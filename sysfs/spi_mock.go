@@ -0,0 +1,63 @@
+package sysfs
+
+var _ SpiDevice = (*MockSpiDevice)(nil)
+
+// MockSpiDevice represents a mock spidev device. Every transfer is recorded in
+// Tx, and the bytes read back are built by Respond.
+type MockSpiDevice struct {
+	Mode   byte
+	Bits   byte
+	Speed  uint32
+	Closed bool
+	// Tx holds the buffers written to the device, in order.
+	Tx [][]byte
+	// Respond returns the bytes read back while tx is written. If Respond is
+	// nil the device reads back zeroes.
+	Respond func(tx []byte) []byte
+}
+
+// NewMockSpiDevice returns a new MockSpiDevice in mode 0 with 8 bits per word
+func NewMockSpiDevice() *MockSpiDevice {
+	return &MockSpiDevice{
+		Bits: 8,
+		Tx:   [][]byte{},
+	}
+}
+
+// SetMode sets d.Mode
+func (d *MockSpiDevice) SetMode(mode byte) error {
+	d.Mode = mode
+	return nil
+}
+
+// SetBitsPerWord sets d.Bits
+func (d *MockSpiDevice) SetBitsPerWord(bits byte) error {
+	d.Bits = bits
+	return nil
+}
+
+// SetSpeed sets d.Speed
+func (d *MockSpiDevice) SetSpeed(speed uint32) error {
+	d.Speed = speed
+	return nil
+}
+
+// Transfer appends a copy of tx to d.Tx and returns a buffer of the same length
+// filled by d.Respond
+func (d *MockSpiDevice) Transfer(tx []byte) (rx []byte, err error) {
+	buf := make([]byte, len(tx))
+	copy(buf, tx)
+	d.Tx = append(d.Tx, buf)
+
+	rx = make([]byte, len(tx))
+	if d.Respond != nil {
+		copy(rx, d.Respond(buf))
+	}
+	return
+}
+
+// Close implements the SpiDevice interface Close function
+func (d *MockSpiDevice) Close() error {
+	d.Closed = true
+	return nil
+}